@@ -23,6 +23,7 @@ import (
 
 	"github.com/ava-labs/hypersdk/codec"
 	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/crypto/bls"
 	"github.com/ava-labs/hypersdk/state"
 	"github.com/ava-labs/hypersdk/utils"
 	"github.com/ava-labs/hypersdk/window"
@@ -53,6 +54,10 @@ type StatefulBlock struct {
 	StateRoot   ids.ID     `json:"stateRoot"`
 	WarpResults set.Bits64 `json:"warpResults"`
 
+	// AuthSignature is the aggregate of the signatures of all [AggregateAuth]
+	// in [Txs] (empty if there are none).
+	AuthSignature []byte `json:"authSignature"`
+
 	size int
 
 	// authCounts can be used by batch signature verification
@@ -179,6 +184,10 @@ func (b *StatelessBlock) populateTxs(ctx context.Context) error {
 	// AWM processing
 	b.txsSet = set.NewSet[ids.ID](len(b.Txs))
 	b.warpMessages = map[ids.ID]*warpJob{}
+	var (
+		aggregateMsgs    [][]byte
+		aggregateSigners []bls.PublicKey
+	)
 	for _, tx := range b.Txs {
 		// Ensure there are no duplicate transactions
 		if b.txsSet.Contains(tx.ID()) {
@@ -192,7 +201,13 @@ func (b *StatelessBlock) populateTxs(ctx context.Context) error {
 			if err != nil {
 				return err
 			}
-			batchVerifier.Add(txDigest, tx.Auth)
+			if aauth, ok := tx.Auth.(AggregateAuth); ok {
+				// Verified with [AuthSignature] once all transactions are processed
+				aggregateMsgs = append(aggregateMsgs, txDigest)
+				aggregateSigners = append(aggregateSigners, aauth.AggregateKey())
+			} else {
+				batchVerifier.Add(txDigest, tx.Auth)
+			}
 		}
 
 		// Check if we need the block context to verify the block (which contains
@@ -218,6 +233,20 @@ func (b *StatelessBlock) populateTxs(ctx context.Context) error {
 			b.containsWarp = true
 		}
 	}
+
+	// Verify the signatures of all [AggregateAuth] at once
+	if len(aggregateMsgs) > 0 {
+		if len(b.AuthSignature) != bls.SignatureLen {
+			return ErrMissingAuthSignature
+		}
+		sig := bls.Signature(b.AuthSignature)
+		b.sigJob.Go(func() error {
+			if !bls.AggregateVerify(aggregateMsgs, aggregateSigners, sig) {
+				return ErrInvalidAuthSignature
+			}
+			return nil
+		})
+	}
 	return nil
 }
 
@@ -263,6 +292,33 @@ func ParseStatefulBlock(
 	return b, b.populateTxs(ctx)
 }
 
+// aggregateAuthSignatures sets [AuthSignature] to the aggregate of the
+// signatures of all [AggregateAuth] in [Txs].
+func (b *StatefulBlock) aggregateAuthSignatures() error {
+	sigs := []bls.Signature{}
+	for _, tx := range b.Txs {
+		aauth, ok := tx.Auth.(AggregateAuth)
+		if !ok {
+			continue
+		}
+		sig := aauth.AggregateSignature()
+		if sig == nil {
+			return ErrMissingAuthSignature
+		}
+		sigs = append(sigs, *sig)
+	}
+	if len(sigs) == 0 {
+		b.AuthSignature = nil
+		return nil
+	}
+	sig, err := bls.AggregateSignatures(sigs)
+	if err != nil {
+		return err
+	}
+	b.AuthSignature = sig[:]
+	return nil
+}
+
 // [initializeBuilt] is invoked after a block is built
 func (b *StatelessBlock) initializeBuilt(
 	ctx context.Context,
@@ -975,7 +1031,8 @@ func (b *StatefulBlock) Marshal() ([]byte, error) {
 	size := consts.IDLen + consts.Uint64Len + consts.Uint64Len +
 		consts.Uint64Len + window.WindowSliceSize +
 		consts.IntLen + codec.CummSize(b.Txs) +
-		consts.IDLen + consts.Uint64Len + consts.Uint64Len
+		consts.IDLen + consts.Uint64Len + consts.Uint64Len +
+		codec.BytesLen(b.AuthSignature)
 
 	p := codec.NewWriter(size, consts.NetworkSizeLimit)
 
@@ -986,7 +1043,7 @@ func (b *StatefulBlock) Marshal() ([]byte, error) {
 	p.PackInt(len(b.Txs))
 	b.authCounts = map[uint8]int{}
 	for _, tx := range b.Txs {
-		if err := tx.Marshal(p); err != nil {
+		if err := tx.marshalBlock(p); err != nil {
 			return nil, err
 		}
 		b.authCounts[tx.Auth.GetTypeID()]++
@@ -994,6 +1051,7 @@ func (b *StatefulBlock) Marshal() ([]byte, error) {
 
 	p.PackID(b.StateRoot)
 	p.PackUint64(uint64(b.WarpResults))
+	p.PackBytes(b.AuthSignature)
 	bytes := p.Bytes()
 	if err := p.Err(); err != nil {
 		return nil, err
@@ -1018,17 +1076,32 @@ func UnmarshalBlock(raw []byte, parser Parser) (*StatefulBlock, error) {
 	actionRegistry, authRegistry := parser.Registry()
 	b.Txs = []*Transaction{} // don't preallocate all to avoid DoS
 	b.authCounts = map[uint8]int{}
+	var aggregated bool
 	for i := 0; i < txCount; i++ {
 		tx, err := UnmarshalTx(p, actionRegistry, authRegistry)
 		if err != nil {
 			return nil, err
 		}
+		if aauth, ok := tx.Auth.(AggregateAuth); ok {
+			// Signatures must be aggregated into [AuthSignature]
+			if aauth.AggregateSignature() != nil {
+				return nil, ErrUnexpectedAuthSignature
+			}
+			aggregated = true
+		}
 		b.Txs = append(b.Txs, tx)
 		b.authCounts[tx.Auth.GetTypeID()]++
 	}
 
 	p.UnpackID(false, &b.StateRoot)
 	b.WarpResults = set.Bits64(p.UnpackUint64(false))
+	p.UnpackBytes(bls.SignatureLen, false, &b.AuthSignature)
+	switch {
+	case aggregated && len(b.AuthSignature) != bls.SignatureLen:
+		return nil, ErrMissingAuthSignature
+	case !aggregated && len(b.AuthSignature) > 0:
+		return nil, ErrUnexpectedAuthSignature
+	}
 
 	// Ensure no leftover bytes
 	if !p.Empty() {
@@ -182,6 +182,16 @@ func BuildBlock(
 				continue
 			}
 
+			// Drop transactions that were included in a rejected block (which
+			// removed the signature we need to aggregate)
+			if aauth, ok := tx.Auth.(AggregateAuth); ok && aauth.AggregateSignature() == nil {
+				log.Debug(
+					"dropping tx without aggregate signature",
+					zap.Stringer("txID", tx.ID()),
+				)
+				continue
+			}
+
 			stateKeys, err := tx.StateKeys(sm)
 			if err != nil {
 				// Drop bad transaction and continue
@@ -484,6 +494,11 @@ func BuildBlock(
 		return nil, err
 	}
 
+	// Aggregate the signatures of all [AggregateAuth]
+	if err := b.aggregateAuthSignatures(); err != nil {
+		return nil, err
+	}
+
 	// Compute block hash and marshaled representation
 	if err := b.initializeBuilt(ctx, view, results, feeManager); err != nil {
 		log.Warn("block failed", zap.Int("txs", len(b.Txs)), zap.Any("consumed", feeManager.UnitsConsumed()))
@@ -17,6 +17,7 @@ import (
 	"github.com/ava-labs/avalanchego/x/merkledb"
 
 	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/crypto/bls"
 	"github.com/ava-labs/hypersdk/executor"
 	"github.com/ava-labs/hypersdk/state"
 	"github.com/ava-labs/hypersdk/workers"
@@ -96,6 +97,7 @@ type Mempool interface {
 	Len(context.Context) int  // items
 	Size(context.Context) int // bytes
 	Add(context.Context, []*Transaction)
+	Peek(context.Context, int) []*Transaction
 
 	Top(
 		context.Context,
@@ -313,6 +315,38 @@ type Auth interface {
 	Size() int
 }
 
+// AggregateAuth is an [Auth] that is authorized by a BLS signature over the
+// digest of its [Transaction] (see [bls.Sign]).
+//
+// When a [Transaction] authorized by an [AggregateAuth] is included in a
+// block, its signature is removed and aggregated with the signatures of all
+// other [AggregateAuth] in the block into [StatefulBlock.AuthSignature]. This
+// reduces the size of the block (and the bandwidth charged to each
+// [Transaction]) and the time it takes to verify it.
+//
+// Because there is only one valid BLS signature for a given key and message,
+// the signature of an [AggregateAuth] is not included in the ID or size of its
+// [Transaction].
+//
+// If a block that includes a [Transaction] authorized by an [AggregateAuth] is
+// rejected, the [Transaction] is dropped instead of being returned to the
+// mempool (its signature can't be recovered from [StatefulBlock.AuthSignature]).
+// It is only included in another block if it is resubmitted by its issuer.
+type AggregateAuth interface {
+	Auth
+
+	// AggregateKey is the key that signed the digest of the [Transaction].
+	AggregateKey() bls.PublicKey
+
+	// AggregateSignature is the signature of [AggregateKey] or nil if it was
+	// removed when the [Transaction] was included in a block.
+	AggregateSignature() *bls.Signature
+
+	// Aggregated returns a copy of [AggregateAuth] without its
+	// [AggregateSignature] (as it is included in a block).
+	Aggregated() AggregateAuth
+}
+
 type AuthFactory interface {
 	// Sign is used by helpers, auth object should store internally to be ready for marshaling
 	Sign(msg []byte, action Action) (Auth, error)
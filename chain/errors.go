@@ -54,6 +54,11 @@ var (
 	ErrBlockTooBig     = errors.New("block too big")
 	ErrKeyNotSpecified = errors.New("key not specified")
 
+	// Aggregate Signatures
+	ErrMissingAuthSignature    = errors.New("missing auth signature")
+	ErrUnexpectedAuthSignature = errors.New("unexpected auth signature")
+	ErrInvalidAuthSignature    = errors.New("invalid auth signature")
+
 	// Warp
 	ErrDisabledChainID           = errors.New("cannot import from chain ID")
 	ErrMissingBlockContext       = errors.New("cannot verify warp messages without block context")
@@ -63,6 +68,9 @@ var (
 	ErrEmptyWarpPayload          = errors.New("empty warp payload")
 	ErrTooManyWarpMessages       = errors.New("too many warp messages")
 	ErrWarpResultMismatch        = errors.New("warp result mismatch")
+	ErrUnknownWarpPayload        = errors.New("unknown warp payload")
+	ErrUnexpectedWarpPayload     = errors.New("unexpected warp payload")
+	ErrReplyNotRequested         = errors.New("reply not requested")
 
 	// Misc
 	ErrNotImplemented         = errors.New("not implemented")
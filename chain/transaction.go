@@ -37,6 +37,7 @@ type Transaction struct {
 
 	digest         []byte
 	bytes          []byte
+	blockBytes     []byte // differs from [bytes] if authorized by an [AggregateAuth]
 	size           int
 	id             ids.ID
 	numWarpSigners int
@@ -598,6 +599,16 @@ func (t *Transaction) Marshal(p *codec.Packer) error {
 	return p.Err()
 }
 
+// marshalBlock encodes [t] as it is included in a block (without the
+// signature of an [AggregateAuth]).
+func (t *Transaction) marshalBlock(p *codec.Packer) error {
+	if len(t.blockBytes) > 0 {
+		p.PackFixedBytes(t.blockBytes)
+		return p.Err()
+	}
+	return t.Marshal(p)
+}
+
 func MarshalTxs(txs []*Transaction) ([]byte, error) {
 	if len(txs) == 0 {
 		return nil, ErrNoTxs
@@ -638,15 +649,41 @@ func UnmarshalTxs(
 	return authCounts, txs, p.Err()
 }
 
-func UnmarshalTx(
+// UnmarshalUnsignedTx unmarshals a [Transaction] that has not yet been
+// signed (as encoded by [Transaction.Digest]).
+//
+// This can be used to pass a [Transaction] between signers that can't sign
+// it at the same time (like the co-signers of a multisig). [p] must not contain
+// anything other than the unsigned transaction.
+func UnmarshalUnsignedTx(
 	p *codec.Packer,
 	actionRegistry *codec.TypeParser[Action, *warp.Message, bool],
-	authRegistry *codec.TypeParser[Auth, *warp.Message, bool],
 ) (*Transaction, error) {
 	start := p.Offset()
+	tx, _, err := unmarshalUnsignedTx(p, actionRegistry)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.Err(); err != nil {
+		return nil, err
+	}
+	if !p.Empty() {
+		// Ensure no leftover bytes
+		return nil, ErrInvalidObject
+	}
+	tx.digest = p.Bytes()[start:p.Offset()]
+	return tx, nil
+}
+
+// unmarshalUnsignedTx unmarshals the [Base], [warp.Message], and [Action] of
+// a [Transaction] and returns whether the [Action] expects a [warp.Message].
+func unmarshalUnsignedTx(
+	p *codec.Packer,
+	actionRegistry *codec.TypeParser[Action, *warp.Message, bool],
+) (*Transaction, bool, error) {
 	base, err := UnmarshalBase(p)
 	if err != nil {
-		return nil, fmt.Errorf("%w: could not unmarshal base", err)
+		return nil, false, fmt.Errorf("%w: could not unmarshal base", err)
 	}
 	var warpBytes []byte
 	p.UnpackBytes(MaxWarpMessageSize, false, &warpBytes)
@@ -655,30 +692,46 @@ func UnmarshalTx(
 	if len(warpBytes) > 0 {
 		msg, err := warp.ParseMessage(warpBytes)
 		if err != nil {
-			return nil, fmt.Errorf("%w: could not unmarshal warp message", err)
+			return nil, false, fmt.Errorf("%w: could not unmarshal warp message", err)
 		}
 		if len(msg.Payload) == 0 {
-			return nil, ErrEmptyWarpPayload
+			return nil, false, ErrEmptyWarpPayload
 		}
 		warpMessage = msg
 		numSigners, err := msg.Signature.NumSigners()
 		if err != nil {
-			return nil, fmt.Errorf("%w: could not calculate number of warp signers", err)
+			return nil, false, fmt.Errorf("%w: could not calculate number of warp signers", err)
 		}
 		numWarpSigners = numSigners
 	}
 	actionType := p.UnpackByte()
 	unmarshalAction, actionWarp, ok := actionRegistry.LookupIndex(actionType)
 	if !ok {
-		return nil, fmt.Errorf("%w: %d is unknown action type", ErrInvalidObject, actionType)
+		return nil, false, fmt.Errorf("%w: %d is unknown action type", ErrInvalidObject, actionType)
 	}
 	if actionWarp && warpMessage == nil {
-		return nil, fmt.Errorf("%w: action %d", ErrExpectedWarpMessage, actionType)
+		return nil, false, fmt.Errorf("%w: action %d", ErrExpectedWarpMessage, actionType)
 	}
 	action, err := unmarshalAction(p, warpMessage)
 	if err != nil {
-		return nil, fmt.Errorf("%w: could not unmarshal action", err)
+		return nil, false, fmt.Errorf("%w: could not unmarshal action", err)
 	}
+	tx := NewTx(base, warpMessage, action)
+	tx.numWarpSigners = numWarpSigners
+	return tx, actionWarp, nil
+}
+
+func UnmarshalTx(
+	p *codec.Packer,
+	actionRegistry *codec.TypeParser[Action, *warp.Message, bool],
+	authRegistry *codec.TypeParser[Auth, *warp.Message, bool],
+) (*Transaction, error) {
+	start := p.Offset()
+	tx, actionWarp, err := unmarshalUnsignedTx(p, actionRegistry)
+	if err != nil {
+		return nil, err
+	}
+	warpMessage := tx.WarpMessage
 	digest := p.Offset()
 	authType := p.UnpackByte()
 	unmarshalAuth, authWarp, ok := authRegistry.LookupIndex(authType)
@@ -697,10 +750,6 @@ func UnmarshalTx(
 		return nil, ErrUnexpectedWarpMessage
 	}
 
-	var tx Transaction
-	tx.Base = base
-	tx.Action = action
-	tx.WarpMessage = warpMessage
 	tx.Auth = auth
 	if err := p.Err(); err != nil {
 		return nil, p.Err()
@@ -708,11 +757,24 @@ func UnmarshalTx(
 	codecBytes := p.Bytes()
 	tx.digest = codecBytes[start:digest]
 	tx.bytes = codecBytes[start:p.Offset()] // ensure errors handled before grabbing memory
-	tx.size = len(tx.bytes)
-	tx.id = utils.ToID(tx.bytes)
+	tx.blockBytes = tx.bytes
+	if aauth, ok := auth.(AggregateAuth); ok && aauth.AggregateSignature() != nil {
+		// The signature of an [AggregateAuth] is removed when it is included in
+		// a block, so it is not included in the ID or size of [tx].
+		aggregated := aauth.Aggregated()
+		bp := codec.NewWriter(len(tx.digest)+consts.ByteLen+aggregated.Size(), consts.NetworkSizeLimit)
+		bp.PackFixedBytes(tx.digest)
+		bp.PackByte(aggregated.GetTypeID())
+		aggregated.Marshal(bp)
+		if err := bp.Err(); err != nil {
+			return nil, err
+		}
+		tx.blockBytes = bp.Bytes()
+	}
+	tx.size = len(tx.blockBytes)
+	tx.id = utils.ToID(tx.blockBytes)
 	if tx.WarpMessage != nil {
-		tx.numWarpSigners = numWarpSigners
 		tx.warpID = tx.WarpMessage.ID()
 	}
-	return &tx, nil
+	return tx, nil
 }
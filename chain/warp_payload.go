@@ -0,0 +1,240 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chain
+
+import (
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+)
+
+const (
+	// CrossChainCallID and CrossChainReplyID are reserved by the hypersdk. VMs
+	// registering their own payloads must use other IDs.
+	CrossChainCallID  uint8 = 0xfe
+	CrossChainReplyID uint8 = 0xff
+
+	// MaxCrossChainMethodSize is the maximum size of the method name of a
+	// [CrossChainCall].
+	MaxCrossChainMethodSize = 256
+)
+
+// WarpPayload is a typed payload of a warp message sent by an [Action].
+//
+// Payloads are prefixed with their type ID (see [MarshalWarpPayload]) so that
+// the receiving chain can decode them with its [WarpPayloadRegistry].
+type WarpPayload interface {
+	// GetTypeID uniquely identifies the payload in the [WarpPayloadRegistry].
+	GetTypeID() uint8
+
+	// Size is the number of bytes it takes to represent this payload (not
+	// including the type ID).
+	Size() int
+
+	// Marshal encodes a payload as bytes.
+	Marshal(p *codec.Packer)
+}
+
+// WarpPayloadRegistry decodes the payloads of verified warp messages by their
+// type ID. The decoder is provided the [warp.Message] the payload was included
+// in.
+type WarpPayloadRegistry struct {
+	decoders map[uint8]func(*codec.Packer, *warp.Message) (WarpPayload, error)
+}
+
+// NewWarpPayloadRegistry returns a [WarpPayloadRegistry] that already
+// includes [CrossChainCall] and [CrossChainReply].
+func NewWarpPayloadRegistry() (*WarpPayloadRegistry, error) {
+	registry := &WarpPayloadRegistry{
+		decoders: map[uint8]func(*codec.Packer, *warp.Message) (WarpPayload, error){},
+	}
+	if err := registry.Register(CrossChainCallID, UnmarshalCrossChainCall); err != nil {
+		return nil, err
+	}
+	if err := registry.Register(CrossChainReplyID, UnmarshalCrossChainReply); err != nil {
+		return nil, err
+	}
+	return registry, nil
+}
+
+// Register sets the decoder of the payloads with [typeID] to [f].
+func (r *WarpPayloadRegistry) Register(
+	typeID uint8,
+	f func(*codec.Packer, *warp.Message) (WarpPayload, error),
+) error {
+	if _, ok := r.decoders[typeID]; ok {
+		return codec.ErrDuplicateItem
+	}
+	r.decoders[typeID] = f
+	return nil
+}
+
+// MarshalWarpPayload encodes [payload] (prefixed by its type ID) so that it
+// can be used as the payload of an [warp.UnsignedMessage].
+func MarshalWarpPayload(payload WarpPayload) ([]byte, error) {
+	size := consts.ByteLen + payload.Size()
+	p := codec.NewWriter(size, MaxWarpMessageSize)
+	p.PackByte(payload.GetTypeID())
+	payload.Marshal(p)
+	return p.Bytes(), p.Err()
+}
+
+// UnmarshalWarpPayload decodes the payload of [msg] using [registry].
+func UnmarshalWarpPayload(registry *WarpPayloadRegistry, msg *warp.Message) (WarpPayload, error) {
+	if msg == nil {
+		return nil, ErrExpectedWarpMessage
+	}
+	if len(msg.Payload) == 0 {
+		return nil, ErrEmptyWarpPayload
+	}
+	p := codec.NewReader(msg.Payload, MaxWarpMessageSize)
+	typeID := p.UnpackByte()
+	unmarshalPayload, ok := registry.decoders[typeID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %d", ErrUnknownWarpPayload, typeID)
+	}
+	payload, err := unmarshalPayload(p, msg)
+	if err != nil {
+		return nil, fmt.Errorf("%w: could not unmarshal warp payload", err)
+	}
+	if !p.Empty() {
+		return nil, ErrInvalidObject
+	}
+	return payload, p.Err()
+}
+
+// ParseWarpPayload decodes the payload of [msg] and ensures it is of type [T].
+//
+// This should be used by actions that consume a verified warp message instead
+// of parsing [warp.Message.Payload] by hand.
+func ParseWarpPayload[T WarpPayload](registry *WarpPayloadRegistry, msg *warp.Message) (T, error) {
+	var empty T
+	payload, err := UnmarshalWarpPayload(registry, msg)
+	if err != nil {
+		return empty, err
+	}
+	typed, ok := payload.(T)
+	if !ok {
+		return empty, fmt.Errorf("%w: got %d", ErrUnexpectedWarpPayload, payload.GetTypeID())
+	}
+	return typed, nil
+}
+
+var (
+	_ WarpPayload = (*CrossChainCall)(nil)
+	_ WarpPayload = (*CrossChainReply)(nil)
+)
+
+// CrossChainCall is a generic request for [DestinationChainID] to execute
+// [Method] with [Args]. How [Method] and [Args] are interpreted is up to the
+// destination VM.
+type CrossChainCall struct {
+	// TxID is the transaction that created this message. This is used to ensure
+	// there is WarpID uniqueness.
+	TxID ids.ID `json:"txID"`
+
+	// DestinationChainID is the destination of this call. We assume this must
+	// be populated (not anycast).
+	DestinationChainID ids.ID `json:"destinationChainID"`
+
+	Method []byte `json:"method"`
+	Args   []byte `json:"args"`
+
+	// ReplyRequested is set to true when the caller expects the destination to
+	// send back a [CrossChainReply].
+	ReplyRequested bool `json:"replyRequested"`
+}
+
+func (*CrossChainCall) GetTypeID() uint8 {
+	return CrossChainCallID
+}
+
+func (c *CrossChainCall) Size() int {
+	return consts.IDLen*2 + codec.BytesLen(c.Method) + codec.BytesLen(c.Args) + consts.BoolLen
+}
+
+func (c *CrossChainCall) Marshal(p *codec.Packer) {
+	p.PackID(c.TxID)
+	p.PackID(c.DestinationChainID)
+	p.PackBytes(c.Method)
+	p.PackBytes(c.Args)
+	p.PackBool(c.ReplyRequested)
+}
+
+// Reply creates a [CrossChainReply] to [c], which was received in [msg]. The
+// reply is sent back to the chain that issued the call.
+func (c *CrossChainCall) Reply(
+	txID ids.ID,
+	msg *warp.Message,
+	success bool,
+	result []byte,
+) (*CrossChainReply, error) {
+	if !c.ReplyRequested {
+		return nil, ErrReplyNotRequested
+	}
+	return &CrossChainReply{
+		TxID:               txID,
+		CallTxID:           c.TxID,
+		DestinationChainID: msg.SourceChainID,
+		Success:            success,
+		Result:             result,
+	}, nil
+}
+
+func UnmarshalCrossChainCall(p *codec.Packer, _ *warp.Message) (WarpPayload, error) {
+	var call CrossChainCall
+	p.UnpackID(true, &call.TxID)
+	p.UnpackID(true, &call.DestinationChainID)
+	p.UnpackBytes(MaxCrossChainMethodSize, true, &call.Method)
+	p.UnpackBytes(MaxWarpMessageSize, false, &call.Args)
+	call.ReplyRequested = p.UnpackBool()
+	return &call, p.Err()
+}
+
+// CrossChainReply is the result of executing a [CrossChainCall] on the
+// destination chain.
+type CrossChainReply struct {
+	// TxID is the transaction that created this message. This is used to ensure
+	// there is WarpID uniqueness.
+	TxID ids.ID `json:"txID"`
+
+	// CallTxID is the [CrossChainCall.TxID] this is a reply to.
+	CallTxID ids.ID `json:"callTxID"`
+
+	// DestinationChainID is the chain that issued the [CrossChainCall].
+	DestinationChainID ids.ID `json:"destinationChainID"`
+
+	Success bool   `json:"success"`
+	Result  []byte `json:"result"`
+}
+
+func (*CrossChainReply) GetTypeID() uint8 {
+	return CrossChainReplyID
+}
+
+func (r *CrossChainReply) Size() int {
+	return consts.IDLen*3 + consts.BoolLen + codec.BytesLen(r.Result)
+}
+
+func (r *CrossChainReply) Marshal(p *codec.Packer) {
+	p.PackID(r.TxID)
+	p.PackID(r.CallTxID)
+	p.PackID(r.DestinationChainID)
+	p.PackBool(r.Success)
+	p.PackBytes(r.Result)
+}
+
+func UnmarshalCrossChainReply(p *codec.Packer, _ *warp.Message) (WarpPayload, error) {
+	var reply CrossChainReply
+	p.UnpackID(true, &reply.TxID)
+	p.UnpackID(true, &reply.CallTxID)
+	p.UnpackID(true, &reply.DestinationChainID)
+	reply.Success = p.UnpackBool()
+	p.UnpackBytes(MaxWarpMessageSize, false, &reply.Result)
+	return &reply, p.Err()
+}
@@ -0,0 +1,161 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chain
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/codec"
+)
+
+func newWarpMessage(t *testing.T, sourceChainID ids.ID, payload []byte) *warp.Message {
+	unsigned, err := warp.NewUnsignedMessage(1, sourceChainID, payload)
+	require.NoError(t, err)
+	msg, err := warp.NewMessage(unsigned, &warp.BitSetSignature{})
+	require.NoError(t, err)
+	return msg
+}
+
+func TestWarpPayloadRoundTrip(t *testing.T) {
+	call := &CrossChainCall{
+		TxID:               ids.GenerateTestID(),
+		DestinationChainID: ids.GenerateTestID(),
+		Method:             []byte("transfer"),
+		Args:               []byte{1, 2, 3},
+		ReplyRequested:     true,
+	}
+	tests := []struct {
+		name    string
+		payload WarpPayload
+	}{
+		{name: "call", payload: call},
+		{name: "call without args", payload: &CrossChainCall{
+			TxID:               ids.GenerateTestID(),
+			DestinationChainID: ids.GenerateTestID(),
+			Method:             []byte("ping"),
+			Args:               []byte{},
+		}},
+		{name: "reply", payload: &CrossChainReply{
+			TxID:               ids.GenerateTestID(),
+			CallTxID:           call.TxID,
+			DestinationChainID: ids.GenerateTestID(),
+			Success:            true,
+			Result:             []byte{4, 5},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			registry, err := NewWarpPayloadRegistry()
+			require.NoError(err)
+			b, err := MarshalWarpPayload(tt.payload)
+			require.NoError(err)
+			require.Len(b, 1+tt.payload.Size())
+			require.Equal(tt.payload.GetTypeID(), b[0])
+
+			payload, err := UnmarshalWarpPayload(registry, newWarpMessage(t, ids.GenerateTestID(), b))
+			require.NoError(err)
+			require.Equal(tt.payload, payload)
+		})
+	}
+}
+
+func TestParseWarpPayload(t *testing.T) {
+	require := require.New(t)
+
+	registry, err := NewWarpPayloadRegistry()
+	require.NoError(err)
+	sourceChainID := ids.GenerateTestID()
+	call := &CrossChainCall{
+		TxID:               ids.GenerateTestID(),
+		DestinationChainID: ids.GenerateTestID(),
+		Method:             []byte("transfer"),
+		Args:               []byte{},
+		ReplyRequested:     true,
+	}
+	b, err := MarshalWarpPayload(call)
+	require.NoError(err)
+	msg := newWarpMessage(t, sourceChainID, b)
+
+	// Payloads are decoded as the requested type
+	parsed, err := ParseWarpPayload[*CrossChainCall](registry, msg)
+	require.NoError(err)
+	require.Equal(call, parsed)
+	_, err = ParseWarpPayload[*CrossChainReply](registry, msg)
+	require.ErrorIs(err, ErrUnexpectedWarpPayload)
+
+	// Replies are sent back to the calling chain
+	txID := ids.GenerateTestID()
+	reply, err := parsed.Reply(txID, msg, true, []byte{1})
+	require.NoError(err)
+	require.Equal(&CrossChainReply{
+		TxID:               txID,
+		CallTxID:           call.TxID,
+		DestinationChainID: sourceChainID,
+		Success:            true,
+		Result:             []byte{1},
+	}, reply)
+	call.ReplyRequested = false
+	_, err = call.Reply(txID, msg, true, nil)
+	require.ErrorIs(err, ErrReplyNotRequested)
+}
+
+type testWarpPayload struct {
+	Value uint64
+}
+
+func (*testWarpPayload) GetTypeID() uint8 { return 0 }
+
+func (*testWarpPayload) Size() int { return 8 }
+
+func (w *testWarpPayload) Marshal(p *codec.Packer) { p.PackUint64(w.Value) }
+
+func unmarshalTestWarpPayload(p *codec.Packer, _ *warp.Message) (WarpPayload, error) {
+	return &testWarpPayload{Value: p.UnpackUint64(true)}, p.Err()
+}
+
+func TestUnmarshalWarpPayloadInvalid(t *testing.T) {
+	require := require.New(t)
+
+	registry, err := NewWarpPayloadRegistry()
+	require.NoError(err)
+	reply, err := MarshalWarpPayload(&CrossChainReply{
+		TxID:               ids.GenerateTestID(),
+		CallTxID:           ids.GenerateTestID(),
+		DestinationChainID: ids.GenerateTestID(),
+		Result:             []byte{},
+	})
+	require.NoError(err)
+	custom, err := MarshalWarpPayload(&testWarpPayload{Value: 1})
+	require.NoError(err)
+
+	tests := []struct {
+		name    string
+		msg     *warp.Message
+		wantErr error
+	}{
+		{name: "no message", wantErr: ErrExpectedWarpMessage},
+		{name: "empty payload", msg: newWarpMessage(t, ids.Empty, nil), wantErr: ErrEmptyWarpPayload},
+		{name: "unknown type", msg: newWarpMessage(t, ids.Empty, custom), wantErr: ErrUnknownWarpPayload},
+		{name: "trailing bytes", msg: newWarpMessage(t, ids.Empty, append(reply, 0)), wantErr: ErrInvalidObject},
+		{name: "truncated", msg: newWarpMessage(t, ids.Empty, reply[:len(reply)-1]), wantErr: wrappers.ErrInsufficientLength},
+	}
+	for _, tt := range tests {
+		_, err := UnmarshalWarpPayload(registry, tt.msg)
+		require.ErrorIs(err, tt.wantErr, tt.name)
+	}
+
+	// VMs can register their own payloads
+	require.NoError(registry.Register(0, unmarshalTestWarpPayload))
+	payload, err := ParseWarpPayload[*testWarpPayload](registry, newWarpMessage(t, ids.Empty, custom))
+	require.NoError(err)
+	require.Equal(uint64(1), payload.Value)
+	require.ErrorIs(registry.Register(CrossChainCallID, unmarshalTestWarpPayload), codec.ErrDuplicateItem)
+}
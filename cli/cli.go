@@ -12,6 +12,7 @@ type Handler struct {
 	c Controller
 
 	db database.Database
+	ks *Keystore
 }
 
 func New(c Controller) (*Handler, error) {
@@ -19,5 +20,5 @@ func New(c Controller) (*Handler, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &Handler{c, db}, nil
+	return &Handler{c, db, NewKeystore(db, keystorePrefix)}, nil
 }
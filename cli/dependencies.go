@@ -13,4 +13,8 @@ type Controller interface {
 	Decimals() uint8
 	Address(ed25519.PublicKey) string
 	ParseAddress(string) (ed25519.PublicKey, error)
+
+	// KeyAddress returns the account controlled by the private key [bytes]
+	// of [KeyType].
+	KeyAddress(KeyType, []byte) (ed25519.PublicKey, error)
 }
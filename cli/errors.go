@@ -14,5 +14,21 @@ var (
 	ErrDuplicate           = errors.New("duplicate")
 	ErrNoChains            = errors.New("no available chains")
 	ErrNoKeys              = errors.New("no available keys")
+	ErrUnknownKeyType      = errors.New("unknown key type")
+	ErrInvalidKey          = errors.New("invalid key")
 	ErrTxFailed            = errors.New("tx failed on-chain")
+	ErrInvalidThreshold    = errors.New("invalid threshold")
+	ErrNotSigner           = errors.New("key is not a signer")
+)
+
+var (
+	ErrKeystoreLocked         = errors.New("keystore is locked")
+	ErrKeystoreInitialized    = errors.New("keystore already initialized")
+	ErrKeystoreNotInitialized = errors.New("keystore not initialized")
+	ErrInvalidKeystore        = errors.New("invalid keystore")
+	ErrWrongPassphrase        = errors.New("wrong passphrase")
+	ErrPassphraseMismatch     = errors.New("passphrases do not match")
+	ErrInvalidMnemonic        = errors.New("invalid mnemonic")
+	ErrSeedExists             = errors.New("seed already stored")
+	ErrNoSeed                 = errors.New("no stored seed")
 )
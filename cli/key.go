@@ -5,48 +5,229 @@ package cli
 
 import (
 	"context"
+	"fmt"
+	"os"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/hypersdk/crypto/ed25519"
+	"github.com/ava-labs/hypersdk/crypto/secp256r1"
 	"github.com/ava-labs/hypersdk/rpc"
 	"github.com/ava-labs/hypersdk/utils"
 )
 
-func (h *Handler) GenerateKey() error {
-	// TODO: encrypt key
-	priv, err := ed25519.GeneratePrivateKey()
+// KeyType is the signature scheme of a [PrivateKey].
+type KeyType uint8
+
+const (
+	ED25519Key KeyType = iota
+	SECP256R1Key
+)
+
+func (t KeyType) String() string {
+	switch t {
+	case ED25519Key:
+		return "ed25519"
+	case SECP256R1Key:
+		return "secp256r1"
+	default:
+		return fmt.Sprintf("unknown(%d)", uint8(t))
+	}
+}
+
+// ParseKeyType returns the [KeyType] named [s].
+func ParseKeyType(s string) (KeyType, error) {
+	switch s {
+	case ED25519Key.String():
+		return ED25519Key, nil
+	case SECP256R1Key.String():
+		return SECP256R1Key, nil
+	default:
+		return 0, fmt.Errorf("%w: %s", ErrUnknownKeyType, s)
+	}
+}
+
+// PrivateKey is a key stored by the CLI and the account (as derived by the
+// [Controller]) it controls.
+type PrivateKey struct {
+	Type    KeyType
+	Bytes   []byte
+	Address ed25519.PublicKey
+}
+
+// NewKey returns a [PrivateKey] of [keyType] with [bytes].
+func (h *Handler) NewKey(keyType KeyType, bytes []byte) (*PrivateKey, error) {
+	switch keyType {
+	case ED25519Key:
+		if len(bytes) != ed25519.PrivateKeyLen {
+			return nil, ErrInvalidKey
+		}
+	case SECP256R1Key:
+		if len(bytes) != secp256r1.PrivateKeyLen {
+			return nil, ErrInvalidKey
+		}
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownKeyType, keyType)
+	}
+	address, err := h.c.KeyAddress(keyType, bytes)
+	if err != nil {
+		return nil, err
+	}
+	return &PrivateKey{keyType, bytes, address}, nil
+}
+
+// PassphraseEnv is the environment variable that, if set, is used as the
+// passphrase of the keystore instead of prompting for it (which is useful for
+// scripts).
+const PassphraseEnv = "HYPERSDK_KEYSTORE_PASSPHRASE"
+
+// UnlockKeystore unlocks the keystore (if it is locked), prompting for its
+// passphrase.
+//
+// If the keystore has not been initialized, a new passphrase is set and any
+// plaintext keys (stored by older versions of the CLI) are encrypted.
+func (h *Handler) UnlockKeystore() error {
+	if !h.ks.Locked() {
+		return nil
+	}
+	initialized, err := h.ks.Initialized()
+	if err != nil {
+		return err
+	}
+	passphrase := os.Getenv(PassphraseEnv)
+	if initialized {
+		if len(passphrase) == 0 {
+			passphrase, err = h.PromptPassphrase("keystore passphrase", false)
+			if err != nil {
+				return err
+			}
+		}
+		return h.ks.Unlock(passphrase)
+	}
+	if len(passphrase) == 0 {
+		utils.Outf("{{yellow}}creating encrypted keystore{{/}}\n")
+		passphrase, err = h.PromptPassphrase("new keystore passphrase", true)
+		if err != nil {
+			return err
+		}
+	}
+	if err := h.ks.Initialize(passphrase); err != nil {
+		return err
+	}
+	return h.migrateKeys()
+}
+
+func (h *Handler) GenerateKey(keyType KeyType) error {
+	var bytes []byte
+	switch keyType {
+	case ED25519Key:
+		priv, err := ed25519.GeneratePrivateKey()
+		if err != nil {
+			return err
+		}
+		bytes = priv[:]
+	case SECP256R1Key:
+		priv, err := secp256r1.GeneratePrivateKey()
+		if err != nil {
+			return err
+		}
+		bytes = priv[:]
+	default:
+		return fmt.Errorf("%w: %s", ErrUnknownKeyType, keyType)
+	}
+	priv, err := h.NewKey(keyType, bytes)
 	if err != nil {
 		return err
 	}
 	if err := h.StoreKey(priv); err != nil {
 		return err
 	}
-	publicKey := priv.PublicKey()
-	if err := h.StoreDefaultKey(publicKey); err != nil {
+	if err := h.StoreDefaultKey(priv.Address); err != nil {
 		return err
 	}
 	utils.Outf(
-		"{{green}}created address:{{/}} %s",
-		h.c.Address(publicKey),
+		"{{green}}created address (%s):{{/}} %s",
+		keyType,
+		h.c.Address(priv.Address),
 	)
 	return nil
 }
 
-func (h *Handler) ImportKey(keyPath string) error {
-	priv, err := ed25519.LoadKey(keyPath)
+// ImportKey imports the key at [keyPath], which is either a raw key of
+// [keyType] or a key encrypted with [EncryptKey] (which includes its type).
+func (h *Handler) ImportKey(keyType KeyType, keyPath string) error {
+	data, err := os.ReadFile(keyPath)
+	if err != nil {
+		return err
+	}
+	var bytes []byte
+	if IsEncryptedKey(data) {
+		passphrase, err := h.PromptPassphrase("key passphrase", false)
+		if err != nil {
+			return err
+		}
+		keyType, bytes, err = DecryptKey(data, passphrase)
+		if err != nil {
+			return err
+		}
+	} else {
+		switch keyType {
+		case ED25519Key:
+			priv, err := ed25519.LoadKey(keyPath)
+			if err != nil {
+				return err
+			}
+			bytes = priv[:]
+		case SECP256R1Key:
+			priv, err := secp256r1.LoadKey(keyPath)
+			if err != nil {
+				return err
+			}
+			bytes = priv[:]
+		default:
+			return fmt.Errorf("%w: %s", ErrUnknownKeyType, keyType)
+		}
+	}
+	priv, err := h.NewKey(keyType, bytes)
 	if err != nil {
 		return err
 	}
 	if err := h.StoreKey(priv); err != nil {
 		return err
 	}
-	publicKey := priv.PublicKey()
-	if err := h.StoreDefaultKey(publicKey); err != nil {
+	if err := h.StoreDefaultKey(priv.Address); err != nil {
 		return err
 	}
 	utils.Outf(
-		"{{green}}imported address:{{/}} %s",
-		h.c.Address(publicKey),
+		"{{green}}imported address (%s):{{/}} %s",
+		keyType,
+		h.c.Address(priv.Address),
+	)
+	return nil
+}
+
+// ExportKey writes the default key to [keyPath], encrypted with a new
+// passphrase (see [EncryptKey]).
+func (h *Handler) ExportKey(keyPath string) error {
+	priv, err := h.GetDefaultKey(true)
+	if err != nil {
+		return err
+	}
+	passphrase, err := h.PromptPassphrase("export passphrase", true)
+	if err != nil {
+		return err
+	}
+	address := h.c.Address(priv.Address)
+	data, err := EncryptKey(priv, address, passphrase)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(keyPath, data, 0o600); err != nil {
+		return err
+	}
+	utils.Outf(
+		"{{green}}exported address (%s):{{/}} %s\n",
+		priv.Type,
+		address,
 	)
 	return nil
 }
@@ -75,7 +256,7 @@ func (h *Handler) SetKey(lookupBalance func(int, string, string, uint32, ids.ID)
 	}
 	utils.Outf("{{cyan}}stored keys:{{/}} %d\n", len(keys))
 	for i := 0; i < len(keys); i++ {
-		if err := lookupBalance(i, h.c.Address(keys[i].PublicKey()), uris[0], networkID, chainID); err != nil {
+		if err := lookupBalance(i, h.c.Address(keys[i].Address), uris[0], networkID, chainID); err != nil {
 			return err
 		}
 	}
@@ -86,11 +267,11 @@ func (h *Handler) SetKey(lookupBalance func(int, string, string, uint32, ids.ID)
 		return err
 	}
 	key := keys[keyIndex]
-	return h.StoreDefaultKey(key.PublicKey())
+	return h.StoreDefaultKey(key.Address)
 }
 
 func (h *Handler) Balance(checkAllChains bool, promptAsset bool, printBalance func(ed25519.PublicKey, string, uint32, ids.ID, ids.ID) error) error {
-	priv, err := h.GetDefaultKey(true)
+	address, err := h.GetDefaultAddress(true)
 	if err != nil {
 		return err
 	}
@@ -117,7 +298,7 @@ func (h *Handler) Balance(checkAllChains bool, promptAsset bool, printBalance fu
 		if err != nil {
 			return err
 		}
-		if err := printBalance(priv.PublicKey(), uri, networkID, chainID, assetID); err != nil {
+		if err := printBalance(address, uri, networkID, chainID, assetID); err != nil {
 			return err
 		}
 	}
@@ -0,0 +1,193 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cli
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"golang.org/x/crypto/scrypt"
+	"golang.org/x/crypto/sha3"
+)
+
+const (
+	keyJSONVersion = 3
+	keyJSONCipher  = "aes-128-ctr"
+	keyJSONKDF     = "scrypt"
+)
+
+// keyJSON is an encrypted key in the Web3 Secret Storage (version 3) format,
+// extended with the [KeyType] of the key.
+//
+// source: https://ethereum.org/en/developers/docs/data-structures-and-encoding/web3-secret-storage
+type keyJSON struct {
+	Version int        `json:"version"`
+	ID      string     `json:"id"`
+	Address string     `json:"address,omitempty"`
+	KeyType string     `json:"keyType"`
+	Crypto  cryptoJSON `json:"crypto"`
+}
+
+type cryptoJSON struct {
+	Cipher       string           `json:"cipher"`
+	CipherText   string           `json:"ciphertext"`
+	CipherParams cipherParamsJSON `json:"cipherparams"`
+	KDF          string           `json:"kdf"`
+	KDFParams    scryptParamsJSON `json:"kdfparams"`
+	MAC          string           `json:"mac"`
+}
+
+type cipherParamsJSON struct {
+	IV string `json:"iv"`
+}
+
+type scryptParamsJSON struct {
+	DKLen int    `json:"dklen"`
+	N     int    `json:"n"`
+	R     int    `json:"r"`
+	P     int    `json:"p"`
+	Salt  string `json:"salt"`
+}
+
+// EncryptKey encrypts [priv] with [passphrase] in the Web3 Secret Storage
+// format. [address] is included (in plaintext) to make it easier to identify
+// the key.
+func EncryptKey(priv *PrivateKey, address string, passphrase string) ([]byte, error) {
+	if len(passphrase) == 0 {
+		return nil, ErrInputEmpty
+	}
+	salt := make([]byte, saltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	derived, err := scrypt.Key([]byte(passphrase), salt, scryptN, scryptR, scryptP, scryptKeyLen)
+	if err != nil {
+		return nil, err
+	}
+	iv := make([]byte, aes.BlockSize)
+	if _, err := rand.Read(iv); err != nil {
+		return nil, err
+	}
+	ciphertext, err := aesCTR(derived[:16], iv, priv.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return nil, err
+	}
+	// Format [id] as a random (version 4) UUID
+	id[6] = (id[6] & 0x0f) | 0x40
+	id[8] = (id[8] & 0x3f) | 0x80
+	return json.MarshalIndent(&keyJSON{
+		Version: keyJSONVersion,
+		ID:      fmt.Sprintf("%x-%x-%x-%x-%x", id[:4], id[4:6], id[6:8], id[8:10], id[10:]),
+		Address: address,
+		KeyType: priv.Type.String(),
+		Crypto: cryptoJSON{
+			Cipher:       keyJSONCipher,
+			CipherText:   hex.EncodeToString(ciphertext),
+			CipherParams: cipherParamsJSON{IV: hex.EncodeToString(iv)},
+			KDF:          keyJSONKDF,
+			KDFParams: scryptParamsJSON{
+				DKLen: scryptKeyLen,
+				N:     scryptN,
+				R:     scryptR,
+				P:     scryptP,
+				Salt:  hex.EncodeToString(salt),
+			},
+			MAC: hex.EncodeToString(keyMAC(derived, ciphertext)),
+		},
+	}, "", "  ")
+}
+
+// IsEncryptedKey returns whether [data] is a key in the format produced by
+// [EncryptKey].
+func IsEncryptedKey(data []byte) bool {
+	var k keyJSON
+	if err := json.Unmarshal(data, &k); err != nil {
+		return false
+	}
+	return k.Version == keyJSONVersion
+}
+
+// DecryptKey decrypts a key produced by [EncryptKey] with [passphrase] and
+// returns its [KeyType] and bytes.
+func DecryptKey(data []byte, passphrase string) (KeyType, []byte, error) {
+	var k keyJSON
+	if err := json.Unmarshal(data, &k); err != nil {
+		return 0, nil, fmt.Errorf("%w: %v", ErrInvalidKeystore, err) //nolint:errorlint
+	}
+	if k.Version != keyJSONVersion {
+		return 0, nil, fmt.Errorf("%w: unsupported version %d", ErrInvalidKeystore, k.Version)
+	}
+	if k.Crypto.Cipher != keyJSONCipher || k.Crypto.KDF != keyJSONKDF {
+		return 0, nil, fmt.Errorf(
+			"%w: unsupported cipher %s with kdf %s",
+			ErrInvalidKeystore,
+			k.Crypto.Cipher,
+			k.Crypto.KDF,
+		)
+	}
+	keyType, err := ParseKeyType(k.KeyType)
+	if err != nil {
+		return 0, nil, err
+	}
+	ciphertext, err := hex.DecodeString(k.Crypto.CipherText)
+	if err != nil {
+		return 0, nil, ErrInvalidKeystore
+	}
+	iv, err := hex.DecodeString(k.Crypto.CipherParams.IV)
+	if err != nil || len(iv) != aes.BlockSize {
+		return 0, nil, ErrInvalidKeystore
+	}
+	mac, err := hex.DecodeString(k.Crypto.MAC)
+	if err != nil {
+		return 0, nil, ErrInvalidKeystore
+	}
+	params := k.Crypto.KDFParams
+	salt, err := hex.DecodeString(params.Salt)
+	if err != nil || params.DKLen < 32 || params.DKLen > maxScryptKeyLen {
+		return 0, nil, ErrInvalidKeystore
+	}
+	if err := checkScryptParams(params.N, params.R, params.P); err != nil {
+		return 0, nil, err
+	}
+	derived, err := scrypt.Key([]byte(passphrase), salt, params.N, params.R, params.P, params.DKLen)
+	if err != nil {
+		return 0, nil, fmt.Errorf("%w: %v", ErrInvalidKeystore, err) //nolint:errorlint
+	}
+	if !bytes.Equal(keyMAC(derived, ciphertext), mac) {
+		return 0, nil, ErrWrongPassphrase
+	}
+	key, err := aesCTR(derived[:16], iv, ciphertext)
+	if err != nil {
+		return 0, nil, err
+	}
+	return keyType, key, nil
+}
+
+// keyMAC is computed over the second half of the derived key and the
+// ciphertext (as specified by the Web3 Secret Storage format).
+func keyMAC(derived []byte, ciphertext []byte) []byte {
+	h := sha3.NewLegacyKeccak256()
+	_, _ = h.Write(derived[16:32])
+	_, _ = h.Write(ciphertext)
+	return h.Sum(nil)
+}
+
+func aesCTR(key []byte, iv []byte, input []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	output := make([]byte, len(input))
+	cipher.NewCTR(block, iv).XORKeyStream(output, input)
+	return output, nil
+}
@@ -0,0 +1,131 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cli
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/crypto/ed25519"
+	"github.com/ava-labs/hypersdk/crypto/secp256r1"
+)
+
+func newTestKeys(t *testing.T) []*PrivateKey {
+	edPriv, err := ed25519.GeneratePrivateKey()
+	require.NoError(t, err)
+	secpPriv, err := secp256r1.GeneratePrivateKey()
+	require.NoError(t, err)
+	return []*PrivateKey{
+		{ED25519Key, edPriv[:], edPriv.PublicKey()},
+		{SECP256R1Key, secpPriv[:], ed25519.PublicKey{1}},
+	}
+}
+
+func TestEncryptKey(t *testing.T) {
+	for _, priv := range newTestKeys(t) {
+		t.Run(priv.Type.String(), func(t *testing.T) {
+			require := require.New(t)
+
+			data, err := EncryptKey(priv, "address", "passphrase")
+			require.NoError(err)
+			require.True(IsEncryptedKey(data))
+
+			// Keys are exported in the Web3 Secret Storage format
+			var k keyJSON
+			require.NoError(json.Unmarshal(data, &k))
+			require.Equal(keyJSONVersion, k.Version)
+			require.Equal("address", k.Address)
+			require.Equal(priv.Type.String(), k.KeyType)
+			require.Equal(keyJSONCipher, k.Crypto.Cipher)
+			require.Equal(keyJSONKDF, k.Crypto.KDF)
+			require.Equal(scryptN, k.Crypto.KDFParams.N)
+			require.Len(k.ID, 36)
+			require.NotContains(string(data), hex.EncodeToString(priv.Bytes))
+
+			keyType, bytes, err := DecryptKey(data, "passphrase")
+			require.NoError(err)
+			require.Equal(priv.Type, keyType)
+			require.Equal(priv.Bytes, bytes)
+
+			_, _, err = DecryptKey(data, "wrong passphrase")
+			require.ErrorIs(err, ErrWrongPassphrase)
+		})
+	}
+
+	_, err := EncryptKey(newTestKeys(t)[0], "address", "")
+	require.ErrorIs(t, err, ErrInputEmpty)
+}
+
+// Test vector from the Web3 Secret Storage definition (with the [KeyType]
+// we require added).
+const web3TestKey = `{
+	"crypto": {
+		"cipher": "aes-128-ctr",
+		"cipherparams": {"iv": "83dbcc02d8ccb40e466191a123791e0e"},
+		"ciphertext": "d172bf743a674da9cdad04534d56926ef8358534d458fffccd4e6ad2fbde479c",
+		"kdf": "scrypt",
+		"kdfparams": {
+			"dklen": 32,
+			"n": 262144,
+			"p": 8,
+			"r": 1,
+			"salt": "ab0c7876052600dd703518d6fc3fe8984592145b591fc8fb5c6d43190334ba19"
+		},
+		"mac": "2103ac29920d71da29f15d75b4a16dbe95cfd7ff8faea1056c33131d846e3097"
+	},
+	"id": "3198bc9c-6672-5ab3-d995-4942343ae5b6",
+	"keyType": "secp256r1",
+	"version": 3
+}`
+
+func TestDecryptWeb3Key(t *testing.T) {
+	require := require.New(t)
+
+	require.True(IsEncryptedKey([]byte(web3TestKey)))
+	keyType, bytes, err := DecryptKey([]byte(web3TestKey), "testpassword")
+	require.NoError(err)
+	require.Equal(SECP256R1Key, keyType)
+	require.Equal("7a28b5ba57c53603b0b07b56bba752f7784bf506fa95edc395f5cf6c7514fe9d", hex.EncodeToString(bytes))
+
+	_, _, err = DecryptKey([]byte(web3TestKey), "wrongpassword")
+	require.ErrorIs(err, ErrWrongPassphrase)
+}
+
+func TestDecryptKeyInvalid(t *testing.T) {
+	modify := func(f func(k *keyJSON)) []byte {
+		var k keyJSON
+		require.NoError(t, json.Unmarshal([]byte(web3TestKey), &k))
+		f(&k)
+		b, err := json.Marshal(&k)
+		require.NoError(t, err)
+		return b
+	}
+	tests := []struct {
+		name    string
+		data    []byte
+		wantErr error
+	}{
+		{name: "not json", data: []byte("key"), wantErr: ErrInvalidKeystore},
+		{name: "version", data: modify(func(k *keyJSON) { k.Version = 2 }), wantErr: ErrInvalidKeystore},
+		{name: "cipher", data: modify(func(k *keyJSON) { k.Crypto.Cipher = "aes-128-cbc" }), wantErr: ErrInvalidKeystore},
+		{name: "kdf", data: modify(func(k *keyJSON) { k.Crypto.KDF = "pbkdf2" }), wantErr: ErrInvalidKeystore},
+		{name: "key type", data: modify(func(k *keyJSON) { k.KeyType = "secp256k1" }), wantErr: ErrUnknownKeyType},
+		{name: "iv", data: modify(func(k *keyJSON) { k.Crypto.CipherParams.IV = "00" }), wantErr: ErrInvalidKeystore},
+		{name: "short dklen", data: modify(func(k *keyJSON) { k.Crypto.KDFParams.DKLen = 16 }), wantErr: ErrInvalidKeystore},
+		{name: "long dklen", data: modify(func(k *keyJSON) { k.Crypto.KDFParams.DKLen = 1 << 20 }), wantErr: ErrInvalidKeystore},
+		{name: "large n", data: modify(func(k *keyJSON) { k.Crypto.KDFParams.N = 1 << 30 }), wantErr: ErrInvalidKeystore},
+		{name: "large r", data: modify(func(k *keyJSON) { k.Crypto.KDFParams.R = 1 << 10 }), wantErr: ErrInvalidKeystore},
+		{name: "large p", data: modify(func(k *keyJSON) { k.Crypto.KDFParams.P = 1 << 10 }), wantErr: ErrInvalidKeystore},
+		{name: "zero n", data: modify(func(k *keyJSON) { k.Crypto.KDFParams.N = 0 }), wantErr: ErrInvalidKeystore},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := DecryptKey(tt.data, "testpassword")
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
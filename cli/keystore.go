@@ -0,0 +1,382 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cli
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/hypersdk/crypto/ed25519"
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	keystoreHeaderPrefix = 0x0
+	keystoreKeyPrefix    = 0x1
+	keystoreSecretPrefix = 0x2
+
+	// Parameters used to derive the encryption key from a passphrase (these
+	// match the "standard" parameters used by most Web3 keystores).
+	scryptN      = 1 << 18
+	scryptR      = 8
+	scryptP      = 1
+	scryptKeyLen = 32
+	saltLen      = 32
+
+	// Limits on the scrypt parameters of keys we decrypt (which are read from
+	// the key itself), so that a malicious key can't make us allocate
+	// (128 * N * r bytes) or compute for an excessive amount of time.
+	maxScryptN      = 1 << 20
+	maxScryptR      = 8
+	maxScryptP      = 16
+	maxScryptKeyLen = 64
+
+	keystoreHeaderLen = saltLen + 3*4
+)
+
+// Keystore stores [PrivateKey]s in a [database.Database], encrypted with a key
+// derived from a passphrase.
+//
+// The encryption key is only held in memory while the [Keystore] is unlocked.
+// Addresses (and the type of key that controls them) are not encrypted, so
+// they can be listed while the [Keystore] is locked.
+type Keystore struct {
+	db     database.Database
+	prefix byte
+
+	l   sync.RWMutex
+	key []byte
+}
+
+// NewKeystore returns a locked [Keystore] that stores its keys in [db] under
+// [prefix].
+func NewKeystore(db database.Database, prefix byte) *Keystore {
+	return &Keystore{db: db, prefix: prefix}
+}
+
+func (k *Keystore) headerKey() []byte {
+	return []byte{k.prefix, keystoreHeaderPrefix}
+}
+
+func (k *Keystore) entryKey(address ed25519.PublicKey) []byte {
+	v := make([]byte, 2+ed25519.PublicKeyLen)
+	v[0] = k.prefix
+	v[1] = keystoreKeyPrefix
+	copy(v[2:], address[:])
+	return v
+}
+
+// Initialized returns whether a passphrase has been set for the [Keystore].
+func (k *Keystore) Initialized() (bool, error) {
+	return k.db.Has(k.headerKey())
+}
+
+// Initialize sets the [passphrase] of an empty [Keystore] and unlocks it.
+func (k *Keystore) Initialize(passphrase string) error {
+	k.l.Lock()
+	defer k.l.Unlock()
+
+	has, err := k.db.Has(k.headerKey())
+	if err != nil {
+		return err
+	}
+	if has {
+		return ErrKeystoreInitialized
+	}
+	if len(passphrase) == 0 {
+		return ErrInputEmpty
+	}
+	header := make([]byte, keystoreHeaderLen)
+	if _, err := rand.Read(header[:saltLen]); err != nil {
+		return err
+	}
+	binary.BigEndian.PutUint32(header[saltLen:], scryptN)
+	binary.BigEndian.PutUint32(header[saltLen+4:], scryptR)
+	binary.BigEndian.PutUint32(header[saltLen+8:], scryptP)
+	key, err := deriveKey(passphrase, header)
+	if err != nil {
+		return err
+	}
+
+	// We store an encryption of nothing so that we can tell if a passphrase is
+	// correct when unlocking.
+	check, err := seal(key, nil, header)
+	if err != nil {
+		return err
+	}
+	if err := k.db.Put(k.headerKey(), append(header, check...)); err != nil {
+		return err
+	}
+	k.key = key
+	return nil
+}
+
+// Unlock derives the encryption key of the [Keystore] from [passphrase].
+func (k *Keystore) Unlock(passphrase string) error {
+	k.l.Lock()
+	defer k.l.Unlock()
+
+	v, err := k.db.Get(k.headerKey())
+	if errors.Is(err, database.ErrNotFound) {
+		return ErrKeystoreNotInitialized
+	}
+	if err != nil {
+		return err
+	}
+	if len(v) < keystoreHeaderLen {
+		return ErrInvalidKeystore
+	}
+	header, check := v[:keystoreHeaderLen], v[keystoreHeaderLen:]
+	key, err := deriveKey(passphrase, header)
+	if err != nil {
+		return err
+	}
+	if _, err := open(key, check, header); err != nil {
+		return ErrWrongPassphrase
+	}
+	k.key = key
+	return nil
+}
+
+// Lock drops the encryption key of the [Keystore] from memory.
+func (k *Keystore) Lock() {
+	k.l.Lock()
+	defer k.l.Unlock()
+
+	for i := range k.key {
+		k.key[i] = 0
+	}
+	k.key = nil
+}
+
+// Locked returns whether the [Keystore] must be unlocked before it can be used
+// to store or retrieve keys.
+func (k *Keystore) Locked() bool {
+	k.l.RLock()
+	defer k.l.RUnlock()
+
+	return k.key == nil
+}
+
+// Put encrypts and stores [priv].
+func (k *Keystore) Put(priv *PrivateKey) error {
+	k.l.RLock()
+	defer k.l.RUnlock()
+
+	if k.key == nil {
+		return ErrKeystoreLocked
+	}
+	ek := k.entryKey(priv.Address)
+	has, err := k.db.Has(ek)
+	if err != nil {
+		return err
+	}
+	if has {
+		return ErrDuplicate
+	}
+	// The address is authenticated (but not encrypted) so that an encrypted key
+	// can't be moved to another address.
+	ciphertext, err := seal(k.key, priv.Bytes, priv.Address[:])
+	if err != nil {
+		return err
+	}
+	v := make([]byte, 1+len(ciphertext))
+	v[0] = byte(priv.Type)
+	copy(v[1:], ciphertext)
+	return k.db.Put(ek, v)
+}
+
+// Get returns the [PrivateKey] that controls [address] (or nil if it is not
+// stored).
+func (k *Keystore) Get(address ed25519.PublicKey) (*PrivateKey, error) {
+	k.l.RLock()
+	defer k.l.RUnlock()
+
+	if k.key == nil {
+		return nil, ErrKeystoreLocked
+	}
+	v, err := k.db.Get(k.entryKey(address))
+	if errors.Is(err, database.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return k.decrypt(address, v)
+}
+
+func (k *Keystore) decrypt(address ed25519.PublicKey, v []byte) (*PrivateKey, error) {
+	if len(v) == 0 {
+		return nil, ErrInvalidKey
+	}
+	bytes, err := open(k.key, v[1:], address[:])
+	if err != nil {
+		return nil, ErrInvalidKey
+	}
+	return &PrivateKey{KeyType(v[0]), bytes, address}, nil
+}
+
+// Keys decrypts and returns all stored [PrivateKey]s.
+func (k *Keystore) Keys() ([]*PrivateKey, error) {
+	k.l.RLock()
+	defer k.l.RUnlock()
+
+	if k.key == nil {
+		return nil, ErrKeystoreLocked
+	}
+	iter := k.db.NewIteratorWithPrefix([]byte{k.prefix, keystoreKeyPrefix})
+	defer iter.Release()
+
+	privateKeys := []*PrivateKey{}
+	for iter.Next() {
+		// It is safe to use these bytes directly because the database copies the
+		// iterator value for us.
+		priv, err := k.decrypt(ed25519.PublicKey(iter.Key()[2:]), iter.Value())
+		if err != nil {
+			return nil, err
+		}
+		privateKeys = append(privateKeys, priv)
+	}
+	return privateKeys, iter.Error()
+}
+
+// Addresses returns the addresses of all stored keys (and their [KeyType]).
+//
+// Unlike [Keys], this can be called while the [Keystore] is locked.
+func (k *Keystore) Addresses() ([]ed25519.PublicKey, []KeyType, error) {
+	iter := k.db.NewIteratorWithPrefix([]byte{k.prefix, keystoreKeyPrefix})
+	defer iter.Release()
+
+	addresses := []ed25519.PublicKey{}
+	keyTypes := []KeyType{}
+	for iter.Next() {
+		v := iter.Value()
+		if len(v) == 0 {
+			return nil, nil, ErrInvalidKey
+		}
+		addresses = append(addresses, ed25519.PublicKey(iter.Key()[2:]))
+		keyTypes = append(keyTypes, KeyType(v[0]))
+	}
+	return addresses, keyTypes, iter.Error()
+}
+
+func (k *Keystore) secretKey(name string) []byte {
+	v := make([]byte, 2+len(name))
+	v[0] = k.prefix
+	v[1] = keystoreSecretPrefix
+	copy(v[2:], name)
+	return v
+}
+
+// PutSecret encrypts and stores [secret] (such as the seed keys are derived
+// from) under [name].
+func (k *Keystore) PutSecret(name string, secret []byte) error {
+	k.l.RLock()
+	defer k.l.RUnlock()
+
+	if k.key == nil {
+		return ErrKeystoreLocked
+	}
+	sk := k.secretKey(name)
+	has, err := k.db.Has(sk)
+	if err != nil {
+		return err
+	}
+	if has {
+		return ErrDuplicate
+	}
+	ciphertext, err := seal(k.key, secret, []byte(name))
+	if err != nil {
+		return err
+	}
+	return k.db.Put(sk, ciphertext)
+}
+
+// GetSecret returns the secret stored under [name] (or nil if it is not
+// stored).
+func (k *Keystore) GetSecret(name string) ([]byte, error) {
+	k.l.RLock()
+	defer k.l.RUnlock()
+
+	if k.key == nil {
+		return nil, ErrKeystoreLocked
+	}
+	v, err := k.db.Get(k.secretKey(name))
+	if errors.Is(err, database.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	secret, err := open(k.key, v, []byte(name))
+	if err != nil {
+		return nil, ErrInvalidKeystore
+	}
+	return secret, nil
+}
+
+// deriveKey derives an encryption key from [passphrase] using the salt and
+// scrypt parameters in [header].
+func deriveKey(passphrase string, header []byte) ([]byte, error) {
+	var (
+		salt = header[:saltLen]
+		n    = binary.BigEndian.Uint32(header[saltLen:])
+		r    = binary.BigEndian.Uint32(header[saltLen+4:])
+		p    = binary.BigEndian.Uint32(header[saltLen+8:])
+	)
+	if err := checkScryptParams(int(n), int(r), int(p)); err != nil {
+		return nil, err
+	}
+	return scrypt.Key([]byte(passphrase), salt, int(n), int(r), int(p), scryptKeyLen)
+}
+
+// checkScryptParams ensures that deriving a key with [n], [r], and [p] is
+// within our limits.
+func checkScryptParams(n int, r int, p int) error {
+	if n <= 1 || n > maxScryptN || r <= 0 || r > maxScryptR || p <= 0 || p > maxScryptP {
+		return fmt.Errorf("%w: unsupported scrypt parameters (N=%d, r=%d, p=%d)", ErrInvalidKeystore, n, r, p)
+	}
+	return nil
+}
+
+// seal encrypts and authenticates [plaintext] (and authenticates [data]) with
+// AES-GCM, prefixing the result with a random nonce.
+func seal(key []byte, plaintext []byte, data []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize(), gcm.NonceSize()+len(plaintext)+gcm.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return gcm.Seal(nonce, nonce, plaintext, data), nil
+}
+
+// open decrypts a ciphertext produced by [seal].
+func open(key []byte, ciphertext []byte, data []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(ciphertext) < gcm.NonceSize() {
+		return nil, ErrInvalidKeystore
+	}
+	nonce, ciphertext := ciphertext[:gcm.NonceSize()], ciphertext[gcm.NonceSize():]
+	return gcm.Open(nil, nonce, ciphertext, data)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
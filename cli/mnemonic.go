@@ -0,0 +1,177 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cli
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/crypto/slip10"
+	"github.com/ava-labs/hypersdk/utils"
+	"github.com/tyler-smith/go-bip39"
+)
+
+const (
+	// DerivationPath is the SLIP-10 path of keys derived from a seed (followed
+	// by the hardened index of the key). 9000 is the coin type of Avalanche.
+	DerivationPath = "m/44'/9000'/0'/0'"
+
+	mnemonicEntropy = 256 // 24 words
+
+	seedSecret         = "seed"
+	derivationIndexKey = "derivationIndex"
+)
+
+// GenerateMnemonicKey generates a new BIP-39 mnemonic, stores its seed, and
+// derives the first key of [keyType] from it.
+//
+// The mnemonic is only printed once and is the only way to recover the keys
+// derived from it.
+func (h *Handler) GenerateMnemonicKey(keyType KeyType) error {
+	entropy, err := bip39.NewEntropy(mnemonicEntropy)
+	if err != nil {
+		return err
+	}
+	mnemonic, err := bip39.NewMnemonic(entropy)
+	if err != nil {
+		return err
+	}
+	if err := h.storeSeed(mnemonic); err != nil {
+		return err
+	}
+	utils.Outf(
+		"{{yellow}}mnemonic (write this down, it is the only way to recover derived keys):{{/}} %s\n",
+		mnemonic,
+	)
+	return h.deriveKeys(keyType, 1)
+}
+
+// RecoverKey stores the seed of a BIP-39 mnemonic and derives the first keys
+// of [keyType] from it.
+func (h *Handler) RecoverKey(keyType KeyType) error {
+	mnemonic, err := h.PromptString("mnemonic", 1, consts.MaxInt)
+	if err != nil {
+		return err
+	}
+	mnemonic = strings.Join(strings.Fields(strings.ToLower(mnemonic)), " ")
+	if !bip39.IsMnemonicValid(mnemonic) {
+		return ErrInvalidMnemonic
+	}
+	count, err := h.PromptInt("keys to recover", consts.MaxInt)
+	if err != nil {
+		return err
+	}
+	if err := h.storeSeed(mnemonic); err != nil {
+		return err
+	}
+	return h.deriveKeys(keyType, count)
+}
+
+// DeriveKey derives the next key of [keyType] from the stored seed.
+func (h *Handler) DeriveKey(keyType KeyType) error {
+	return h.deriveKeys(keyType, 1)
+}
+
+func (h *Handler) storeSeed(mnemonic string) error {
+	if err := h.UnlockKeystore(); err != nil {
+		return err
+	}
+	seed := bip39.NewSeed(mnemonic, "")
+	if err := h.ks.PutSecret(seedSecret, seed); err != nil {
+		if errors.Is(err, ErrDuplicate) {
+			return ErrSeedExists
+		}
+		return err
+	}
+	return h.StoreDefault(derivationIndexKey, binary.BigEndian.AppendUint32(nil, 0))
+}
+
+// deriveKeys derives and stores the next [count] keys of [keyType] from the
+// stored seed (setting the first as the default key).
+func (h *Handler) deriveKeys(keyType KeyType, count int) error {
+	if err := h.UnlockKeystore(); err != nil {
+		return err
+	}
+	seed, err := h.ks.GetSecret(seedSecret)
+	if err != nil {
+		return err
+	}
+	if seed == nil {
+		return ErrNoSeed
+	}
+	v, err := h.GetDefault(derivationIndexKey)
+	if err != nil {
+		return err
+	}
+	var index uint32
+	if len(v) == consts.Uint32Len {
+		index = binary.BigEndian.Uint32(v)
+	}
+	for i := 0; i < count; i++ {
+		priv, path, err := h.deriveKey(seed, keyType, index)
+		if err != nil {
+			return err
+		}
+		// Keys that were already derived (or imported) are kept as-is
+		if err := h.ks.Put(priv); err != nil && !errors.Is(err, ErrDuplicate) {
+			return err
+		}
+		if i == 0 {
+			if err := h.StoreDefaultKey(priv.Address); err != nil {
+				return err
+			}
+		}
+		utils.Outf(
+			"{{green}}derived address (%s) %s:{{/}} %s\n",
+			keyType,
+			path,
+			h.c.Address(priv.Address),
+		)
+		index++
+	}
+	return h.StoreDefault(derivationIndexKey, binary.BigEndian.AppendUint32(nil, index))
+}
+
+// deriveKey derives the key of [keyType] at [index] from [seed] and returns
+// it with its derivation path.
+func (h *Handler) deriveKey(seed []byte, keyType KeyType, index uint32) (*PrivateKey, string, error) {
+	path := fmt.Sprintf("%s/%d'", DerivationPath, index)
+	indices, err := slip10.ParsePath(path)
+	if err != nil {
+		return nil, "", err
+	}
+	var bytes []byte
+	switch keyType {
+	case ED25519Key:
+		k, err := slip10.Derive(slip10.ED25519, seed, indices)
+		if err != nil {
+			return nil, "", err
+		}
+		priv, err := k.ED25519()
+		if err != nil {
+			return nil, "", err
+		}
+		bytes = priv[:]
+	case SECP256R1Key:
+		k, err := slip10.Derive(slip10.NIST256P1, seed, indices)
+		if err != nil {
+			return nil, "", err
+		}
+		priv, err := k.SECP256R1()
+		if err != nil {
+			return nil, "", err
+		}
+		bytes = priv[:]
+	default:
+		return nil, "", fmt.Errorf("%w: %s", ErrUnknownKeyType, keyType)
+	}
+	priv, err := h.NewKey(keyType, bytes)
+	if err != nil {
+		return nil, "", err
+	}
+	return priv, path, nil
+}
@@ -0,0 +1,159 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/crypto/ed25519"
+	"github.com/ava-labs/hypersdk/crypto/secp256r1"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+// PartialSigner is one of the signers of a [PartialTx] and its signature over
+// the [PartialTx] (if it has signed).
+type PartialSigner struct {
+	Type      KeyType `json:"type"`
+	PublicKey []byte  `json:"publicKey"`
+	Signature []byte  `json:"signature,omitempty"`
+}
+
+// PartialTx is an unsigned transaction that is passed between the signers of
+// a multisig so that each can sign it offline. Once [Threshold] signers have
+// signed, the signatures can be used to construct the [chain.Auth] of the
+// transaction.
+type PartialTx struct {
+	// Tx is the digest of the unsigned transaction (the bytes each signer
+	// signs).
+	Tx        []byte           `json:"tx"`
+	Threshold uint8            `json:"threshold"`
+	Signers   []*PartialSigner `json:"signers"`
+}
+
+// Unsigned returns the unsigned transaction encoded in [p].
+func (p *PartialTx) Unsigned(actionRegistry chain.ActionRegistry) (*chain.Transaction, error) {
+	return chain.UnmarshalUnsignedTx(codec.NewReader(p.Tx, consts.NetworkSizeLimit), actionRegistry)
+}
+
+// Signatures returns the number of signers that have signed [p].
+func (p *PartialTx) Signatures() int {
+	var signatures int
+	for _, signer := range p.Signers {
+		if len(signer.Signature) > 0 {
+			signatures++
+		}
+	}
+	return signatures
+}
+
+// PublicKey returns the public key of [priv].
+func PublicKey(priv *PrivateKey) ([]byte, error) {
+	switch priv.Type {
+	case ED25519Key:
+		pk := ed25519.PrivateKey(priv.Bytes).PublicKey()
+		return pk[:], nil
+	case SECP256R1Key:
+		pk := secp256r1.PrivateKey(priv.Bytes).PublicKey()
+		return pk[:], nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownKeyType, priv.Type)
+	}
+}
+
+// Sign returns the signature of [priv] over [msg].
+func Sign(priv *PrivateKey, msg []byte) ([]byte, error) {
+	switch priv.Type {
+	case ED25519Key:
+		sig := ed25519.Sign(msg, ed25519.PrivateKey(priv.Bytes))
+		return sig[:], nil
+	case SECP256R1Key:
+		sig, err := secp256r1.Sign(msg, secp256r1.PrivateKey(priv.Bytes))
+		if err != nil {
+			return nil, err
+		}
+		return sig[:], nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownKeyType, priv.Type)
+	}
+}
+
+// ExportTx writes [tx] (which must not be signed) to [path] so that it can be
+// signed by [threshold] of [signers] with [SignTx].
+func (*Handler) ExportTx(path string, tx *chain.Transaction, threshold uint8, signers []*PartialSigner) error {
+	if threshold == 0 || int(threshold) > len(signers) {
+		return ErrInvalidThreshold
+	}
+	digest, err := tx.Digest()
+	if err != nil {
+		return err
+	}
+	return writePartialTx(path, &PartialTx{digest, threshold, signers})
+}
+
+// ImportTx reads the [PartialTx] at [path].
+func (*Handler) ImportTx(path string) (*PartialTx, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var p PartialTx
+	if err := json.Unmarshal(b, &p); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// SignTx adds the signature of [priv] to [p] (as returned by [ImportTx]) and
+// writes it to [path].
+//
+// [p] is signed as-is (rather than re-read from [path]) so that the
+// transaction signed is the one that was displayed to the signer. It is
+// decoded with [actionRegistry] before it is signed to ensure that only
+// well-formed transactions are signed.
+func (*Handler) SignTx(path string, p *PartialTx, actionRegistry chain.ActionRegistry, priv *PrivateKey) error {
+	if _, err := p.Unsigned(actionRegistry); err != nil {
+		return err
+	}
+	pk, err := PublicKey(priv)
+	if err != nil {
+		return err
+	}
+	var signer *PartialSigner
+	for _, s := range p.Signers {
+		if s.Type == priv.Type && string(s.PublicKey) == string(pk) {
+			signer = s
+			break
+		}
+	}
+	if signer == nil {
+		return ErrNotSigner
+	}
+	sig, err := Sign(priv, p.Tx)
+	if err != nil {
+		return err
+	}
+	signer.Signature = sig
+	if err := writePartialTx(path, p); err != nil {
+		return err
+	}
+	utils.Outf(
+		"{{green}}signed tx:{{/}} %d/%d signatures\n",
+		p.Signatures(),
+		p.Threshold,
+	)
+	return nil
+}
+
+func writePartialTx(path string, p *PartialTx) error {
+	b, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, b, fsModeWrite)
+}
@@ -55,6 +55,37 @@ func (*Handler) PromptString(label string, min int, max int) (string, error) {
 	return strings.TrimSpace(text), err
 }
 
+// PromptPassphrase prompts for a (masked) passphrase. If [confirm] is true,
+// the passphrase must be entered twice.
+func (*Handler) PromptPassphrase(label string, confirm bool) (string, error) {
+	promptText := promptui.Prompt{
+		Label: label,
+		Mask:  '*',
+		Validate: func(input string) error {
+			if len(input) == 0 {
+				return ErrInputEmpty
+			}
+			return nil
+		},
+	}
+	passphrase, err := promptText.Run()
+	if err != nil {
+		return "", err
+	}
+	if !confirm {
+		return passphrase, nil
+	}
+	promptText.Label = "confirm " + label
+	confirmation, err := promptText.Run()
+	if err != nil {
+		return "", err
+	}
+	if passphrase != confirmation {
+		return "", ErrPassphraseMismatch
+	}
+	return passphrase, nil
+}
+
 func (h *Handler) PromptAsset(label string, allowNative bool) (ids.ID, error) {
 	symbol := h.c.Symbol()
 	text := fmt.Sprintf("%s (use %s for native token)", label, symbol)
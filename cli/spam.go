@@ -48,11 +48,11 @@ var (
 func (h *Handler) Spam(
 	maxTxBacklog int, maxFee *uint64, randomRecipient bool,
 	createClient func(string, uint32, ids.ID), // must save on caller side
-	getFactory func(ed25519.PrivateKey) chain.AuthFactory,
+	getFactory func(*PrivateKey) (chain.AuthFactory, error),
 	lookupBalance func(int, string) (uint64, error),
 	getParser func(context.Context, ids.ID) (chain.Parser, error),
 	getTransfer func(ed25519.PublicKey, uint64) chain.Action,
-	submitDummy func(*rpc.JSONRPCClient, ed25519.PrivateKey) func(context.Context, uint64) error,
+	submitDummy func(*rpc.JSONRPCClient, *PrivateKey) func(context.Context, uint64) error,
 ) error {
 	ctx := context.Background()
 
@@ -79,7 +79,7 @@ func (h *Handler) Spam(
 	balances := make([]uint64, len(keys))
 	createClient(uris[0], networkID, chainID)
 	for i := 0; i < len(keys); i++ {
-		address := h.c.Address(keys[i].PublicKey())
+		address := h.c.Address(keys[i].Address)
 		balance, err := lookupBalance(i, address)
 		if err != nil {
 			return err
@@ -92,7 +92,10 @@ func (h *Handler) Spam(
 	}
 	key := keys[keyIndex]
 	balance := balances[keyIndex]
-	factory := getFactory(key)
+	factory, err := getFactory(key)
+	if err != nil {
+		return err
+	}
 
 	// No longer using db, so we close
 	if err := h.CloseDatabase(); err != nil {
@@ -104,7 +107,7 @@ func (h *Handler) Spam(
 	if err != nil {
 		return err
 	}
-	action := getTransfer(keys[0].PublicKey(), 0)
+	action := getTransfer(keys[0].Address, 0)
 	maxUnits, err := chain.EstimateMaxUnits(parser.Rules(time.Now().UnixMilli()), action, factory, nil)
 	if err != nil {
 		return err
@@ -145,7 +148,7 @@ func (h *Handler) Spam(
 		utils.FormatBalance(distAmount, h.c.Decimals()),
 		h.c.Symbol(),
 	)
-	accounts := make([]ed25519.PrivateKey, numAccounts)
+	accounts := make([]*PrivateKey, numAccounts)
 	dcli, err := rpc.NewWebSocketClient(uris[0], rpc.DefaultHandshakeTimeout, pubsub.MaxPendingMessages, pubsub.MaxReadMessageSize) // we write the max read
 	if err != nil {
 		return err
@@ -158,17 +161,21 @@ func (h *Handler) Spam(
 		if err != nil {
 			return err
 		}
-		accounts[i] = pk
+		account, err := h.NewKey(ED25519Key, pk[:])
+		if err != nil {
+			return err
+		}
+		accounts[i] = account
 
 		// Send funds
-		_, tx, err := cli.GenerateTransactionManual(parser, nil, getTransfer(pk.PublicKey(), distAmount), factory, feePerTx)
+		_, tx, err := cli.GenerateTransactionManual(parser, nil, getTransfer(account.Address, distAmount), factory, feePerTx)
 		if err != nil {
 			return err
 		}
 		if err := dcli.RegisterTx(tx); err != nil {
 			return fmt.Errorf("%w: failed to register tx", err)
 		}
-		funds[pk.PublicKey()] = distAmount
+		funds[account.Address] = distAmount
 	}
 	for i := 0; i < numAccounts; i++ {
 		_, dErr, result, err := dcli.ListenTx(ctx)
@@ -253,13 +260,16 @@ func (h *Handler) Spam(
 			defer t.Stop()
 
 			issuerIndex, issuer := getRandomIssuer(clients)
-			factory := getFactory(accounts[i])
+			factory, err := getFactory(accounts[i])
+			if err != nil {
+				return err
+			}
 			fundsL.Lock()
-			balance := funds[accounts[i].PublicKey()]
+			balance := funds[accounts[i].Address]
 			fundsL.Unlock()
 			defer func() {
 				fundsL.Lock()
-				funds[accounts[i].PublicKey()] = balance
+				funds[accounts[i].Address] = balance
 				fundsL.Unlock()
 			}()
 			ut := time.Now().Unix()
@@ -394,20 +404,24 @@ func (h *Handler) Spam(
 	if maxFee != nil {
 		feePerTx = *maxFee
 	}
-	utils.Outf("{{yellow}}returning funds to %s{{/}}\n", h.c.Address(key.PublicKey()))
+	utils.Outf("{{yellow}}returning funds to %s{{/}}\n", h.c.Address(key.Address))
 	var (
 		returnedBalance uint64
 		returnsSent     int
 	)
 	for i := 0; i < numAccounts; i++ {
-		balance := funds[accounts[i].PublicKey()]
+		balance := funds[accounts[i].Address]
 		if feePerTx > balance {
 			continue
 		}
 		returnsSent++
 		// Send funds
 		returnAmt := balance - feePerTx
-		_, tx, err := cli.GenerateTransactionManual(parser, nil, getTransfer(key.PublicKey(), returnAmt), getFactory(accounts[i]), feePerTx)
+		factory, err := getFactory(accounts[i])
+		if err != nil {
+			return err
+		}
+		_, tx, err := cli.GenerateTransactionManual(parser, nil, getTransfer(key.Address, returnAmt), factory, feePerTx)
 		if err != nil {
 			return err
 		}
@@ -511,7 +525,7 @@ func startIssuer(cctx context.Context, issuer *txIssuer) {
 	}()
 }
 
-func getNextRecipient(randomRecipient bool, self int, keys []ed25519.PrivateKey) (ed25519.PublicKey, error) {
+func getNextRecipient(randomRecipient bool, self int, keys []*PrivateKey) (ed25519.PublicKey, error) {
 	if randomRecipient {
 		priv, err := ed25519.GeneratePrivateKey()
 		if err != nil {
@@ -528,7 +542,7 @@ func getNextRecipient(randomRecipient bool, self int, keys []ed25519.PrivateKey)
 			index = 0
 		}
 	}
-	return keys[index].PublicKey(), nil
+	return keys[index].Address, nil
 }
 
 func getRandomIssuer(issuers []*txIssuer) (int, *txIssuer) {
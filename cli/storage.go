@@ -15,9 +15,10 @@ import (
 )
 
 const (
-	defaultPrefix = 0x0
-	keyPrefix     = 0x1
-	chainPrefix   = 0x2
+	defaultPrefix  = 0x0
+	keyPrefix      = 0x1 // deprecated: keys are now stored in [keystorePrefix]
+	chainPrefix    = 0x2
+	keystorePrefix = 0x3
 
 	defaultKeyKey   = "key"
 	defaultChainKey = "chain"
@@ -67,67 +68,103 @@ func (h *Handler) GetDefaultChain(log bool) (ids.ID, []string, error) {
 	return chainID, uris, nil
 }
 
-func (h *Handler) StoreKey(privateKey ed25519.PrivateKey) error {
-	publicKey := privateKey.PublicKey()
-	k := make([]byte, 1+ed25519.PublicKeyLen)
-	k[0] = keyPrefix
-	copy(k[1:], publicKey[:])
-	has, err := h.db.Has(k)
-	if err != nil {
-		return err
-	}
-	if has {
-		return ErrDuplicate
-	}
-	return h.db.Put(k, privateKey[:])
-}
-
-func (h *Handler) GetKey(publicKey ed25519.PublicKey) (ed25519.PrivateKey, error) {
-	k := make([]byte, 1+ed25519.PublicKeyLen)
-	k[0] = keyPrefix
-	copy(k[1:], publicKey[:])
-	v, err := h.db.Get(k)
-	if errors.Is(err, database.ErrNotFound) {
-		return ed25519.EmptyPrivateKey, nil
+// Before keys were encrypted, they were stored (in plaintext) by the address
+// they control. For backwards compatibility, ed25519 keys were stored as raw
+// bytes and all other keys were prefixed by their [KeyType].
+func decodeKey(address []byte, v []byte) (*PrivateKey, error) {
+	if len(v) == ed25519.PrivateKeyLen {
+		return &PrivateKey{ED25519Key, v, ed25519.PublicKey(address)}, nil
 	}
-	if err != nil {
-		return ed25519.EmptyPrivateKey, err
+	if len(v) == 0 {
+		return nil, ErrInvalidKey
 	}
-	return ed25519.PrivateKey(v), nil
+	return &PrivateKey{KeyType(v[0]), v[1:], ed25519.PublicKey(address)}, nil
 }
 
-func (h *Handler) GetKeys() ([]ed25519.PrivateKey, error) {
+// migrateKeys moves any plaintext keys into the (unlocked) keystore.
+func (h *Handler) migrateKeys() error {
 	iter := h.db.NewIteratorWithPrefix([]byte{keyPrefix})
 	defer iter.Release()
 
-	privateKeys := []ed25519.PrivateKey{}
+	migrated := 0
 	for iter.Next() {
 		// It is safe to use these bytes directly because the database copies the
 		// iterator value for us.
-		privateKeys = append(privateKeys, ed25519.PrivateKey(iter.Value()))
+		k := iter.Key()
+		priv, err := decodeKey(k[1:], iter.Value())
+		if err != nil {
+			return err
+		}
+		if err := h.ks.Put(priv); err != nil && !errors.Is(err, ErrDuplicate) {
+			return err
+		}
+		if err := h.db.Delete(k); err != nil {
+			return err
+		}
+		migrated++
+	}
+	if err := iter.Error(); err != nil {
+		return err
+	}
+	if migrated > 0 {
+		utils.Outf("{{yellow}}encrypted plaintext keys:{{/}} %d\n", migrated)
+	}
+	return nil
+}
+
+func (h *Handler) StoreKey(priv *PrivateKey) error {
+	if err := h.UnlockKeystore(); err != nil {
+		return err
 	}
-	return privateKeys, iter.Error()
+	return h.ks.Put(priv)
 }
 
-func (h *Handler) StoreDefaultKey(pk ed25519.PublicKey) error {
-	return h.StoreDefault(defaultKeyKey, pk[:])
+func (h *Handler) GetKey(address ed25519.PublicKey) (*PrivateKey, error) {
+	if err := h.UnlockKeystore(); err != nil {
+		return nil, err
+	}
+	return h.ks.Get(address)
 }
 
-func (h *Handler) GetDefaultKey(log bool) (ed25519.PrivateKey, error) {
+func (h *Handler) GetKeys() ([]*PrivateKey, error) {
+	if err := h.UnlockKeystore(); err != nil {
+		return nil, err
+	}
+	return h.ks.Keys()
+}
+
+func (h *Handler) StoreDefaultKey(address ed25519.PublicKey) error {
+	return h.StoreDefault(defaultKeyKey, address[:])
+}
+
+// GetDefaultAddress returns the address controlled by the default key (which
+// does not require unlocking the keystore).
+func (h *Handler) GetDefaultAddress(log bool) (ed25519.PublicKey, error) {
 	v, err := h.GetDefault(defaultKeyKey)
 	if err != nil {
-		return ed25519.EmptyPrivateKey, err
+		return ed25519.EmptyPublicKey, err
 	}
 	if len(v) == 0 {
-		return ed25519.EmptyPrivateKey, ErrNoKeys
+		return ed25519.EmptyPublicKey, ErrNoKeys
+	}
+	address := ed25519.PublicKey(v)
+	if log {
+		utils.Outf("{{yellow}}address:{{/}} %s\n", h.c.Address(address))
+	}
+	return address, nil
+}
+
+func (h *Handler) GetDefaultKey(log bool) (*PrivateKey, error) {
+	address, err := h.GetDefaultAddress(log)
+	if err != nil {
+		return nil, err
 	}
-	publicKey := ed25519.PublicKey(v)
-	priv, err := h.GetKey(publicKey)
+	priv, err := h.GetKey(address)
 	if err != nil {
-		return ed25519.EmptyPrivateKey, err
+		return nil, err
 	}
-	if log {
-		utils.Outf("{{yellow}}address:{{/}} %s\n", h.c.Address(publicKey))
+	if priv == nil {
+		return nil, ErrNoKeys
 	}
 	return priv, nil
 }
@@ -0,0 +1,83 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cli
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrateKeys(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	h := &Handler{db: db, ks: NewKeystore(db, keystorePrefix)}
+
+	// Older versions of the CLI stored ed25519 keys as raw bytes and all other
+	// keys prefixed by their type
+	keys := newTestKeys(t)
+	plaintext := map[*PrivateKey][]byte{
+		keys[0]: keys[0].Bytes,
+		keys[1]: append([]byte{byte(keys[1].Type)}, keys[1].Bytes...),
+	}
+	for priv, v := range plaintext {
+		require.NoError(db.Put(append([]byte{keyPrefix}, priv.Address[:]...), v))
+	}
+
+	// Keys can't be migrated while the keystore is locked
+	require.ErrorIs(h.migrateKeys(), ErrKeystoreLocked)
+
+	require.NoError(h.ks.Initialize("passphrase"))
+	require.NoError(h.migrateKeys())
+	migrated, err := h.ks.Keys()
+	require.NoError(err)
+	require.ElementsMatch(keys, migrated)
+
+	// Plaintext keys are removed once they are encrypted
+	iter := db.NewIteratorWithPrefix([]byte{keyPrefix})
+	require.False(iter.Next())
+	iter.Release()
+
+	// Keys that were already migrated are not duplicated
+	require.NoError(db.Put(append([]byte{keyPrefix}, keys[0].Address[:]...), keys[0].Bytes))
+	require.NoError(h.migrateKeys())
+	migrated, err = h.ks.Keys()
+	require.NoError(err)
+	require.ElementsMatch(keys, migrated)
+	has, err := db.Has(append([]byte{keyPrefix}, keys[0].Address[:]...))
+	require.NoError(err)
+	require.False(has)
+
+	// Migrated keys can be decrypted after the keystore is locked
+	h.ks.Lock()
+	require.ErrorIs(h.ks.Unlock("wrong passphrase"), ErrWrongPassphrase)
+	require.NoError(h.ks.Unlock("passphrase"))
+	priv, err := h.ks.Get(keys[1].Address)
+	require.NoError(err)
+	require.Equal(keys[1], priv)
+
+	// Invalid plaintext keys are not migrated
+	require.NoError(db.Put([]byte{keyPrefix, 1}, nil))
+	require.ErrorIs(h.migrateKeys(), ErrInvalidKey)
+}
+
+func TestKeystoreScryptLimits(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	ks := NewKeystore(db, keystorePrefix)
+	require.NoError(ks.Initialize("passphrase"))
+	ks.Lock()
+
+	// Keystores with parameters above our limits are not unlocked
+	header, err := db.Get(ks.headerKey())
+	require.NoError(err)
+	binary.BigEndian.PutUint32(header[saltLen:], 1<<30)
+	require.NoError(db.Put(ks.headerKey(), header))
+	require.ErrorIs(ks.Unlock("passphrase"), ErrInvalidKeystore)
+	require.True(ks.Locked())
+}
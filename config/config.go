@@ -10,6 +10,8 @@ import (
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/profiler"
 	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/hypersdk/network"
+	"github.com/ava-labs/hypersdk/ratelimit"
 	"github.com/ava-labs/hypersdk/trace"
 )
 
@@ -45,3 +47,30 @@ func (c *Config) GetTargetBuildDuration() time.Duration  { return 100 * time.Mil
 func (c *Config) GetProcessingBuildSkip() int            { return 16 }
 func (c *Config) GetTargetGossipDuration() time.Duration { return 20 * time.Millisecond }
 func (c *Config) GetBlockCompactionFrequency() int       { return 32 } // 64 MB of deletion if 2 MB blocks
+
+// Rate limits are disabled by default
+func (c *Config) GetRPCRateLimit() ratelimit.Config                   { return ratelimit.Config{} }
+func (c *Config) GetRPCMethodRateLimits() map[string]ratelimit.Config { return nil }
+func (c *Config) GetRateLimitKeyHeader() string                       { return "" }
+func (c *Config) GetStreamingClientConnections() int                  { return 0 }
+func (c *Config) GetStreamingClientMessageLimit() ratelimit.Config    { return ratelimit.Config{} }
+
+// Compression is disabled by default
+func (c *Config) GetStreamingCompressionThreshold() int { return 0 }
+func (c *Config) GetGossipCompression() network.CompressionConfig {
+	return network.DefaultCompressionConfig()
+}
+
+// Health thresholds
+func (c *Config) GetHealthAcceptedQueueThreshold() float64 { return 0.9 }
+func (c *Config) GetHealthMempoolThreshold() float64       { return 0.9 }
+func (c *Config) GetHealthMaxAcceptDelay() time.Duration   { return time.Minute }
+func (c *Config) GetHealthWarpBacklogThreshold() int       { return 1_024 }
+
+func (c *Config) GetPeerReputation() network.ReputationConfig {
+	return network.DefaultReputationConfig()
+}
+
+// Warp signature aggregation
+func (c *Config) GetWarpSignatureThreshold() uint64 { return 80 }
+func (c *Config) GetWarpMaxOutstanding() int        { return 8 }
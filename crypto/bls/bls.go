@@ -0,0 +1,188 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package bls
+
+import (
+	"encoding/hex"
+	"os"
+
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/hypersdk/crypto"
+	blst "github.com/supranational/blst/bindings/go"
+)
+
+const (
+	PublicKeyLen  = bls.PublicKeyLen // compressed G1 point
+	PrivateKeyLen = bls.SecretKeyLen
+	SignatureLen  = bls.SignatureLen // compressed G2 point
+)
+
+type (
+	PublicKey  [PublicKeyLen]byte
+	PrivateKey [PrivateKeyLen]byte
+	Signature  [SignatureLen]byte
+)
+
+var (
+	EmptyPublicKey  = [PublicKeyLen]byte{}
+	EmptyPrivateKey = [PrivateKeyLen]byte{}
+	EmptySignature  = [SignatureLen]byte{}
+)
+
+// ciphersuite is the domain separation tag of the message augmentation scheme
+// (which is distinct from the tag of the proof of possession scheme used by
+// [bls.Sign], so signatures by one scheme are never valid in the other).
+//
+// source: https://www.ietf.org/archive/id/draft-irtf-cfrg-bls-signature-05.html#name-ciphersuites
+var ciphersuite = []byte("BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_AUG_")
+
+// GeneratePrivateKey returns a BLS PrivateKey.
+func GeneratePrivateKey() (PrivateKey, error) {
+	sk, err := bls.NewSecretKey()
+	if err != nil {
+		return EmptyPrivateKey, err
+	}
+	return PrivateKey(bls.SecretKeyToBytes(sk)), nil
+}
+
+func (p PrivateKey) secretKey() (*bls.SecretKey, error) {
+	sk, err := bls.SecretKeyFromBytes(p[:])
+	if err != nil {
+		return nil, crypto.ErrInvalidPrivateKey
+	}
+	return sk, nil
+}
+
+// PublicKey returns a PublicKey associated with the BLS PrivateKey p.
+//
+// If p is not a valid PrivateKey, [EmptyPublicKey] is returned.
+func (p PrivateKey) PublicKey() PublicKey {
+	sk, err := p.secretKey()
+	if err != nil {
+		return EmptyPublicKey
+	}
+	return PublicKey(bls.PublicKeyToBytes(bls.PublicFromSecretKey(sk)))
+}
+
+// ToHex converts a PrivateKey to a hex string.
+func (p PrivateKey) ToHex() string {
+	return hex.EncodeToString(p[:])
+}
+
+// Save writes [PrivateKey] to a file [filename]. If filename does
+// not exist, it creates a new file with read/write permissions (0o600).
+func (p PrivateKey) Save(filename string) error {
+	return os.WriteFile(filename, p[:], 0o600)
+}
+
+// LoadKey returns a PrivateKey from a file filename.
+// If there is an error reading the file, or the file contains an
+// invalid PrivateKey, LoadKey returns an EmptyPrivateKey and an error.
+func LoadKey(filename string) (PrivateKey, error) {
+	bytes, err := os.ReadFile(filename)
+	if err != nil {
+		return EmptyPrivateKey, err
+	}
+	if len(bytes) != PrivateKeyLen {
+		return EmptyPrivateKey, crypto.ErrInvalidPrivateKey
+	}
+	p := PrivateKey(bytes)
+	if _, err := p.secretKey(); err != nil {
+		return EmptyPrivateKey, err
+	}
+	return p, nil
+}
+
+// augment prefixes [msg] with the [PublicKey] that signs it.
+//
+// Signatures are always computed over augmented messages so that the
+// signatures of the same message by different keys can be aggregated without
+// being vulnerable to rogue key attacks (which would otherwise require each
+// key to have a proof of possession).
+//
+// source: https://www.ietf.org/archive/id/draft-irtf-cfrg-bls-signature-05.html#name-message-augmentation
+func augment(msg []byte, p PublicKey) []byte {
+	augmented := make([]byte, PublicKeyLen+len(msg))
+	copy(augmented, p[:])
+	copy(augmented[PublicKeyLen:], msg)
+	return augmented
+}
+
+// Sign returns a valid signature for msg using pk.
+//
+// BLS signatures are deterministic, so there is only one valid signature of
+// msg by pk.
+func Sign(msg []byte, pk PrivateKey) (Signature, error) {
+	sk, err := pk.secretKey()
+	if err != nil {
+		return EmptySignature, err
+	}
+	sig := new(blst.P2Affine).Sign(sk, augment(msg, pk.PublicKey()), ciphersuite)
+	return Signature(bls.SignatureToBytes(sig)), nil
+}
+
+func parsePublicKey(p PublicKey) (*bls.PublicKey, bool) {
+	pk, err := bls.PublicKeyFromBytes(p[:])
+	return pk, err == nil
+}
+
+func parseSignature(s Signature) (*bls.Signature, bool) {
+	sig, err := bls.SignatureFromBytes(s[:])
+	return sig, err == nil
+}
+
+// Verify returns whether sig is a valid signature of msg by p.
+func Verify(msg []byte, p PublicKey, sig Signature) bool {
+	pk, ok := parsePublicKey(p)
+	if !ok {
+		return false
+	}
+	s, ok := parseSignature(sig)
+	if !ok {
+		return false
+	}
+	// [pk] and [s] are validated when they are parsed
+	return s.Verify(false, pk, false, augment(msg, p), ciphersuite)
+}
+
+// AggregateSignatures aggregates a non-zero number of signatures into a
+// single signature that can be verified with [AggregateVerify].
+func AggregateSignatures(sigs []Signature) (Signature, error) {
+	parsed := make([]*bls.Signature, len(sigs))
+	for i, sig := range sigs {
+		s, ok := parseSignature(sig)
+		if !ok {
+			return EmptySignature, crypto.ErrInvalidSignature
+		}
+		parsed[i] = s
+	}
+	agg, err := bls.AggregateSignatures(parsed)
+	if err != nil {
+		return EmptySignature, err
+	}
+	return Signature(bls.SignatureToBytes(agg)), nil
+}
+
+// AggregateVerify returns whether sig is the aggregate of valid signatures of
+// each msgs[i] by ps[i].
+func AggregateVerify(msgs [][]byte, ps []PublicKey, sig Signature) bool {
+	if len(msgs) == 0 || len(msgs) != len(ps) {
+		return false
+	}
+	pks := make([]*bls.PublicKey, len(ps))
+	augmented := make([]blst.Message, len(ps))
+	for i, p := range ps {
+		pk, ok := parsePublicKey(p)
+		if !ok {
+			return false
+		}
+		pks[i] = pk
+		augmented[i] = augment(msgs[i], p)
+	}
+	s, ok := parseSignature(sig)
+	if !ok {
+		return false
+	}
+	return s.AggregateVerify(false, pks, false, augmented, ciphersuite)
+}
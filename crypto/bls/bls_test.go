@@ -0,0 +1,140 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package bls
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeneratePrivateKey(t *testing.T) {
+	require := require.New(t)
+	priv, err := GeneratePrivateKey()
+	require.NoError(err)
+	require.NotEqual(EmptyPrivateKey, priv)
+	require.NotEqual(EmptyPublicKey, priv.PublicKey())
+}
+
+func TestSignVerify(t *testing.T) {
+	require := require.New(t)
+
+	// Generate private key
+	priv, err := GeneratePrivateKey()
+	require.NoError(err)
+
+	// Sign message
+	msg := []byte("hello")
+	sig, err := Sign(msg, priv)
+	require.NoError(err)
+
+	// Signatures are deterministic
+	sig2, err := Sign(msg, priv)
+	require.NoError(err)
+	require.Equal(sig, sig2)
+
+	// Verify signature
+	require.True(Verify(msg, priv.PublicKey(), sig))
+	require.False(Verify([]byte("world"), priv.PublicKey(), sig))
+	priv2, err := GeneratePrivateKey()
+	require.NoError(err)
+	require.False(Verify(msg, priv2.PublicKey(), sig))
+	require.False(Verify(msg, EmptyPublicKey, sig))
+	require.False(Verify(msg, priv.PublicKey(), EmptySignature))
+}
+
+func TestCiphersuite(t *testing.T) {
+	require := require.New(t)
+
+	priv, err := GeneratePrivateKey()
+	require.NoError(err)
+	sk, err := priv.secretKey()
+	require.NoError(err)
+	pk, ok := parsePublicKey(priv.PublicKey())
+	require.True(ok)
+	msg := []byte("hello")
+	augmented := augment(msg, priv.PublicKey())
+
+	// Signatures of augmented messages use the augmentation ciphersuite, so
+	// they aren't interchangeable with proof of possession signatures
+	sig, err := Sign(msg, priv)
+	require.NoError(err)
+	s, ok := parseSignature(sig)
+	require.True(ok)
+	require.False(bls.Verify(pk, s, augmented))
+	popSig := Signature(bls.SignatureToBytes(bls.Sign(sk, augmented)))
+	require.False(Verify(msg, priv.PublicKey(), popSig))
+}
+
+func TestAggregateVerify(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		msgs = make([][]byte, 10)
+		pks  = make([]PublicKey, 10)
+		sigs = make([]Signature, 10)
+	)
+	for i := range msgs {
+		priv, err := GeneratePrivateKey()
+		require.NoError(err)
+		msgs[i] = []byte{byte(i)}
+		pks[i] = priv.PublicKey()
+		sigs[i], err = Sign(msgs[i], priv)
+		require.NoError(err)
+	}
+	sig, err := AggregateSignatures(sigs)
+	require.NoError(err)
+	require.True(AggregateVerify(msgs, pks, sig))
+
+	// Signatures of different messages can't be swapped
+	msgs[0], msgs[1] = msgs[1], msgs[0]
+	require.False(AggregateVerify(msgs, pks, sig))
+	msgs[0], msgs[1] = msgs[1], msgs[0]
+
+	// All signatures must be included
+	partial, err := AggregateSignatures(sigs[1:])
+	require.NoError(err)
+	require.False(AggregateVerify(msgs, pks, partial))
+	require.True(AggregateVerify(msgs[1:], pks[1:], partial))
+
+	// Messages and keys must match
+	require.False(AggregateVerify(msgs[1:], pks, sig))
+	require.False(AggregateVerify(nil, nil, sig))
+}
+
+func TestAggregateVerifySameMessage(t *testing.T) {
+	require := require.New(t)
+
+	// Signatures of the same message by different keys can be aggregated
+	msg := []byte("hello")
+	var (
+		msgs = make([][]byte, 3)
+		pks  = make([]PublicKey, 3)
+		sigs = make([]Signature, 3)
+	)
+	for i := range msgs {
+		priv, err := GeneratePrivateKey()
+		require.NoError(err)
+		msgs[i] = msg
+		pks[i] = priv.PublicKey()
+		sigs[i], err = Sign(msg, priv)
+		require.NoError(err)
+	}
+	sig, err := AggregateSignatures(sigs)
+	require.NoError(err)
+	require.True(AggregateVerify(msgs, pks, sig))
+}
+
+func TestSaveLoadKey(t *testing.T) {
+	require := require.New(t)
+	priv, err := GeneratePrivateKey()
+	require.NoError(err)
+	path := filepath.Join(t.TempDir(), "key")
+	require.NoError(priv.Save(path))
+	loaded, err := LoadKey(path)
+	require.NoError(err)
+	require.Equal(priv, loaded)
+}
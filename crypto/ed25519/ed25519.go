@@ -7,9 +7,10 @@ import (
 	"crypto/rand"
 	"encoding/hex"
 	"os"
+	"sync/atomic"
 
 	"github.com/oasisprotocol/curve25519-voi/primitives/ed25519"
-	"github.com/oasisprotocol/curve25519-voi/primitives/ed25519/extra/cache"
+	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/ava-labs/avalanchego/utils/formatting/address"
 	"github.com/ava-labs/hypersdk/crypto"
@@ -33,8 +34,10 @@ const (
 	// TODO: make this tunable
 	MinBatchSize = 16
 
-	// TODO: make this tunable
-	cacheSize = 128_000 // ~179MB (keys are ~1.4KB each)
+	// DefaultKeyCacheSize is the size of the [KeyCache] used by [Verify],
+	// [NewBatch], and [CachePublicKey] until it is replaced with
+	// [SetDefaultKeyCache].
+	DefaultKeyCacheSize = 128_000 // ~179MB (keys are ~1.4KB each)
 )
 
 var (
@@ -43,7 +46,9 @@ var (
 	EmptySignature  = [ed25519.SignatureSize]byte{}
 
 	verifyOptions ed25519.Options
-	cacheVerifier *Verifier
+
+	// defaultCache is shared by all callers that don't provide a [KeyCache]
+	defaultCache atomic.Pointer[KeyCache]
 )
 
 func init() {
@@ -61,9 +66,31 @@ func init() {
 	// https://eprint.iacr.org/2020/1244.pdf
 	verifyOptions.Verify = ed25519.VerifyOptionsZIP_215
 
-	// cacheVerifier stores expanded ed25519 Public Keys (each is ~1.4KB). Using
-	// a cached expanded key reduces verification latency by ~25%.
-	cacheVerifier = NewVerifier(cache.NewLRUCache(cacheSize))
+	c, err := NewKeyCache(DefaultKeyCacheSize, "", nil)
+	if err != nil {
+		panic(err)
+	}
+	defaultCache.Store(c)
+}
+
+// SetDefaultKeyCache replaces the [KeyCache] used by [Verify], [NewBatch], and
+// [CachePublicKey] with [c] (which may be nil to disable caching).
+func SetDefaultKeyCache(c *KeyCache) {
+	defaultCache.Store(c)
+}
+
+// DefaultKeyCache returns the [KeyCache] used by callers that don't provide
+// one.
+func DefaultKeyCache() *KeyCache {
+	return defaultCache.Load()
+}
+
+// CachePublicKey adds the expanded form of [p] to the default [KeyCache].
+//
+// This should only be called on "fee protected" paths (like after block
+// accept) to prevent trivial flushing of the cache.
+func CachePublicKey(p PublicKey) {
+	defaultCache.Load().Add(p)
 }
 
 // Address returns a Bech32 address from hrp and p.
@@ -104,6 +131,15 @@ func GeneratePrivateKey() (PrivateKey, error) {
 	return PrivateKey(k), nil
 }
 
+// PrivateKeyFromSeed returns the Ed25519 PrivateKey derived from [seed] (as
+// specified by RFC 8032). [seed] must be [PrivateKeySeedLen] bytes.
+func PrivateKeyFromSeed(seed []byte) (PrivateKey, error) {
+	if len(seed) != PrivateKeySeedLen {
+		return EmptyPrivateKey, crypto.ErrInvalidPrivateKey
+	}
+	return PrivateKey(ed25519.NewKeyFromSeed(seed)), nil
+}
+
 // PublicKey returns a PublicKey associated with the Ed25519 PrivateKey p.
 // The PublicKey is the last 32 bytes of p.
 func (p PrivateKey) PublicKey() PublicKey {
@@ -141,9 +177,19 @@ func Sign(msg []byte, pk PrivateKey) Signature {
 	return Signature(sig)
 }
 
-// Verify returns whether s is a valid signature of msg by p.
+// Verify returns whether s is a valid signature of msg by p, using the
+// expanded form of p if it is in the default [KeyCache].
 func Verify(msg []byte, p PublicKey, s Signature) bool {
-	return cacheVerifier.VerifyWithOptions(p[:], msg, s[:], &verifyOptions)
+	return VerifyWithCache(defaultCache.Load(), msg, p, s)
+}
+
+// VerifyWithCache returns whether s is a valid signature of msg by p, using
+// the expanded form of p if it is in c.
+func VerifyWithCache(c *KeyCache, msg []byte, p PublicKey, s Signature) bool {
+	if expanded, ok := c.Get(p); ok {
+		return ed25519.VerifyExpandedWithOptions(expanded, msg, s[:], &verifyOptions)
+	}
+	return ed25519.VerifyWithOptions(p[:], msg, s[:], &verifyOptions)
 }
 
 // HexToKey Converts a hexadecimal encoded key into a PrivateKey. Returns
@@ -159,23 +205,46 @@ func HexToKey(key string) (PrivateKey, error) {
 	return PrivateKey(bytes), nil
 }
 
-func CachePublicKey(p PublicKey) {
-	cacheVerifier.AddPublicKey(p[:])
+// KeyCache stores expanded ed25519 Public Keys (each is ~1.4KB). Using a
+// cached expanded key reduces verification latency by ~25%.
+type KeyCache = crypto.KeyCache[PublicKey, *ed25519.ExpandedPublicKey]
+
+// NewKeyCache returns a [KeyCache] of up to [size] keys (see
+// [crypto.NewKeyCache]).
+func NewKeyCache(size int, namespace string, registerer prometheus.Registerer) (*KeyCache, error) {
+	return crypto.NewKeyCache(size, expandPublicKey, namespace, registerer)
+}
+
+func expandPublicKey(p PublicKey) (*ed25519.ExpandedPublicKey, error) {
+	return ed25519.NewExpandedPublicKey(p[:])
 }
 
 type Batch struct {
-	bv *ed25519.BatchVerifier
+	bv    *ed25519.BatchVerifier
+	cache *KeyCache
 }
 
+// NewBatch returns a [Batch] that uses the expanded form of the public keys in
+// the default [KeyCache].
 func NewBatch(numItems int) *Batch {
+	return NewBatchWithCache(numItems, defaultCache.Load())
+}
+
+// NewBatchWithCache returns a [Batch] that uses the expanded form of the
+// public keys in [c].
+func NewBatchWithCache(numItems int, c *KeyCache) *Batch {
 	if numItems <= 0 {
-		return &Batch{ed25519.NewBatchVerifier()}
+		return &Batch{ed25519.NewBatchVerifier(), c}
 	}
-	return &Batch{ed25519.NewBatchVerifierWithCapacity(numItems)}
+	return &Batch{ed25519.NewBatchVerifierWithCapacity(numItems), c}
 }
 
 func (b *Batch) Add(msg []byte, p PublicKey, s Signature) {
-	cacheVerifier.AddWithOptions(b.bv, p[:], msg, s[:], &verifyOptions)
+	if expanded, ok := b.cache.Get(p); ok {
+		b.bv.AddExpandedWithOptions(expanded, msg, s[:], &verifyOptions)
+		return
+	}
+	b.bv.AddWithOptions(p[:], msg, s[:], &verifyOptions)
 }
 
 func (b *Batch) Verify() bool {
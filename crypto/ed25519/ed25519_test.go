@@ -70,6 +70,16 @@ func TestGeneratePrivateKeyDifferent(t *testing.T) {
 	}
 }
 
+func TestPrivateKeyFromSeed(t *testing.T) {
+	require := require.New(t)
+	priv, err := PrivateKeyFromSeed(TestPrivateKey[:PrivateKeySeedLen])
+	require.NoError(err)
+	require.Equal(TestPrivateKey, priv)
+
+	_, err = PrivateKeyFromSeed(TestPrivateKey[:])
+	require.ErrorIs(err, crypto.ErrInvalidPrivateKey)
+}
+
 func TestPublicKeyValid(t *testing.T) {
 	require := require.New(t)
 	// Hardcoded test values
@@ -238,6 +248,65 @@ func TestVerifyInvalidParams(t *testing.T) {
 		"Verify incorrectly verified a message")
 }
 
+func TestVerifyWithCache(t *testing.T) {
+	require := require.New(t)
+
+	c, err := NewKeyCache(2, "", nil)
+	require.NoError(err)
+	msg := []byte("msg")
+	sig := Sign(msg, TestPrivateKey)
+	pk := TestPrivateKey.PublicKey()
+
+	// Keys are only cached once added
+	require.True(VerifyWithCache(c, msg, pk, sig))
+	require.Zero(c.Len())
+	c.Add(pk)
+	require.Equal(1, c.Len())
+	require.True(VerifyWithCache(c, msg, pk, sig))
+	require.False(VerifyWithCache(c, []byte("diff msg"), pk, sig))
+
+	// Batches use cached keys
+	batch := NewBatchWithCache(2, c)
+	batch.Add(msg, pk, sig)
+	batch.Add(msg, pk, sig)
+	require.True(batch.Verify())
+	batch = NewBatchWithCache(2, c)
+	batch.Add(msg, pk, sig)
+	batch.Add([]byte("diff msg"), pk, sig)
+	require.False(batch.Verify())
+}
+
+func TestDefaultKeyCache(t *testing.T) {
+	require := require.New(t)
+
+	c, err := NewKeyCache(2, "", nil)
+	require.NoError(err)
+	prev := DefaultKeyCache()
+	SetDefaultKeyCache(c)
+	t.Cleanup(func() { SetDefaultKeyCache(prev) })
+	msg := []byte("msg")
+	sig := Sign(msg, TestPrivateKey)
+	pk := TestPrivateKey.PublicKey()
+
+	// Verify and NewBatch use keys added with CachePublicKey
+	CachePublicKey(pk)
+	require.Equal(1, c.Len())
+	_, ok := c.Get(pk)
+	require.True(ok)
+	require.True(Verify(msg, pk, sig))
+	require.False(Verify([]byte("diff msg"), pk, sig))
+	batch := NewBatch(2)
+	require.Equal(c, batch.cache)
+	batch.Add(msg, pk, sig)
+	batch.Add(msg, pk, sig)
+	require.True(batch.Verify())
+
+	// Caching can be disabled
+	SetDefaultKeyCache(nil)
+	CachePublicKey(pk)
+	require.True(Verify(msg, pk, sig))
+}
+
 func TestHexToKeyInvalidKey(t *testing.T) {
 	require := require.New(t)
 	invalidHex := "1234"
@@ -0,0 +1,89 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package crypto
+
+import (
+	"github.com/ava-labs/avalanchego/cache"
+	"github.com/ava-labs/avalanchego/cache/metercacher"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// KeyCache is a size-bounded LRU cache of the parsed form of public keys (like
+// expanded ed25519 keys or decompressed secp256r1 points). Parsing a public key
+// is a significant part of verifying a signature, so reusing the parsed keys
+// of repeat signers reduces verification time.
+//
+// Keys are only added with [Add] (never when they miss) so that callers can
+// restrict insertion to paths where it is expensive to flush the cache with
+// keys that will not be seen again (like after a block is accepted).
+//
+// All methods can be called on a nil [KeyCache] (which never hits).
+type KeyCache[K comparable, V any] struct {
+	cache cache.Cacher[K, V]
+	parse func(K) (V, error)
+
+	// inner is [cache] without metrics, so that checking if a key is already
+	// cached in [Add] isn't recorded as a hit or a miss
+	inner cache.Cacher[K, V]
+}
+
+// NewKeyCache returns a [KeyCache] of up to [size] keys (or a disabled cache
+// if [size] is 0) that parses keys with [parse].
+//
+// If [registerer] is not nil, hits and misses (among other cache metrics) are
+// recorded in it under [namespace].
+func NewKeyCache[K comparable, V any](
+	size int,
+	parse func(K) (V, error),
+	namespace string,
+	registerer prometheus.Registerer,
+) (*KeyCache[K, V], error) {
+	var inner cache.Cacher[K, V] = &cache.Empty[K, V]{}
+	if size > 0 {
+		inner = &cache.LRU[K, V]{Size: size}
+	}
+	c := inner
+	if registerer != nil {
+		mc, err := metercacher.New[K, V](namespace, registerer, inner)
+		if err != nil {
+			return nil, err
+		}
+		c = mc
+	}
+	return &KeyCache[K, V]{c, parse, inner}, nil
+}
+
+// Get returns the parsed form of [k], if it is cached.
+func (c *KeyCache[K, V]) Get(k K) (V, bool) {
+	if c == nil {
+		var v V
+		return v, false
+	}
+	return c.cache.Get(k)
+}
+
+// Add parses [k] and adds it to the cache (if it is not already cached).
+//
+// Invalid keys are not cached.
+func (c *KeyCache[K, V]) Add(k K) {
+	if c == nil {
+		return
+	}
+	if _, ok := c.inner.Get(k); ok {
+		return
+	}
+	v, err := c.parse(k)
+	if err != nil {
+		return
+	}
+	c.cache.Put(k, v)
+}
+
+// Len returns the number of cached keys.
+func (c *KeyCache[_, _]) Len() int {
+	if c == nil {
+		return 0
+	}
+	return c.cache.Len()
+}
@@ -0,0 +1,100 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package crypto
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+)
+
+var errOdd = errors.New("odd")
+
+func parseEven(k int) (int, error) {
+	if k%2 == 1 {
+		return 0, errOdd
+	}
+	return k * 10, nil
+}
+
+func TestKeyCache(t *testing.T) {
+	require := require.New(t)
+
+	c, err := NewKeyCache(2, parseEven, "", nil)
+	require.NoError(err)
+
+	// Keys are not added on a miss
+	_, ok := c.Get(2)
+	require.False(ok)
+	require.Zero(c.Len())
+
+	c.Add(2)
+	v, ok := c.Get(2)
+	require.True(ok)
+	require.Equal(20, v)
+
+	// Invalid keys are not cached
+	c.Add(3)
+	_, ok = c.Get(3)
+	require.False(ok)
+
+	// Least recently used key is evicted
+	c.Add(4)
+	_, ok = c.Get(2)
+	require.True(ok)
+	c.Add(6)
+	require.Equal(2, c.Len())
+	_, ok = c.Get(4)
+	require.False(ok)
+	_, ok = c.Get(2)
+	require.True(ok)
+}
+
+func TestKeyCacheDisabled(t *testing.T) {
+	require := require.New(t)
+
+	c, err := NewKeyCache(0, parseEven, "", nil)
+	require.NoError(err)
+	c.Add(2)
+	_, ok := c.Get(2)
+	require.False(ok)
+	require.Zero(c.Len())
+
+	// A nil cache never hits
+	var nc *KeyCache[int, int]
+	nc.Add(2)
+	_, ok = nc.Get(2)
+	require.False(ok)
+	require.Zero(nc.Len())
+}
+
+func TestKeyCacheMetrics(t *testing.T) {
+	require := require.New(t)
+
+	r := prometheus.NewRegistry()
+	c, err := NewKeyCache(2, parseEven, "keys", r)
+	require.NoError(err)
+
+	// Adding a key is not recorded as a hit or a miss
+	c.Add(2)
+	c.Add(2)
+	_, _ = c.Get(2)
+	_, _ = c.Get(4)
+	_, _ = c.Get(4)
+
+	families, err := r.Gather()
+	require.NoError(err)
+	counts := map[string]float64{}
+	for _, family := range families {
+		for _, m := range family.GetMetric() {
+			if m.GetCounter() != nil {
+				counts[family.GetName()] = m.GetCounter().GetValue()
+			}
+		}
+	}
+	require.Equal(float64(1), counts["keys_hit"])
+	require.Equal(float64(2), counts["keys_miss"])
+}
@@ -0,0 +1,13 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package secp256r1
+
+import "errors"
+
+var (
+	ErrInvalidAuthenticatorData = errors.New("invalid authenticator data")
+	ErrUserNotPresent           = errors.New("user not present")
+	ErrInvalidClientData        = errors.New("invalid client data")
+	ErrChallengeMismatch        = errors.New("challenge mismatch")
+)
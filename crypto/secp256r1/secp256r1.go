@@ -13,9 +13,11 @@ import (
 	"fmt"
 	"math/big"
 	"os"
+	"sync/atomic"
 
 	"github.com/ava-labs/avalanchego/utils/formatting/address"
 	"github.com/ava-labs/hypersdk/crypto"
+	"github.com/prometheus/client_golang/prometheus"
 	"golang.org/x/crypto/cryptobyte"
 	"golang.org/x/crypto/cryptobyte/asn1"
 )
@@ -26,6 +28,11 @@ const (
 	SignatureLen  = 64 // R || S
 
 	rsLen = 32
+
+	// DefaultKeyCacheSize is the size of the [KeyCache] used by [Verify],
+	// [VerifyWebAuthn], and [CachePublicKey] until it is replaced with
+	// [SetDefaultKeyCache].
+	DefaultKeyCacheSize = 128_000
 )
 
 type (
@@ -38,8 +45,40 @@ var (
 	EmptyPublicKey  = [PublicKeyLen]byte{}
 	EmptyPrivateKey = [PrivateKeyLen]byte{}
 	EmptySignature  = [SignatureLen]byte{}
+
+	// defaultCache is shared by all callers that don't provide a [KeyCache]
+	defaultCache atomic.Pointer[KeyCache]
 )
 
+func init() {
+	c, err := NewKeyCache(DefaultKeyCacheSize, "", nil)
+	if err != nil {
+		panic(err)
+	}
+	defaultCache.Store(c)
+}
+
+// SetDefaultKeyCache replaces the [KeyCache] used by [Verify],
+// [VerifyWebAuthn], and [CachePublicKey] with [c] (which may be nil to disable
+// caching).
+func SetDefaultKeyCache(c *KeyCache) {
+	defaultCache.Store(c)
+}
+
+// DefaultKeyCache returns the [KeyCache] used by callers that don't provide
+// one.
+func DefaultKeyCache() *KeyCache {
+	return defaultCache.Load()
+}
+
+// CachePublicKey adds the decompressed form of [p] to the default [KeyCache].
+//
+// This should only be called on "fee protected" paths (like after block
+// accept) to prevent trivial flushing of the cache.
+func CachePublicKey(p PublicKey) {
+	defaultCache.Load().Add(p)
+}
+
 // secp256r1Order returns the curve order for the secp256r1 (P-256) curve.
 //
 // source: https://github.com/cosmos/cosmos-sdk/blob/b71ec62807628b9a94bef32071e1c8686fcd9d36/crypto/keys/internal/ecdsa/privkey.go#L12-L37
@@ -178,7 +217,15 @@ func Sign(msg []byte, pk PrivateKey) (Signature, error) {
 //
 // The value of [s] in [sig] must be in the lower half of the curve
 // order for the signature to be considered valid.
+//
+// The decompressed form of p is used if it is in the default [KeyCache].
 func Verify(msg []byte, p PublicKey, sig Signature) bool {
+	return VerifyWithCache(defaultCache.Load(), msg, p, sig)
+}
+
+// VerifyWithCache returns whether sig is a valid signature of msg by p, using
+// the decompressed form of p if it is in c.
+func VerifyWithCache(c *KeyCache, msg []byte, p PublicKey, sig Signature) bool {
 	// Perform sanity checks
 	if len(p) != PublicKeyLen {
 		fmt.Println("invalid pk len")
@@ -190,18 +237,13 @@ func Verify(msg []byte, p PublicKey, sig Signature) bool {
 	}
 
 	// Parse PublicKey
-	x, y := elliptic.UnmarshalCompressed(elliptic.P256(), p[:])
-	if x == nil || y == nil {
-		// This can happen if the point is not in compressed form, not
-		// on the curve, or is at infinity.
-		//
-		// source: https://cs.opensource.google/go/go/+/refs/tags/go1.21.3:src/crypto/elliptic/elliptic.go;l=147-149
-		return false
-	}
-	pk := &ecdsa.PublicKey{
-		Curve: elliptic.P256(),
-		X:     x,
-		Y:     y,
+	pk, ok := c.Get(p)
+	if !ok {
+		var err error
+		pk, err = decompressPublicKey(p)
+		if err != nil {
+			return false
+		}
 	}
 
 	// Parse Signature
@@ -218,6 +260,32 @@ func Verify(msg []byte, p PublicKey, sig Signature) bool {
 	return ecdsa.Verify(pk, digest[:], r, s)
 }
 
+// KeyCache stores decompressed secp256r1 Public Keys. Using a cached
+// decompressed key avoids computing a modular square root for each signature.
+type KeyCache = crypto.KeyCache[PublicKey, *ecdsa.PublicKey]
+
+// NewKeyCache returns a [KeyCache] of up to [size] keys (see
+// [crypto.NewKeyCache]).
+func NewKeyCache(size int, namespace string, registerer prometheus.Registerer) (*KeyCache, error) {
+	return crypto.NewKeyCache(size, decompressPublicKey, namespace, registerer)
+}
+
+func decompressPublicKey(p PublicKey) (*ecdsa.PublicKey, error) {
+	x, y := elliptic.UnmarshalCompressed(elliptic.P256(), p[:])
+	if x == nil || y == nil {
+		// This can happen if the point is not in compressed form, not
+		// on the curve, or is at infinity.
+		//
+		// source: https://cs.opensource.google/go/go/+/refs/tags/go1.21.3:src/crypto/elliptic/elliptic.go;l=147-149
+		return nil, crypto.ErrInvalidPublicKey
+	}
+	return &ecdsa.PublicKey{
+		Curve: elliptic.P256(),
+		X:     x,
+		Y:     y,
+	}, nil
+}
+
 // HexToKey Converts a hexadecimal encoded key into a PrivateKey. Returns
 // an EmptyPrivateKey and error if key is invalid.
 func HexToKey(key string) (PrivateKey, error) {
@@ -109,6 +109,60 @@ func TestEmptyPublicKey(t *testing.T) {
 	require.False(Verify(msg, EmptyPublicKey, Signature(sig)))
 }
 
+func TestVerifyWithCache(t *testing.T) {
+	require := require.New(t)
+
+	c, err := NewKeyCache(2, "", nil)
+	require.NoError(err)
+	priv, err := GeneratePrivateKey()
+	require.NoError(err)
+	msg := []byte("hello")
+	sig, err := Sign(msg, priv)
+	require.NoError(err)
+	pk := priv.PublicKey()
+
+	// Keys are only cached once added
+	require.True(VerifyWithCache(c, msg, pk, sig))
+	require.Zero(c.Len())
+	c.Add(pk)
+	require.Equal(1, c.Len())
+	require.True(VerifyWithCache(c, msg, pk, sig))
+	require.False(VerifyWithCache(c, []byte("diff msg"), pk, sig))
+
+	// Invalid keys are not cached
+	c.Add(EmptyPublicKey)
+	require.Equal(1, c.Len())
+}
+
+func TestDefaultKeyCache(t *testing.T) {
+	require := require.New(t)
+
+	c, err := NewKeyCache(2, "", nil)
+	require.NoError(err)
+	prev := DefaultKeyCache()
+	SetDefaultKeyCache(c)
+	t.Cleanup(func() { SetDefaultKeyCache(prev) })
+	priv, err := GeneratePrivateKey()
+	require.NoError(err)
+	msg := []byte("hello")
+	sig, err := Sign(msg, priv)
+	require.NoError(err)
+	pk := priv.PublicKey()
+
+	// Verify uses keys added with CachePublicKey
+	CachePublicKey(pk)
+	require.Equal(1, c.Len())
+	_, ok := c.Get(pk)
+	require.True(ok)
+	require.True(Verify(msg, pk, sig))
+	require.False(Verify([]byte("diff msg"), pk, sig))
+
+	// Caching can be disabled
+	SetDefaultKeyCache(nil)
+	CachePublicKey(pk)
+	require.True(Verify(msg, pk, sig))
+}
+
 func TestSignatureLargeS(t *testing.T) {
 	msg := []byte("hello")
 	publicKey := "03ca7eedb087dd30ba97c468f1c3ac06e0571dd055d0cdaf4147d11df0337c11f9"
@@ -0,0 +1,165 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package secp256r1
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+
+	"github.com/ava-labs/hypersdk/crypto"
+)
+
+const (
+	// WebAuthnGetType is the [clientData.Type] of an assertion (signature)
+	// produced by an authenticator.
+	WebAuthnGetType = "webauthn.get"
+
+	// MinAuthenticatorDataLen is the size of the authenticator data without
+	// any extensions: rpIdHash (32) || flags (1) || signCount (4).
+	MinAuthenticatorDataLen = sha256.Size + 1 + 4
+
+	// MaxAuthenticatorDataLen and MaxClientDataJSONLen bound the size of the
+	// assertion included with a WebAuthn signature (authenticators may append
+	// extensions and arbitrary client data).
+	MaxAuthenticatorDataLen = 512
+	MaxClientDataJSONLen    = 1024
+
+	// flagUserPresent is set in the authenticator data when the user
+	// interacted with the authenticator to produce a signature.
+	flagUserPresent = 0x01
+	// flagUserVerified is set in the authenticator data when the user was
+	// verified (PIN, biometric, etc.) by the authenticator.
+	flagUserVerified = 0x04
+)
+
+// clientData is the subset of the CollectedClientData dictionary that we
+// verify.
+//
+// source: https://www.w3.org/TR/webauthn-2/#dictionary-client-data
+type clientData struct {
+	Type      string `json:"type"`
+	Challenge string `json:"challenge"`
+	Origin    string `json:"origin"`
+}
+
+// WebAuthnChallenge returns the challenge that an authenticator must sign to
+// authorize [msg]. Because [msg] may be large, we use its hash rather than
+// the raw bytes.
+func WebAuthnChallenge(msg []byte) string {
+	digest := sha256.Sum256(msg)
+	return base64.RawURLEncoding.EncodeToString(digest[:])
+}
+
+// VerifyWebAuthn returns nil if [sig] is a valid WebAuthn assertion of [msg]
+// by [p].
+//
+// Authenticators sign authenticatorData || sha256(clientDataJSON), so we
+// ensure that [clientDataJSON] is an assertion that commits to [msg] (via
+// [WebAuthnChallenge]) and that the user was present when it was produced.
+// The relying party (rpIdHash) and origin are not checked because the chain
+// has no notion of either.
+//
+// The decompressed form of [p] is used if it is in the default [KeyCache].
+func VerifyWebAuthn(
+	msg []byte,
+	p PublicKey,
+	authenticatorData []byte,
+	clientDataJSON []byte,
+	sig Signature,
+) error {
+	return VerifyWebAuthnWithCache(defaultCache.Load(), msg, p, authenticatorData, clientDataJSON, sig)
+}
+
+// VerifyWebAuthnWithCache is [VerifyWebAuthn] using the decompressed form of
+// [p] if it is in [c].
+func VerifyWebAuthnWithCache(
+	c *KeyCache,
+	msg []byte,
+	p PublicKey,
+	authenticatorData []byte,
+	clientDataJSON []byte,
+	sig Signature,
+) error {
+	if len(authenticatorData) < MinAuthenticatorDataLen {
+		return ErrInvalidAuthenticatorData
+	}
+	if authenticatorData[sha256.Size]&flagUserPresent == 0 {
+		return ErrUserNotPresent
+	}
+	var cd clientData
+	if err := json.Unmarshal(clientDataJSON, &cd); err != nil {
+		return ErrInvalidClientData
+	}
+	if cd.Type != WebAuthnGetType {
+		return ErrInvalidClientData
+	}
+	if cd.Challenge != WebAuthnChallenge(msg) {
+		return ErrChallengeMismatch
+	}
+	if !VerifyWithCache(c, webAuthnMessage(authenticatorData, clientDataJSON), p, sig) {
+		return crypto.ErrInvalidSignature
+	}
+	return nil
+}
+
+// SignWebAuthn produces a WebAuthn assertion of [msg] using [pk], emulating a
+// platform authenticator registered to [rpID].
+//
+// This is useful for testing and for signing from keys that are not stored
+// on a device (like those held by a CLI).
+func SignWebAuthn(msg []byte, pk PrivateKey, rpID string, origin string) ([]byte, []byte, Signature, error) {
+	rpIDHash := sha256.Sum256([]byte(rpID))
+	authenticatorData := make([]byte, MinAuthenticatorDataLen)
+	copy(authenticatorData, rpIDHash[:])
+	// signCount is left as 0 (authenticators that don't track a counter do the
+	// same)
+	authenticatorData[sha256.Size] = flagUserPresent | flagUserVerified
+	clientDataJSON, err := json.Marshal(&clientData{
+		Type:      WebAuthnGetType,
+		Challenge: WebAuthnChallenge(msg),
+		Origin:    origin,
+	})
+	if err != nil {
+		return nil, nil, EmptySignature, err
+	}
+	sig, err := Sign(webAuthnMessage(authenticatorData, clientDataJSON), pk)
+	if err != nil {
+		return nil, nil, EmptySignature, err
+	}
+	return authenticatorData, clientDataJSON, sig, nil
+}
+
+// WebAuthnClientDataJSONLen returns the size of the clientDataJSON produced
+// by [SignWebAuthn] for [origin].
+func WebAuthnClientDataJSONLen(origin string) int {
+	b, _ := json.Marshal(&clientData{
+		Type:      WebAuthnGetType,
+		Challenge: WebAuthnChallenge(nil),
+		Origin:    origin,
+	})
+	return len(b)
+}
+
+// SignatureFromASN1 converts an ASN.1 (DER) signature (as returned by
+// authenticators) into a normalized [Signature].
+func SignatureFromASN1(sig []byte) (Signature, error) {
+	r, s, err := ParseASN1Signature(sig)
+	if err != nil {
+		return EmptySignature, err
+	}
+	if len(r) > rsLen || len(s) > rsLen {
+		return EmptySignature, crypto.ErrInvalidSignature
+	}
+	ns := normalizeS(new(big.Int).SetBytes(s))
+	return generateSignature(new(big.Int).SetBytes(r), ns), nil
+}
+
+func webAuthnMessage(authenticatorData []byte, clientDataJSON []byte) []byte {
+	clientDataHash := sha256.Sum256(clientDataJSON)
+	msg := make([]byte, 0, len(authenticatorData)+len(clientDataHash))
+	msg = append(msg, authenticatorData...)
+	return append(msg, clientDataHash[:]...)
+}
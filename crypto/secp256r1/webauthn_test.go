@@ -0,0 +1,118 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package secp256r1
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/crypto"
+)
+
+func TestWebAuthnSignVerify(t *testing.T) {
+	require := require.New(t)
+	priv, err := GeneratePrivateKey()
+	require.NoError(err)
+	msg := []byte("hello")
+
+	authenticatorData, clientDataJSON, sig, err := SignWebAuthn(msg, priv, "example.com", "https://example.com")
+	require.NoError(err)
+	require.NoError(VerifyWebAuthn(msg, priv.PublicKey(), authenticatorData, clientDataJSON, sig))
+
+	// Assertion must commit to [msg]
+	require.ErrorIs(VerifyWebAuthn([]byte("world"), priv.PublicKey(), authenticatorData, clientDataJSON, sig), ErrChallengeMismatch)
+
+	// Signature must cover authenticator data
+	modified := make([]byte, len(authenticatorData))
+	copy(modified, authenticatorData)
+	modified[0]++
+	require.ErrorIs(VerifyWebAuthn(msg, priv.PublicKey(), modified, clientDataJSON, sig), crypto.ErrInvalidSignature)
+
+	// Signature must be produced by signer
+	other, err := GeneratePrivateKey()
+	require.NoError(err)
+	require.ErrorIs(VerifyWebAuthn(msg, other.PublicKey(), authenticatorData, clientDataJSON, sig), crypto.ErrInvalidSignature)
+}
+
+func TestWebAuthnInvalidAssertion(t *testing.T) {
+	require := require.New(t)
+	priv, err := GeneratePrivateKey()
+	require.NoError(err)
+	msg := []byte("hello")
+	authenticatorData, _, _, err := SignWebAuthn(msg, priv, "example.com", "https://example.com")
+	require.NoError(err)
+
+	tests := []struct {
+		name              string
+		authenticatorData []byte
+		clientData        []byte
+		err               error
+	}{
+		{
+			name:              "short authenticator data",
+			authenticatorData: authenticatorData[:MinAuthenticatorDataLen-1],
+			err:               ErrInvalidAuthenticatorData,
+		},
+		{
+			name:              "user not present",
+			authenticatorData: append(append([]byte{}, authenticatorData[:sha256.Size]...), 0, 0, 0, 0, 0),
+			err:               ErrUserNotPresent,
+		},
+		{
+			name:              "malformed client data",
+			authenticatorData: authenticatorData,
+			clientData:        []byte("{"),
+			err:               ErrInvalidClientData,
+		},
+		{
+			name:              "registration client data",
+			authenticatorData: authenticatorData,
+			clientData: func() []byte {
+				b, _ := json.Marshal(&clientData{Type: "webauthn.create", Challenge: WebAuthnChallenge(msg)})
+				return b
+			}(),
+			err: ErrInvalidClientData,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sig, err := Sign(webAuthnMessage(tt.authenticatorData, tt.clientData), priv)
+			require.NoError(err)
+			require.ErrorIs(VerifyWebAuthn(msg, priv.PublicKey(), tt.authenticatorData, tt.clientData, sig), tt.err)
+		})
+	}
+}
+
+func TestSignatureFromASN1(t *testing.T) {
+	require := require.New(t)
+	priv, err := GeneratePrivateKey()
+	require.NoError(err)
+	x, y := priv.generateCoordinates()
+	key := &ecdsa.PrivateKey{
+		PublicKey: ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y},
+		D:         new(big.Int).SetBytes(priv[:]),
+	}
+	msg := []byte("hello")
+	digest := sha256.Sum256(msg)
+
+	// Authenticators may return signatures with a large [s], which must be
+	// normalized to be considered valid
+	for i := 0; i < 100; i++ {
+		der, err := ecdsa.SignASN1(rand.Reader, key, digest[:])
+		require.NoError(err)
+		sig, err := SignatureFromASN1(der)
+		require.NoError(err)
+		require.True(Verify(msg, priv.PublicKey(), sig))
+	}
+
+	_, err = SignatureFromASN1([]byte{0x30})
+	require.Error(err)
+}
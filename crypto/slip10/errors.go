@@ -0,0 +1,13 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package slip10
+
+import "errors"
+
+var (
+	ErrUnknownCurve = errors.New("unknown curve")
+	ErrInvalidSeed  = errors.New("invalid seed")
+	ErrInvalidPath  = errors.New("invalid path")
+	ErrNotHardened  = errors.New("ed25519 only supports hardened derivation")
+)
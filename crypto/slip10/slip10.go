@@ -0,0 +1,195 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package slip10 derives hierarchical deterministic keys from a seed (such as
+// one produced from a BIP-39 mnemonic) as specified by SLIP-10.
+//
+// source: https://github.com/satoshilabs/slips/blob/master/slip-0010.md
+package slip10
+
+import (
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/ava-labs/hypersdk/crypto/ed25519"
+	"github.com/ava-labs/hypersdk/crypto/secp256r1"
+)
+
+const (
+	// HardenedOffset is added to an index to derive a hardened child key.
+	HardenedOffset uint32 = 0x80000000
+
+	KeyLen       = 32
+	ChainCodeLen = 32
+
+	MinSeedLen = 16
+	MaxSeedLen = 64
+)
+
+// Curve is the curve keys are derived for.
+type Curve uint8
+
+const (
+	ED25519 Curve = iota
+	NIST256P1
+)
+
+// hmacKey returns the key used to derive master keys for [c].
+func (c Curve) hmacKey() ([]byte, error) {
+	switch c {
+	case ED25519:
+		return []byte("ed25519 seed"), nil
+	case NIST256P1:
+		return []byte("Nist256p1 seed"), nil
+	default:
+		return nil, ErrUnknownCurve
+	}
+}
+
+var nist256p1Order = elliptic.P256().Params().N
+
+// Key is an extended private key (a private key and its chain code).
+type Key struct {
+	Curve     Curve
+	Key       [KeyLen]byte
+	ChainCode [ChainCodeLen]byte
+}
+
+// NewMasterKey returns the master [Key] of [seed] for [curve].
+func NewMasterKey(curve Curve, seed []byte) (*Key, error) {
+	if len(seed) < MinSeedLen || len(seed) > MaxSeedLen {
+		return nil, ErrInvalidSeed
+	}
+	hmacKey, err := curve.hmacKey()
+	if err != nil {
+		return nil, err
+	}
+	i := hmacSHA512(hmacKey, seed)
+	if curve == NIST256P1 {
+		// Keys that are not valid scalars are rehashed
+		for !validScalar(i[:KeyLen]) {
+			i = hmacSHA512(hmacKey, i)
+		}
+	}
+	return newKey(curve, i), nil
+}
+
+// Child returns the child of [k] at [index] (which must be hardened for
+// ED25519).
+func (k *Key) Child(index uint32) (*Key, error) {
+	hardened := index >= HardenedOffset
+	if !hardened && k.Curve == ED25519 {
+		return nil, ErrNotHardened
+	}
+	data := make([]byte, 0, 1+secp256r1.PublicKeyLen+4)
+	if hardened {
+		data = append(data, 0x0)
+		data = append(data, k.Key[:]...)
+	} else {
+		pk := secp256r1.PrivateKey(k.Key).PublicKey()
+		data = append(data, pk[:]...)
+	}
+	data = binary.BigEndian.AppendUint32(data, index)
+	i := hmacSHA512(k.ChainCode[:], data)
+	if k.Curve == ED25519 {
+		return newKey(k.Curve, i), nil
+	}
+
+	// Add the parent key to the derived scalar (rehashing if the result is not
+	// a valid key)
+	parent := new(big.Int).SetBytes(k.Key[:])
+	for {
+		il := new(big.Int).SetBytes(i[:KeyLen])
+		if il.Cmp(nist256p1Order) < 0 {
+			il.Add(il, parent)
+			il.Mod(il, nist256p1Order)
+			if il.Sign() != 0 {
+				il.FillBytes(i[:KeyLen])
+				return newKey(k.Curve, i), nil
+			}
+		}
+		data = data[:0]
+		data = append(data, 0x1)
+		data = append(data, i[KeyLen:]...)
+		data = binary.BigEndian.AppendUint32(data, index)
+		i = hmacSHA512(k.ChainCode[:], data)
+	}
+}
+
+// ED25519 returns the [ed25519.PrivateKey] of [k].
+func (k *Key) ED25519() (ed25519.PrivateKey, error) {
+	if k.Curve != ED25519 {
+		return ed25519.EmptyPrivateKey, ErrUnknownCurve
+	}
+	return ed25519.PrivateKeyFromSeed(k.Key[:])
+}
+
+// SECP256R1 returns the [secp256r1.PrivateKey] of [k].
+func (k *Key) SECP256R1() (secp256r1.PrivateKey, error) {
+	if k.Curve != NIST256P1 {
+		return secp256r1.EmptyPrivateKey, ErrUnknownCurve
+	}
+	return secp256r1.PrivateKey(k.Key), nil
+}
+
+// Derive returns the [Key] of [seed] for [curve] at [path].
+func Derive(curve Curve, seed []byte, path []uint32) (*Key, error) {
+	k, err := NewMasterKey(curve, seed)
+	if err != nil {
+		return nil, err
+	}
+	for _, index := range path {
+		k, err = k.Child(index)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return k, nil
+}
+
+// ParsePath parses a derivation path like "m/44'/9000'/0'" (where a trailing
+// ' or H denotes a hardened index).
+func ParsePath(path string) ([]uint32, error) {
+	segments := strings.Split(path, "/")
+	if segments[0] != "m" {
+		return nil, fmt.Errorf("%w: must start with m", ErrInvalidPath)
+	}
+	indices := make([]uint32, 0, len(segments)-1)
+	for _, segment := range segments[1:] {
+		var offset uint32
+		if strings.HasSuffix(segment, "'") || strings.HasSuffix(segment, "H") {
+			offset = HardenedOffset
+			segment = segment[:len(segment)-1]
+		}
+		index, err := strconv.ParseUint(segment, 10, 31)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidPath, segment)
+		}
+		indices = append(indices, uint32(index)+offset)
+	}
+	return indices, nil
+}
+
+func newKey(curve Curve, i []byte) *Key {
+	k := &Key{Curve: curve}
+	copy(k.Key[:], i[:KeyLen])
+	copy(k.ChainCode[:], i[KeyLen:])
+	return k
+}
+
+func validScalar(b []byte) bool {
+	s := new(big.Int).SetBytes(b)
+	return s.Sign() != 0 && s.Cmp(nist256p1Order) < 0
+}
+
+func hmacSHA512(key []byte, data []byte) []byte {
+	h := hmac.New(sha512.New, key)
+	_, _ = h.Write(data)
+	return h.Sum(nil)
+}
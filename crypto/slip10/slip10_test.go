@@ -0,0 +1,129 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package slip10
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Test vector 1 of SLIP-10
+//
+// source: https://github.com/satoshilabs/slips/blob/master/slip-0010.md#test-vectors
+var testSeed = "000102030405060708090a0b0c0d0e0f"
+
+func TestDerive(t *testing.T) {
+	tests := []struct {
+		curve     Curve
+		path      string
+		chainCode string
+		key       string
+	}{
+		{
+			curve:     ED25519,
+			path:      "m",
+			chainCode: "90046a93de5380a72b5e45010748567d5ea02bbf6522f979e05c0d8d8ca9fffb",
+			key:       "2b4be7f19ee27bbf30c667b642d5f4aa69fd169872f8fc3059c08ebae2eb19e7",
+		},
+		{
+			curve:     ED25519,
+			path:      "m/0H",
+			chainCode: "8b59aa11380b624e81507a27fedda59fea6d0b779a778918a2fd3590e16e9c69",
+			key:       "68e0fe46dfb67e368c75379acec591dad19df3cde26e63b93a8e704f1dade7a3",
+		},
+		{
+			curve:     ED25519,
+			path:      "m/0H/1H",
+			chainCode: "a320425f77d1b5c2505a6b1b27382b37368ee640e3557c315416801243552f14",
+			key:       "b1d0bad404bf35da785a64ca1ac54b2617211d2777696fbffaf208f746ae84f2",
+		},
+		{
+			curve:     NIST256P1,
+			path:      "m",
+			chainCode: "beeb672fe4621673f722f38529c07392fecaa61015c80c34f29ce8b41b3cb6ea",
+			key:       "612091aaa12e22dd2abef664f8a01a82cae99ad7441b7ef8110424915c268bc2",
+		},
+		{
+			curve:     NIST256P1,
+			path:      "m/0H",
+			chainCode: "3460cea53e6a6bb5fb391eeef3237ffd8724bf0a40e94943c98b83825342ee11",
+			key:       "6939694369114c67917a182c59ddb8cafc3004e63ca5d3b84403ba8613debc0c",
+		},
+		{
+			curve:     NIST256P1,
+			path:      "m/0H/1",
+			chainCode: "4187afff1aafa8445010097fb99d23aee9f599450c7bd140b6826ac22ba21d0c",
+			key:       "284e9d38d07d21e4e281b645089a94f4cf5a5a81369acf151a1c3a57f18b2129",
+		},
+	}
+	seed, err := hex.DecodeString(testSeed)
+	require.NoError(t, err)
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			require := require.New(t)
+			path, err := ParsePath(tt.path)
+			require.NoError(err)
+			k, err := Derive(tt.curve, seed, path)
+			require.NoError(err)
+			require.Equal(tt.chainCode, hex.EncodeToString(k.ChainCode[:]))
+			require.Equal(tt.key, hex.EncodeToString(k.Key[:]))
+		})
+	}
+}
+
+func TestDeriveErrors(t *testing.T) {
+	require := require.New(t)
+	seed, err := hex.DecodeString(testSeed)
+	require.NoError(err)
+
+	// ed25519 only supports hardened derivation
+	_, err = Derive(ED25519, seed, []uint32{0})
+	require.ErrorIs(err, ErrNotHardened)
+
+	_, err = NewMasterKey(ED25519, seed[:MinSeedLen-1])
+	require.ErrorIs(err, ErrInvalidSeed)
+	_, err = NewMasterKey(Curve(2), seed)
+	require.ErrorIs(err, ErrUnknownCurve)
+}
+
+func TestKeys(t *testing.T) {
+	require := require.New(t)
+	seed, err := hex.DecodeString(testSeed)
+	require.NoError(err)
+
+	k, err := NewMasterKey(ED25519, seed)
+	require.NoError(err)
+	priv, err := k.ED25519()
+	require.NoError(err)
+	require.Equal(k.Key[:], priv[:KeyLen])
+	_, err = k.SECP256R1()
+	require.ErrorIs(err, ErrUnknownCurve)
+
+	k, err = NewMasterKey(NIST256P1, seed)
+	require.NoError(err)
+	rpriv, err := k.SECP256R1()
+	require.NoError(err)
+	require.Equal(k.Key[:], rpriv[:])
+	_, err = k.ED25519()
+	require.ErrorIs(err, ErrUnknownCurve)
+}
+
+func TestParsePath(t *testing.T) {
+	require := require.New(t)
+
+	path, err := ParsePath("m/44'/9000H/0/1")
+	require.NoError(err)
+	require.Equal([]uint32{44 + HardenedOffset, 9000 + HardenedOffset, 0, 1}, path)
+
+	path, err = ParsePath("m")
+	require.NoError(err)
+	require.Empty(path)
+
+	for _, invalid := range []string{"", "44'/0'", "m/", "m/a'", "m/2147483648", "m/-1"} {
+		_, err := ParsePath(invalid)
+		require.ErrorIs(err, ErrInvalidPath, invalid)
+	}
+}
@@ -3,15 +3,42 @@
 
 package auth
 
-import "github.com/ava-labs/hypersdk/vm"
+import (
+	"github.com/ava-labs/hypersdk/crypto/ed25519"
+	"github.com/ava-labs/hypersdk/crypto/secp256r1"
+	"github.com/ava-labs/hypersdk/vm"
+	"github.com/prometheus/client_golang/prometheus"
+)
 
 // Note: Registry will error during initialization if a duplicate ID is assigned. We explicitly assign IDs to avoid accidental remapping.
 const (
-	ed25519ID uint8 = 0
+	ed25519ID   uint8 = 0
+	secp256r1ID uint8 = 1
+	webAuthnID  uint8 = 2
 )
 
-func Engines() map[uint8]vm.AuthEngine {
-	return map[uint8]vm.AuthEngine{
-		ed25519ID: &ED25519AuthEngine{},
+// Engines returns the [vm.AuthEngine] of each auth that can be verified in
+// batches. Engines of auths signed by the same key type share a cache of up to
+// [cacheSize] public keys, which records its metrics in [registerer].
+//
+// These caches also replace the default caches of [ed25519] and [secp256r1],
+// so that auths verified one at a time (like those submitted to the API or
+// nested in other auths) use the same parsed keys.
+func Engines(cacheSize int, registerer prometheus.Registerer) (map[uint8]vm.AuthEngine, error) {
+	ed25519Cache, err := ed25519.NewKeyCache(cacheSize, "ed25519_key_cache", registerer)
+	if err != nil {
+		return nil, err
 	}
+	secp256r1Cache, err := secp256r1.NewKeyCache(cacheSize, "secp256r1_key_cache", registerer)
+	if err != nil {
+		return nil, err
+	}
+	ed25519.SetDefaultKeyCache(ed25519Cache)
+	secp256r1.SetDefaultKeyCache(secp256r1Cache)
+	secp256r1Engine := &SECP256R1AuthEngine{secp256r1Cache}
+	return map[uint8]vm.AuthEngine{
+		ed25519ID:   &ED25519AuthEngine{ed25519Cache},
+		secp256r1ID: secp256r1Engine,
+		webAuthnID:  secp256r1Engine,
+	}, nil
 }
@@ -135,22 +135,25 @@ func (*ED25519Factory) MaxUnits() (uint64, uint64, []uint16) {
 	return ED25519Size, ED25519ComputeUnits, []uint16{storage.BalanceChunks}
 }
 
-type ED25519AuthEngine struct{}
+type ED25519AuthEngine struct {
+	cache *ed25519.KeyCache
+}
 
-func (*ED25519AuthEngine) GetBatchVerifier(cores int, count int) chain.AuthBatchVerifier {
+func (e *ED25519AuthEngine) GetBatchVerifier(cores int, count int) chain.AuthBatchVerifier {
 	batchSize := math.Max(count/cores, ed25519.MinBatchSize)
 	return &ED25519Batch{
+		cache:     e.cache,
 		batchSize: batchSize,
 		total:     count,
 	}
 }
 
-func (*ED25519AuthEngine) Cache(auth chain.Auth) {
-	pk := GetSigner(auth)
-	ed25519.CachePublicKey(pk)
+func (e *ED25519AuthEngine) Cache(auth chain.Auth) {
+	e.cache.Add(GetSigner(auth))
 }
 
 type ED25519Batch struct {
+	cache     *ed25519.KeyCache
 	batchSize int
 	total     int
 
@@ -162,7 +165,7 @@ type ED25519Batch struct {
 func (b *ED25519Batch) Add(msg []byte, rauth chain.Auth) func() error {
 	auth := rauth.(*ED25519)
 	if b.batch == nil {
-		b.batch = ed25519.NewBatch(b.batchSize)
+		b.batch = ed25519.NewBatchWithCache(b.batchSize, b.cache)
 	}
 	b.batch.Add(msg, auth.Signer, auth.Signature)
 	b.counter++
@@ -172,7 +175,7 @@ func (b *ED25519Batch) Add(msg []byte, rauth chain.Auth) func() error {
 		b.counter = 0
 		if b.totalCounter < b.total {
 			// don't create a new batch if we are done
-			b.batch = ed25519.NewBatch(b.batchSize)
+			b.batch = ed25519.NewBatchWithCache(b.batchSize, b.cache)
 		}
 		return last.VerifyAsync()
 	}
@@ -12,6 +12,10 @@ func GetActor(auth chain.Auth) ed25519.PublicKey {
 	switch a := auth.(type) {
 	case *ED25519:
 		return a.Signer
+	case *SECP256R1:
+		return NewSECP256R1Address(a.Signer)
+	case *WebAuthn:
+		return NewWebAuthnAddress(a.Signer)
 	default:
 		return ed25519.EmptyPublicKey
 	}
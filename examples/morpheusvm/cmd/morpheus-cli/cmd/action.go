@@ -28,7 +28,7 @@ var transferCmd = &cobra.Command{
 		}
 
 		// Get balance info
-		balance, err := handler.GetBalance(ctx, bcli, priv.PublicKey())
+		balance, err := handler.GetBalance(ctx, bcli, priv.Address)
 		if balance == 0 || err != nil {
 			return err
 		}
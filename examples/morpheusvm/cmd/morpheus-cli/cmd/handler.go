@@ -7,8 +7,10 @@ import (
 	"context"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/hypersdk/chain"
 	"github.com/ava-labs/hypersdk/cli"
 	"github.com/ava-labs/hypersdk/crypto/ed25519"
+	"github.com/ava-labs/hypersdk/crypto/secp256r1"
 	"github.com/ava-labs/hypersdk/examples/morpheusvm/auth"
 	"github.com/ava-labs/hypersdk/examples/morpheusvm/consts"
 	brpc "github.com/ava-labs/hypersdk/examples/morpheusvm/rpc"
@@ -32,26 +34,28 @@ func (h *Handler) Root() *cli.Handler {
 }
 
 func (h *Handler) DefaultActor() (
-	ids.ID, ed25519.PrivateKey, *auth.ED25519Factory,
+	ids.ID, *cli.PrivateKey, chain.AuthFactory,
 	*rpc.JSONRPCClient, *brpc.JSONRPCClient, error,
 ) {
 	priv, err := h.h.GetDefaultKey(true)
 	if err != nil {
-		return ids.Empty, ed25519.EmptyPrivateKey, nil, nil, nil, err
+		return ids.Empty, nil, nil, nil, nil, err
+	}
+	factory, err := getFactory(priv)
+	if err != nil {
+		return ids.Empty, nil, nil, nil, nil, err
 	}
 	chainID, uris, err := h.h.GetDefaultChain(true)
 	if err != nil {
-		return ids.Empty, ed25519.EmptyPrivateKey, nil, nil, nil, err
+		return ids.Empty, nil, nil, nil, nil, err
 	}
 	cli := rpc.NewJSONRPCClient(uris[0])
 	networkID, _, _, err := cli.Network(context.TODO())
 	if err != nil {
-		return ids.Empty, ed25519.EmptyPrivateKey, nil, nil, nil, err
+		return ids.Empty, nil, nil, nil, nil, err
 	}
 	// For [defaultActor], we always send requests to the first returned URI.
-	return chainID, priv, auth.NewED25519Factory(
-			priv,
-		), cli,
+	return chainID, priv, factory, cli,
 		brpc.NewJSONRPCClient(
 			uris[0],
 			networkID,
@@ -110,3 +114,25 @@ func (*Controller) Address(pk ed25519.PublicKey) string {
 func (*Controller) ParseAddress(address string) (ed25519.PublicKey, error) {
 	return utils.ParseAddress(address)
 }
+
+func (*Controller) KeyAddress(keyType cli.KeyType, priv []byte) (ed25519.PublicKey, error) {
+	switch keyType {
+	case cli.ED25519Key:
+		return ed25519.PrivateKey(priv).PublicKey(), nil
+	case cli.SECP256R1Key:
+		return auth.NewSECP256R1Address(secp256r1.PrivateKey(priv).PublicKey()), nil
+	default:
+		return ed25519.EmptyPublicKey, cli.ErrUnknownKeyType
+	}
+}
+
+func getFactory(priv *cli.PrivateKey) (chain.AuthFactory, error) {
+	switch priv.Type {
+	case cli.ED25519Key:
+		return auth.NewED25519Factory(ed25519.PrivateKey(priv.Bytes)), nil
+	case cli.SECP256R1Key:
+		return auth.NewSECP256R1Factory(secp256r1.PrivateKey(priv.Bytes)), nil
+	default:
+		return nil, cli.ErrUnknownKeyType
+	}
+}
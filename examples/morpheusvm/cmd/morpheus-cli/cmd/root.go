@@ -37,6 +37,8 @@ var (
 	prometheusData        string
 	startPrometheus       bool
 	maxFee                int64
+	keyType               string
+	mnemonic              bool
 
 	rootCmd = &cobra.Command{
 		Use:        "morpheus-cli",
@@ -118,9 +120,38 @@ func init() {
 		false,
 		"check all chains",
 	)
+	genKeyCmd.PersistentFlags().StringVar(
+		&keyType,
+		"type",
+		cli.ED25519Key.String(),
+		"type of key to generate (ed25519 or secp256r1)",
+	)
+	genKeyCmd.PersistentFlags().BoolVar(
+		&mnemonic,
+		"mnemonic",
+		false,
+		"generate a mnemonic and derive the key from it",
+	)
+	for _, cmd := range []*cobra.Command{recoverKeyCmd, deriveKeyCmd} {
+		cmd.PersistentFlags().StringVar(
+			&keyType,
+			"type",
+			cli.ED25519Key.String(),
+			"type of key to derive (ed25519 or secp256r1)",
+		)
+	}
+	importKeyCmd.PersistentFlags().StringVar(
+		&keyType,
+		"type",
+		cli.ED25519Key.String(),
+		"type of key to import (ed25519 or secp256r1)",
+	)
 	keyCmd.AddCommand(
 		genKeyCmd,
+		recoverKeyCmd,
+		deriveKeyCmd,
 		importKeyCmd,
+		exportKeyCmd,
 		setKeyCmd,
 		balanceKeyCmd,
 	)
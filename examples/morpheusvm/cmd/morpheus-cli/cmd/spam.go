@@ -8,9 +8,9 @@ import (
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/hypersdk/chain"
+	hcli "github.com/ava-labs/hypersdk/cli"
 	"github.com/ava-labs/hypersdk/crypto/ed25519"
 	"github.com/ava-labs/hypersdk/examples/morpheusvm/actions"
-	"github.com/ava-labs/hypersdk/examples/morpheusvm/auth"
 	"github.com/ava-labs/hypersdk/examples/morpheusvm/consts"
 	brpc "github.com/ava-labs/hypersdk/examples/morpheusvm/rpc"
 	"github.com/ava-labs/hypersdk/rpc"
@@ -38,9 +38,7 @@ var runSpamCmd = &cobra.Command{
 			func(uri string, networkID uint32, chainID ids.ID) {
 				bclient = brpc.NewJSONRPCClient(uri, networkID, chainID)
 			},
-			func(pk ed25519.PrivateKey) chain.AuthFactory {
-				return auth.NewED25519Factory(pk)
-			},
+			getFactory,
 			func(choice int, address string) (uint64, error) {
 				balance, err := bclient.Balance(context.TODO(), address)
 				if err != nil {
@@ -64,12 +62,16 @@ var runSpamCmd = &cobra.Command{
 					Value: amount,
 				}
 			},
-			func(cli *rpc.JSONRPCClient, pk ed25519.PrivateKey) func(context.Context, uint64) error {
+			func(cli *rpc.JSONRPCClient, priv *hcli.PrivateKey) func(context.Context, uint64) error {
 				return func(ictx context.Context, count uint64) error {
-					_, _, err := sendAndWait(ictx, nil, &actions.Transfer{
-						To:    pk.PublicKey(),
+					factory, err := getFactory(priv)
+					if err != nil {
+						return err
+					}
+					_, _, err = sendAndWait(ictx, nil, &actions.Transfer{
+						To:    priv.Address,
 						Value: count, // prevent duplicate txs
-					}, cli, bclient, auth.NewED25519Factory(pk), false)
+					}, cli, bclient, factory, false)
 					return err
 				}
 			},
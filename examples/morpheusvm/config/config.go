@@ -13,6 +13,8 @@ import (
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/profiler"
 	"github.com/ava-labs/hypersdk/config"
+	"github.com/ava-labs/hypersdk/network"
+	"github.com/ava-labs/hypersdk/ratelimit"
 	"github.com/ava-labs/hypersdk/trace"
 	"github.com/ava-labs/hypersdk/vm"
 
@@ -27,6 +29,7 @@ const (
 	defaultContinuousProfilerFrequency = 1 * time.Minute
 	defaultContinuousProfilerMaxFiles  = 10
 	defaultStoreTransactions           = true
+	defaultAuthCacheSize               = 128_000
 )
 
 type Config struct {
@@ -37,6 +40,9 @@ type Config struct {
 	RootGenerationCores        int `json:"rootGenerationCores"`
 	TransactionExecutionCores  int `json:"transactionExecutionCores"`
 
+	// Gossip
+	GossipCompression network.CompressionConfig `json:"gossipCompression"`
+
 	// Tracing
 	TraceEnabled    bool    `json:"traceEnabled"`
 	TraceSampleRate float64 `json:"traceSampleRate"`
@@ -45,7 +51,45 @@ type Config struct {
 	ContinuousProfilerDir string `json:"continuousProfilerDir"` // "*" is replaced with rand int
 
 	// Streaming settings
-	StreamingBacklogSize int `json:"streamingBacklogSize"`
+	StreamingBacklogSize          int              `json:"streamingBacklogSize"`
+	StreamingClientConnections    int              `json:"streamingClientConnections"`
+	StreamingClientMessageLimit   ratelimit.Config `json:"streamingClientMessageLimit"`
+	StreamingCompressionThreshold int              `json:"streamingCompressionThreshold"` // 0 disables compression
+
+	// Rate limiting
+	RPCRateLimit        ratelimit.Config            `json:"rpcRateLimit"`
+	RPCMethodRateLimits map[string]ratelimit.Config `json:"rpcMethodRateLimits"`
+	RateLimitKeyHeader  string                      `json:"rateLimitKeyHeader"`
+
+	// Health
+	HealthAcceptedQueueThreshold float64       `json:"healthAcceptedQueueThreshold"`
+	HealthMempoolThreshold       float64       `json:"healthMempoolThreshold"`
+	HealthMaxAcceptDelay         time.Duration `json:"healthMaxAcceptDelay"`
+	HealthWarpBacklogThreshold   int           `json:"healthWarpBacklogThreshold"`
+
+	// Warp
+	WarpSignatureThreshold uint64 `json:"warpSignatureThreshold"` // percent of stake
+	WarpMaxOutstanding     int    `json:"warpMaxOutstanding"`
+
+	// Peer Reputation
+	PeerReputation network.ReputationConfig `json:"peerReputation"`
+
+	// Auth
+	//
+	// The most public keys (of each key type) that are cached to speed up
+	// verifying the signatures of repeat signers (0 disables the cache).
+	AuthCacheSize int `json:"authCacheSize"`
+
+	// Caching the keys of gossiped transactions speeds up verifying them once
+	// they are included in a block but lets any peer evict the keys of active
+	// signers (by gossiping transactions from many new signers), so it is
+	// disabled by default.
+	WarmAuthCache bool `json:"warmAuthCache"`
+
+	// Admin
+	//
+	// The admin API is only served if a token is provided.
+	AdminToken string `json:"adminToken"`
 
 	// Mempool
 	MempoolSize         int      `json:"mempoolSize"`
@@ -98,8 +142,18 @@ func (c *Config) setDefault() {
 	c.MempoolPayerSize = c.Config.GetMempoolPayerSize()
 	c.StateSyncServerDelay = c.Config.GetStateSyncServerDelay()
 	c.StreamingBacklogSize = c.Config.GetStreamingBacklogSize()
+	c.StreamingCompressionThreshold = c.Config.GetStreamingCompressionThreshold()
+	c.GossipCompression = c.Config.GetGossipCompression()
 	c.VerifySignatures = c.Config.GetVerifySignatures()
+	c.HealthAcceptedQueueThreshold = c.Config.GetHealthAcceptedQueueThreshold()
+	c.HealthMempoolThreshold = c.Config.GetHealthMempoolThreshold()
+	c.HealthMaxAcceptDelay = c.Config.GetHealthMaxAcceptDelay()
+	c.HealthWarpBacklogThreshold = c.Config.GetHealthWarpBacklogThreshold()
+	c.PeerReputation = c.Config.GetPeerReputation()
+	c.WarpSignatureThreshold = c.Config.GetWarpSignatureThreshold()
+	c.WarpMaxOutstanding = c.Config.GetWarpMaxOutstanding()
 	c.StoreTransactions = defaultStoreTransactions
+	c.AuthCacheSize = defaultAuthCacheSize
 }
 
 func (c *Config) GetLogLevel() logging.Level         { return c.LogLevel }
@@ -121,6 +175,30 @@ func (c *Config) GetTraceConfig() *trace.Config {
 }
 func (c *Config) GetStateSyncServerDelay() time.Duration { return c.StateSyncServerDelay }
 func (c *Config) GetStreamingBacklogSize() int           { return c.StreamingBacklogSize }
+func (c *Config) GetStreamingClientConnections() int     { return c.StreamingClientConnections }
+func (c *Config) GetStreamingClientMessageLimit() ratelimit.Config {
+	return c.StreamingClientMessageLimit
+}
+func (c *Config) GetStreamingCompressionThreshold() int { return c.StreamingCompressionThreshold }
+func (c *Config) GetGossipCompression() network.CompressionConfig {
+	return c.GossipCompression
+}
+func (c *Config) GetRPCRateLimit() ratelimit.Config { return c.RPCRateLimit }
+func (c *Config) GetRPCMethodRateLimits() map[string]ratelimit.Config {
+	return c.RPCMethodRateLimits
+}
+func (c *Config) GetRateLimitKeyHeader() string { return c.RateLimitKeyHeader }
+func (c *Config) GetHealthAcceptedQueueThreshold() float64 {
+	return c.HealthAcceptedQueueThreshold
+}
+func (c *Config) GetHealthMempoolThreshold() float64     { return c.HealthMempoolThreshold }
+func (c *Config) GetHealthMaxAcceptDelay() time.Duration { return c.HealthMaxAcceptDelay }
+func (c *Config) GetHealthWarpBacklogThreshold() int     { return c.HealthWarpBacklogThreshold }
+func (c *Config) GetWarpSignatureThreshold() uint64      { return c.WarpSignatureThreshold }
+func (c *Config) GetWarpMaxOutstanding() int             { return c.WarpMaxOutstanding }
+func (c *Config) GetPeerReputation() network.ReputationConfig {
+	return c.PeerReputation
+}
 func (c *Config) GetContinuousProfilerConfig() *profiler.Config {
 	if len(c.ContinuousProfilerDir) == 0 {
 		return &profiler.Config{Enabled: false}
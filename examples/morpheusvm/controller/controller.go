@@ -17,6 +17,7 @@ import (
 	hrpc "github.com/ava-labs/hypersdk/rpc"
 	hstorage "github.com/ava-labs/hypersdk/storage"
 	"github.com/ava-labs/hypersdk/vm"
+	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
 
 	"github.com/ava-labs/hypersdk/examples/morpheusvm/actions"
@@ -108,15 +109,23 @@ func (c *Controller) Initialize(
 	// hypersdk handler are initiatlized automatically, you just need to
 	// initialize custom handlers here.
 	apis := map[string]*common.HTTPHandler{}
-	jsonRPCHandler, err := hrpc.NewJSONRPCHandler(
+	jsonRPCHandler, err := hrpc.NewRateLimitedJSONRPCHandler(
 		consts.Name,
 		rpc.NewJSONRPCServer(c),
 		common.NoLock,
+		inner.NewRPCLimiter(c.config),
 	)
 	if err != nil {
 		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, err
 	}
 	apis[rpc.JSONRPCEndpoint] = jsonRPCHandler
+	if len(c.config.AdminToken) > 0 {
+		adminHandler, err := hrpc.NewAdminHandler(inner, c.config, c.config.AdminToken)
+		if err != nil {
+			return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, err
+		}
+		apis[hrpc.AdminEndpoint] = adminHandler
+	}
 
 	// Create builder and gossiper
 	var (
@@ -130,12 +139,23 @@ func (c *Controller) Initialize(
 	} else {
 		build = builder.NewTime(inner)
 		gcfg := gossiper.DefaultProposerConfig()
+		gcfg.WarmAuthCache = c.config.WarmAuthCache
 		gossip, err = gossiper.NewProposer(inner, gcfg)
 		if err != nil {
 			return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, err
 		}
 	}
-	return c.config, c.genesis, build, gossip, blockDB, stateDB, apis, consts.ActionRegistry, consts.AuthRegistry, auth.Engines(), nil
+
+	// Create auth engines
+	authRegistry := prometheus.NewRegistry()
+	authEngines, err := auth.Engines(c.config.AuthCacheSize, authRegistry)
+	if err != nil {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, err
+	}
+	if err := gatherer.Register("auth", authRegistry); err != nil {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, err
+	}
+	return c.config, c.genesis, build, gossip, blockDB, stateDB, apis, consts.ActionRegistry, consts.AuthRegistry, authEngines, nil
 }
 
 func (c *Controller) Rules(t int64) chain.Rules {
@@ -149,6 +149,7 @@ cat <<EOF > ${TMPDIR}/morpheusvm.config
   "rootGenerationCores": 2,
   "transactionExecutionCores": 2,
   "verifySignatures":true,
+  "warmAuthCache":false,
   "storeTransactions": ${STORE_TXS},
   "streamingBacklogSize": 10000000,
   "logLevel": "${LOGLEVEL}",
@@ -0,0 +1,86 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/auth"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/storage"
+)
+
+func accountSignerSize(signer *storage.AccountSigner) int {
+	return consts.Uint8Len*2 + len(signer.Key)
+}
+
+func packAccountSigner(p *codec.Packer, signer *storage.AccountSigner) {
+	p.PackByte(signer.KeyType)
+	p.PackByte(signer.Weight)
+	p.PackFixedBytes(signer.Key)
+}
+
+func unpackAccountSigner(p *codec.Packer) (*storage.AccountSigner, error) {
+	var signer storage.AccountSigner
+	signer.KeyType = p.UnpackByte()
+	signer.Weight = p.UnpackByte()
+	if err := unpackSignerKey(p, signer.KeyType, &signer.Key); err != nil {
+		return nil, err
+	}
+	return &signer, nil
+}
+
+func unpackSignerKey(p *codec.Packer, keyType uint8, dest *[]byte) error {
+	keyLen, ok := storage.SignerKeyLen(keyType)
+	if !ok {
+		return auth.ErrUnknownKeyType
+	}
+	*dest = make([]byte, keyLen)
+	p.UnpackFixedBytes(keyLen, dest)
+	return nil
+}
+
+// findAccountSigner returns the index of the signer with [keyType] and [key]
+// or -1 if it is not a signer.
+func findAccountSigner(signers []*storage.AccountSigner, keyType uint8, key []byte) int {
+	for i, signer := range signers {
+		if signer.KeyType == keyType && string(signer.Key) == string(key) {
+			return i
+		}
+	}
+	return -1
+}
+
+// verifyAccount returns a non-nil output if [signers] can't be stored as an
+// account with [threshold].
+func verifyAccount(threshold uint8, signers []*storage.AccountSigner) []byte {
+	if threshold == 0 {
+		return OutputThresholdZero
+	}
+	if len(signers) > storage.MaxAccountSigners {
+		return OutputTooManySigners
+	}
+	var (
+		weight int
+		seen   = set.NewSet[string](len(signers))
+	)
+	for _, signer := range signers {
+		if keyLen, ok := storage.SignerKeyLen(signer.KeyType); !ok || len(signer.Key) != keyLen {
+			return OutputInvalidSigner
+		}
+		if signer.Weight == 0 {
+			return OutputSignerWeightZero
+		}
+		k := string(signer.Key)
+		if seen.Contains(k) {
+			return OutputDuplicateSigner
+		}
+		seen.Add(k)
+		weight += int(signer.Weight)
+	}
+	if weight < int(threshold) {
+		return OutputThresholdUnreachable
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/auth"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/storage"
+	"github.com/ava-labs/hypersdk/state"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var _ chain.Action = (*AddAccountSigner)(nil)
+
+// AddAccountSigner adds [Signer] to the account of the actor.
+type AddAccountSigner struct {
+	// Signer is the key to authorize.
+	Signer *storage.AccountSigner `json:"signer"`
+
+	// Threshold is the new threshold of the account.
+	Threshold uint8 `json:"threshold"`
+}
+
+func (*AddAccountSigner) GetTypeID() uint8 {
+	return addAccountSignerID
+}
+
+func (*AddAccountSigner) StateKeys(rauth chain.Auth, _ ids.ID) []string {
+	return []string{
+		string(storage.AccountKey(auth.GetActor(rauth))),
+	}
+}
+
+func (*AddAccountSigner) StateKeysMaxChunks() []uint16 {
+	return []uint16{storage.AccountChunks}
+}
+
+func (*AddAccountSigner) OutputsWarpMessage() bool {
+	return false
+}
+
+func (a *AddAccountSigner) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	_ int64,
+	rauth chain.Auth,
+	_ ids.ID,
+	_ bool,
+) (bool, uint64, []byte, *warp.UnsignedMessage, error) {
+	// Sessions can't change who controls the account
+	if isSession(rauth) {
+		return false, AddAccountSignerComputeUnits, OutputSessionNotPermitted, nil, nil
+	}
+	actor := auth.GetActor(rauth)
+	exists, _, signers, err := storage.GetAccount(ctx, mu, actor)
+	if err != nil {
+		return false, AddAccountSignerComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	if !exists {
+		return false, AddAccountSignerComputeUnits, OutputAccountMissing, nil, nil
+	}
+	signers = append(signers, a.Signer)
+	if output := verifyAccount(a.Threshold, signers); output != nil {
+		return false, AddAccountSignerComputeUnits, output, nil, nil
+	}
+	if err := storage.SetAccount(ctx, mu, actor, a.Threshold, signers); err != nil {
+		return false, AddAccountSignerComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	return true, AddAccountSignerComputeUnits, nil, nil, nil
+}
+
+func (*AddAccountSigner) MaxComputeUnits(chain.Rules) uint64 {
+	return AddAccountSignerComputeUnits
+}
+
+func (a *AddAccountSigner) Size() int {
+	return accountSignerSize(a.Signer) + consts.Uint8Len
+}
+
+func (a *AddAccountSigner) Marshal(p *codec.Packer) {
+	packAccountSigner(p, a.Signer)
+	p.PackByte(a.Threshold)
+}
+
+func UnmarshalAddAccountSigner(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var add AddAccountSigner
+	signer, err := unpackAccountSigner(p)
+	if err != nil {
+		return nil, err
+	}
+	add.Signer = signer
+	add.Threshold = p.UnpackByte()
+	return &add, p.Err()
+}
+
+func (*AddAccountSigner) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
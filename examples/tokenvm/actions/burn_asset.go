@@ -19,7 +19,10 @@ import (
 	"github.com/ava-labs/hypersdk/utils"
 )
 
-var _ chain.Action = (*BurnAsset)(nil)
+var (
+	_ chain.Action = (*BurnAsset)(nil)
+	_ auth.Spender = (*BurnAsset)(nil)
+)
 
 type BurnAsset struct {
 	// Asset is the [TxID] that created the asset.
@@ -58,11 +61,10 @@ func (b *BurnAsset) Execute(
 	_ ids.ID,
 	_ bool,
 ) (bool, uint64, []byte, *warp.UnsignedMessage, error) {
-	actor := auth.GetActor(rauth)
 	if b.Value == 0 {
 		return false, BurnComputeUnits, OutputValueZero, nil, nil
 	}
-	if err := storage.SubBalance(ctx, mu, actor, b.Asset, b.Value); err != nil {
+	if err := subActorBalance(ctx, mu, rauth, b.Asset, b.Value); err != nil {
 		return false, BurnComputeUnits, utils.ErrBytes(err), nil, nil
 	}
 	exists, symbol, decimals, metadata, supply, owner, warp, err := storage.GetAsset(ctx, mu, b.Asset)
@@ -82,6 +84,10 @@ func (b *BurnAsset) Execute(
 	return true, BurnComputeUnits, nil, nil, nil
 }
 
+func (b *BurnAsset) Spends() map[ids.ID]uint64 {
+	return map[ids.ID]uint64{b.Asset: b.Value}
+}
+
 func (*BurnAsset) MaxComputeUnits(chain.Rules) uint64 {
 	return BurnComputeUnits
 }
@@ -17,6 +17,19 @@ const (
 	createNFTID     uint8 = 9
 	getNFTID        uint8 = 10
 	zkTransactionID uint8 = 11
+
+	createAccountID       uint8 = 12
+	addAccountSignerID    uint8 = 13
+	removeAccountSignerID uint8 = 14
+	rotateAccountSignerID uint8 = 15
+
+	registerSessionID uint8 = 16
+	revokeSessionID   uint8 = 17
+)
+
+// Warp payload IDs must not collide with those reserved by [chain.NewWarpPayloadRegistry].
+const (
+	warpTransferID uint8 = 0
 )
 
 const (
@@ -34,6 +47,14 @@ const (
 	CreateNFTComputeUnits     = 2
 	ZkTransactionComputeUnits = 1
 
+	CreateAccountComputeUnits       = 5
+	AddAccountSignerComputeUnits    = 5
+	RemoveAccountSignerComputeUnits = 5
+	RotateAccountSignerComputeUnits = 5
+
+	RegisterSessionComputeUnits = 5
+	RevokeSessionComputeUnits   = 5
+
 	MaxSymbolSize   = 8
 	MaxMemoSize     = 256
 	MaxMetadataSize = 256
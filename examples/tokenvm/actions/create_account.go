@@ -0,0 +1,112 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/storage"
+	"github.com/ava-labs/hypersdk/state"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var _ chain.Action = (*CreateAccount)(nil)
+
+// CreateAccount creates an account controlled by [Signers] at the address
+// derived from the [TxID] (see [storage.AccountAddress]).
+type CreateAccount struct {
+	// Threshold is the sum of signer weights required to authorize a
+	// transaction from the account.
+	Threshold uint8 `json:"threshold"`
+
+	// Signers are the keys that may sign on behalf of the account.
+	Signers []*storage.AccountSigner `json:"signers"`
+}
+
+func (*CreateAccount) GetTypeID() uint8 {
+	return createAccountID
+}
+
+func (*CreateAccount) StateKeys(_ chain.Auth, txID ids.ID) []string {
+	return []string{
+		string(storage.AccountKey(storage.AccountAddress(txID))),
+	}
+}
+
+func (*CreateAccount) StateKeysMaxChunks() []uint16 {
+	return []uint16{storage.AccountChunks}
+}
+
+func (*CreateAccount) OutputsWarpMessage() bool {
+	return false
+}
+
+func (c *CreateAccount) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	_ int64,
+	_ chain.Auth,
+	txID ids.ID,
+	_ bool,
+) (bool, uint64, []byte, *warp.UnsignedMessage, error) {
+	if output := verifyAccount(c.Threshold, c.Signers); output != nil {
+		return false, CreateAccountComputeUnits, output, nil, nil
+	}
+	// It should only be possible to overwrite an existing account if there is
+	// a hash collision.
+	if err := storage.SetAccount(ctx, mu, storage.AccountAddress(txID), c.Threshold, c.Signers); err != nil {
+		return false, CreateAccountComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	return true, CreateAccountComputeUnits, nil, nil, nil
+}
+
+func (*CreateAccount) MaxComputeUnits(chain.Rules) uint64 {
+	return CreateAccountComputeUnits
+}
+
+func (c *CreateAccount) Size() int {
+	size := consts.Uint8Len * 2
+	for _, signer := range c.Signers {
+		size += accountSignerSize(signer)
+	}
+	return size
+}
+
+func (c *CreateAccount) Marshal(p *codec.Packer) {
+	p.PackByte(c.Threshold)
+	p.PackByte(uint8(len(c.Signers)))
+	for _, signer := range c.Signers {
+		packAccountSigner(p, signer)
+	}
+}
+
+func UnmarshalCreateAccount(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var create CreateAccount
+	create.Threshold = p.UnpackByte()
+	count := int(p.UnpackByte())
+	if count > storage.MaxAccountSigners {
+		return nil, ErrTooManySigners
+	}
+	create.Signers = make([]*storage.AccountSigner, count)
+	for i := range create.Signers {
+		signer, err := unpackAccountSigner(p)
+		if err != nil {
+			return nil, err
+		}
+		create.Signers[i] = signer
+	}
+	return &create, p.Err()
+}
+
+func (*CreateAccount) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
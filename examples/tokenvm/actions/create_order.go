@@ -18,7 +18,10 @@ import (
 	"github.com/ava-labs/hypersdk/utils"
 )
 
-var _ chain.Action = (*CreateOrder)(nil)
+var (
+	_ chain.Action = (*CreateOrder)(nil)
+	_ auth.Spender = (*CreateOrder)(nil)
+)
 
 type CreateOrder struct {
 	// [In] is the asset you trade for [Out].
@@ -93,7 +96,7 @@ func (c *CreateOrder) Execute(
 	if c.Supply%c.OutTick != 0 {
 		return false, CreateOrderComputeUnits, OutputSupplyMisaligned, nil, nil
 	}
-	if err := storage.SubBalance(ctx, mu, actor, c.Out, c.Supply); err != nil {
+	if err := subActorBalance(ctx, mu, rauth, c.Out, c.Supply); err != nil {
 		return false, CreateOrderComputeUnits, utils.ErrBytes(err), nil, nil
 	}
 	if err := storage.SetOrder(ctx, mu, txID, c.In, c.InTick, c.Out, c.OutTick, c.Supply, actor); err != nil {
@@ -102,6 +105,10 @@ func (c *CreateOrder) Execute(
 	return true, CreateOrderComputeUnits, nil, nil, nil
 }
 
+func (c *CreateOrder) Spends() map[ids.ID]uint64 {
+	return map[ids.ID]uint64{c.Out: c.Supply}
+}
+
 func (*CreateOrder) MaxComputeUnits(chain.Rules) uint64 {
 	return CreateOrderComputeUnits
 }
@@ -5,4 +5,10 @@ package actions
 
 import "errors"
 
-var ErrNoSwapToFill = errors.New("no swap to fill")
+var (
+	ErrNoSwapToFill   = errors.New("no swap to fill")
+	ErrTooManySigners = errors.New("too many signers")
+
+	ErrTooManySessionActions = errors.New("too many session actions")
+	ErrTooManySessionLimits  = errors.New("too many session limits")
+)
@@ -20,7 +20,10 @@ import (
 	"github.com/ava-labs/hypersdk/utils"
 )
 
-var _ chain.Action = (*ExportAsset)(nil)
+var (
+	_ chain.Action = (*ExportAsset)(nil)
+	_ auth.Spender = (*ExportAsset)(nil)
+)
 
 type ExportAsset struct {
 	To          ed25519.PublicKey `json:"to"`
@@ -68,7 +71,7 @@ func (*ExportAsset) OutputsWarpMessage() bool {
 func (e *ExportAsset) executeReturn(
 	ctx context.Context,
 	mu state.Mutable,
-	actor ed25519.PublicKey,
+	rauth chain.Auth,
 	txID ids.ID,
 ) (bool, uint64, []byte, *warp.UnsignedMessage, error) {
 	exists, symbol, decimals, metadata, supply, _, isWarp, err := storage.GetAsset(ctx, mu, e.Asset)
@@ -105,11 +108,11 @@ func (e *ExportAsset) executeReturn(
 			return false, ExportAssetComputeUnits, utils.ErrBytes(err), nil, nil
 		}
 	}
-	if err := storage.SubBalance(ctx, mu, actor, e.Asset, e.Value); err != nil {
+	if err := subActorBalance(ctx, mu, rauth, e.Asset, e.Value); err != nil {
 		return false, ExportAssetComputeUnits, utils.ErrBytes(err), nil, nil
 	}
 	if e.Reward > 0 {
-		if err := storage.SubBalance(ctx, mu, actor, e.Asset, e.Reward); err != nil {
+		if err := subActorBalance(ctx, mu, rauth, e.Asset, e.Reward); err != nil {
 			return false, ExportAssetComputeUnits, utils.ErrBytes(err), nil, nil
 		}
 	}
@@ -132,7 +135,7 @@ func (e *ExportAsset) executeReturn(
 		TxID:               txID,
 		DestinationChainID: e.Destination,
 	}
-	payload, err := wt.Marshal()
+	payload, err := chain.MarshalWarpPayload(wt)
 	if err != nil {
 		return false, ExportAssetComputeUnits, utils.ErrBytes(err), nil, nil
 	}
@@ -146,7 +149,7 @@ func (e *ExportAsset) executeReturn(
 func (e *ExportAsset) executeLoan(
 	ctx context.Context,
 	mu state.Mutable,
-	actor ed25519.PublicKey,
+	rauth chain.Auth,
 	txID ids.ID,
 ) (bool, uint64, []byte, *warp.UnsignedMessage, error) {
 	exists, symbol, decimals, _, _, _, isWarp, err := storage.GetAsset(ctx, mu, e.Asset)
@@ -163,14 +166,14 @@ func (e *ExportAsset) executeLoan(
 	if err := storage.AddLoan(ctx, mu, e.Asset, e.Destination, e.Value); err != nil {
 		return false, ExportAssetComputeUnits, utils.ErrBytes(err), nil, nil
 	}
-	if err := storage.SubBalance(ctx, mu, actor, e.Asset, e.Value); err != nil {
+	if err := subActorBalance(ctx, mu, rauth, e.Asset, e.Value); err != nil {
 		return false, ExportAssetComputeUnits, utils.ErrBytes(err), nil, nil
 	}
 	if e.Reward > 0 {
 		if err := storage.AddLoan(ctx, mu, e.Asset, e.Destination, e.Reward); err != nil {
 			return false, ExportAssetComputeUnits, utils.ErrBytes(err), nil, nil
 		}
-		if err := storage.SubBalance(ctx, mu, actor, e.Asset, e.Reward); err != nil {
+		if err := subActorBalance(ctx, mu, rauth, e.Asset, e.Reward); err != nil {
 			return false, ExportAssetComputeUnits, utils.ErrBytes(err), nil, nil
 		}
 	}
@@ -189,7 +192,7 @@ func (e *ExportAsset) executeLoan(
 		TxID:               txID,
 		DestinationChainID: e.Destination,
 	}
-	payload, err := wt.Marshal()
+	payload, err := chain.MarshalWarpPayload(wt)
 	if err != nil {
 		return false, ExportAssetComputeUnits, utils.ErrBytes(err), nil, nil
 	}
@@ -209,7 +212,6 @@ func (e *ExportAsset) Execute(
 	txID ids.ID,
 	_ bool,
 ) (bool, uint64, []byte, *warp.UnsignedMessage, error) {
-	actor := auth.GetActor(rauth)
 	if e.Value == 0 {
 		return false, ExportAssetComputeUnits, OutputValueZero, nil, nil
 	}
@@ -220,9 +222,17 @@ func (e *ExportAsset) Execute(
 	}
 	// TODO: check if destination is ourselves
 	if e.Return {
-		return e.executeReturn(ctx, mu, actor, txID)
+		return e.executeReturn(ctx, mu, rauth, txID)
+	}
+	return e.executeLoan(ctx, mu, rauth, txID)
+}
+
+func (e *ExportAsset) Spends() map[ids.ID]uint64 {
+	value, err := smath.Add64(e.Value, e.Reward)
+	if err != nil {
+		value = consts.MaxUint64
 	}
-	return e.executeLoan(ctx, mu, actor, txID)
+	return map[ids.ID]uint64{e.Asset: value}
 }
 
 func (*ExportAsset) MaxComputeUnits(chain.Rules) uint64 {
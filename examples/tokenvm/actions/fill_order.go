@@ -20,7 +20,10 @@ import (
 	"github.com/ava-labs/hypersdk/utils"
 )
 
-var _ chain.Action = (*FillOrder)(nil)
+var (
+	_ chain.Action = (*FillOrder)(nil)
+	_ auth.Spender = (*FillOrder)(nil)
+)
 
 type FillOrder struct {
 	// [Order] is the OrderID you wish to close.
@@ -133,7 +136,7 @@ func (f *FillOrder) Execute(
 		// Don't allow free trades (can happen due to refund rounding)
 		return false, NoFillOrderComputeUnits, OutputInsufficientInput, nil, nil
 	}
-	if err := storage.SubBalance(ctx, mu, actor, f.In, inputAmount); err != nil {
+	if err := subActorBalance(ctx, mu, rauth, f.In, inputAmount); err != nil {
 		return false, NoFillOrderComputeUnits, utils.ErrBytes(err), nil, nil
 	}
 	if err := storage.AddBalance(ctx, mu, f.Owner, f.In, inputAmount, true); err != nil {
@@ -159,6 +162,10 @@ func (f *FillOrder) Execute(
 	return true, FillOrderComputeUnits, output, nil, nil
 }
 
+func (f *FillOrder) Spends() map[ids.ID]uint64 {
+	return map[ids.ID]uint64{f.In: f.Value}
+}
+
 func (*FillOrder) MaxComputeUnits(chain.Rules) uint64 {
 	return FillOrderComputeUnits
 }
@@ -16,12 +16,16 @@ import (
 	"github.com/ava-labs/hypersdk/consts"
 	"github.com/ava-labs/hypersdk/crypto/ed25519"
 	"github.com/ava-labs/hypersdk/examples/tokenvm/auth"
+	tconsts "github.com/ava-labs/hypersdk/examples/tokenvm/consts"
 	"github.com/ava-labs/hypersdk/examples/tokenvm/storage"
 	"github.com/ava-labs/hypersdk/state"
 	"github.com/ava-labs/hypersdk/utils"
 )
 
-var _ chain.Action = (*ImportAsset)(nil)
+var (
+	_ chain.Action = (*ImportAsset)(nil)
+	_ auth.Spender = (*ImportAsset)(nil)
+)
 
 type ImportAsset struct {
 	// Fill indicates if the actor wishes to fill the order request in the warp
@@ -247,7 +251,7 @@ func (i *ImportAsset) Execute(
 	if err := storage.AddBalance(ctx, mu, actor, assetIn, i.warpTransfer.SwapIn, true); err != nil {
 		return false, ImportAssetComputeUnits, utils.ErrBytes(err), nil, nil
 	}
-	if err := storage.SubBalance(ctx, mu, actor, i.warpTransfer.AssetOut, i.warpTransfer.SwapOut); err != nil {
+	if err := subActorBalance(ctx, mu, rauth, i.warpTransfer.AssetOut, i.warpTransfer.SwapOut); err != nil {
 		return false, ImportAssetComputeUnits, utils.ErrBytes(err), nil, nil
 	}
 	if err := storage.AddBalance(ctx, mu, i.warpTransfer.To, i.warpTransfer.AssetOut, i.warpTransfer.SwapOut, true); err != nil {
@@ -256,6 +260,13 @@ func (i *ImportAsset) Execute(
 	return true, ImportAssetComputeUnits, nil, nil, nil
 }
 
+func (i *ImportAsset) Spends() map[ids.ID]uint64 {
+	if !i.Fill {
+		return nil
+	}
+	return map[ids.ID]uint64{i.warpTransfer.AssetOut: i.warpTransfer.SwapOut}
+}
+
 func (*ImportAsset) MaxComputeUnits(chain.Rules) uint64 {
 	return ImportAssetComputeUnits
 }
@@ -280,7 +291,7 @@ func UnmarshalImportAsset(p *codec.Packer, wm *warp.Message) (chain.Action, erro
 		return nil, err
 	}
 	imp.warpMessage = wm
-	imp.warpTransfer, err = UnmarshalWarpTransfer(imp.warpMessage.Payload)
+	imp.warpTransfer, err = chain.ParseWarpPayload[*WarpTransfer](tconsts.WarpPayloadRegistry, wm)
 	if err != nil {
 		return nil, err
 	}
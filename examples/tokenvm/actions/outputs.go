@@ -37,4 +37,16 @@ var (
 	OutputMustFill               = []byte("must fill request")
 	OutputWarpVerificationFailed = []byte("warp verification failed")
 	OutputInvalidDestination     = []byte("invalid destination")
+	OutputAccountMissing         = []byte("account missing")
+	OutputThresholdZero          = []byte("threshold is zero")
+	OutputThresholdUnreachable   = []byte("threshold is unreachable")
+	OutputTooManySigners         = []byte("too many signers")
+	OutputInvalidSigner          = []byte("invalid signer")
+	OutputSignerWeightZero       = []byte("signer weight is zero")
+	OutputDuplicateSigner        = []byte("duplicate signer")
+	OutputSignerMissing          = []byte("signer missing")
+	OutputSessionNotPermitted    = []byte("action not permitted for sessions")
+	OutputSessionExpired         = []byte("session expired")
+	OutputDuplicateLimit         = []byte("duplicate spend limit")
+	OutputSessionMissing         = []byte("session missing")
 )
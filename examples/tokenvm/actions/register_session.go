@@ -0,0 +1,144 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/crypto/ed25519"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/auth"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/storage"
+	"github.com/ava-labs/hypersdk/state"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var _ chain.Action = (*RegisterSession)(nil)
+
+// RegisterSession allows [Key] to authorize [Actions] on behalf of the actor
+// (with [auth.Session]) until [Expiry].
+//
+// Registering a session for a key that already has one replaces it.
+type RegisterSession struct {
+	// Key is the session key.
+	Key ed25519.PublicKey `json:"key"`
+
+	// Expiry is the timestamp (in ms) after which the session can't be used.
+	Expiry int64 `json:"expiry"`
+
+	// Actions are the type IDs of the actions the session may authorize.
+	Actions []uint8 `json:"actions"`
+
+	// Limits are the total amount of each asset the session may spend
+	// (including fees). Assets without a limit can't be spent.
+	Limits []*storage.SpendLimit `json:"limits"`
+}
+
+func (*RegisterSession) GetTypeID() uint8 {
+	return registerSessionID
+}
+
+func (r *RegisterSession) StateKeys(rauth chain.Auth, _ ids.ID) []string {
+	return []string{
+		string(storage.SessionKey(auth.GetActor(rauth), r.Key)),
+	}
+}
+
+func (*RegisterSession) StateKeysMaxChunks() []uint16 {
+	return []uint16{storage.SessionChunks}
+}
+
+func (*RegisterSession) OutputsWarpMessage() bool {
+	return false
+}
+
+func (r *RegisterSession) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	t int64,
+	rauth chain.Auth,
+	_ ids.ID,
+	_ bool,
+) (bool, uint64, []byte, *warp.UnsignedMessage, error) {
+	// Sessions can't grant themselves (or anyone else) more permissions
+	if isSession(rauth) {
+		return false, RegisterSessionComputeUnits, OutputSessionNotPermitted, nil, nil
+	}
+	if r.Expiry <= t {
+		return false, RegisterSessionComputeUnits, OutputSessionExpired, nil, nil
+	}
+	assets := set.NewSet[ids.ID](len(r.Limits))
+	for _, limit := range r.Limits {
+		if assets.Contains(limit.Asset) {
+			return false, RegisterSessionComputeUnits, OutputDuplicateLimit, nil, nil
+		}
+		assets.Add(limit.Asset)
+	}
+	if err := storage.SetSession(ctx, mu, auth.GetActor(rauth), r.Key, r.Expiry, r.Actions, r.Limits); err != nil {
+		return false, RegisterSessionComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	return true, RegisterSessionComputeUnits, nil, nil, nil
+}
+
+func (*RegisterSession) MaxComputeUnits(chain.Rules) uint64 {
+	return RegisterSessionComputeUnits
+}
+
+func (r *RegisterSession) Size() int {
+	return ed25519.PublicKeyLen + consts.Int64Len +
+		consts.Uint8Len + len(r.Actions) +
+		consts.Uint8Len + len(r.Limits)*(consts.IDLen+consts.Uint64Len)
+}
+
+func (r *RegisterSession) Marshal(p *codec.Packer) {
+	p.PackPublicKey(r.Key)
+	p.PackInt64(r.Expiry)
+	p.PackByte(uint8(len(r.Actions)))
+	for _, action := range r.Actions {
+		p.PackByte(action)
+	}
+	p.PackByte(uint8(len(r.Limits)))
+	for _, limit := range r.Limits {
+		p.PackID(limit.Asset)
+		p.PackUint64(limit.Amount)
+	}
+}
+
+func UnmarshalRegisterSession(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var register RegisterSession
+	p.UnpackPublicKey(true, &register.Key)
+	register.Expiry = p.UnpackInt64(true)
+	actions := int(p.UnpackByte())
+	if actions > storage.MaxSessionActions {
+		return nil, ErrTooManySessionActions
+	}
+	register.Actions = make([]uint8, actions)
+	for i := range register.Actions {
+		register.Actions[i] = p.UnpackByte()
+	}
+	limits := int(p.UnpackByte())
+	if limits > storage.MaxSessionLimits {
+		return nil, ErrTooManySessionLimits
+	}
+	register.Limits = make([]*storage.SpendLimit, limits)
+	for i := range register.Limits {
+		var limit storage.SpendLimit
+		p.UnpackID(false, &limit.Asset) // empty ID is the native asset
+		limit.Amount = p.UnpackUint64(true)
+		register.Limits[i] = &limit
+	}
+	return &register, p.Err()
+}
+
+func (*RegisterSession) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
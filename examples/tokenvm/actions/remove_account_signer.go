@@ -0,0 +1,115 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/auth"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/storage"
+	"github.com/ava-labs/hypersdk/state"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var _ chain.Action = (*RemoveAccountSigner)(nil)
+
+// RemoveAccountSigner removes the signer with [Key] from the account of the
+// actor.
+type RemoveAccountSigner struct {
+	// KeyType and Key identify the signer to remove.
+	KeyType uint8  `json:"keyType"`
+	Key     []byte `json:"key"`
+
+	// Threshold is the new threshold of the account.
+	Threshold uint8 `json:"threshold"`
+}
+
+func (*RemoveAccountSigner) GetTypeID() uint8 {
+	return removeAccountSignerID
+}
+
+func (*RemoveAccountSigner) StateKeys(rauth chain.Auth, _ ids.ID) []string {
+	return []string{
+		string(storage.AccountKey(auth.GetActor(rauth))),
+	}
+}
+
+func (*RemoveAccountSigner) StateKeysMaxChunks() []uint16 {
+	return []uint16{storage.AccountChunks}
+}
+
+func (*RemoveAccountSigner) OutputsWarpMessage() bool {
+	return false
+}
+
+func (r *RemoveAccountSigner) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	_ int64,
+	rauth chain.Auth,
+	_ ids.ID,
+	_ bool,
+) (bool, uint64, []byte, *warp.UnsignedMessage, error) {
+	// Sessions can't change who controls the account
+	if isSession(rauth) {
+		return false, RemoveAccountSignerComputeUnits, OutputSessionNotPermitted, nil, nil
+	}
+	actor := auth.GetActor(rauth)
+	exists, _, signers, err := storage.GetAccount(ctx, mu, actor)
+	if err != nil {
+		return false, RemoveAccountSignerComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	if !exists {
+		return false, RemoveAccountSignerComputeUnits, OutputAccountMissing, nil, nil
+	}
+	i := findAccountSigner(signers, r.KeyType, r.Key)
+	if i < 0 {
+		return false, RemoveAccountSignerComputeUnits, OutputSignerMissing, nil, nil
+	}
+	signers = append(signers[:i], signers[i+1:]...)
+	// Ensures that the remaining signers can still authorize transactions
+	if output := verifyAccount(r.Threshold, signers); output != nil {
+		return false, RemoveAccountSignerComputeUnits, output, nil, nil
+	}
+	if err := storage.SetAccount(ctx, mu, actor, r.Threshold, signers); err != nil {
+		return false, RemoveAccountSignerComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	return true, RemoveAccountSignerComputeUnits, nil, nil, nil
+}
+
+func (*RemoveAccountSigner) MaxComputeUnits(chain.Rules) uint64 {
+	return RemoveAccountSignerComputeUnits
+}
+
+func (r *RemoveAccountSigner) Size() int {
+	return consts.Uint8Len + len(r.Key) + consts.Uint8Len
+}
+
+func (r *RemoveAccountSigner) Marshal(p *codec.Packer) {
+	p.PackByte(r.KeyType)
+	p.PackFixedBytes(r.Key)
+	p.PackByte(r.Threshold)
+}
+
+func UnmarshalRemoveAccountSigner(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var remove RemoveAccountSigner
+	remove.KeyType = p.UnpackByte()
+	if err := unpackSignerKey(p, remove.KeyType, &remove.Key); err != nil {
+		return nil, err
+	}
+	remove.Threshold = p.UnpackByte()
+	return &remove, p.Err()
+}
+
+func (*RemoveAccountSigner) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
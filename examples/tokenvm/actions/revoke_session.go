@@ -0,0 +1,91 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/crypto/ed25519"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/auth"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/storage"
+	"github.com/ava-labs/hypersdk/state"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var _ chain.Action = (*RevokeSession)(nil)
+
+// RevokeSession removes the session of [Key] before it expires.
+type RevokeSession struct {
+	// Key is the session key.
+	Key ed25519.PublicKey `json:"key"`
+}
+
+func (*RevokeSession) GetTypeID() uint8 {
+	return revokeSessionID
+}
+
+func (r *RevokeSession) StateKeys(rauth chain.Auth, _ ids.ID) []string {
+	return []string{
+		string(storage.SessionKey(auth.GetActor(rauth), r.Key)),
+	}
+}
+
+func (*RevokeSession) StateKeysMaxChunks() []uint16 {
+	return []uint16{storage.SessionChunks}
+}
+
+func (*RevokeSession) OutputsWarpMessage() bool {
+	return false
+}
+
+func (r *RevokeSession) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	_ int64,
+	rauth chain.Auth,
+	_ ids.ID,
+	_ bool,
+) (bool, uint64, []byte, *warp.UnsignedMessage, error) {
+	actor := auth.GetActor(rauth)
+	exists, _, _, _, err := storage.GetSession(ctx, mu, actor, r.Key)
+	if err != nil {
+		return false, RevokeSessionComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	if !exists {
+		return false, RevokeSessionComputeUnits, OutputSessionMissing, nil, nil
+	}
+	if err := storage.DeleteSession(ctx, mu, actor, r.Key); err != nil {
+		return false, RevokeSessionComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	return true, RevokeSessionComputeUnits, nil, nil, nil
+}
+
+func (*RevokeSession) MaxComputeUnits(chain.Rules) uint64 {
+	return RevokeSessionComputeUnits
+}
+
+func (*RevokeSession) Size() int {
+	return ed25519.PublicKeyLen
+}
+
+func (r *RevokeSession) Marshal(p *codec.Packer) {
+	p.PackPublicKey(r.Key)
+}
+
+func UnmarshalRevokeSession(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var revoke RevokeSession
+	p.UnpackPublicKey(true, &revoke.Key)
+	return &revoke, p.Err()
+}
+
+func (*RevokeSession) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
@@ -0,0 +1,123 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/auth"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/storage"
+	"github.com/ava-labs/hypersdk/state"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var _ chain.Action = (*RotateAccountSigner)(nil)
+
+// RotateAccountSigner replaces the key of a signer of the account of the
+// actor (keeping its weight).
+type RotateAccountSigner struct {
+	// KeyType and Key identify the signer to rotate.
+	KeyType uint8  `json:"keyType"`
+	Key     []byte `json:"key"`
+
+	// NewKeyType and NewKey replace [KeyType] and [Key].
+	NewKeyType uint8  `json:"newKeyType"`
+	NewKey     []byte `json:"newKey"`
+}
+
+func (*RotateAccountSigner) GetTypeID() uint8 {
+	return rotateAccountSignerID
+}
+
+func (*RotateAccountSigner) StateKeys(rauth chain.Auth, _ ids.ID) []string {
+	return []string{
+		string(storage.AccountKey(auth.GetActor(rauth))),
+	}
+}
+
+func (*RotateAccountSigner) StateKeysMaxChunks() []uint16 {
+	return []uint16{storage.AccountChunks}
+}
+
+func (*RotateAccountSigner) OutputsWarpMessage() bool {
+	return false
+}
+
+func (r *RotateAccountSigner) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	_ int64,
+	rauth chain.Auth,
+	_ ids.ID,
+	_ bool,
+) (bool, uint64, []byte, *warp.UnsignedMessage, error) {
+	// Sessions can't change who controls the account
+	if isSession(rauth) {
+		return false, RotateAccountSignerComputeUnits, OutputSessionNotPermitted, nil, nil
+	}
+	actor := auth.GetActor(rauth)
+	exists, threshold, signers, err := storage.GetAccount(ctx, mu, actor)
+	if err != nil {
+		return false, RotateAccountSignerComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	if !exists {
+		return false, RotateAccountSignerComputeUnits, OutputAccountMissing, nil, nil
+	}
+	i := findAccountSigner(signers, r.KeyType, r.Key)
+	if i < 0 {
+		return false, RotateAccountSignerComputeUnits, OutputSignerMissing, nil, nil
+	}
+	signers[i] = &storage.AccountSigner{
+		KeyType: r.NewKeyType,
+		Key:     r.NewKey,
+		Weight:  signers[i].Weight,
+	}
+	if output := verifyAccount(threshold, signers); output != nil {
+		return false, RotateAccountSignerComputeUnits, output, nil, nil
+	}
+	if err := storage.SetAccount(ctx, mu, actor, threshold, signers); err != nil {
+		return false, RotateAccountSignerComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	return true, RotateAccountSignerComputeUnits, nil, nil, nil
+}
+
+func (*RotateAccountSigner) MaxComputeUnits(chain.Rules) uint64 {
+	return RotateAccountSignerComputeUnits
+}
+
+func (r *RotateAccountSigner) Size() int {
+	return consts.Uint8Len*2 + len(r.Key) + len(r.NewKey)
+}
+
+func (r *RotateAccountSigner) Marshal(p *codec.Packer) {
+	p.PackByte(r.KeyType)
+	p.PackFixedBytes(r.Key)
+	p.PackByte(r.NewKeyType)
+	p.PackFixedBytes(r.NewKey)
+}
+
+func UnmarshalRotateAccountSigner(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var rotate RotateAccountSigner
+	rotate.KeyType = p.UnpackByte()
+	if err := unpackSignerKey(p, rotate.KeyType, &rotate.Key); err != nil {
+		return nil, err
+	}
+	rotate.NewKeyType = p.UnpackByte()
+	if err := unpackSignerKey(p, rotate.NewKeyType, &rotate.NewKey); err != nil {
+		return nil, err
+	}
+	return &rotate, p.Err()
+}
+
+func (*RotateAccountSigner) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
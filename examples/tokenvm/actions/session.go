@@ -0,0 +1,38 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/auth"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/storage"
+	"github.com/ava-labs/hypersdk/state"
+)
+
+// subActorBalance subtracts [amount] of [asset] from the actor of [rauth]
+// (counting it towards the spend limit of [rauth] if it is a session).
+func subActorBalance(
+	ctx context.Context,
+	mu state.Mutable,
+	rauth chain.Auth,
+	asset ids.ID,
+	amount uint64,
+) error {
+	if err := auth.Spend(ctx, mu, rauth, asset, amount); err != nil {
+		return err
+	}
+	return storage.SubBalance(ctx, mu, auth.GetActor(rauth), asset, amount)
+}
+
+// isSession returns true if [rauth] is (or is sponsoring) a session.
+func isSession(rauth chain.Auth) bool {
+	if sponsored, ok := rauth.(*auth.Sponsored); ok {
+		rauth = sponsored.Actor
+	}
+	_, ok := rauth.(*auth.Session)
+	return ok
+}
@@ -18,7 +18,10 @@ import (
 	"github.com/ava-labs/hypersdk/utils"
 )
 
-var _ chain.Action = (*Transfer)(nil)
+var (
+	_ chain.Action = (*Transfer)(nil)
+	_ auth.Spender = (*Transfer)(nil)
+)
 
 type Transfer struct {
 	// To is the recipient of the [Value].
@@ -62,14 +65,13 @@ func (t *Transfer) Execute(
 	_ ids.ID,
 	_ bool,
 ) (bool, uint64, []byte, *warp.UnsignedMessage, error) {
-	actor := auth.GetActor(rauth)
 	if t.Value == 0 {
 		return false, TransferComputeUnits, OutputValueZero, nil, nil
 	}
 	if len(t.Memo) > MaxMemoSize {
 		return false, CreateAssetComputeUnits, OutputMemoTooLarge, nil, nil
 	}
-	if err := storage.SubBalance(ctx, mu, actor, t.Asset, t.Value); err != nil {
+	if err := subActorBalance(ctx, mu, rauth, t.Asset, t.Value); err != nil {
 		return false, TransferComputeUnits, utils.ErrBytes(err), nil, nil
 	}
 	// TODO: allow sender to configure whether they will pay to create
@@ -79,6 +81,10 @@ func (t *Transfer) Execute(
 	return true, TransferComputeUnits, nil, nil, nil
 }
 
+func (t *Transfer) Spends() map[ids.ID]uint64 {
+	return map[ids.ID]uint64{t.Asset: t.Value}
+}
+
 func (*Transfer) MaxComputeUnits(chain.Rules) uint64 {
 	return TransferComputeUnits
 }
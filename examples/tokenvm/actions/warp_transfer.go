@@ -5,13 +5,19 @@ package actions
 
 import (
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+
 	"github.com/ava-labs/hypersdk/chain"
 	"github.com/ava-labs/hypersdk/codec"
 	"github.com/ava-labs/hypersdk/consts"
 	"github.com/ava-labs/hypersdk/crypto/ed25519"
 	"github.com/ava-labs/hypersdk/utils"
+
+	tconsts "github.com/ava-labs/hypersdk/examples/tokenvm/consts"
 )
 
+var _ chain.WarpPayload = (*WarpTransfer)(nil)
+
 type WarpTransfer struct {
 	To       ed25519.PublicKey `json:"to"`
 	Symbol   []byte            `json:"symbol"`
@@ -46,7 +52,11 @@ type WarpTransfer struct {
 	DestinationChainID ids.ID `json:"destinationChainID"`
 }
 
-func (w *WarpTransfer) size() int {
+func (*WarpTransfer) GetTypeID() uint8 {
+	return warpTransferID
+}
+
+func (w *WarpTransfer) Size() int {
 	return ed25519.PublicKeyLen + codec.BytesLen(w.Symbol) + consts.Uint8Len + consts.IDLen +
 		consts.Uint64Len + consts.BoolLen +
 		consts.Uint64Len + /* op bits */
@@ -54,8 +64,7 @@ func (w *WarpTransfer) size() int {
 		consts.IDLen + consts.IDLen
 }
 
-func (w *WarpTransfer) Marshal() ([]byte, error) {
-	p := codec.NewWriter(w.size(), w.size())
+func (w *WarpTransfer) Marshal(p *codec.Packer) {
 	p.PackPublicKey(w.To)
 	p.PackBytes(w.Symbol)
 	p.PackByte(w.Decimals)
@@ -71,7 +80,6 @@ func (w *WarpTransfer) Marshal() ([]byte, error) {
 	p.PackOptional(op)
 	p.PackID(w.TxID)
 	p.PackID(w.DestinationChainID)
-	return p.Bytes(), p.Err()
 }
 
 func ImportedAssetID(assetID ids.ID, sourceChainID ids.ID) ids.ID {
@@ -85,15 +93,16 @@ func ImportedAssetMetadata(assetID ids.ID, sourceChainID ids.ID) []byte {
 	return k
 }
 
+// UnmarshalWarpTransfer parses a [WarpTransfer] from the payload of a warp
+// message (as produced by [chain.MarshalWarpPayload]).
 func UnmarshalWarpTransfer(b []byte) (*WarpTransfer, error) {
-	maxWarpTransferSize := ed25519.PublicKeyLen + codec.BytesLenSize(MaxSymbolSize) + consts.Uint8Len + consts.IDLen +
-		consts.Uint64Len + consts.BoolLen +
-		consts.Uint64Len + /* op bits */
-		consts.Uint64Len + consts.Uint64Len + consts.IDLen + consts.Uint64Len + consts.Int64Len +
-		consts.IDLen + consts.IDLen
+	return chain.ParseWarpPayload[*WarpTransfer](tconsts.WarpPayloadRegistry, &warp.Message{
+		UnsignedMessage: warp.UnsignedMessage{Payload: b},
+	})
+}
 
+func UnmarshalWarpTransferPayload(p *codec.Packer, _ *warp.Message) (chain.WarpPayload, error) {
 	var transfer WarpTransfer
-	p := codec.NewReader(b, maxWarpTransferSize)
 	p.UnpackPublicKey(false, &transfer.To)
 	p.UnpackBytes(MaxSymbolSize, true, &transfer.Symbol)
 	transfer.Decimals = p.UnpackByte()
@@ -112,9 +121,6 @@ func UnmarshalWarpTransfer(b []byte) (*WarpTransfer, error) {
 	if err := p.Err(); err != nil {
 		return nil, err
 	}
-	if !p.Empty() {
-		return nil, chain.ErrInvalidObject
-	}
 	// Handle swap checks
 	if !ValidSwapParams(
 		transfer.Value,
@@ -0,0 +1,314 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package auth
+
+import (
+	"bytes"
+	"context"
+	"sort"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/crypto"
+	"github.com/ava-labs/hypersdk/crypto/ed25519"
+	"github.com/ava-labs/hypersdk/crypto/secp256r1"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/storage"
+	"github.com/ava-labs/hypersdk/state"
+)
+
+var _ chain.Auth = (*Account)(nil)
+
+const (
+	AccountComputeUnits = 1 // reading the account signers
+)
+
+// AccountSignature is a signature by one of the signers of an account.
+type AccountSignature struct {
+	KeyType   uint8  `json:"keyType"`
+	Key       []byte `json:"key"`
+	Signature []byte `json:"signature"`
+}
+
+func (s *AccountSignature) computeUnits() uint64 {
+	if s.KeyType == storage.SECP256R1Signer {
+		return SECP256R1ComputeUnits
+	}
+	return ED25519ComputeUnits
+}
+
+func (s *AccountSignature) size() int {
+	return consts.Uint8Len + len(s.Key) + len(s.Signature)
+}
+
+// compareSignatures orders [AccountSignature]s by their signer (key type and
+// then key).
+func compareSignatures(a *AccountSignature, b *AccountSignature) int {
+	switch {
+	case a.KeyType < b.KeyType:
+		return -1
+	case a.KeyType > b.KeyType:
+		return 1
+	default:
+		return bytes.Compare(a.Key, b.Key)
+	}
+}
+
+// verifySorted ensures [sigs] are strictly sorted by signer (so there is only
+// one valid ordering of any set of signatures).
+func verifySorted(sigs []*AccountSignature) error {
+	for i := 1; i < len(sigs); i++ {
+		switch c := compareSignatures(sigs[i-1], sigs[i]); {
+		case c == 0:
+			return ErrDuplicateSigner
+		case c > 0:
+			return ErrUnsortedSignatures
+		}
+	}
+	return nil
+}
+
+// Account authorizes a transaction on behalf of the account at [Address].
+//
+// The signatures are verified against the signers registered for the
+// account in state (during [Verify]), so keys can be added, removed, and
+// rotated without changing the address of the account.
+//
+// The ID of a transaction includes its signatures, so there must be exactly
+// one valid set of signatures for a transaction (otherwise, anyone could
+// reorder or drop the signatures of an included transaction to replay it
+// with a new ID). [Signatures] must be sorted by signer and every signature
+// must be needed to meet the threshold of the account.
+type Account struct {
+	Address    ed25519.PublicKey   `json:"address"`
+	Signatures []*AccountSignature `json:"signatures"`
+}
+
+func (*Account) GetTypeID() uint8 {
+	return accountID
+}
+
+func (a *Account) MaxComputeUnits(chain.Rules) uint64 {
+	units := uint64(AccountComputeUnits)
+	for _, sig := range a.Signatures {
+		units += sig.computeUnits()
+	}
+	return units
+}
+
+func (*Account) ValidRange(chain.Rules) (int64, int64) {
+	return -1, -1
+}
+
+func (a *Account) StateKeys() []string {
+	return []string{
+		// We always pay fees with the native asset (which is [ids.Empty])
+		string(storage.BalanceKey(a.Address, ids.Empty)),
+		string(storage.AccountKey(a.Address)),
+	}
+}
+
+func (a *Account) AsyncVerify(msg []byte) error {
+	for _, sig := range a.Signatures {
+		var valid bool
+		switch sig.KeyType {
+		case storage.ED25519Signer:
+			valid = ed25519.Verify(msg, ed25519.PublicKey(sig.Key), ed25519.Signature(sig.Signature))
+		case storage.SECP256R1Signer:
+			valid = secp256r1.Verify(msg, secp256r1.PublicKey(sig.Key), secp256r1.Signature(sig.Signature))
+		}
+		if !valid {
+			return crypto.ErrInvalidSignature
+		}
+	}
+	return nil
+}
+
+func (a *Account) Verify(
+	ctx context.Context,
+	r chain.Rules,
+	im state.Immutable,
+	_ chain.Action,
+) (uint64, error) {
+	exists, threshold, signers, err := storage.GetAccount(ctx, im, a.Address)
+	if err != nil {
+		return 0, err
+	}
+	if !exists {
+		return 0, ErrAccountMissing
+	}
+	if err := verifySorted(a.Signatures); err != nil {
+		return 0, err
+	}
+	var (
+		weight    int
+		minWeight = int(consts.MaxUint8)
+	)
+	for _, sig := range a.Signatures {
+		signer := findSigner(signers, sig.KeyType, sig.Key)
+		if signer == nil {
+			return 0, ErrUnauthorizedSigner
+		}
+		weight += int(signer.Weight)
+		if int(signer.Weight) < minWeight {
+			minWeight = int(signer.Weight)
+		}
+	}
+	if weight < int(threshold) {
+		return 0, ErrThresholdNotMet
+	}
+	// If the threshold is still met without the lightest signature, the
+	// transaction would also be valid with it removed.
+	if weight-minWeight >= int(threshold) {
+		return 0, ErrUnneededSignature
+	}
+	return a.MaxComputeUnits(r), nil
+}
+
+func findSigner(signers []*storage.AccountSigner, keyType uint8, key []byte) *storage.AccountSigner {
+	for _, signer := range signers {
+		if signer.KeyType == keyType && string(signer.Key) == string(key) {
+			return signer
+		}
+	}
+	return nil
+}
+
+func (a *Account) Payer() []byte {
+	return a.Address[:]
+}
+
+func (a *Account) Size() int {
+	size := ed25519.PublicKeyLen + consts.Uint8Len
+	for _, sig := range a.Signatures {
+		size += sig.size()
+	}
+	return size
+}
+
+func (a *Account) Marshal(p *codec.Packer) {
+	p.PackPublicKey(a.Address)
+	p.PackByte(uint8(len(a.Signatures)))
+	for _, sig := range a.Signatures {
+		p.PackByte(sig.KeyType)
+		p.PackFixedBytes(sig.Key)
+		p.PackFixedBytes(sig.Signature)
+	}
+}
+
+func UnmarshalAccount(p *codec.Packer, _ *warp.Message) (chain.Auth, error) {
+	var a Account
+	p.UnpackPublicKey(true, &a.Address)
+	count := int(p.UnpackByte())
+	if count == 0 || count > storage.MaxAccountSigners {
+		return nil, ErrInvalidSignatureCount
+	}
+	a.Signatures = make([]*AccountSignature, count)
+	for i := range a.Signatures {
+		var sig AccountSignature
+		sig.KeyType = p.UnpackByte()
+		keyLen, ok := storage.SignerKeyLen(sig.KeyType)
+		if !ok {
+			return nil, ErrUnknownKeyType
+		}
+		sig.Key = make([]byte, keyLen)
+		p.UnpackFixedBytes(keyLen, &sig.Key)
+		// ed25519 and secp256r1 signatures have the same length
+		sig.Signature = make([]byte, ed25519.SignatureLen)
+		p.UnpackFixedBytes(ed25519.SignatureLen, &sig.Signature)
+		a.Signatures[i] = &sig
+	}
+	if err := p.Err(); err != nil {
+		return nil, err
+	}
+	if err := verifySorted(a.Signatures); err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+func (a *Account) CanDeduct(
+	ctx context.Context,
+	im state.Immutable,
+	amount uint64,
+) error {
+	bal, err := storage.GetBalance(ctx, im, a.Address, ids.Empty)
+	if err != nil {
+		return err
+	}
+	if bal < amount {
+		return storage.ErrInvalidBalance
+	}
+	return nil
+}
+
+func (a *Account) Deduct(
+	ctx context.Context,
+	mu state.Mutable,
+	amount uint64,
+) error {
+	return storage.SubBalance(ctx, mu, a.Address, ids.Empty, amount)
+}
+
+func (a *Account) Refund(
+	ctx context.Context,
+	mu state.Mutable,
+	amount uint64,
+) error {
+	// Don't create account if it doesn't exist (may have sent all funds).
+	return storage.AddBalance(ctx, mu, a.Address, ids.Empty, amount, false)
+}
+
+var _ chain.AuthFactory = (*AccountFactory)(nil)
+
+// NewAccountFactory returns an [AccountFactory] that signs on behalf of the
+// account at [address] with each of the provided keys. The caller is
+// responsible for providing keys with enough weight to meet the threshold of
+// the account (and no keys that aren't needed to meet it).
+func NewAccountFactory(
+	address ed25519.PublicKey,
+	ed25519Keys []ed25519.PrivateKey,
+	secp256r1Keys []secp256r1.PrivateKey,
+) *AccountFactory {
+	return &AccountFactory{address, ed25519Keys, secp256r1Keys}
+}
+
+type AccountFactory struct {
+	address       ed25519.PublicKey
+	ed25519Keys   []ed25519.PrivateKey
+	secp256r1Keys []secp256r1.PrivateKey
+}
+
+func (a *AccountFactory) Sign(msg []byte, _ chain.Action) (chain.Auth, error) {
+	sigs := make([]*AccountSignature, 0, len(a.ed25519Keys)+len(a.secp256r1Keys))
+	for _, priv := range a.ed25519Keys {
+		pk := priv.PublicKey()
+		sig := ed25519.Sign(msg, priv)
+		sigs = append(sigs, &AccountSignature{storage.ED25519Signer, pk[:], sig[:]})
+	}
+	for _, priv := range a.secp256r1Keys {
+		pk := priv.PublicKey()
+		sig, err := secp256r1.Sign(msg, priv)
+		if err != nil {
+			return nil, err
+		}
+		sigs = append(sigs, &AccountSignature{storage.SECP256R1Signer, pk[:], sig[:]})
+	}
+	sort.Slice(sigs, func(i, j int) bool {
+		return compareSignatures(sigs[i], sigs[j]) < 0
+	})
+	return &Account{a.address, sigs}, nil
+}
+
+func (a *AccountFactory) MaxUnits() (uint64, uint64, []uint16) {
+	size := ed25519.PublicKeyLen + consts.Uint8Len +
+		len(a.ed25519Keys)*(consts.Uint8Len+ED25519Size) +
+		len(a.secp256r1Keys)*(consts.Uint8Len+SECP256R1Size)
+	computeUnits := AccountComputeUnits +
+		len(a.ed25519Keys)*ED25519ComputeUnits +
+		len(a.secp256r1Keys)*SECP256R1ComputeUnits
+	return uint64(size), uint64(computeUnits), []uint16{storage.BalanceChunks, storage.AccountChunks}
+}
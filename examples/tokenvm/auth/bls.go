@@ -0,0 +1,187 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package auth
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/crypto"
+	"github.com/ava-labs/hypersdk/crypto/bls"
+	"github.com/ava-labs/hypersdk/crypto/ed25519"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/storage"
+	"github.com/ava-labs/hypersdk/state"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var (
+	_ chain.Auth          = (*BLS)(nil)
+	_ chain.AggregateAuth = (*BLS)(nil)
+)
+
+const (
+	BLSComputeUnits = 10 // pairing per signer when verifying the block
+
+	// BLSSize is the size of a [BLS] in a block (once its signature has been
+	// aggregated), which is what is charged for bandwidth.
+	BLSSize = bls.PublicKeyLen + consts.BoolLen
+)
+
+// NewBLSAddress returns the account controlled by [pk].
+//
+// Because accounts are 32 bytes and BLS public keys are 48 bytes, the account
+// is derived by hashing the public key (prefixed by the auth type ID).
+func NewBLSAddress(pk bls.PublicKey) ed25519.PublicKey {
+	b := make([]byte, 1+bls.PublicKeyLen)
+	b[0] = blsID
+	copy(b[1:], pk[:])
+	return ed25519.PublicKey(utils.ToID(b))
+}
+
+// BLS authorizes a transaction with a BLS signature. When the transaction is
+// included in a block, [Signature] is aggregated into the signature of the
+// block (see [chain.AggregateAuth]) and removed.
+type BLS struct {
+	Signer    bls.PublicKey  `json:"signer"`
+	Signature *bls.Signature `json:"signature,omitempty"`
+}
+
+func (*BLS) GetTypeID() uint8 {
+	return blsID
+}
+
+func (*BLS) MaxComputeUnits(chain.Rules) uint64 {
+	return BLSComputeUnits
+}
+
+func (*BLS) ValidRange(chain.Rules) (int64, int64) {
+	return -1, -1
+}
+
+func (b *BLS) StateKeys() []string {
+	return []string{
+		// We always pay fees with the native asset (which is [ids.Empty])
+		string(storage.BalanceKey(NewBLSAddress(b.Signer), ids.Empty)),
+	}
+}
+
+func (b *BLS) AsyncVerify(msg []byte) error {
+	if b.Signature == nil || !bls.Verify(msg, b.Signer, *b.Signature) {
+		return crypto.ErrInvalidSignature
+	}
+	return nil
+}
+
+func (b *BLS) Verify(
+	_ context.Context,
+	r chain.Rules,
+	_ state.Immutable,
+	_ chain.Action,
+) (uint64, error) {
+	// We don't do anything during verify (there is no additional state to check
+	// to authorize the signer other than verifying the signature)
+	return b.MaxComputeUnits(r), nil
+}
+
+func (b *BLS) Payer() []byte {
+	addr := NewBLSAddress(b.Signer)
+	return addr[:]
+}
+
+func (b *BLS) Size() int {
+	if b.Signature == nil {
+		return BLSSize
+	}
+	return BLSSize + bls.SignatureLen
+}
+
+func (b *BLS) Marshal(p *codec.Packer) {
+	p.PackFixedBytes(b.Signer[:])
+	p.PackBool(b.Signature != nil)
+	if b.Signature != nil {
+		p.PackFixedBytes(b.Signature[:])
+	}
+}
+
+func UnmarshalBLS(p *codec.Packer, _ *warp.Message) (chain.Auth, error) {
+	var b BLS
+	signer := b.Signer[:]
+	p.UnpackFixedBytes(bls.PublicKeyLen, &signer)
+	if p.UnpackBool() {
+		b.Signature = new(bls.Signature)
+		signature := b.Signature[:]
+		p.UnpackFixedBytes(bls.SignatureLen, &signature)
+	}
+	return &b, p.Err()
+}
+
+func (b *BLS) AggregateKey() bls.PublicKey {
+	return b.Signer
+}
+
+func (b *BLS) AggregateSignature() *bls.Signature {
+	return b.Signature
+}
+
+func (b *BLS) Aggregated() chain.AggregateAuth {
+	return &BLS{Signer: b.Signer}
+}
+
+func (b *BLS) CanDeduct(
+	ctx context.Context,
+	im state.Immutable,
+	amount uint64,
+) error {
+	bal, err := storage.GetBalance(ctx, im, NewBLSAddress(b.Signer), ids.Empty)
+	if err != nil {
+		return err
+	}
+	if bal < amount {
+		return storage.ErrInvalidBalance
+	}
+	return nil
+}
+
+func (b *BLS) Deduct(
+	ctx context.Context,
+	mu state.Mutable,
+	amount uint64,
+) error {
+	return storage.SubBalance(ctx, mu, NewBLSAddress(b.Signer), ids.Empty, amount)
+}
+
+func (b *BLS) Refund(
+	ctx context.Context,
+	mu state.Mutable,
+	amount uint64,
+) error {
+	// Don't create account if it doesn't exist (may have sent all funds).
+	return storage.AddBalance(ctx, mu, NewBLSAddress(b.Signer), ids.Empty, amount, false)
+}
+
+var _ chain.AuthFactory = (*BLSFactory)(nil)
+
+func NewBLSFactory(priv bls.PrivateKey) *BLSFactory {
+	return &BLSFactory{priv}
+}
+
+type BLSFactory struct {
+	priv bls.PrivateKey
+}
+
+func (b *BLSFactory) Sign(msg []byte, _ chain.Action) (chain.Auth, error) {
+	sig, err := bls.Sign(msg, b.priv)
+	if err != nil {
+		return nil, err
+	}
+	return &BLS{b.priv.PublicKey(), &sig}, nil
+}
+
+func (*BLSFactory) MaxUnits() (uint64, uint64, []uint16) {
+	return BLSSize, BLSComputeUnits, []uint16{storage.BalanceChunks}
+}
@@ -5,4 +5,23 @@ package auth
 
 import "errors"
 
-var ErrInvalidSignature = errors.New("invalid signature")
+var (
+	ErrInvalidSignature      = errors.New("invalid signature")
+	ErrInvalidSignatureCount = errors.New("invalid signature count")
+	ErrUnknownKeyType        = errors.New("unknown key type")
+	ErrAccountMissing        = errors.New("account missing")
+	ErrDuplicateSigner       = errors.New("duplicate signer")
+	ErrUnsortedSignatures    = errors.New("signatures not sorted")
+	ErrUnneededSignature     = errors.New("signature not needed to meet threshold")
+	ErrUnauthorizedSigner    = errors.New("unauthorized signer")
+	ErrThresholdNotMet       = errors.New("signer weight does not meet threshold")
+	ErrInvalidMultisigPolicy = errors.New("invalid multisig policy")
+	ErrInvalidSignatureIndex = errors.New("invalid signature index")
+	ErrUnknownAuthType       = errors.New("unknown auth type")
+	ErrNestedSponsored       = errors.New("sponsored auth cannot be nested")
+	ErrSelfSponsored         = errors.New("actor cannot sponsor itself")
+	ErrSessionMissing        = errors.New("session missing")
+	ErrWrongSessionExpiry    = errors.New("wrong session expiry")
+	ErrActionNotAllowed      = errors.New("action not allowed by session")
+	ErrSpendLimitExceeded    = errors.New("session spend limit exceeded")
+)
@@ -12,6 +12,20 @@ func GetActor(auth chain.Auth) ed25519.PublicKey {
 	switch a := auth.(type) {
 	case *ED25519:
 		return a.Signer
+	case *SECP256R1:
+		return NewSECP256R1Address(a.Signer)
+	case *WebAuthn:
+		return NewWebAuthnAddress(a.Signer)
+	case *BLS:
+		return NewBLSAddress(a.Signer)
+	case *Account:
+		return a.Address
+	case *Multisig:
+		return a.address()
+	case *Sponsored:
+		return GetActor(a.Actor)
+	case *Session:
+		return a.Actor
 	default:
 		return ed25519.EmptyPublicKey
 	}
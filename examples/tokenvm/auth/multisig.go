@@ -0,0 +1,488 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package auth
+
+import (
+	"context"
+	"sort"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/crypto"
+	"github.com/ava-labs/hypersdk/crypto/ed25519"
+	"github.com/ava-labs/hypersdk/crypto/secp256r1"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/storage"
+	"github.com/ava-labs/hypersdk/state"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var _ chain.Auth = (*Multisig)(nil)
+
+const (
+	MultisigComputeUnits = 1 // in addition to the cost of each signature
+
+	// MaxMultisigSigners is the maximum N of an M-of-N [Multisig].
+	MaxMultisigSigners = 16
+
+	// ed25519 and secp256r1 signatures have the same length
+	multisigSignatureLen = ed25519.SignatureLen
+)
+
+// MultisigSigner is one of the N keys of a [Multisig].
+type MultisigSigner struct {
+	KeyType uint8  `json:"keyType"`
+	Key     []byte `json:"key"`
+}
+
+func (s *MultisigSigner) computeUnits() uint64 {
+	if s.KeyType == storage.SECP256R1Signer {
+		return SECP256R1ComputeUnits
+	}
+	return ED25519ComputeUnits
+}
+
+// MultisigSignature is a signature by the signer at [Index].
+type MultisigSignature struct {
+	Index     uint8  `json:"index"`
+	Signature []byte `json:"signature"`
+}
+
+// NewMultisigAddress returns the account controlled by [threshold] of
+// [signers].
+//
+// The address commits to the entire policy (including the order of
+// [signers]), so the policy does not need to be stored in state.
+func NewMultisigAddress(threshold uint8, signers []*MultisigSigner) (ed25519.PublicKey, error) {
+	if err := verifyMultisigPolicy(threshold, signers); err != nil {
+		return ed25519.EmptyPublicKey, err
+	}
+	return multisigAddress(threshold, signers), nil
+}
+
+// verifyMultisigPolicy ensures [threshold] of [signers] is a valid policy.
+//
+// Each signer may only be included once (otherwise the signature of a single
+// signer could be counted at multiple indices to meet the threshold, and
+// including it at a different index would change the ID of a transaction).
+func verifyMultisigPolicy(threshold uint8, signers []*MultisigSigner) error {
+	if threshold == 0 || int(threshold) > len(signers) || len(signers) > MaxMultisigSigners {
+		return ErrInvalidMultisigPolicy
+	}
+	for i, signer := range signers {
+		keyLen, ok := storage.SignerKeyLen(signer.KeyType)
+		if !ok {
+			return ErrUnknownKeyType
+		}
+		if len(signer.Key) != keyLen {
+			return ErrInvalidMultisigPolicy
+		}
+		for _, other := range signers[:i] {
+			if other.KeyType == signer.KeyType && string(other.Key) == string(signer.Key) {
+				return ErrDuplicateSigner
+			}
+		}
+	}
+	return nil
+}
+
+func multisigAddress(threshold uint8, signers []*MultisigSigner) ed25519.PublicKey {
+	size := consts.Uint8Len * 3
+	for _, signer := range signers {
+		size += consts.Uint8Len + len(signer.Key)
+	}
+	b := make([]byte, 0, size)
+	b = append(b, multisigID, threshold, uint8(len(signers)))
+	for _, signer := range signers {
+		b = append(b, signer.KeyType)
+		b = append(b, signer.Key...)
+	}
+	return ed25519.PublicKey(utils.ToID(b))
+}
+
+// Multisig authorizes a transaction with [Threshold] signatures from
+// [Signers] (M-of-N).
+type Multisig struct {
+	Threshold  uint8                `json:"threshold"`
+	Signers    []*MultisigSigner    `json:"signers"`
+	Signatures []*MultisigSignature `json:"signatures"`
+}
+
+func (*Multisig) GetTypeID() uint8 {
+	return multisigID
+}
+
+func (m *Multisig) MaxComputeUnits(chain.Rules) uint64 {
+	units := uint64(MultisigComputeUnits)
+	for _, sig := range m.Signatures {
+		units += m.Signers[sig.Index].computeUnits()
+	}
+	return units
+}
+
+func (*Multisig) ValidRange(chain.Rules) (int64, int64) {
+	return -1, -1
+}
+
+func (m *Multisig) address() ed25519.PublicKey {
+	return multisigAddress(m.Threshold, m.Signers)
+}
+
+func (m *Multisig) StateKeys() []string {
+	return []string{
+		// We always pay fees with the native asset (which is [ids.Empty])
+		string(storage.BalanceKey(m.address(), ids.Empty)),
+	}
+}
+
+func (m *Multisig) AsyncVerify(msg []byte) error {
+	for _, sig := range m.Signatures {
+		if !verifyMultisigSignature(msg, m.Signers[sig.Index], sig.Signature) {
+			return crypto.ErrInvalidSignature
+		}
+	}
+	return nil
+}
+
+func verifyMultisigSignature(msg []byte, signer *MultisigSigner, sig []byte) bool {
+	switch signer.KeyType {
+	case storage.ED25519Signer:
+		return ed25519.Verify(msg, ed25519.PublicKey(signer.Key), ed25519.Signature(sig))
+	case storage.SECP256R1Signer:
+		return secp256r1.Verify(msg, secp256r1.PublicKey(signer.Key), secp256r1.Signature(sig))
+	default:
+		return false
+	}
+}
+
+func (m *Multisig) Verify(
+	_ context.Context,
+	r chain.Rules,
+	_ state.Immutable,
+	_ chain.Action,
+) (uint64, error) {
+	// The policy is enforced during unmarshal and the signatures are checked
+	// in [AsyncVerify]
+	return m.MaxComputeUnits(r), nil
+}
+
+func (m *Multisig) Payer() []byte {
+	addr := m.address()
+	return addr[:]
+}
+
+func (m *Multisig) Size() int {
+	return multisigSize(m.Signers, len(m.Signatures))
+}
+
+func multisigSize(signers []*MultisigSigner, signatures int) int {
+	size := consts.Uint8Len * 3
+	for _, signer := range signers {
+		size += consts.Uint8Len + len(signer.Key)
+	}
+	return size + signatures*(consts.Uint8Len+multisigSignatureLen)
+}
+
+func (m *Multisig) Marshal(p *codec.Packer) {
+	p.PackByte(m.Threshold)
+	p.PackByte(uint8(len(m.Signers)))
+	for _, signer := range m.Signers {
+		p.PackByte(signer.KeyType)
+		p.PackFixedBytes(signer.Key)
+	}
+	p.PackByte(uint8(len(m.Signatures)))
+	for _, sig := range m.Signatures {
+		p.PackByte(sig.Index)
+		p.PackFixedBytes(sig.Signature)
+	}
+}
+
+func UnmarshalMultisig(p *codec.Packer, _ *warp.Message) (chain.Auth, error) {
+	var m Multisig
+	m.Threshold = p.UnpackByte()
+	signers := int(p.UnpackByte())
+	if m.Threshold == 0 || int(m.Threshold) > signers || signers > MaxMultisigSigners {
+		return nil, ErrInvalidMultisigPolicy
+	}
+	m.Signers = make([]*MultisigSigner, signers)
+	for i := range m.Signers {
+		var signer MultisigSigner
+		signer.KeyType = p.UnpackByte()
+		keyLen, ok := storage.SignerKeyLen(signer.KeyType)
+		if !ok {
+			return nil, ErrUnknownKeyType
+		}
+		signer.Key = make([]byte, keyLen)
+		p.UnpackFixedBytes(keyLen, &signer.Key)
+		m.Signers[i] = &signer
+	}
+	if err := p.Err(); err != nil {
+		return nil, err
+	}
+	if err := verifyMultisigPolicy(m.Threshold, m.Signers); err != nil {
+		return nil, err
+	}
+	// Exactly [Threshold] signatures must be provided (any more would just
+	// increase the fee)
+	if int(p.UnpackByte()) != int(m.Threshold) {
+		return nil, ErrInvalidSignatureCount
+	}
+	m.Signatures = make([]*MultisigSignature, m.Threshold)
+	for i := range m.Signatures {
+		var sig MultisigSignature
+		sig.Index = p.UnpackByte()
+		// Requiring signatures to be sorted by signer ensures no signer is
+		// counted twice
+		if int(sig.Index) >= signers || (i > 0 && sig.Index <= m.Signatures[i-1].Index) {
+			return nil, ErrInvalidSignatureIndex
+		}
+		sig.Signature = make([]byte, multisigSignatureLen)
+		p.UnpackFixedBytes(multisigSignatureLen, &sig.Signature)
+		m.Signatures[i] = &sig
+	}
+	return &m, p.Err()
+}
+
+func (m *Multisig) CanDeduct(
+	ctx context.Context,
+	im state.Immutable,
+	amount uint64,
+) error {
+	bal, err := storage.GetBalance(ctx, im, m.address(), ids.Empty)
+	if err != nil {
+		return err
+	}
+	if bal < amount {
+		return storage.ErrInvalidBalance
+	}
+	return nil
+}
+
+func (m *Multisig) Deduct(
+	ctx context.Context,
+	mu state.Mutable,
+	amount uint64,
+) error {
+	return storage.SubBalance(ctx, mu, m.address(), ids.Empty, amount)
+}
+
+func (m *Multisig) Refund(
+	ctx context.Context,
+	mu state.Mutable,
+	amount uint64,
+) error {
+	// Don't create account if it doesn't exist (may have sent all funds).
+	return storage.AddBalance(ctx, mu, m.address(), ids.Empty, amount, false)
+}
+
+var _ chain.AuthFactory = (*MultisigFactory)(nil)
+
+// NewMultisigFactory returns a [MultisigFactory] for [threshold] of
+// [signers] that signs with each of the provided keys.
+//
+// Signatures produced elsewhere (by co-signers that don't share their keys)
+// can be included with [MultisigFactory.AddSignature].
+func NewMultisigFactory(
+	threshold uint8,
+	signers []*MultisigSigner,
+	ed25519Keys []ed25519.PrivateKey,
+	secp256r1Keys []secp256r1.PrivateKey,
+) *MultisigFactory {
+	return &MultisigFactory{
+		threshold:     threshold,
+		signers:       signers,
+		ed25519Keys:   ed25519Keys,
+		secp256r1Keys: secp256r1Keys,
+		signatures:    map[uint8][]byte{},
+	}
+}
+
+type MultisigFactory struct {
+	threshold     uint8
+	signers       []*MultisigSigner
+	ed25519Keys   []ed25519.PrivateKey
+	secp256r1Keys []secp256r1.PrivateKey
+
+	signatures map[uint8][]byte
+}
+
+func (m *MultisigFactory) index(keyType uint8, key []byte) (uint8, error) {
+	for i, signer := range m.signers {
+		if signer.KeyType == keyType && string(signer.Key) == string(key) {
+			return uint8(i), nil
+		}
+	}
+	return 0, ErrUnauthorizedSigner
+}
+
+// AddSignature includes [sig] by [key] in the next [Multisig] produced by
+// [Sign].
+func (m *MultisigFactory) AddSignature(keyType uint8, key []byte, sig []byte) error {
+	if len(sig) != multisigSignatureLen {
+		return crypto.ErrInvalidSignature
+	}
+	i, err := m.index(keyType, key)
+	if err != nil {
+		return err
+	}
+	m.signatures[i] = sig
+	return nil
+}
+
+func (m *MultisigFactory) Sign(msg []byte, _ chain.Action) (chain.Auth, error) {
+	sigs := make(map[uint8][]byte, len(m.signatures)+len(m.ed25519Keys)+len(m.secp256r1Keys))
+	for i, sig := range m.signatures {
+		sigs[i] = sig
+	}
+	for _, priv := range m.ed25519Keys {
+		pk := priv.PublicKey()
+		i, err := m.index(storage.ED25519Signer, pk[:])
+		if err != nil {
+			return nil, err
+		}
+		sig := ed25519.Sign(msg, priv)
+		sigs[i] = sig[:]
+	}
+	for _, priv := range m.secp256r1Keys {
+		pk := priv.PublicKey()
+		i, err := m.index(storage.SECP256R1Signer, pk[:])
+		if err != nil {
+			return nil, err
+		}
+		sig, err := secp256r1.Sign(msg, priv)
+		if err != nil {
+			return nil, err
+		}
+		sigs[i] = sig[:]
+	}
+	if len(sigs) < int(m.threshold) {
+		return nil, ErrThresholdNotMet
+	}
+	indices := make([]int, 0, len(sigs))
+	for i := range sigs {
+		indices = append(indices, int(i))
+	}
+	sort.Ints(indices)
+	signatures := make([]*MultisigSignature, m.threshold)
+	for i := range signatures {
+		index := uint8(indices[i])
+		signatures[i] = &MultisigSignature{index, sigs[index]}
+	}
+	return &Multisig{m.threshold, m.signers, signatures}, nil
+}
+
+func (m *MultisigFactory) MaxUnits() (uint64, uint64, []uint16) {
+	// Assume the most expensive signers sign
+	units := make([]int, len(m.signers))
+	for i, signer := range m.signers {
+		units[i] = int(signer.computeUnits())
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(units)))
+	computeUnits := MultisigComputeUnits
+	for i := 0; i < int(m.threshold) && i < len(units); i++ {
+		computeUnits += units[i]
+	}
+	size := multisigSize(m.signers, int(m.threshold))
+	return uint64(size), uint64(computeUnits), []uint16{storage.BalanceChunks}
+}
+
+// MultisigAuthEngine verifies the ed25519 signatures of [Multisig] in batches
+// (secp256r1 signatures are verified individually).
+type MultisigAuthEngine struct {
+	ed25519Cache   *ed25519.KeyCache
+	secp256r1Cache *secp256r1.KeyCache
+}
+
+func (e *MultisigAuthEngine) GetBatchVerifier(cores int, count int) chain.AuthBatchVerifier {
+	batchSize := math.Max(count/cores, ed25519.MinBatchSize)
+	return &MultisigBatch{
+		ed25519Cache:   e.ed25519Cache,
+		secp256r1Cache: e.secp256r1Cache,
+		batchSize:      batchSize,
+	}
+}
+
+func (e *MultisigAuthEngine) Cache(rauth chain.Auth) {
+	m := rauth.(*Multisig)
+	for _, sig := range m.Signatures {
+		signer := m.Signers[sig.Index]
+		switch signer.KeyType {
+		case storage.ED25519Signer:
+			e.ed25519Cache.Add(ed25519.PublicKey(signer.Key))
+		case storage.SECP256R1Signer:
+			e.secp256r1Cache.Add(secp256r1.PublicKey(signer.Key))
+		}
+	}
+}
+
+type multisigSECP256R1Job struct {
+	msg []byte
+	pk  secp256r1.PublicKey
+	sig secp256r1.Signature
+}
+
+// MultisigBatch groups [Multisig] auths so that they are verified in jobs of
+// [batchSize] auths.
+type MultisigBatch struct {
+	ed25519Cache   *ed25519.KeyCache
+	secp256r1Cache *secp256r1.KeyCache
+	batchSize      int
+
+	counter       int
+	ed25519Batch  *ed25519.Batch
+	secp256r1Jobs []*multisigSECP256R1Job
+}
+
+func (b *MultisigBatch) Add(msg []byte, rauth chain.Auth) func() error {
+	m := rauth.(*Multisig)
+	for _, sig := range m.Signatures {
+		signer := m.Signers[sig.Index]
+		switch signer.KeyType {
+		case storage.ED25519Signer:
+			if b.ed25519Batch == nil {
+				b.ed25519Batch = ed25519.NewBatchWithCache(b.batchSize, b.ed25519Cache)
+			}
+			b.ed25519Batch.Add(msg, ed25519.PublicKey(signer.Key), ed25519.Signature(sig.Signature))
+		case storage.SECP256R1Signer:
+			b.secp256r1Jobs = append(b.secp256r1Jobs, &multisigSECP256R1Job{
+				msg,
+				secp256r1.PublicKey(signer.Key),
+				secp256r1.Signature(sig.Signature),
+			})
+		}
+	}
+	b.counter++
+	if b.counter == b.batchSize {
+		return b.flush()
+	}
+	return nil
+}
+
+func (b *MultisigBatch) Done() []func() error {
+	if b.counter == 0 {
+		return nil
+	}
+	return []func() error{b.flush()}
+}
+
+func (b *MultisigBatch) flush() func() error {
+	ed25519Batch, secp256r1Jobs, secp256r1Cache := b.ed25519Batch, b.secp256r1Jobs, b.secp256r1Cache
+	b.counter = 0
+	b.ed25519Batch = nil
+	b.secp256r1Jobs = nil
+	return func() error {
+		if ed25519Batch != nil && !ed25519Batch.Verify() {
+			return crypto.ErrInvalidSignature
+		}
+		for _, job := range secp256r1Jobs {
+			if !secp256r1.VerifyWithCache(secp256r1Cache, job.msg, job.pk, job.sig) {
+				return crypto.ErrInvalidSignature
+			}
+		}
+		return nil
+	}
+}
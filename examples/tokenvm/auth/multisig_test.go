@@ -0,0 +1,77 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/crypto/ed25519"
+
+	"github.com/ava-labs/hypersdk/examples/tokenvm/storage"
+)
+
+func newED25519Signer(t *testing.T) (ed25519.PrivateKey, *MultisigSigner) {
+	priv, err := ed25519.GeneratePrivateKey()
+	require.NoError(t, err)
+	pk := priv.PublicKey()
+	return priv, &MultisigSigner{KeyType: storage.ED25519Signer, Key: pk[:]}
+}
+
+func TestMultisig(t *testing.T) {
+	require := require.New(t)
+
+	priv0, signer0 := newED25519Signer(t)
+	priv1, signer1 := newED25519Signer(t)
+	_, signer2 := newED25519Signer(t)
+	signers := []*MultisigSigner{signer0, signer1, signer2}
+	addr, err := NewMultisigAddress(2, signers)
+	require.NoError(err)
+
+	msg := []byte("tx digest")
+	rauth, err := NewMultisigFactory(2, signers, []ed25519.PrivateKey{priv1, priv0}, nil).Sign(msg, nil)
+	require.NoError(err)
+	require.NoError(rauth.AsyncVerify(msg))
+	require.Equal(addr[:], rauth.Payer())
+
+	p := codec.NewWriter(rauth.Size(), rauth.Size())
+	rauth.Marshal(p)
+	require.NoError(p.Err())
+	parsed, err := UnmarshalMultisig(codec.NewReader(p.Bytes(), len(p.Bytes())), nil)
+	require.NoError(err)
+	require.Equal(rauth, parsed)
+}
+
+func TestMultisigDuplicateSigners(t *testing.T) {
+	require := require.New(t)
+
+	priv, signer := newED25519Signer(t)
+	_, other := newED25519Signer(t)
+	signers := []*MultisigSigner{signer, other, {KeyType: signer.KeyType, Key: signer.Key}}
+
+	// A policy can't include the same signer more than once
+	_, err := NewMultisigAddress(2, signers)
+	require.ErrorIs(err, ErrDuplicateSigner)
+
+	// Otherwise, a single signer could meet the threshold by signing at each
+	// of its indices
+	msg := []byte("tx digest")
+	sig := ed25519.Sign(msg, priv)
+	m := &Multisig{
+		Threshold: 2,
+		Signers:   signers,
+		Signatures: []*MultisigSignature{
+			{Index: 0, Signature: sig[:]},
+			{Index: 2, Signature: sig[:]},
+		},
+	}
+	require.NoError(m.AsyncVerify(msg))
+	p := codec.NewWriter(m.Size(), m.Size())
+	m.Marshal(p)
+	require.NoError(p.Err())
+	_, err = UnmarshalMultisig(codec.NewReader(p.Bytes(), len(p.Bytes())), nil)
+	require.ErrorIs(err, ErrDuplicateSigner)
+}
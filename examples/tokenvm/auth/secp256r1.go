@@ -0,0 +1,242 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package auth
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/crypto"
+	"github.com/ava-labs/hypersdk/crypto/ed25519"
+	"github.com/ava-labs/hypersdk/crypto/secp256r1"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/storage"
+	"github.com/ava-labs/hypersdk/state"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var _ chain.Auth = (*SECP256R1)(nil)
+
+const (
+	SECP256R1ComputeUnits = 10 // can't be batched like ed25519
+	SECP256R1Size         = secp256r1.PublicKeyLen + secp256r1.SignatureLen
+
+	// secp256r1MinBatchSize is the minimum number of signatures verified in
+	// a single job. There is no batch verification for ECDSA, so this just
+	// amortizes the overhead of scheduling verification.
+	secp256r1MinBatchSize = 16
+)
+
+// NewSECP256R1Address returns the account controlled by [pk].
+//
+// Because accounts are 32 bytes and secp256r1 public keys are 33 bytes, the
+// account is derived by hashing the public key (prefixed by the auth type ID
+// so that the same key used with different auth types controls different
+// accounts).
+func NewSECP256R1Address(pk secp256r1.PublicKey) ed25519.PublicKey {
+	return newAddress(secp256r1ID, pk)
+}
+
+func newAddress(typeID uint8, pk secp256r1.PublicKey) ed25519.PublicKey {
+	b := make([]byte, 1+secp256r1.PublicKeyLen)
+	b[0] = typeID
+	copy(b[1:], pk[:])
+	return ed25519.PublicKey(utils.ToID(b))
+}
+
+type SECP256R1 struct {
+	Signer    secp256r1.PublicKey `json:"signer"`
+	Signature secp256r1.Signature `json:"signature"`
+}
+
+func (*SECP256R1) GetTypeID() uint8 {
+	return secp256r1ID
+}
+
+func (*SECP256R1) MaxComputeUnits(chain.Rules) uint64 {
+	return SECP256R1ComputeUnits
+}
+
+func (*SECP256R1) ValidRange(chain.Rules) (int64, int64) {
+	return -1, -1
+}
+
+func (d *SECP256R1) StateKeys() []string {
+	return []string{
+		// We always pay fees with the native asset (which is [ids.Empty])
+		string(storage.BalanceKey(NewSECP256R1Address(d.Signer), ids.Empty)),
+	}
+}
+
+func (d *SECP256R1) AsyncVerify(msg []byte) error {
+	return d.verify(secp256r1.DefaultKeyCache(), msg)
+}
+
+func (d *SECP256R1) verify(c *secp256r1.KeyCache, msg []byte) error {
+	if !secp256r1.VerifyWithCache(c, msg, d.Signer, d.Signature) {
+		return crypto.ErrInvalidSignature
+	}
+	return nil
+}
+
+func (d *SECP256R1) Verify(
+	_ context.Context,
+	r chain.Rules,
+	_ state.Immutable,
+	_ chain.Action,
+) (uint64, error) {
+	// We don't do anything during verify (there is no additional state to check
+	// to authorize the signer other than verifying the signature)
+	return d.MaxComputeUnits(r), nil
+}
+
+func (d *SECP256R1) Payer() []byte {
+	addr := NewSECP256R1Address(d.Signer)
+	return addr[:]
+}
+
+func (*SECP256R1) Size() int {
+	return SECP256R1Size
+}
+
+func (d *SECP256R1) Marshal(p *codec.Packer) {
+	p.PackFixedBytes(d.Signer[:])
+	p.PackFixedBytes(d.Signature[:])
+}
+
+func UnmarshalSECP256R1(p *codec.Packer, _ *warp.Message) (chain.Auth, error) {
+	var d SECP256R1
+	signer := d.Signer[:]
+	p.UnpackFixedBytes(secp256r1.PublicKeyLen, &signer)
+	signature := d.Signature[:]
+	p.UnpackFixedBytes(secp256r1.SignatureLen, &signature)
+	return &d, p.Err()
+}
+
+func (d *SECP256R1) CanDeduct(
+	ctx context.Context,
+	im state.Immutable,
+	amount uint64,
+) error {
+	bal, err := storage.GetBalance(ctx, im, NewSECP256R1Address(d.Signer), ids.Empty)
+	if err != nil {
+		return err
+	}
+	if bal < amount {
+		return storage.ErrInvalidBalance
+	}
+	return nil
+}
+
+func (d *SECP256R1) Deduct(
+	ctx context.Context,
+	mu state.Mutable,
+	amount uint64,
+) error {
+	return storage.SubBalance(ctx, mu, NewSECP256R1Address(d.Signer), ids.Empty, amount)
+}
+
+func (d *SECP256R1) Refund(
+	ctx context.Context,
+	mu state.Mutable,
+	amount uint64,
+) error {
+	// Don't create account if it doesn't exist (may have sent all funds).
+	return storage.AddBalance(ctx, mu, NewSECP256R1Address(d.Signer), ids.Empty, amount, false)
+}
+
+var _ chain.AuthFactory = (*SECP256R1Factory)(nil)
+
+func NewSECP256R1Factory(priv secp256r1.PrivateKey) *SECP256R1Factory {
+	return &SECP256R1Factory{priv}
+}
+
+type SECP256R1Factory struct {
+	priv secp256r1.PrivateKey
+}
+
+func (d *SECP256R1Factory) Sign(msg []byte, _ chain.Action) (chain.Auth, error) {
+	sig, err := secp256r1.Sign(msg, d.priv)
+	if err != nil {
+		return nil, err
+	}
+	return &SECP256R1{d.priv.PublicKey(), sig}, nil
+}
+
+func (*SECP256R1Factory) MaxUnits() (uint64, uint64, []uint16) {
+	return SECP256R1Size, SECP256R1ComputeUnits, []uint16{storage.BalanceChunks}
+}
+
+// SECP256R1AuthEngine verifies [SECP256R1] and [WebAuthn] signatures.
+type SECP256R1AuthEngine struct {
+	cache *secp256r1.KeyCache
+}
+
+func (e *SECP256R1AuthEngine) GetBatchVerifier(cores int, count int) chain.AuthBatchVerifier {
+	batchSize := math.Max(count/cores, secp256r1MinBatchSize)
+	return &SECP256R1Batch{cache: e.cache, batchSize: batchSize}
+}
+
+func (e *SECP256R1AuthEngine) Cache(auth chain.Auth) {
+	switch a := auth.(type) {
+	case *SECP256R1:
+		e.cache.Add(a.Signer)
+	case *WebAuthn:
+		e.cache.Add(a.Signer)
+	}
+}
+
+// secp256r1Auth is implemented by the auths verified by
+// [SECP256R1AuthEngine].
+type secp256r1Auth interface {
+	// verify is [chain.Auth.AsyncVerify] using the public keys in [c].
+	verify(c *secp256r1.KeyCache, msg []byte) error
+}
+
+type secp256r1Job struct {
+	msg  []byte
+	auth secp256r1Auth
+}
+
+// SECP256R1Batch groups signatures so that they are verified in jobs of
+// [batchSize].
+type SECP256R1Batch struct {
+	cache     *secp256r1.KeyCache
+	batchSize int
+	batch     []*secp256r1Job
+}
+
+func (b *SECP256R1Batch) Add(msg []byte, auth chain.Auth) func() error {
+	if b.batch == nil {
+		b.batch = make([]*secp256r1Job, 0, b.batchSize)
+	}
+	b.batch = append(b.batch, &secp256r1Job{msg, auth.(secp256r1Auth)})
+	if len(b.batch) == b.batchSize {
+		last := b.batch
+		b.batch = nil
+		return verifySECP256R1Batch(b.cache, last)
+	}
+	return nil
+}
+
+func (b *SECP256R1Batch) Done() []func() error {
+	if len(b.batch) == 0 {
+		return nil
+	}
+	return []func() error{verifySECP256R1Batch(b.cache, b.batch)}
+}
+
+func verifySECP256R1Batch(c *secp256r1.KeyCache, batch []*secp256r1Job) func() error {
+	return func() error {
+		for _, job := range batch {
+			if err := job.auth.verify(c, job.msg); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
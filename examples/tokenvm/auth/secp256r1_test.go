@@ -0,0 +1,154 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package auth
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/crypto"
+	"github.com/ava-labs/hypersdk/crypto/ed25519"
+	"github.com/ava-labs/hypersdk/crypto/secp256r1"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+const (
+	testRPID   = "example.com"
+	testOrigin = "https://example.com"
+)
+
+func newSECP256R1Key(t *testing.T) secp256r1.PrivateKey {
+	priv, err := secp256r1.GeneratePrivateKey()
+	require.NoError(t, err)
+	return priv
+}
+
+func TestSECP256R1Auth(t *testing.T) {
+	priv := newSECP256R1Key(t)
+	tests := []struct {
+		name      string
+		factory   chain.AuthFactory
+		unmarshal func(*codec.Packer) (chain.Auth, error)
+	}{
+		{
+			name:      "secp256r1",
+			factory:   NewSECP256R1Factory(priv),
+			unmarshal: func(p *codec.Packer) (chain.Auth, error) { return UnmarshalSECP256R1(p, nil) },
+		},
+		{
+			name:      "webauthn",
+			factory:   NewWebAuthnFactory(priv, testRPID, testOrigin),
+			unmarshal: func(p *codec.Packer) (chain.Auth, error) { return UnmarshalWebAuthn(p, nil) },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			msg := []byte("tx digest")
+			auth, err := tt.factory.Sign(msg, nil)
+			require.NoError(err)
+			require.NoError(auth.AsyncVerify(msg))
+			require.Error(auth.AsyncVerify([]byte("other digest")))
+
+			// The factory reserves exactly the size of the auth
+			size, _, _ := tt.factory.MaxUnits()
+			require.Equal(uint64(auth.Size()), size)
+
+			p := codec.NewWriter(auth.Size(), auth.Size())
+			auth.Marshal(p)
+			require.NoError(p.Err())
+			b := p.Bytes()
+			require.Len(b, auth.Size())
+
+			parsed, err := tt.unmarshal(codec.NewReader(b, len(b)))
+			require.NoError(err)
+			require.Equal(auth, parsed)
+			require.NoError(parsed.AsyncVerify(msg))
+
+			// Truncated auth can't be parsed
+			_, err = tt.unmarshal(codec.NewReader(b[:len(b)-1], len(b)))
+			require.Error(err)
+		})
+	}
+}
+
+func TestSECP256R1Address(t *testing.T) {
+	require := require.New(t)
+
+	priv := newSECP256R1Key(t)
+	pk := priv.PublicKey()
+
+	// Addresses are derived from the type ID and the public key
+	secpAddr := NewSECP256R1Address(pk)
+	require.Equal(ed25519.PublicKey(utils.ToID(append([]byte{secp256r1ID}, pk[:]...))), secpAddr)
+	webAuthnAddr := NewWebAuthnAddress(pk)
+	require.Equal(ed25519.PublicKey(utils.ToID(append([]byte{webAuthnID}, pk[:]...))), webAuthnAddr)
+	require.NotEqual(secpAddr, webAuthnAddr)
+	require.NotEqual(NewSECP256R1Address(newSECP256R1Key(t).PublicKey()), secpAddr)
+
+	// The same key controls different accounts with each auth type
+	secpAuth, err := NewSECP256R1Factory(priv).Sign([]byte{1}, nil)
+	require.NoError(err)
+	require.Equal(secpAddr, GetActor(secpAuth))
+	require.Equal(secpAddr[:], secpAuth.Payer())
+	webAuthn, err := NewWebAuthnFactory(priv, testRPID, testOrigin).Sign([]byte{1}, nil)
+	require.NoError(err)
+	require.Equal(webAuthnAddr, GetActor(webAuthn))
+	require.Equal(webAuthnAddr[:], webAuthn.Payer())
+}
+
+func TestSECP256R1Batch(t *testing.T) {
+	require := require.New(t)
+
+	engines, err := Engines(16, prometheus.NewRegistry())
+	require.NoError(err)
+	engine := engines[secp256r1ID]
+	require.Equal(engine, engines[webAuthnID])
+
+	var (
+		msg       = []byte("tx digest")
+		factories = []chain.AuthFactory{
+			NewSECP256R1Factory(newSECP256R1Key(t)),
+			NewWebAuthnFactory(newSECP256R1Key(t), testRPID, testOrigin),
+		}
+		auths = make([]chain.Auth, 40)
+	)
+	for i := range auths {
+		auths[i], err = factories[i%len(factories)].Sign(msg, nil)
+		require.NoError(err)
+		engine.Cache(auths[i])
+	}
+
+	// Signatures are verified in jobs of at least [secp256r1MinBatchSize]
+	batch := engine.GetBatchVerifier(4, len(auths))
+	var jobs []func() error
+	for i, auth := range auths {
+		job := batch.Add(msg, auth)
+		if (i+1)%secp256r1MinBatchSize == 0 {
+			require.NotNil(job)
+			jobs = append(jobs, job)
+		} else {
+			require.Nil(job)
+		}
+	}
+	done := batch.Done()
+	require.Len(done, 1)
+	jobs = append(jobs, done...)
+	for _, job := range jobs {
+		require.NoError(job())
+	}
+
+	// A single invalid signature fails its job
+	batch = engine.GetBatchVerifier(4, 2)
+	require.Nil(batch.Add(msg, auths[1]))
+	require.Nil(batch.Add([]byte("other digest"), auths[0]))
+	done = batch.Done()
+	require.Len(done, 1)
+	require.ErrorIs(done[0](), crypto.ErrInvalidSignature)
+}
@@ -0,0 +1,269 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package auth
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	smath "github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/crypto"
+	"github.com/ava-labs/hypersdk/crypto/ed25519"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/storage"
+	"github.com/ava-labs/hypersdk/state"
+)
+
+var _ chain.Auth = (*Session)(nil)
+
+const (
+	SessionComputeUnits = ED25519ComputeUnits + 1 // reading the session
+	SessionSize         = ed25519.PublicKeyLen*2 + consts.Int64Len + ed25519.SignatureLen
+)
+
+// Spender is implemented by actions that spend the assets of the actor, so
+// that their spending can be limited when they are authorized by a [Session].
+type Spender interface {
+	// Spends returns the maximum amount of each asset the action could spend.
+	Spends() map[ids.ID]uint64
+}
+
+// Session authorizes a transaction on behalf of [Actor] with a session key
+// ([Signer]) that the actor registered in state.
+//
+// A session is only valid until its expiry (enforced with [ValidRange]) and
+// can only authorize the actions and spend the assets it was registered
+// with.
+type Session struct {
+	Actor     ed25519.PublicKey `json:"actor"`
+	Signer    ed25519.PublicKey `json:"signer"`
+	Expiry    int64             `json:"expiry"`
+	Signature ed25519.Signature `json:"signature"`
+}
+
+func (*Session) GetTypeID() uint8 {
+	return sessionID
+}
+
+func (*Session) MaxComputeUnits(chain.Rules) uint64 {
+	return SessionComputeUnits
+}
+
+func (s *Session) ValidRange(chain.Rules) (int64, int64) {
+	return -1, s.Expiry
+}
+
+func (s *Session) StateKeys() []string {
+	return []string{
+		// We always pay fees with the native asset (which is [ids.Empty])
+		string(storage.BalanceKey(s.Actor, ids.Empty)),
+		string(storage.SessionKey(s.Actor, s.Signer)),
+	}
+}
+
+func (s *Session) AsyncVerify(msg []byte) error {
+	if !ed25519.Verify(msg, s.Signer, s.Signature) {
+		return crypto.ErrInvalidSignature
+	}
+	return nil
+}
+
+func (s *Session) Verify(
+	ctx context.Context,
+	r chain.Rules,
+	im state.Immutable,
+	action chain.Action,
+) (uint64, error) {
+	exists, expiry, actions, limits, err := storage.GetSession(ctx, im, s.Actor, s.Signer)
+	if err != nil {
+		return 0, err
+	}
+	if !exists {
+		return 0, ErrSessionMissing
+	}
+	// [ValidRange] ensures the transaction is executed before [Expiry], so we
+	// just need to make sure it is the expiry of the session.
+	if expiry != s.Expiry {
+		return 0, ErrWrongSessionExpiry
+	}
+	if !sessionAllows(actions, action.GetTypeID()) {
+		return 0, ErrActionNotAllowed
+	}
+	if spender, ok := action.(Spender); ok {
+		for asset, amount := range spender.Spends() {
+			if amount > sessionLimit(limits, asset) {
+				return 0, ErrSpendLimitExceeded
+			}
+		}
+	}
+	return s.MaxComputeUnits(r), nil
+}
+
+func sessionAllows(actions []uint8, action uint8) bool {
+	for _, allowed := range actions {
+		if allowed == action {
+			return true
+		}
+	}
+	return false
+}
+
+// sessionLimit returns the amount of [asset] that may be spent (assets
+// without a limit may not be spent).
+func sessionLimit(limits []*storage.SpendLimit, asset ids.ID) uint64 {
+	for _, limit := range limits {
+		if limit.Asset == asset {
+			return limit.Amount
+		}
+	}
+	return 0
+}
+
+func (s *Session) Payer() []byte {
+	return s.Actor[:]
+}
+
+func (*Session) Size() int {
+	return SessionSize
+}
+
+func (s *Session) Marshal(p *codec.Packer) {
+	p.PackPublicKey(s.Actor)
+	p.PackPublicKey(s.Signer)
+	p.PackInt64(s.Expiry)
+	p.PackSignature(s.Signature)
+}
+
+func UnmarshalSession(p *codec.Packer, _ *warp.Message) (chain.Auth, error) {
+	var s Session
+	p.UnpackPublicKey(true, &s.Actor)
+	p.UnpackPublicKey(true, &s.Signer)
+	s.Expiry = p.UnpackInt64(true)
+	p.UnpackSignature(&s.Signature)
+	return &s, p.Err()
+}
+
+func (s *Session) CanDeduct(
+	ctx context.Context,
+	im state.Immutable,
+	amount uint64,
+) error {
+	_, _, _, limits, err := storage.GetSession(ctx, im, s.Actor, s.Signer)
+	if err != nil {
+		return err
+	}
+	// Fees count towards the limit of the native asset
+	if amount > sessionLimit(limits, ids.Empty) {
+		return ErrSpendLimitExceeded
+	}
+	bal, err := storage.GetBalance(ctx, im, s.Actor, ids.Empty)
+	if err != nil {
+		return err
+	}
+	if bal < amount {
+		return storage.ErrInvalidBalance
+	}
+	return nil
+}
+
+func (s *Session) Deduct(
+	ctx context.Context,
+	mu state.Mutable,
+	amount uint64,
+) error {
+	if err := Spend(ctx, mu, s, ids.Empty, amount); err != nil {
+		return err
+	}
+	return storage.SubBalance(ctx, mu, s.Actor, ids.Empty, amount)
+}
+
+func (s *Session) Refund(
+	ctx context.Context,
+	mu state.Mutable,
+	amount uint64,
+) error {
+	// Don't create account if it doesn't exist (may have sent all funds).
+	if err := storage.AddBalance(ctx, mu, s.Actor, ids.Empty, amount, false); err != nil {
+		return err
+	}
+	exists, expiry, actions, limits, err := storage.GetSession(ctx, mu, s.Actor, s.Signer)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		// The session may have been revoked by the action
+		return nil
+	}
+	for _, limit := range limits {
+		if limit.Asset == ids.Empty {
+			limit.Amount, err = smath.Add64(limit.Amount, amount)
+			if err != nil {
+				return err
+			}
+			return storage.SetSession(ctx, mu, s.Actor, s.Signer, expiry, actions, limits)
+		}
+	}
+	return nil
+}
+
+// Spend reduces the amount of [asset] that may be spent by [rauth] (if it is
+// a [Session] or is sponsoring one) by [amount].
+func Spend(
+	ctx context.Context,
+	mu state.Mutable,
+	rauth chain.Auth,
+	asset ids.ID,
+	amount uint64,
+) error {
+	if sponsored, ok := rauth.(*Sponsored); ok {
+		rauth = sponsored.Actor
+	}
+	s, ok := rauth.(*Session)
+	if !ok || amount == 0 {
+		return nil
+	}
+	exists, expiry, actions, limits, err := storage.GetSession(ctx, mu, s.Actor, s.Signer)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrSessionMissing
+	}
+	for _, limit := range limits {
+		if limit.Asset == asset {
+			if amount > limit.Amount {
+				return ErrSpendLimitExceeded
+			}
+			limit.Amount -= amount
+			return storage.SetSession(ctx, mu, s.Actor, s.Signer, expiry, actions, limits)
+		}
+	}
+	return ErrSpendLimitExceeded
+}
+
+var _ chain.AuthFactory = (*SessionFactory)(nil)
+
+// NewSessionFactory returns a [SessionFactory] that signs on behalf of [actor]
+// with the session key [priv] (registered with [expiry]).
+func NewSessionFactory(actor ed25519.PublicKey, priv ed25519.PrivateKey, expiry int64) *SessionFactory {
+	return &SessionFactory{actor, priv, expiry}
+}
+
+type SessionFactory struct {
+	actor  ed25519.PublicKey
+	priv   ed25519.PrivateKey
+	expiry int64
+}
+
+func (s *SessionFactory) Sign(msg []byte, _ chain.Action) (chain.Auth, error) {
+	sig := ed25519.Sign(msg, s.priv)
+	return &Session{s.actor, s.priv.PublicKey(), s.expiry, sig}, nil
+}
+
+func (*SessionFactory) MaxUnits() (uint64, uint64, []uint16) {
+	return SessionSize, SessionComputeUnits, []uint16{storage.BalanceChunks, storage.SessionChunks}
+}
@@ -0,0 +1,233 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package auth
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/crypto/ed25519"
+	tconsts "github.com/ava-labs/hypersdk/examples/tokenvm/consts"
+	"github.com/ava-labs/hypersdk/state"
+)
+
+var _ chain.Auth = (*Sponsored)(nil)
+
+// SponsorPolicy determines whether [sponsor] pays the fees of [action] by
+// [actor]. It is invoked during [Sponsored.Verify], so it must be
+// deterministic.
+type SponsorPolicy func(
+	ctx context.Context,
+	im state.Immutable,
+	sponsor ed25519.PublicKey,
+	actor ed25519.PublicKey,
+	action chain.Action,
+) error
+
+// DefaultSponsorPolicy allows a sponsor to pay the fees of any action it has
+// signed, except those by itself (which should not be sponsored).
+func DefaultSponsorPolicy(
+	_ context.Context,
+	_ state.Immutable,
+	sponsor ed25519.PublicKey,
+	actor ed25519.PublicKey,
+	_ chain.Action,
+) error {
+	if sponsor == actor {
+		return ErrSelfSponsored
+	}
+	return nil
+}
+
+// Sponsored authorizes a transaction on behalf of [Actor] while charging its
+// fees to [Sponsor]. Actions are executed as [Actor] and the mempool limits
+// the number of pending transactions by [Sponsor] (the [Payer]).
+//
+// Both [Actor] and [Sponsor] sign the digest of the transaction and the
+// address of [Sponsor] (see [SponsoredMessage]), so a signature by [Actor]
+// can't be reused with a different [Sponsor] to replay the transaction.
+type Sponsored struct {
+	Actor   chain.Auth `json:"actor"`
+	Sponsor chain.Auth `json:"sponsor"`
+
+	policy SponsorPolicy
+}
+
+// SponsoredMessage returns the message signed by the [Actor] and [Sponsor]
+// of a [Sponsored] transaction with [digest].
+func SponsoredMessage(digest []byte, sponsor ed25519.PublicKey) []byte {
+	msg := make([]byte, len(digest)+ed25519.PublicKeyLen)
+	copy(msg, digest)
+	copy(msg[len(digest):], sponsor[:])
+	return msg
+}
+
+func (*Sponsored) GetTypeID() uint8 {
+	return sponsoredID
+}
+
+func (s *Sponsored) MaxComputeUnits(r chain.Rules) uint64 {
+	return s.Actor.MaxComputeUnits(r) + s.Sponsor.MaxComputeUnits(r)
+}
+
+func (s *Sponsored) ValidRange(r chain.Rules) (int64, int64) {
+	// The transaction is only valid when both [Actor] and [Sponsor] are valid
+	actorStart, actorEnd := s.Actor.ValidRange(r)
+	sponsorStart, sponsorEnd := s.Sponsor.ValidRange(r)
+	start, end := actorStart, actorEnd
+	if sponsorStart > start {
+		start = sponsorStart
+	}
+	if end == -1 || (sponsorEnd != -1 && sponsorEnd < end) {
+		end = sponsorEnd
+	}
+	return start, end
+}
+
+func (s *Sponsored) StateKeys() []string {
+	return append(s.Actor.StateKeys(), s.Sponsor.StateKeys()...)
+}
+
+func (s *Sponsored) AsyncVerify(digest []byte) error {
+	msg := SponsoredMessage(digest, GetActor(s.Sponsor))
+	if err := s.Actor.AsyncVerify(msg); err != nil {
+		return err
+	}
+	return s.Sponsor.AsyncVerify(msg)
+}
+
+func (s *Sponsored) Verify(
+	ctx context.Context,
+	r chain.Rules,
+	im state.Immutable,
+	action chain.Action,
+) (uint64, error) {
+	actorUnits, err := s.Actor.Verify(ctx, r, im, action)
+	if err != nil {
+		return 0, err
+	}
+	sponsorUnits, err := s.Sponsor.Verify(ctx, r, im, action)
+	if err != nil {
+		return 0, err
+	}
+	if s.policy != nil {
+		if err := s.policy(ctx, im, GetActor(s.Sponsor), GetActor(s.Actor), action); err != nil {
+			return 0, err
+		}
+	}
+	return actorUnits + sponsorUnits, nil
+}
+
+func (s *Sponsored) Payer() []byte {
+	return s.Sponsor.Payer()
+}
+
+func (s *Sponsored) Size() int {
+	return consts.ByteLen*2 + s.Actor.Size() + s.Sponsor.Size()
+}
+
+func (s *Sponsored) Marshal(p *codec.Packer) {
+	p.PackByte(s.Actor.GetTypeID())
+	s.Actor.Marshal(p)
+	p.PackByte(s.Sponsor.GetTypeID())
+	s.Sponsor.Marshal(p)
+}
+
+// NewSponsoredUnmarshaler returns a function that unmarshals a [Sponsored]
+// auth that enforces [policy].
+func NewSponsoredUnmarshaler(policy SponsorPolicy) func(*codec.Packer, *warp.Message) (chain.Auth, error) {
+	return func(p *codec.Packer, wm *warp.Message) (chain.Auth, error) {
+		actor, err := unmarshalSponsoredAuth(p, wm)
+		if err != nil {
+			return nil, err
+		}
+		sponsor, err := unmarshalSponsoredAuth(p, wm)
+		if err != nil {
+			return nil, err
+		}
+		return &Sponsored{actor, sponsor, policy}, p.Err()
+	}
+}
+
+func unmarshalSponsoredAuth(p *codec.Packer, wm *warp.Message) (chain.Auth, error) {
+	authType := p.UnpackByte()
+	if err := p.Err(); err != nil {
+		return nil, err
+	}
+	// Sponsored auths can't be nested
+	if authType == sponsoredID {
+		return nil, ErrNestedSponsored
+	}
+	unmarshalAuth, _, ok := tconsts.AuthRegistry.LookupIndex(authType)
+	if !ok {
+		return nil, ErrUnknownAuthType
+	}
+	return unmarshalAuth(p, wm)
+}
+
+func (s *Sponsored) CanDeduct(
+	ctx context.Context,
+	im state.Immutable,
+	amount uint64,
+) error {
+	return s.Sponsor.CanDeduct(ctx, im, amount)
+}
+
+func (s *Sponsored) Deduct(
+	ctx context.Context,
+	mu state.Mutable,
+	amount uint64,
+) error {
+	return s.Sponsor.Deduct(ctx, mu, amount)
+}
+
+func (s *Sponsored) Refund(
+	ctx context.Context,
+	mu state.Mutable,
+	amount uint64,
+) error {
+	return s.Sponsor.Refund(ctx, mu, amount)
+}
+
+var _ chain.AuthFactory = (*SponsoredFactory)(nil)
+
+// NewSponsoredFactory returns a [SponsoredFactory] that signs as [actor] and
+// has the fees paid by [sponsorAddress] (signing with [sponsor]).
+func NewSponsoredFactory(
+	actor chain.AuthFactory,
+	sponsor chain.AuthFactory,
+	sponsorAddress ed25519.PublicKey,
+) *SponsoredFactory {
+	return &SponsoredFactory{actor, sponsor, sponsorAddress}
+}
+
+type SponsoredFactory struct {
+	actor          chain.AuthFactory
+	sponsor        chain.AuthFactory
+	sponsorAddress ed25519.PublicKey
+}
+
+func (s *SponsoredFactory) Sign(digest []byte, action chain.Action) (chain.Auth, error) {
+	msg := SponsoredMessage(digest, s.sponsorAddress)
+	actor, err := s.actor.Sign(msg, action)
+	if err != nil {
+		return nil, err
+	}
+	sponsor, err := s.sponsor.Sign(msg, action)
+	if err != nil {
+		return nil, err
+	}
+	return &Sponsored{Actor: actor, Sponsor: sponsor}, nil
+}
+
+func (s *SponsoredFactory) MaxUnits() (uint64, uint64, []uint16) {
+	actorSize, actorUnits, actorChunks := s.actor.MaxUnits()
+	sponsorSize, sponsorUnits, sponsorChunks := s.sponsor.MaxUnits()
+	return consts.ByteLen*2 + actorSize + sponsorSize,
+		actorUnits + sponsorUnits,
+		append(actorChunks, sponsorChunks...)
+}
@@ -0,0 +1,167 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package auth
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/crypto/ed25519"
+	"github.com/ava-labs/hypersdk/crypto/secp256r1"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/storage"
+	"github.com/ava-labs/hypersdk/state"
+)
+
+var _ chain.Auth = (*WebAuthn)(nil)
+
+const (
+	WebAuthnComputeUnits = 12 // includes parsing client data
+)
+
+// NewWebAuthnAddress returns the account controlled by the passkey with
+// public key [pk].
+func NewWebAuthnAddress(pk secp256r1.PublicKey) ed25519.PublicKey {
+	return newAddress(webAuthnID, pk)
+}
+
+// WebAuthn authorizes a transaction with a WebAuthn assertion (produced by a
+// passkey) over the transaction digest.
+type WebAuthn struct {
+	Signer            secp256r1.PublicKey `json:"signer"`
+	AuthenticatorData []byte              `json:"authenticatorData"`
+	ClientDataJSON    []byte              `json:"clientDataJSON"`
+	Signature         secp256r1.Signature `json:"signature"`
+}
+
+func (*WebAuthn) GetTypeID() uint8 {
+	return webAuthnID
+}
+
+func (*WebAuthn) MaxComputeUnits(chain.Rules) uint64 {
+	return WebAuthnComputeUnits
+}
+
+func (*WebAuthn) ValidRange(chain.Rules) (int64, int64) {
+	return -1, -1
+}
+
+func (d *WebAuthn) StateKeys() []string {
+	return []string{
+		// We always pay fees with the native asset (which is [ids.Empty])
+		string(storage.BalanceKey(NewWebAuthnAddress(d.Signer), ids.Empty)),
+	}
+}
+
+func (d *WebAuthn) AsyncVerify(msg []byte) error {
+	return d.verify(secp256r1.DefaultKeyCache(), msg)
+}
+
+func (d *WebAuthn) verify(c *secp256r1.KeyCache, msg []byte) error {
+	return secp256r1.VerifyWebAuthnWithCache(c, msg, d.Signer, d.AuthenticatorData, d.ClientDataJSON, d.Signature)
+}
+
+func (d *WebAuthn) Verify(
+	_ context.Context,
+	r chain.Rules,
+	_ state.Immutable,
+	_ chain.Action,
+) (uint64, error) {
+	// We don't do anything during verify (there is no additional state to check
+	// to authorize the signer other than verifying the signature)
+	return d.MaxComputeUnits(r), nil
+}
+
+func (d *WebAuthn) Payer() []byte {
+	addr := NewWebAuthnAddress(d.Signer)
+	return addr[:]
+}
+
+func (d *WebAuthn) Size() int {
+	return webAuthnSize(len(d.AuthenticatorData), len(d.ClientDataJSON))
+}
+
+func webAuthnSize(authenticatorDataLen int, clientDataJSONLen int) int {
+	return secp256r1.PublicKeyLen + codec.BytesLenSize(authenticatorDataLen) +
+		codec.BytesLenSize(clientDataJSONLen) + secp256r1.SignatureLen
+}
+
+func (d *WebAuthn) Marshal(p *codec.Packer) {
+	p.PackFixedBytes(d.Signer[:])
+	p.PackBytes(d.AuthenticatorData)
+	p.PackBytes(d.ClientDataJSON)
+	p.PackFixedBytes(d.Signature[:])
+}
+
+func UnmarshalWebAuthn(p *codec.Packer, _ *warp.Message) (chain.Auth, error) {
+	var d WebAuthn
+	signer := d.Signer[:]
+	p.UnpackFixedBytes(secp256r1.PublicKeyLen, &signer)
+	p.UnpackBytes(secp256r1.MaxAuthenticatorDataLen, true, &d.AuthenticatorData)
+	p.UnpackBytes(secp256r1.MaxClientDataJSONLen, true, &d.ClientDataJSON)
+	signature := d.Signature[:]
+	p.UnpackFixedBytes(secp256r1.SignatureLen, &signature)
+	return &d, p.Err()
+}
+
+func (d *WebAuthn) CanDeduct(
+	ctx context.Context,
+	im state.Immutable,
+	amount uint64,
+) error {
+	bal, err := storage.GetBalance(ctx, im, NewWebAuthnAddress(d.Signer), ids.Empty)
+	if err != nil {
+		return err
+	}
+	if bal < amount {
+		return storage.ErrInvalidBalance
+	}
+	return nil
+}
+
+func (d *WebAuthn) Deduct(
+	ctx context.Context,
+	mu state.Mutable,
+	amount uint64,
+) error {
+	return storage.SubBalance(ctx, mu, NewWebAuthnAddress(d.Signer), ids.Empty, amount)
+}
+
+func (d *WebAuthn) Refund(
+	ctx context.Context,
+	mu state.Mutable,
+	amount uint64,
+) error {
+	// Don't create account if it doesn't exist (may have sent all funds).
+	return storage.AddBalance(ctx, mu, NewWebAuthnAddress(d.Signer), ids.Empty, amount, false)
+}
+
+var _ chain.AuthFactory = (*WebAuthnFactory)(nil)
+
+// NewWebAuthnFactory returns a [WebAuthnFactory] that emulates a passkey
+// registered to [rpID] (used when the key is not stored on a device).
+func NewWebAuthnFactory(priv secp256r1.PrivateKey, rpID string, origin string) *WebAuthnFactory {
+	return &WebAuthnFactory{priv, rpID, origin}
+}
+
+type WebAuthnFactory struct {
+	priv   secp256r1.PrivateKey
+	rpID   string
+	origin string
+}
+
+func (d *WebAuthnFactory) Sign(msg []byte, _ chain.Action) (chain.Auth, error) {
+	authenticatorData, clientDataJSON, sig, err := secp256r1.SignWebAuthn(msg, d.priv, d.rpID, d.origin)
+	if err != nil {
+		return nil, err
+	}
+	return &WebAuthn{d.priv.PublicKey(), authenticatorData, clientDataJSON, sig}, nil
+}
+
+func (d *WebAuthnFactory) MaxUnits() (uint64, uint64, []uint16) {
+	size := webAuthnSize(secp256r1.MinAuthenticatorDataLen, secp256r1.WebAuthnClientDataJSONLen(d.origin))
+	return uint64(size), WebAuthnComputeUnits, []uint16{storage.BalanceChunks}
+}
@@ -66,7 +66,7 @@ var fundFaucetCmd = &cobra.Command{
 		}
 
 		// Get balance
-		_, decimals, balance, _, err := handler.GetAssetInfo(ctx, tcli, priv.PublicKey(), ids.Empty, true)
+		_, decimals, balance, _, err := handler.GetAssetInfo(ctx, tcli, priv.Address, ids.Empty, true)
 		if balance == 0 || err != nil {
 			return err
 		}
@@ -114,7 +114,7 @@ var transferCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
-		_, decimals, balance, _, err := handler.GetAssetInfo(ctx, tcli, priv.PublicKey(), assetID, true)
+		_, decimals, balance, _, err := handler.GetAssetInfo(ctx, tcli, priv.Address, assetID, true)
 		if balance == 0 || err != nil {
 			return err
 		}
@@ -239,7 +239,7 @@ var mintAssetCmd = &cobra.Command{
 			return nil
 		}
 
-		if owner != utils.Address(priv.PublicKey()) {
+		if owner != utils.Address(priv.Address) {
 			hutils.Outf("{{red}}%s is the owner of %s, you are not{{/}}\n", owner, assetID)
 			hutils.Outf("{{red}}exiting...{{/}}\n")
 			return nil
@@ -380,7 +380,7 @@ var createOrderCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
-		_, decimals, balance, _, err := handler.GetAssetInfo(ctx, tcli, priv.PublicKey(), outAssetID, true)
+		_, decimals, balance, _, err := handler.GetAssetInfo(ctx, tcli, priv.Address, outAssetID, true)
 		if balance == 0 || err != nil {
 			return err
 		}
@@ -439,7 +439,7 @@ var fillOrderCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
-		inSymbol, inDecimals, balance, _, err := handler.GetAssetInfo(ctx, tcli, priv.PublicKey(), inAssetID, true)
+		inSymbol, inDecimals, balance, _, err := handler.GetAssetInfo(ctx, tcli, priv.Address, inAssetID, true)
 		if balance == 0 || err != nil {
 			return err
 		}
@@ -449,7 +449,7 @@ var fillOrderCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
-		outSymbol, outDecimals, _, _, err := handler.GetAssetInfo(ctx, tcli, priv.PublicKey(), outAssetID, false)
+		outSymbol, outDecimals, _, _, err := handler.GetAssetInfo(ctx, tcli, priv.Address, outAssetID, false)
 		if err != nil {
 			return err
 		}
@@ -687,7 +687,7 @@ var exportAssetCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
-		_, decimals, balance, sourceChainID, err := handler.GetAssetInfo(ctx, tcli, priv.PublicKey(), assetID, true)
+		_, decimals, balance, sourceChainID, err := handler.GetAssetInfo(ctx, tcli, priv.Address, assetID, true)
 		if balance == 0 || err != nil {
 			return err
 		}
@@ -754,7 +754,7 @@ var exportAssetCmd = &cobra.Command{
 				return err
 			}
 			dcli := trpc.NewJSONRPCClient(uris[0], networkID, destination)
-			_, decimals, _, _, err := handler.GetAssetInfo(ctx, dcli, priv.PublicKey(), assetOut, false)
+			_, decimals, _, _, err := handler.GetAssetInfo(ctx, dcli, priv.Address, assetOut, false)
 			if err != nil {
 				return err
 			}
@@ -1073,7 +1073,7 @@ var getMyNFTCmd = &cobra.Command{
 
 				nft_data := strings.Split(string(v), ",")
 
-				if nft_data[2] == utils.Address(priv.PublicKey()) {
+				if nft_data[2] == utils.Address(priv.Address) {
 					hutils.Outf("{{green}}Transaction Hash:{{/}} %s\n", k)
 					hutils.Outf("{{green}}NFT MetaData:{{/}} %s\n", []byte(nft_data[1]))
 					hutils.Outf("{{green}}NFT Owner:{{/}} %s\n", []byte(nft_data[2]))
@@ -6,11 +6,16 @@ package cmd
 
 import (
 	"context"
+	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/rpc"
+	"github.com/ava-labs/hypersdk/utils"
 	"github.com/spf13/cobra"
 
+	"github.com/ava-labs/hypersdk/examples/tokenvm/genesis"
 	trpc "github.com/ava-labs/hypersdk/examples/tokenvm/rpc"
 )
 
@@ -78,3 +83,68 @@ var watchChainCmd = &cobra.Command{
 		})
 	},
 }
+
+var warpChainCmd = &cobra.Command{
+	Use: "warp",
+	RunE: func(_ *cobra.Command, args []string) error {
+		ctx := context.Background()
+		_, uris, err := handler.Root().PromptChain("select chainID", nil)
+		if err != nil {
+			return err
+		}
+		cli := rpc.NewJSONRPCClient(uris[0])
+		networkID, _, chainID, err := cli.Network(ctx)
+		if err != nil {
+			return err
+		}
+		tcli := trpc.NewJSONRPCClient(uris[0], networkID, chainID)
+		g, err := tcli.Genesis(ctx)
+		if err != nil {
+			return err
+		}
+
+		// Print active configs
+		now := time.Now().UnixMilli()
+		sourceChains := set.Set[ids.ID]{}
+		for _, c := range g.WarpSourceChains {
+			sourceChains.Add(c.SourceChainID)
+		}
+		for _, u := range g.WarpUpgrades {
+			for _, c := range u.SourceChains {
+				sourceChains.Add(c.SourceChainID)
+			}
+		}
+		printWarpConfig("default", g.WarpConfig(now, ids.Empty))
+		for sourceChainID := range sourceChains {
+			printWarpConfig(sourceChainID.String(), g.WarpConfig(now, sourceChainID))
+		}
+
+		// Print scheduled upgrades
+		for _, u := range g.WarpUpgrades {
+			if u.Timestamp <= now {
+				continue
+			}
+			utils.Outf("{{yellow}}upgrade at:{{/}} %s\n", time.UnixMilli(u.Timestamp).Format(time.RFC3339))
+			if u.Default != nil {
+				printWarpConfig("  default", u.Default)
+			}
+			for _, c := range u.SourceChains {
+				printWarpConfig("  "+c.SourceChainID.String(), &c.WarpConfig)
+			}
+		}
+		return nil
+	},
+}
+
+func printWarpConfig(name string, w *genesis.WarpConfig) {
+	if !w.Enabled {
+		utils.Outf("{{cyan}}%s:{{/}} {{red}}disabled{{/}}\n", name)
+		return
+	}
+	utils.Outf(
+		"{{cyan}}%s:{{/}} {{green}}enabled{{/}} {{yellow}}quorum:{{/}} %d/%d\n",
+		name,
+		w.QuorumNumerator,
+		w.QuorumDenominator,
+	)
+}
@@ -12,3 +12,5 @@ var (
 	ErrInsufficientSupply = errors.New("insufficient supply")
 	ErrMustFill           = errors.New("must fill")
 )
+
+var ErrSignerNotSupported = errors.New("not supported with an external signer")
@@ -5,17 +5,21 @@ package cmd
 
 import (
 	"context"
+	"os"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/hypersdk/chain"
 	"github.com/ava-labs/hypersdk/cli"
 	hconsts "github.com/ava-labs/hypersdk/consts"
 	"github.com/ava-labs/hypersdk/crypto/ed25519"
+	"github.com/ava-labs/hypersdk/crypto/secp256r1"
 	"github.com/ava-labs/hypersdk/examples/tokenvm/auth"
 	"github.com/ava-labs/hypersdk/examples/tokenvm/consts"
 	trpc "github.com/ava-labs/hypersdk/examples/tokenvm/rpc"
 	"github.com/ava-labs/hypersdk/examples/tokenvm/utils"
 	"github.com/ava-labs/hypersdk/pubsub"
 	"github.com/ava-labs/hypersdk/rpc"
+	"github.com/ava-labs/hypersdk/signer"
 	hutils "github.com/ava-labs/hypersdk/utils"
 )
 
@@ -94,22 +98,22 @@ func (*Handler) GetAssetInfo(
 }
 
 func (h *Handler) DefaultActor() (
-	ids.ID, ed25519.PrivateKey, *auth.ED25519Factory,
+	ids.ID, *cli.PrivateKey, chain.AuthFactory,
 	*rpc.JSONRPCClient, *rpc.WebSocketClient, *trpc.JSONRPCClient, error,
 ) {
-	priv, err := h.h.GetDefaultKey(true)
+	priv, factory, err := h.defaultSigner()
 	if err != nil {
-		return ids.Empty, ed25519.EmptyPrivateKey, nil, nil, nil, nil, err
+		return ids.Empty, nil, nil, nil, nil, nil, err
 	}
 	chainID, uris, err := h.h.GetDefaultChain(true)
 	if err != nil {
-		return ids.Empty, ed25519.EmptyPrivateKey, nil, nil, nil, nil, err
+		return ids.Empty, nil, nil, nil, nil, nil, err
 	}
 	// For [defaultActor], we always send requests to the first returned URI.
 	cli := rpc.NewJSONRPCClient(uris[0])
 	networkID, _, _, err := cli.Network(context.TODO())
 	if err != nil {
-		return ids.Empty, ed25519.EmptyPrivateKey, nil, nil, nil, nil, err
+		return ids.Empty, nil, nil, nil, nil, nil, err
 	}
 	scli, err := rpc.NewWebSocketClient(
 		uris[0],
@@ -118,9 +122,9 @@ func (h *Handler) DefaultActor() (
 		pubsub.MaxReadMessageSize,
 	)
 	if err != nil {
-		return ids.Empty, ed25519.EmptyPrivateKey, nil, nil, nil, nil, err
+		return ids.Empty, nil, nil, nil, nil, nil, err
 	}
-	return chainID, priv, auth.NewED25519Factory(priv), cli, scli,
+	return chainID, priv, factory, cli, scli,
 		trpc.NewJSONRPCClient(
 			uris[0],
 			networkID,
@@ -128,6 +132,35 @@ func (h *Handler) DefaultActor() (
 		), nil
 }
 
+// defaultSigner returns the default key and the [chain.AuthFactory] that signs
+// with it (delegating to the signer at [signerURI], if set).
+func (h *Handler) defaultSigner() (*cli.PrivateKey, chain.AuthFactory, error) {
+	if len(signerURI) == 0 {
+		priv, err := h.h.GetDefaultKey(true)
+		if err != nil {
+			return nil, nil, err
+		}
+		factory, err := getFactory(priv)
+		if err != nil {
+			return nil, nil, err
+		}
+		return priv, factory, nil
+	}
+	scli, err := signer.NewJSONRPCClient(signerURI, os.Getenv(signer.TokenEnv))
+	if err != nil {
+		return nil, nil, err
+	}
+	factory, err := signer.NewFactory(context.TODO(), scli, consts.AuthRegistry)
+	if err != nil {
+		return nil, nil, err
+	}
+	hutils.Outf("{{yellow}}signer:{{/}} %s\n", signerURI)
+	hutils.Outf("{{yellow}}address:{{/}} %s\n", utils.Address(factory.Address()))
+
+	// The key is held by the signer, so only its address is known
+	return &cli.PrivateKey{Address: factory.Address()}, factory, nil
+}
+
 type Controller struct {
 	databasePath string
 }
@@ -155,3 +188,25 @@ func (*Controller) Address(pk ed25519.PublicKey) string {
 func (*Controller) ParseAddress(address string) (ed25519.PublicKey, error) {
 	return utils.ParseAddress(address)
 }
+
+func (*Controller) KeyAddress(keyType cli.KeyType, priv []byte) (ed25519.PublicKey, error) {
+	switch keyType {
+	case cli.ED25519Key:
+		return ed25519.PrivateKey(priv).PublicKey(), nil
+	case cli.SECP256R1Key:
+		return auth.NewSECP256R1Address(secp256r1.PrivateKey(priv).PublicKey()), nil
+	default:
+		return ed25519.EmptyPublicKey, cli.ErrUnknownKeyType
+	}
+}
+
+func getFactory(priv *cli.PrivateKey) (chain.AuthFactory, error) {
+	switch priv.Type {
+	case cli.ED25519Key:
+		return auth.NewED25519Factory(ed25519.PrivateKey(priv.Bytes)), nil
+	case cli.SECP256R1Key:
+		return auth.NewSECP256R1Factory(secp256r1.PrivateKey(priv.Bytes)), nil
+	default:
+		return nil, cli.ErrUnknownKeyType
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/hypersdk/cli"
 	"github.com/ava-labs/hypersdk/consts"
 	"github.com/ava-labs/hypersdk/crypto/ed25519"
 	"github.com/ava-labs/hypersdk/utils"
@@ -30,7 +31,36 @@ var keyCmd = &cobra.Command{
 var genKeyCmd = &cobra.Command{
 	Use: "generate",
 	RunE: func(*cobra.Command, []string) error {
-		return handler.Root().GenerateKey()
+		t, err := cli.ParseKeyType(keyType)
+		if err != nil {
+			return err
+		}
+		if mnemonic {
+			return handler.Root().GenerateMnemonicKey(t)
+		}
+		return handler.Root().GenerateKey(t)
+	},
+}
+
+var recoverKeyCmd = &cobra.Command{
+	Use: "recover",
+	RunE: func(*cobra.Command, []string) error {
+		t, err := cli.ParseKeyType(keyType)
+		if err != nil {
+			return err
+		}
+		return handler.Root().RecoverKey(t)
+	},
+}
+
+var deriveKeyCmd = &cobra.Command{
+	Use: "derive",
+	RunE: func(*cobra.Command, []string) error {
+		t, err := cli.ParseKeyType(keyType)
+		if err != nil {
+			return err
+		}
+		return handler.Root().DeriveKey(t)
 	},
 }
 
@@ -43,7 +73,24 @@ var importKeyCmd = &cobra.Command{
 		return nil
 	},
 	RunE: func(_ *cobra.Command, args []string) error {
-		return handler.Root().ImportKey(args[0])
+		t, err := cli.ParseKeyType(keyType)
+		if err != nil {
+			return err
+		}
+		return handler.Root().ImportKey(t, args[0])
+	},
+}
+
+var exportKeyCmd = &cobra.Command{
+	Use: "export [path]",
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return ErrInvalidArgs
+		}
+		return nil
+	},
+	RunE: func(_ *cobra.Command, args []string) error {
+		return handler.Root().ExportKey(args[0])
 	},
 }
 
@@ -116,7 +163,7 @@ var faucetKeyCmd = &cobra.Command{
 		start := time.Now()
 		solution, attempts := challenge.Search(salt, difficulty, numCores)
 		utils.Outf("{{cyan}}found solution (attempts=%d, t=%s):{{/}} %x\n", attempts, time.Since(start), solution)
-		txID, amount, err := fcli.SolveChallenge(ctx, tutils.Address(priv.PublicKey()), salt, solution)
+		txID, amount, err := fcli.SolveChallenge(ctx, tutils.Address(priv.Address), salt, solution)
 		if err != nil {
 			return err
 		}
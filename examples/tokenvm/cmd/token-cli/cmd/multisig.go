@@ -0,0 +1,327 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/cli"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/actions"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/auth"
+	tconsts "github.com/ava-labs/hypersdk/examples/tokenvm/consts"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/storage"
+	tutils "github.com/ava-labs/hypersdk/examples/tokenvm/utils"
+	"github.com/ava-labs/hypersdk/utils"
+	"github.com/spf13/cobra"
+)
+
+var multisigCmd = &cobra.Command{
+	Use: "multisig",
+	RunE: func(*cobra.Command, []string) error {
+		return ErrMissingSubcommand
+	},
+}
+
+// signerType converts a [cli.KeyType] to the signer type used by
+// [auth.Multisig].
+func signerType(t cli.KeyType) (uint8, error) {
+	switch t {
+	case cli.ED25519Key:
+		return storage.ED25519Signer, nil
+	case cli.SECP256R1Key:
+		return storage.SECP256R1Signer, nil
+	default:
+		return 0, cli.ErrUnknownKeyType
+	}
+}
+
+// parseMultisigSigners parses signers formatted as <type>:<hex public key>.
+func parseMultisigSigners(signers []string) ([]*cli.PartialSigner, error) {
+	parsed := make([]*cli.PartialSigner, len(signers))
+	for i, signer := range signers {
+		parts := strings.SplitN(signer, ":", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("%w: signer %q must be <type>:<public key>", ErrInvalidArgs, signer)
+		}
+		t, err := cli.ParseKeyType(parts[0])
+		if err != nil {
+			return nil, err
+		}
+		pk, err := hex.DecodeString(strings.TrimPrefix(parts[1], "0x"))
+		if err != nil {
+			return nil, err
+		}
+		st, err := signerType(t)
+		if err != nil {
+			return nil, err
+		}
+		if l, _ := storage.SignerKeyLen(st); len(pk) != l {
+			return nil, fmt.Errorf("%w: %s", cli.ErrInvalidKey, signer)
+		}
+		parsed[i] = &cli.PartialSigner{Type: t, PublicKey: pk}
+	}
+	return parsed, nil
+}
+
+// multisigPolicy returns the [auth.Multisig] signers of [signers].
+func multisigPolicy(signers []*cli.PartialSigner) ([]*auth.MultisigSigner, error) {
+	policy := make([]*auth.MultisigSigner, len(signers))
+	for i, signer := range signers {
+		t, err := signerType(signer.Type)
+		if err != nil {
+			return nil, err
+		}
+		policy[i] = &auth.MultisigSigner{KeyType: t, Key: signer.PublicKey}
+	}
+	return policy, nil
+}
+
+var publicKeyMultisigCmd = &cobra.Command{
+	Use: "public-key",
+	RunE: func(*cobra.Command, []string) error {
+		priv, err := handler.Root().GetDefaultKey(true)
+		if err != nil {
+			return err
+		}
+		pk, err := cli.PublicKey(priv)
+		if err != nil {
+			return err
+		}
+		utils.Outf("{{green}}signer:{{/}} %s:%s\n", priv.Type, hex.EncodeToString(pk))
+		return nil
+	},
+}
+
+var addressMultisigCmd = &cobra.Command{
+	Use: "address",
+	RunE: func(*cobra.Command, []string) error {
+		signers, err := parseMultisigSigners(multisigSigners)
+		if err != nil {
+			return err
+		}
+		if multisigThreshold <= 0 || multisigThreshold > len(signers) || len(signers) > auth.MaxMultisigSigners {
+			return cli.ErrInvalidThreshold
+		}
+		policy, err := multisigPolicy(signers)
+		if err != nil {
+			return err
+		}
+		addr, err := auth.NewMultisigAddress(uint8(multisigThreshold), policy)
+		if err != nil {
+			return err
+		}
+		utils.Outf("{{green}}multisig address:{{/}} %s\n", tutils.Address(addr))
+		return nil
+	},
+}
+
+var transferMultisigCmd = &cobra.Command{
+	Use: "transfer [path]",
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return ErrInvalidArgs
+		}
+		return nil
+	},
+	RunE: func(_ *cobra.Command, args []string) error {
+		ctx := context.Background()
+		signers, err := parseMultisigSigners(multisigSigners)
+		if err != nil {
+			return err
+		}
+		if multisigThreshold <= 0 || multisigThreshold > len(signers) || len(signers) > auth.MaxMultisigSigners {
+			return cli.ErrInvalidThreshold
+		}
+		policy, err := multisigPolicy(signers)
+		if err != nil {
+			return err
+		}
+		threshold := uint8(multisigThreshold)
+		addr, err := auth.NewMultisigAddress(threshold, policy)
+		if err != nil {
+			return err
+		}
+		utils.Outf("{{yellow}}multisig address:{{/}} %s\n", tutils.Address(addr))
+		_, _, _, cli, _, tcli, err := handler.DefaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select token to send
+		assetID, err := handler.Root().PromptAsset("assetID", true)
+		if err != nil {
+			return err
+		}
+		_, decimals, balance, _, err := handler.GetAssetInfo(ctx, tcli, addr, assetID, true)
+		if balance == 0 || err != nil {
+			return err
+		}
+
+		// Select recipient
+		recipient, err := handler.Root().PromptAddress("recipient")
+		if err != nil {
+			return err
+		}
+
+		// Select amount
+		amount, err := handler.Root().PromptAmount("amount", decimals, balance, nil)
+		if err != nil {
+			return err
+		}
+
+		// Confirm action
+		cont, err := handler.Root().PromptContinue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate unsigned transaction
+		//
+		// The transaction must be signed and submitted before it expires
+		// (within the validity window of the chain).
+		parser, err := tcli.Parser(ctx)
+		if err != nil {
+			return err
+		}
+		action := &actions.Transfer{
+			To:    recipient,
+			Asset: assetID,
+			Value: amount,
+		}
+		unitPrices, err := cli.UnitPrices(ctx, true)
+		if err != nil {
+			return err
+		}
+		now := time.Now().UnixMilli()
+		rules := parser.Rules(now)
+		maxUnits, err := chain.EstimateMaxUnits(rules, action, auth.NewMultisigFactory(threshold, policy, nil, nil), nil)
+		if err != nil {
+			return err
+		}
+		maxFee, err := chain.MulSum(unitPrices, maxUnits)
+		if err != nil {
+			return err
+		}
+		tx := chain.NewTx(&chain.Base{
+			Timestamp: utils.UnixRMilli(now, rules.GetValidityWindow()),
+			ChainID:   rules.ChainID(),
+			MaxFee:    maxFee,
+		}, nil, action)
+		if err := handler.Root().ExportTx(args[0], tx, threshold, signers); err != nil {
+			return err
+		}
+		utils.Outf("{{green}}exported unsigned tx:{{/}} %s\n", args[0])
+		return nil
+	},
+}
+
+var signMultisigCmd = &cobra.Command{
+	Use: "sign [path]",
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return ErrInvalidArgs
+		}
+		return nil
+	},
+	RunE: func(_ *cobra.Command, args []string) error {
+		// Signatures are collected by signing the digest directly (rather than
+		// with a [chain.AuthFactory])
+		if len(signerURI) > 0 {
+			return ErrSignerNotSupported
+		}
+		ctx := context.Background()
+		_, priv, _, _, _, tcli, err := handler.DefaultActor()
+		if err != nil {
+			return err
+		}
+		parser, err := tcli.Parser(ctx)
+		if err != nil {
+			return err
+		}
+		actionRegistry, _ := parser.Registry()
+
+		// Display transaction before signing it
+		p, err := handler.Root().ImportTx(args[0])
+		if err != nil {
+			return err
+		}
+		tx, err := p.Unsigned(actionRegistry)
+		if err != nil {
+			return err
+		}
+		action, err := json.Marshal(tx.Action)
+		if err != nil {
+			return err
+		}
+		utils.Outf(
+			"{{yellow}}action:{{/}} %d %s {{yellow}}expiry:{{/}} %s {{yellow}}max fee:{{/}} %s\n",
+			tx.Action.GetTypeID(),
+			action,
+			time.UnixMilli(tx.Expiry()),
+			utils.FormatBalance(tx.MaxFee(), tconsts.Decimals),
+		)
+		cont, err := handler.Root().PromptContinue()
+		if !cont || err != nil {
+			return err
+		}
+		return handler.Root().SignTx(args[0], p, actionRegistry, priv)
+	},
+}
+
+var submitMultisigCmd = &cobra.Command{
+	Use: "submit [path]",
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return ErrInvalidArgs
+		}
+		return nil
+	},
+	RunE: func(_ *cobra.Command, args []string) error {
+		ctx := context.Background()
+		_, _, _, _, scli, tcli, err := handler.DefaultActor()
+		if err != nil {
+			return err
+		}
+		parser, err := tcli.Parser(ctx)
+		if err != nil {
+			return err
+		}
+		actionRegistry, authRegistry := parser.Registry()
+		p, err := handler.Root().ImportTx(args[0])
+		if err != nil {
+			return err
+		}
+		tx, err := p.Unsigned(actionRegistry)
+		if err != nil {
+			return err
+		}
+
+		// Construct multisig from collected signatures
+		policy, err := multisigPolicy(p.Signers)
+		if err != nil {
+			return err
+		}
+		factory := auth.NewMultisigFactory(p.Threshold, policy, nil, nil)
+		for i, signer := range p.Signers {
+			if len(signer.Signature) == 0 {
+				continue
+			}
+			if err := factory.AddSignature(policy[i].KeyType, policy[i].Key, signer.Signature); err != nil {
+				return err
+			}
+		}
+		tx, err = tx.Sign(factory, actionRegistry, authRegistry)
+		if err != nil {
+			return err
+		}
+		_, _, err = submitAndWait(ctx, tx, scli, true)
+		return err
+	},
+}
@@ -34,7 +34,13 @@ func sendAndWait(
 	if err != nil {
 		return false, ids.Empty, err
 	}
+	return submitAndWait(ctx, tx, scli, printStatus)
+}
 
+// submitAndWait issues the signed [tx] and waits for its result.
+func submitAndWait(
+	ctx context.Context, tx *chain.Transaction, scli *rpc.WebSocketClient, printStatus bool,
+) (bool, ids.ID, error) {
 	if err := scli.RegisterTx(tx); err != nil {
 		return false, ids.Empty, err
 	}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/ava-labs/hypersdk/cli"
+	"github.com/ava-labs/hypersdk/signer"
 	"github.com/ava-labs/hypersdk/utils"
 	"github.com/spf13/cobra"
 )
@@ -22,6 +23,7 @@ var (
 	handler *Handler
 
 	dbPath                string
+	signerURI             string
 	genesisFile           string
 	minBlockGap           int64
 	minUnitPrice          []string
@@ -38,6 +40,10 @@ var (
 	startPrometheus       bool
 	maxFee                int64
 	numCores              int
+	keyType               string
+	mnemonic              bool
+	multisigThreshold     int
+	multisigSigners       []string
 
 	rootCmd = &cobra.Command{
 		Use:        "token-cli",
@@ -56,6 +62,7 @@ func init() {
 		spamCmd,
 		prometheusCmd,
 		aliasCmd,
+		multisigCmd,
 	)
 	rootCmd.PersistentFlags().StringVar(
 		&dbPath,
@@ -63,6 +70,12 @@ func init() {
 		defaultDatabase,
 		"path to database (will create it missing)",
 	)
+	rootCmd.PersistentFlags().StringVar(
+		&signerURI,
+		"signer",
+		"",
+		"sign with the external signer at this uri (unix://[path] or http://[host]) instead of the default key (its token is read from $"+signer.TokenEnv+")",
+	)
 	rootCmd.PersistentPreRunE = func(*cobra.Command, []string) error {
 		utils.Outf("{{yellow}}database:{{/}} %s\n", dbPath)
 		controller := NewController(dbPath)
@@ -126,9 +139,38 @@ func init() {
 		4,
 		"number of cores to use when searching for faucet solutions",
 	)
+	genKeyCmd.PersistentFlags().StringVar(
+		&keyType,
+		"type",
+		cli.ED25519Key.String(),
+		"type of key to generate (ed25519 or secp256r1)",
+	)
+	genKeyCmd.PersistentFlags().BoolVar(
+		&mnemonic,
+		"mnemonic",
+		false,
+		"generate a mnemonic and derive the key from it",
+	)
+	for _, cmd := range []*cobra.Command{recoverKeyCmd, deriveKeyCmd} {
+		cmd.PersistentFlags().StringVar(
+			&keyType,
+			"type",
+			cli.ED25519Key.String(),
+			"type of key to derive (ed25519 or secp256r1)",
+		)
+	}
+	importKeyCmd.PersistentFlags().StringVar(
+		&keyType,
+		"type",
+		cli.ED25519Key.String(),
+		"type of key to import (ed25519 or secp256r1)",
+	)
 	keyCmd.AddCommand(
 		genKeyCmd,
+		recoverKeyCmd,
+		deriveKeyCmd,
 		importKeyCmd,
+		exportKeyCmd,
 		setKeyCmd,
 		balanceKeyCmd,
 		faucetKeyCmd,
@@ -138,6 +180,29 @@ func init() {
 		addAliasCmd,
 	)
 
+	// multisig
+	for _, cmd := range []*cobra.Command{addressMultisigCmd, transferMultisigCmd} {
+		cmd.PersistentFlags().IntVar(
+			&multisigThreshold,
+			"threshold",
+			1,
+			"number of signers required to authorize a transaction",
+		)
+		cmd.PersistentFlags().StringSliceVar(
+			&multisigSigners,
+			"signers",
+			[]string{},
+			"signers of the multisig (formatted as <type>:<hex public key>)",
+		)
+	}
+	multisigCmd.AddCommand(
+		publicKeyMultisigCmd,
+		addressMultisigCmd,
+		transferMultisigCmd,
+		signMultisigCmd,
+		submitMultisigCmd,
+	)
+
 	// chain
 	watchChainCmd.PersistentFlags().BoolVar(
 		&hideTxs,
@@ -152,6 +217,7 @@ func init() {
 		setChainCmd,
 		chainInfoCmd,
 		watchChainCmd,
+		warpChainCmd,
 	)
 
 	// actions
@@ -8,9 +8,9 @@ import (
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/hypersdk/chain"
+	hcli "github.com/ava-labs/hypersdk/cli"
 	"github.com/ava-labs/hypersdk/crypto/ed25519"
 	"github.com/ava-labs/hypersdk/examples/tokenvm/actions"
-	"github.com/ava-labs/hypersdk/examples/tokenvm/auth"
 	"github.com/ava-labs/hypersdk/examples/tokenvm/consts"
 	trpc "github.com/ava-labs/hypersdk/examples/tokenvm/rpc"
 	"github.com/ava-labs/hypersdk/pubsub"
@@ -45,9 +45,7 @@ var runSpamCmd = &cobra.Command{
 				}
 				sclient = sc
 			},
-			func(pk ed25519.PrivateKey) chain.AuthFactory {
-				return auth.NewED25519Factory(pk)
-			},
+			getFactory,
 			func(choice int, address string) (uint64, error) {
 				balance, err := tclient.Balance(context.TODO(), address, ids.Empty)
 				if err != nil {
@@ -72,12 +70,16 @@ var runSpamCmd = &cobra.Command{
 					Value: amount,
 				}
 			},
-			func(cli *rpc.JSONRPCClient, pk ed25519.PrivateKey) func(context.Context, uint64) error {
+			func(cli *rpc.JSONRPCClient, priv *hcli.PrivateKey) func(context.Context, uint64) error {
 				return func(ictx context.Context, count uint64) error {
-					_, _, err := sendAndWait(ictx, nil, &actions.Transfer{
-						To:    pk.PublicKey(),
+					factory, err := getFactory(priv)
+					if err != nil {
+						return err
+					}
+					_, _, err = sendAndWait(ictx, nil, &actions.Transfer{
+						To:    priv.Address,
 						Value: count, // prevent duplicate txs
-					}, cli, sclient, tclient, auth.NewED25519Factory(pk), false)
+					}, cli, sclient, tclient, factory, false)
 					return err
 				}
 			},
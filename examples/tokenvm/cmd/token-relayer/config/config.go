@@ -0,0 +1,59 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package config
+
+import "github.com/ava-labs/hypersdk/crypto/ed25519"
+
+const (
+	defaultCheckpointPath     = ".token-relayer.json"
+	defaultSignatureThreshold = 80
+	defaultRetryInterval      = 5
+	defaultMaxFee             = 10_000_000 // 0.01 TKN
+)
+
+type Config struct {
+	// PrivateKeyBytes is the key used to issue [ImportAsset] on the
+	// destination. The relayer pays the fee for each import and collects any
+	// [Reward] specified by the transfer.
+	PrivateKeyBytes []byte `json:"privateKeyBytes"`
+
+	SourceRPC      string `json:"sourceRPC"`
+	DestinationRPC string `json:"destinationRPC"`
+
+	// CheckpointPath is where the relayer persists the last processed block
+	// and any transfers that have not yet been imported.
+	CheckpointPath string `json:"checkpointPath"`
+
+	// MinReward is the minimum [Reward] a transfer must offer to be relayed.
+	// If 0, the relayer will pay to import all transfers.
+	MinReward uint64 `json:"minReward"`
+	// MaxFee is the maximum fee the relayer will pay to import a transfer.
+	// If 0, [defaultMaxFee] is used.
+	MaxFee uint64 `json:"maxFee"`
+	// SignatureThreshold is the percentage of source stake that must sign a
+	// message before it is imported.
+	SignatureThreshold uint64 `json:"signatureThreshold"`
+	RetryInterval      int64  `json:"retryInterval"` // seconds
+	MaxAttempts        int    `json:"maxAttempts"`
+}
+
+func (c *Config) PrivateKey() ed25519.PrivateKey {
+	return ed25519.PrivateKey(c.PrivateKeyBytes)
+}
+
+// SetDefaults populates any required fields that were not specified.
+func (c *Config) SetDefaults() {
+	if len(c.CheckpointPath) == 0 {
+		c.CheckpointPath = defaultCheckpointPath
+	}
+	if c.SignatureThreshold == 0 {
+		c.SignatureThreshold = defaultSignatureThreshold
+	}
+	if c.RetryInterval <= 0 {
+		c.RetryInterval = defaultRetryInterval
+	}
+	if c.MaxFee == 0 {
+		c.MaxFee = defaultMaxFee
+	}
+}
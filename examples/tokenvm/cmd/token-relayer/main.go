@@ -0,0 +1,92 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/hypersdk/crypto/ed25519"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/cmd/token-relayer/config"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/cmd/token-relayer/manager"
+	tutils "github.com/ava-labs/hypersdk/examples/tokenvm/utils"
+	"github.com/ava-labs/hypersdk/utils"
+	"go.uber.org/zap"
+)
+
+func fatal(l logging.Logger, msg string, fields ...zap.Field) {
+	l.Fatal(msg, fields...)
+	os.Exit(1)
+}
+
+func main() {
+	logFactory := logging.NewFactory(logging.Config{
+		DisplayLevel: logging.Info,
+	})
+	l, err := logFactory.Make("main")
+	if err != nil {
+		utils.Outf("{{red}}unable to initialize logger{{/}}: %v\n", err)
+		os.Exit(1)
+	}
+	log := l
+
+	// Load config
+	if len(os.Args) != 2 {
+		fatal(log, "no config file specified")
+	}
+	configPath := os.Args[1]
+	rawConfig, err := os.ReadFile(configPath)
+	if err != nil {
+		fatal(log, "cannot open config file", zap.String("path", configPath), zap.Error(err))
+	}
+	var c config.Config
+	if err := json.Unmarshal(rawConfig, &c); err != nil {
+		fatal(log, "cannot read config file", zap.Error(err))
+	}
+	c.SetDefaults()
+
+	// Create private key
+	if len(c.PrivateKeyBytes) == 0 {
+		priv, err := ed25519.GeneratePrivateKey()
+		if err != nil {
+			fatal(log, "cannot generate private key", zap.Error(err))
+		}
+		c.PrivateKeyBytes = priv[:]
+		b, err := json.MarshalIndent(&c, "", "  ")
+		if err != nil {
+			fatal(log, "cannot marshal new config", zap.Error(err))
+		}
+		fi, err := os.Lstat(configPath)
+		if err != nil {
+			fatal(log, "cannot get file stats for config", zap.Error(err))
+		}
+		if err := os.WriteFile(configPath, b, fi.Mode().Perm()); err != nil {
+			fatal(log, "cannot write new config", zap.Error(err))
+		}
+		log.Info("created new relayer address", zap.String("address", tutils.Address(priv.PublicKey())))
+	} else {
+		log.Info("loaded relayer address", zap.String("address", tutils.Address(c.PrivateKey().PublicKey())))
+	}
+
+	// Create manager
+	manager, err := manager.New(log, &c)
+	if err != nil {
+		fatal(log, "cannot create manager", zap.Error(err))
+	}
+
+	// Start relayer
+	ctx, cancel := context.WithCancel(context.Background())
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		sig := <-sigs
+		log.Info("triggering relayer shutdown", zap.Any("signal", sig))
+		cancel()
+	}()
+	log.Info("relayer exited", zap.Error(manager.Run(ctx)))
+}
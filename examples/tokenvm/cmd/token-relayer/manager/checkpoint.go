@@ -0,0 +1,132 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package manager
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"sync"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+// Transfer is an outgoing [ExportAsset] that has not yet been imported on the
+// destination.
+type Transfer struct {
+	TxID        ids.ID `json:"txID"`
+	Height      uint64 `json:"height"`
+	Attempts    int    `json:"attempts"`
+	NextAttempt int64  `json:"nextAttempt"` // unix seconds
+}
+
+// Checkpoint is the state the relayer needs to resume after a restart.
+type Checkpoint struct {
+	path string
+
+	l sync.Mutex
+	// Height is the last source block that was processed.
+	Height  uint64      `json:"height"`
+	Pending []*Transfer `json:"pending"`
+}
+
+// LoadCheckpoint reads the checkpoint at [path] or returns an empty
+// checkpoint if none exists.
+func LoadCheckpoint(path string) (*Checkpoint, error) {
+	c := &Checkpoint{path: path, Pending: []*Transfer{}}
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return c, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(b, c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// LastHeight returns the last source block that was processed.
+func (c *Checkpoint) LastHeight() uint64 {
+	c.l.Lock()
+	defer c.l.Unlock()
+
+	return c.Height
+}
+
+// Accept records that all transfers in the source block at [height] have been
+// added.
+func (c *Checkpoint) Accept(height uint64, transfers []*Transfer) error {
+	c.l.Lock()
+	defer c.l.Unlock()
+
+	c.Height = height
+	c.Pending = append(c.Pending, transfers...)
+	return c.save()
+}
+
+// Ready returns all pending transfers that should be attempted at [now].
+func (c *Checkpoint) Ready(now int64) []*Transfer {
+	c.l.Lock()
+	defer c.l.Unlock()
+
+	ready := []*Transfer{}
+	for _, t := range c.Pending {
+		if t.NextAttempt <= now {
+			ready = append(ready, &Transfer{
+				TxID:        t.TxID,
+				Height:      t.Height,
+				Attempts:    t.Attempts,
+				NextAttempt: t.NextAttempt,
+			})
+		}
+	}
+	return ready
+}
+
+// Retry schedules [txID] to be attempted again at [next].
+func (c *Checkpoint) Retry(txID ids.ID, attempts int, next int64) error {
+	c.l.Lock()
+	defer c.l.Unlock()
+
+	for _, t := range c.Pending {
+		if t.TxID == txID {
+			t.Attempts = attempts
+			t.NextAttempt = next
+			break
+		}
+	}
+	return c.save()
+}
+
+// Remove forgets [txID] because it was imported or abandoned.
+func (c *Checkpoint) Remove(txID ids.ID) error {
+	c.l.Lock()
+	defer c.l.Unlock()
+
+	for i, t := range c.Pending {
+		if t.TxID == txID {
+			c.Pending = append(c.Pending[:i], c.Pending[i+1:]...)
+			break
+		}
+	}
+	return c.save()
+}
+
+// save writes the checkpoint to a temporary file and then renames it so that
+// a crash never leaves a partially written checkpoint.
+//
+// Assumes [c.l] is held.
+func (c *Checkpoint) save() error {
+	b, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := c.path + ".tmp"
+	if err := os.WriteFile(tmp, b, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, c.path)
+}
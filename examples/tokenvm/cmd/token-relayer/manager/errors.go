@@ -0,0 +1,12 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package manager
+
+import "errors"
+
+var (
+	ErrInsufficientSignatures = errors.New("insufficient signature weight")
+	ErrSwapNotExpired         = errors.New("swap not expired")
+	ErrFeeTooHigh             = errors.New("network fee too high")
+)
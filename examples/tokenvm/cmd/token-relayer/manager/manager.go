@@ -0,0 +1,369 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package manager
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/actions"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/auth"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/cmd/token-relayer/config"
+	trpc "github.com/ava-labs/hypersdk/examples/tokenvm/rpc"
+	tutils "github.com/ava-labs/hypersdk/examples/tokenvm/utils"
+	"github.com/ava-labs/hypersdk/pubsub"
+	"github.com/ava-labs/hypersdk/rpc"
+	"go.uber.org/zap"
+)
+
+// importTimeout is how long we wait for an [ImportAsset] transaction to be
+// accepted before trying again.
+const importTimeout = time.Minute
+
+type Manager struct {
+	log        logging.Logger
+	config     *config.Config
+	checkpoint *Checkpoint
+
+	factory chain.AuthFactory
+
+	sourceChainID ids.ID
+	scli          *rpc.JSONRPCClient
+	stcli         *trpc.JSONRPCClient
+
+	destinationChainID ids.ID
+	dcli               *rpc.JSONRPCClient
+	dtcli              *trpc.JSONRPCClient
+}
+
+func New(logger logging.Logger, config *config.Config) (*Manager, error) {
+	ctx := context.TODO()
+	scli := rpc.NewJSONRPCClient(config.SourceRPC)
+	sourceNetworkID, _, sourceChainID, err := scli.Network(ctx)
+	if err != nil {
+		return nil, err
+	}
+	dcli := rpc.NewJSONRPCClient(config.DestinationRPC)
+	destinationNetworkID, _, destinationChainID, err := dcli.Network(ctx)
+	if err != nil {
+		return nil, err
+	}
+	checkpoint, err := LoadCheckpoint(config.CheckpointPath)
+	if err != nil {
+		return nil, err
+	}
+	m := &Manager{
+		log:                logger,
+		config:             config,
+		checkpoint:         checkpoint,
+		factory:            auth.NewED25519Factory(config.PrivateKey()),
+		sourceChainID:      sourceChainID,
+		scli:               scli,
+		stcli:              trpc.NewJSONRPCClient(config.SourceRPC, sourceNetworkID, sourceChainID),
+		destinationChainID: destinationChainID,
+		dcli:               dcli,
+		dtcli:              trpc.NewJSONRPCClient(config.DestinationRPC, destinationNetworkID, destinationChainID),
+	}
+	m.log.Info("relayer initialized",
+		zap.String("address", tutils.Address(config.PrivateKey().PublicKey())),
+		zap.Stringer("source", sourceChainID),
+		zap.Stringer("destination", destinationChainID),
+		zap.Uint64("height", checkpoint.LastHeight()),
+		zap.Int("pending", len(checkpoint.Pending)),
+	)
+	return m, nil
+}
+
+// Run watches the source for accepted [ExportAsset] transactions to the
+// destination and imports them until [ctx] is cancelled.
+func (m *Manager) Run(ctx context.Context) error {
+	parser, err := m.stcli.Parser(ctx)
+	if err != nil {
+		return err
+	}
+	dparser, err := m.dtcli.Parser(ctx)
+	if err != nil {
+		return err
+	}
+	go m.relay(ctx, dparser)
+
+	for ctx.Err() == nil { // handle WS client failure
+		scli, err := rpc.NewWebSocketClient(m.config.SourceRPC, rpc.DefaultHandshakeTimeout, pubsub.MaxPendingMessages, pubsub.MaxReadMessageSize)
+		if err != nil {
+			m.log.Warn("unable to connect to RPC", zap.String("uri", m.config.SourceRPC), zap.Error(err))
+			time.Sleep(10 * time.Second)
+			continue
+		}
+		if err := scli.RegisterBlocks(); err != nil {
+			m.log.Warn("unable to connect to register for blocks", zap.String("uri", m.config.SourceRPC), zap.Error(err))
+			_ = scli.Close()
+			time.Sleep(10 * time.Second)
+			continue
+		}
+
+		// Catch up on any blocks accepted since the checkpoint (we registered
+		// first, so no block is missed between the backfill and the stream)
+		if err := m.catchUp(ctx, parser); err != nil {
+			m.log.Warn("unable to backfill blocks", zap.Error(err))
+			_ = scli.Close()
+			time.Sleep(10 * time.Second)
+			continue
+		}
+		for ctx.Err() == nil {
+			// Listen for blocks
+			blk, results, _, err := scli.ListenBlock(ctx, parser)
+			if err != nil {
+				m.log.Warn("unable to listen for blocks", zap.Error(err))
+				break
+			}
+			if err := m.processBlock(ctx, parser, blk, results); err != nil {
+				m.log.Warn("unable to process block", zap.Uint64("height", blk.Hght), zap.Error(err))
+				break
+			}
+		}
+		_ = scli.Close()
+	}
+	return ctx.Err()
+}
+
+// catchUp backfills all source blocks between the checkpoint and the last
+// accepted block. If there is no checkpoint, we start relaying from the next
+// block streamed.
+func (m *Manager) catchUp(ctx context.Context, parser chain.Parser) error {
+	if m.checkpoint.LastHeight() == 0 {
+		return nil
+	}
+	_, height, _, err := m.scli.Accepted(ctx)
+	if err != nil {
+		return err
+	}
+	return m.backfill(ctx, parser, height)
+}
+
+// processBlock adds all successful transfers to the destination in [blk] to
+// the checkpoint.
+func (m *Manager) processBlock(
+	ctx context.Context,
+	parser chain.Parser,
+	blk *chain.StatefulBlock,
+	results []*chain.Result,
+) error {
+	last := m.checkpoint.LastHeight()
+	if blk.Hght <= last {
+		// We've already processed this block (we may see it again after
+		// reconnecting)
+		return nil
+	}
+	if last > 0 && blk.Hght > last+1 {
+		// Blocks are only streamed as they are accepted, so we must fetch any
+		// blocks we skipped.
+		if err := m.backfill(ctx, parser, blk.Hght-1); err != nil {
+			return err
+		}
+	}
+	transfers := []*Transfer{}
+	for i, tx := range blk.Txs {
+		result := results[i]
+		if !result.Success || result.WarpMessage == nil {
+			continue
+		}
+		if _, ok := tx.Action.(*actions.ExportAsset); !ok {
+			continue
+		}
+		wt, err := actions.UnmarshalWarpTransfer(result.WarpMessage.Payload)
+		if err != nil {
+			m.log.Warn("unable to parse warp transfer", zap.Stringer("txID", tx.ID()), zap.Error(err))
+			continue
+		}
+		if transfer := m.track(tx.ID(), blk.Hght, wt); transfer != nil {
+			transfers = append(transfers, transfer)
+		}
+	}
+	return m.checkpoint.Accept(blk.Hght, transfers)
+}
+
+// backfill adds all transfers to the destination in the source blocks after
+// the checkpoint up to (and including) [height] to the checkpoint.
+//
+// Results are not stored with blocks, so we can't tell whether an
+// [ExportAsset] in a backfilled block succeeded. Failed exports don't produce
+// a warp message and are dropped when we try to import them.
+func (m *Manager) backfill(ctx context.Context, parser chain.Parser, height uint64) error {
+	for h := m.checkpoint.LastHeight() + 1; h <= height; h++ {
+		blk, err := m.scli.GetBlock(ctx, parser, h)
+		if err != nil && strings.Contains(err.Error(), database.ErrNotFound.Error()) {
+			// The source has pruned the block, so any transfers in it must be
+			// imported manually.
+			m.log.Warn("unable to backfill pruned block", zap.Uint64("height", h))
+			if err := m.checkpoint.Accept(h, nil); err != nil {
+				return err
+			}
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		transfers := []*Transfer{}
+		for _, tx := range blk.Txs {
+			action, ok := tx.Action.(*actions.ExportAsset)
+			if !ok {
+				continue
+			}
+			wt := &actions.WarpTransfer{
+				To:                 action.To,
+				Value:              action.Value,
+				Reward:             action.Reward,
+				DestinationChainID: action.Destination,
+			}
+			if transfer := m.track(tx.ID(), h, wt); transfer != nil {
+				transfers = append(transfers, transfer)
+			}
+		}
+		m.log.Info("backfilled block", zap.Uint64("height", h), zap.Int("transfers", len(transfers)))
+		if err := m.checkpoint.Accept(h, transfers); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// track returns the [Transfer] to add to the checkpoint for [wt] or nil if
+// it should not be relayed.
+func (m *Manager) track(txID ids.ID, height uint64, wt *actions.WarpTransfer) *Transfer {
+	if wt.DestinationChainID != m.destinationChainID {
+		return nil
+	}
+	if wt.Reward < m.config.MinReward {
+		m.log.Info("skipping transfer with insufficient reward",
+			zap.Stringer("txID", txID),
+			zap.Uint64("reward", wt.Reward),
+			zap.Uint64("required", m.config.MinReward),
+		)
+		return nil
+	}
+	m.log.Info("found outgoing transfer",
+		zap.Stringer("txID", txID),
+		zap.Uint64("height", height),
+		zap.String("to", tutils.Address(wt.To)),
+		zap.Uint64("value", wt.Value),
+		zap.Uint64("reward", wt.Reward),
+	)
+	return &Transfer{TxID: txID, Height: height}
+}
+
+// relay periodically attempts to import all pending transfers.
+func (m *Manager) relay(ctx context.Context, parser chain.Parser) {
+	t := time.NewTicker(time.Duration(m.config.RetryInterval) * time.Second)
+	defer t.Stop()
+	for {
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			return
+		}
+		now := time.Now().Unix()
+		for _, transfer := range m.checkpoint.Ready(now) {
+			if ctx.Err() != nil {
+				return
+			}
+			next, err := m.importTransfer(ctx, parser, transfer.TxID)
+			if err == nil {
+				if err := m.checkpoint.Remove(transfer.TxID); err != nil {
+					m.log.Error("unable to update checkpoint", zap.Error(err))
+				}
+				continue
+			}
+			attempts := transfer.Attempts + 1
+			if m.config.MaxAttempts > 0 && attempts >= m.config.MaxAttempts {
+				m.log.Warn("abandoning transfer",
+					zap.Stringer("txID", transfer.TxID),
+					zap.Int("attempts", attempts),
+					zap.Error(err),
+				)
+				if err := m.checkpoint.Remove(transfer.TxID); err != nil {
+					m.log.Error("unable to update checkpoint", zap.Error(err))
+				}
+				continue
+			}
+			m.log.Info("unable to import transfer",
+				zap.Stringer("txID", transfer.TxID),
+				zap.Int("attempts", attempts),
+				zap.Error(err),
+			)
+			if next == 0 {
+				next = time.Now().Unix() + m.config.RetryInterval
+			}
+			if err := m.checkpoint.Retry(transfer.TxID, attempts, next); err != nil {
+				m.log.Error("unable to update checkpoint", zap.Error(err))
+			}
+		}
+	}
+}
+
+// importTransfer issues [ImportAsset] on the destination for the message
+// created by [txID]. If the transfer can't be imported yet, it returns an
+// error and (optionally) the earliest time it should be attempted again.
+func (m *Manager) importTransfer(ctx context.Context, parser chain.Parser, txID ids.ID) (int64, error) {
+	msg, weight, sigWeight, err := m.scli.GetAggregateWarpMessage(ctx, txID)
+	if err != nil && strings.Contains(err.Error(), rpc.ErrMessageMissing.Error()) {
+		// The export failed, so there is nothing to import (we only track
+		// failed exports when backfilling)
+		m.log.Warn("transfer has no warp message", zap.Stringer("txID", txID))
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	if sigWeight*100 < weight*m.config.SignatureThreshold {
+		return 0, ErrInsufficientSignatures
+	}
+	wt, err := actions.UnmarshalWarpTransfer(msg.UnsignedMessage.Payload)
+	if err != nil {
+		return 0, err
+	}
+	if wt.SwapIn > 0 && wt.SwapExpiry > time.Now().UnixMilli() {
+		// The relayer never fills swaps, so we must wait until the swap
+		// expires to import the transfer.
+		return wt.SwapExpiry/1000 + 1, ErrSwapNotExpired
+	}
+
+	submit, tx, maxFee, err := m.dcli.GenerateTransaction(ctx, parser, msg, &actions.ImportAsset{}, m.factory)
+	if err != nil {
+		return 0, err
+	}
+	if maxFee > m.config.MaxFee {
+		return 0, ErrFeeTooHigh
+	}
+	if err := submit(ctx); err != nil {
+		return 0, err
+	}
+	wctx, cancel := context.WithTimeout(ctx, importTimeout)
+	defer cancel()
+	success, fee, err := m.dtcli.WaitForTransaction(wctx, tx.ID())
+	if err != nil {
+		return 0, err
+	}
+	if !success {
+		// The message may have already been imported by someone else, so we
+		// don't try again.
+		m.log.Warn("import failed on-chain",
+			zap.Stringer("txID", txID),
+			zap.Stringer("importTxID", tx.ID()),
+			zap.Uint64("fee", fee),
+		)
+		return 0, nil
+	}
+	m.log.Info("imported transfer",
+		zap.Stringer("txID", txID),
+		zap.Stringer("importTxID", tx.ID()),
+		zap.Uint64("fee", fee),
+		zap.Uint64("reward", wt.Reward),
+	)
+	return 0, nil
+}
@@ -0,0 +1,161 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package config
+
+import (
+	"fmt"
+	"net"
+	"os"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/cli"
+	"github.com/ava-labs/hypersdk/crypto"
+	"github.com/ava-labs/hypersdk/crypto/ed25519"
+	"github.com/ava-labs/hypersdk/crypto/secp256r1"
+
+	"github.com/ava-labs/hypersdk/examples/tokenvm/auth"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/cmd/token-signer/policy"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/utils"
+)
+
+const keyMode = 0o600
+
+type Config struct {
+	// Socket is the path of the unix socket to listen on (if empty, the
+	// signer listens on [HTTPHost]:[HTTPPort] instead, which must be a
+	// loopback address).
+	Socket   string `json:"socket"`
+	HTTPHost string `json:"host"`
+	HTTPPort int    `json:"port"`
+
+	// AllowedOrigins are the origins browsers may call the signer from (none
+	// if empty).
+	AllowedOrigins []string `json:"allowedOrigins"`
+
+	// AuthToken is the bearer token clients must present (one is generated
+	// if empty).
+	AuthToken string `json:"authToken"`
+
+	// KeyPath is the path of the signing key, encrypted with a passphrase
+	// (like the keys written by `token-cli key export`). A key of [KeyType]
+	// is generated if it does not exist.
+	KeyPath string `json:"keyPath"`
+	KeyType string `json:"keyType"`
+
+	// PrivateKeyBytes is only read to migrate keys stored in plaintext by
+	// older versions of the signer to [KeyPath].
+	PrivateKeyBytes []byte `json:"privateKeyBytes,omitempty"`
+
+	Policy *policy.Config `json:"policy"`
+}
+
+// Verify returns an error if the signer should not be started with [c].
+func (c *Config) Verify() error {
+	if c.Policy == nil {
+		return ErrMissingPolicy
+	}
+	if len(c.KeyPath) == 0 {
+		return ErrMissingKeyPath
+	}
+	if len(c.Socket) > 0 {
+		return nil
+	}
+	if c.HTTPHost == "localhost" {
+		return nil
+	}
+	if ip := net.ParseIP(c.HTTPHost); ip == nil || !ip.IsLoopback() {
+		return ErrNotLoopback
+	}
+	return nil
+}
+
+// GeneratePrivateKey returns a new key of [KeyType].
+func (c *Config) GeneratePrivateKey() (*cli.PrivateKey, error) {
+	keyType, err := cli.ParseKeyType(c.KeyType)
+	if err != nil {
+		return nil, err
+	}
+	var bytes []byte
+	switch keyType {
+	case cli.ED25519Key:
+		priv, err := ed25519.GeneratePrivateKey()
+		if err != nil {
+			return nil, err
+		}
+		bytes = priv[:]
+	case cli.SECP256R1Key:
+		priv, err := secp256r1.GeneratePrivateKey()
+		if err != nil {
+			return nil, err
+		}
+		bytes = priv[:]
+	}
+	return newPrivateKey(keyType, bytes)
+}
+
+// LegacyPrivateKey returns the plaintext key in [PrivateKeyBytes].
+func (c *Config) LegacyPrivateKey() (*cli.PrivateKey, error) {
+	keyType, err := cli.ParseKeyType(c.KeyType)
+	if err != nil {
+		return nil, err
+	}
+	return newPrivateKey(keyType, c.PrivateKeyBytes)
+}
+
+// StorePrivateKey encrypts [priv] with [passphrase] and writes it to
+// [KeyPath] (which must not exist).
+func (c *Config) StorePrivateKey(priv *cli.PrivateKey, passphrase string) error {
+	data, err := cli.EncryptKey(priv, utils.Address(priv.Address), passphrase)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(c.KeyPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, keyMode)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// LoadPrivateKey decrypts the key at [KeyPath] with [passphrase].
+func (c *Config) LoadPrivateKey(passphrase string) (*cli.PrivateKey, error) {
+	data, err := os.ReadFile(c.KeyPath)
+	if err != nil {
+		return nil, err
+	}
+	keyType, bytes, err := cli.DecryptKey(data, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	return newPrivateKey(keyType, bytes)
+}
+
+func newPrivateKey(keyType cli.KeyType, bytes []byte) (*cli.PrivateKey, error) {
+	switch keyType {
+	case cli.ED25519Key:
+		if len(bytes) != ed25519.PrivateKeyLen {
+			return nil, crypto.ErrInvalidPrivateKey
+		}
+		return &cli.PrivateKey{Type: keyType, Bytes: bytes, Address: ed25519.PrivateKey(bytes).PublicKey()}, nil
+	case cli.SECP256R1Key:
+		if len(bytes) != secp256r1.PrivateKeyLen {
+			return nil, crypto.ErrInvalidPrivateKey
+		}
+		pk := secp256r1.PrivateKey(bytes).PublicKey()
+		return &cli.PrivateKey{Type: keyType, Bytes: bytes, Address: auth.NewSECP256R1Address(pk)}, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", cli.ErrUnknownKeyType, keyType)
+	}
+}
+
+// Factory returns the [chain.AuthFactory] of [priv].
+func Factory(priv *cli.PrivateKey) chain.AuthFactory {
+	if priv.Type == cli.ED25519Key {
+		return auth.NewED25519Factory(ed25519.PrivateKey(priv.Bytes))
+	}
+	return auth.NewSECP256R1Factory(secp256r1.PrivateKey(priv.Bytes))
+}
@@ -0,0 +1,12 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package config
+
+import "errors"
+
+var (
+	ErrMissingPolicy  = errors.New("no policy specified")
+	ErrMissingKeyPath = errors.New("no key path specified")
+	ErrNotLoopback    = errors.New("host must be a loopback address")
+)
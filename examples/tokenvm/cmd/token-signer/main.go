@@ -0,0 +1,204 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/hypersdk/cli"
+	"github.com/ava-labs/hypersdk/server"
+	"github.com/ava-labs/hypersdk/signer"
+	"github.com/ava-labs/hypersdk/utils"
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/hypersdk/examples/tokenvm/cmd/token-signer/config"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/cmd/token-signer/policy"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/consts"
+	_ "github.com/ava-labs/hypersdk/examples/tokenvm/registry" // ensure registry populated
+	tutils "github.com/ava-labs/hypersdk/examples/tokenvm/utils"
+)
+
+const socketMode = 0o600
+
+var (
+	// Only requests addressed to localhost (or an IP) are served, so a
+	// website can't reach the signer by rebinding its DNS name to a loopback
+	// address.
+	allowedHosts    = []string{"localhost"}
+	shutdownTimeout = 30 * time.Second
+	httpConfig      = server.HTTPConfig{
+		ReadTimeout:       30 * time.Second,
+		ReadHeaderTimeout: 30 * time.Second,
+		WriteTimeout:      30 * time.Second,
+		IdleTimeout:       120 * time.Second,
+	}
+)
+
+func fatal(l logging.Logger, msg string, fields ...zap.Field) {
+	l.Fatal(msg, fields...)
+	os.Exit(1)
+}
+
+// getPassphrase returns the passphrase of the key from [cli.PassphraseEnv] (or
+// prompts for it, twice if [confirm] is true).
+func getPassphrase(confirm bool) (string, error) {
+	if passphrase := os.Getenv(cli.PassphraseEnv); len(passphrase) > 0 {
+		return passphrase, nil
+	}
+	return new(cli.Handler).PromptPassphrase("key passphrase", confirm)
+}
+
+func main() {
+	logFactory := logging.NewFactory(logging.Config{
+		DisplayLevel: logging.Info,
+	})
+	l, err := logFactory.Make("main")
+	if err != nil {
+		utils.Outf("{{red}}unable to initialize logger{{/}}: %v\n", err)
+		os.Exit(1)
+	}
+	log := l
+
+	// Load config
+	if len(os.Args) != 2 {
+		fatal(log, "no config file specified")
+	}
+	configPath := os.Args[1]
+	rawConfig, err := os.ReadFile(configPath)
+	if err != nil {
+		fatal(log, "cannot open config file", zap.String("path", configPath), zap.Error(err))
+	}
+	var c config.Config
+	if err := json.Unmarshal(rawConfig, &c); err != nil {
+		fatal(log, "cannot read config file", zap.Error(err))
+	}
+	if err := c.Verify(); err != nil {
+		fatal(log, "invalid config", zap.Error(err))
+	}
+
+	// Load private key
+	//
+	// Keys are only stored encrypted, so a key is generated (or migrated from
+	// the plaintext key stored in the config by older versions) and encrypted
+	// to [c.KeyPath] if it does not exist.
+	_, err = os.Stat(c.KeyPath)
+	missingKey := errors.Is(err, os.ErrNotExist)
+	if err != nil && !missingKey {
+		fatal(log, "cannot get file stats for key", zap.Error(err))
+	}
+	if !missingKey && len(c.PrivateKeyBytes) > 0 {
+		fatal(log, "plaintext private key must be removed from config", zap.String("keyPath", c.KeyPath))
+	}
+	passphrase, err := getPassphrase(missingKey)
+	if err != nil {
+		fatal(log, "cannot read passphrase", zap.Error(err))
+	}
+	var updateConfig bool
+	if missingKey {
+		var priv *cli.PrivateKey
+		if len(c.PrivateKeyBytes) > 0 {
+			priv, err = c.LegacyPrivateKey()
+		} else {
+			priv, err = c.GeneratePrivateKey()
+		}
+		if err != nil {
+			fatal(log, "cannot create private key", zap.Error(err))
+		}
+		if err := c.StorePrivateKey(priv, passphrase); err != nil {
+			fatal(log, "cannot store private key", zap.Error(err))
+		}
+		log.Info("stored encrypted private key", zap.String("keyPath", c.KeyPath))
+		updateConfig = len(c.PrivateKeyBytes) > 0
+		c.PrivateKeyBytes = nil
+	}
+	priv, err := c.LoadPrivateKey(passphrase)
+	if err != nil {
+		fatal(log, "cannot load private key", zap.Error(err))
+	}
+	log.Info("loaded signer address", zap.String("address", tutils.Address(priv.Address)))
+
+	// Create auth token
+	if len(c.AuthToken) == 0 {
+		c.AuthToken, err = signer.GenerateToken()
+		if err != nil {
+			fatal(log, "cannot generate auth token", zap.Error(err))
+		}
+		updateConfig = true
+	}
+	if updateConfig {
+		b, err := json.MarshalIndent(&c, "", "  ")
+		if err != nil {
+			fatal(log, "cannot marshal new config", zap.Error(err))
+		}
+		fi, err := os.Lstat(configPath)
+		if err != nil {
+			fatal(log, "cannot get file stats for config", zap.Error(err))
+		}
+		if err := os.WriteFile(configPath, b, fi.Mode().Perm()); err != nil {
+			fatal(log, "cannot write new config", zap.Error(err))
+		}
+	}
+
+	// Create server
+	//
+	// Only the owner of a unix socket can connect to it, so it should be
+	// preferred over a TCP listener (which any local process can connect to).
+	var listener net.Listener
+	if len(c.Socket) > 0 {
+		if err := os.Remove(c.Socket); err != nil && !errors.Is(err, os.ErrNotExist) {
+			fatal(log, "cannot remove stale socket", zap.Error(err))
+		}
+		listener, err = net.Listen("unix", c.Socket)
+		if err == nil {
+			err = os.Chmod(c.Socket, socketMode)
+		}
+	} else {
+		listenAddress := net.JoinHostPort(c.HTTPHost, fmt.Sprintf("%d", c.HTTPPort))
+		listener, err = net.Listen("tcp", listenAddress)
+	}
+	if err != nil {
+		fatal(log, "cannot create listener", zap.Error(err))
+	}
+	srv, err := server.New("", log, listener, httpConfig, c.AllowedOrigins, allowedHosts, shutdownTimeout)
+	if err != nil {
+		fatal(log, "cannot create server", zap.Error(err))
+	}
+
+	// Add signer handler
+	s := signer.New(log, consts.ActionRegistry, config.Factory(priv), priv.Address, policy.New(c.Policy))
+	handler, err := server.NewHandler(signer.NewJSONRPCServer(s), signer.Name)
+	if err != nil {
+		fatal(log, "cannot create handler", zap.Error(err))
+	}
+	handler, err = signer.RequireToken(handler, c.AuthToken)
+	if err != nil {
+		fatal(log, "cannot create handler", zap.Error(err))
+	}
+	if err := srv.AddRoute(&common.HTTPHandler{
+		LockOptions: common.NoLock,
+		Handler:     handler,
+	}, &sync.RWMutex{}, signer.Name, ""); err != nil {
+		fatal(log, "cannot add signer route", zap.Error(err))
+	}
+
+	// Start server
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		sig := <-sigs
+		log.Info("triggering server shutdown", zap.Any("signal", sig))
+		_ = srv.Shutdown()
+	}()
+	log.Info("server exited", zap.Error(srv.Dispatch()))
+}
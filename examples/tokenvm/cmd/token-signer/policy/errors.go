@@ -0,0 +1,14 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package policy
+
+import "errors"
+
+var (
+	ErrWrongChain          = errors.New("wrong chain")
+	ErrActionNotAllowed    = errors.New("action not allowed")
+	ErrFeeTooHigh          = errors.New("fee too high")
+	ErrSpendLimitExceeded  = errors.New("spend limit exceeded")
+	ErrWindowLimitExceeded = errors.New("window spend limit exceeded")
+)
@@ -0,0 +1,169 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package policy
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/signer"
+
+	"github.com/ava-labs/hypersdk/examples/tokenvm/auth"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/storage"
+)
+
+var _ signer.Policy = (*Policy)(nil)
+
+type Config struct {
+	// ChainID is the only chain transactions are signed for (if not empty).
+	ChainID ids.ID `json:"chainID"`
+
+	// AllowedActions are the type IDs of the actions that are signed.
+	AllowedActions []uint8 `json:"allowedActions"`
+
+	// MaxFee is the largest fee a transaction may pay (unlimited if 0).
+	MaxFee uint64 `json:"maxFee"`
+
+	// SpendLimits are the most of each asset a transaction may spend (assets
+	// without a limit may not be spent).
+	SpendLimits []*storage.SpendLimit `json:"spendLimits"`
+
+	// Window is the period (in seconds) over which [WindowSpendLimits] apply.
+	// If 0, they apply to all transactions signed since the signer started
+	// (spends are not persisted, so they are reset when the signer restarts).
+	Window int64 `json:"window"`
+
+	// WindowSpendLimits are the most of each asset that all transactions
+	// signed in the last [Window] may spend together (including the fees they
+	// may pay, which are counted against the native asset). Assets without a
+	// limit are only limited by [SpendLimits].
+	WindowSpendLimits []*storage.SpendLimit `json:"windowSpendLimits"`
+}
+
+// spend is the amount of each asset a signed transaction may spend.
+type spend struct {
+	time    time.Time
+	amounts map[ids.ID]uint64
+}
+
+// Policy only allows the transactions that satisfy its [Config] to be
+// signed (mirroring the restrictions of a [auth.Session]).
+type Policy struct {
+	c     *Config
+	clock mockable.Clock
+
+	// spends are the transactions signed in the current window (oldest first)
+	// and spent are their total spends.
+	l      sync.Mutex
+	spends []*spend
+	spent  map[ids.ID]uint64
+}
+
+func New(c *Config) *Policy {
+	return &Policy{c: c, spent: map[ids.ID]uint64{}}
+}
+
+func (p *Policy) Allow(tx *chain.Transaction) error {
+	if p.c.ChainID != ids.Empty && tx.Base.ChainID != p.c.ChainID {
+		return fmt.Errorf("%w: %s", ErrWrongChain, tx.Base.ChainID)
+	}
+	typeID := tx.Action.GetTypeID()
+	if !p.allows(typeID) {
+		return fmt.Errorf("%w: %d", ErrActionNotAllowed, typeID)
+	}
+	if p.c.MaxFee > 0 && tx.MaxFee() > p.c.MaxFee {
+		return fmt.Errorf("%w: %d > %d", ErrFeeTooHigh, tx.MaxFee(), p.c.MaxFee)
+	}
+	var spends map[ids.ID]uint64
+	if spender, ok := tx.Action.(auth.Spender); ok {
+		spends = spender.Spends()
+		for asset, amount := range spends {
+			if limit, _ := findLimit(p.c.SpendLimits, asset); amount > limit {
+				return fmt.Errorf("%w: %d > %d of %s", ErrSpendLimitExceeded, amount, limit, asset)
+			}
+		}
+	}
+	return p.spend(tx.MaxFee(), spends)
+}
+
+// spend records the spends of a transaction (and the most fee it may pay) if
+// they don't exceed the [WindowSpendLimits].
+//
+// Spends are recorded when a transaction is signed (not when it is accepted),
+// so transactions that are never included still count against the limits
+// until they fall out of the window.
+func (p *Policy) spend(fee uint64, spends map[ids.ID]uint64) error {
+	if len(p.c.WindowSpendLimits) == 0 {
+		return nil
+	}
+
+	p.l.Lock()
+	defer p.l.Unlock()
+
+	now := p.clock.Time()
+	if p.c.Window > 0 {
+		start := now.Add(-time.Duration(p.c.Window) * time.Second)
+		var expired int
+		for _, s := range p.spends {
+			if s.time.After(start) {
+				break
+			}
+			for asset, amount := range s.amounts {
+				p.spent[asset] -= amount
+			}
+			expired++
+		}
+		p.spends = p.spends[expired:]
+	}
+
+	// Only the assets with a limit are tracked
+	amounts := map[ids.ID]uint64{}
+	for asset, amount := range spends {
+		if _, ok := findLimit(p.c.WindowSpendLimits, asset); ok {
+			amounts[asset] = amount
+		}
+	}
+	if _, ok := findLimit(p.c.WindowSpendLimits, ids.Empty); ok {
+		nativeAmount, err := math.Add64(amounts[ids.Empty], fee)
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrWindowLimitExceeded, err) //nolint:errorlint
+		}
+		amounts[ids.Empty] = nativeAmount
+	}
+	for asset, amount := range amounts {
+		limit, _ := findLimit(p.c.WindowSpendLimits, asset)
+		if total, err := math.Add64(p.spent[asset], amount); err != nil || total > limit {
+			return fmt.Errorf("%w: %d + %d > %d of %s", ErrWindowLimitExceeded, p.spent[asset], amount, limit, asset)
+		}
+	}
+	for asset, amount := range amounts {
+		p.spent[asset] += amount
+	}
+	p.spends = append(p.spends, &spend{now, amounts})
+	return nil
+}
+
+func (p *Policy) allows(action uint8) bool {
+	for _, allowed := range p.c.AllowedActions {
+		if allowed == action {
+			return true
+		}
+	}
+	return false
+}
+
+// findLimit returns the limit on [asset] in [limits] (and whether there is one).
+func findLimit(limits []*storage.SpendLimit, asset ids.ID) (uint64, bool) {
+	for _, spendLimit := range limits {
+		if spendLimit.Asset == asset {
+			return spendLimit.Amount, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,115 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package policy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/chain"
+
+	"github.com/ava-labs/hypersdk/examples/tokenvm/actions"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/storage"
+)
+
+func newTransfer(chainID ids.ID, asset ids.ID, value uint64, maxFee uint64) *chain.Transaction {
+	return chain.NewTx(
+		&chain.Base{ChainID: chainID, MaxFee: maxFee},
+		nil,
+		&actions.Transfer{Asset: asset, Value: value},
+	)
+}
+
+func TestPolicyAllow(t *testing.T) {
+	var (
+		chainID = ids.GenerateTestID()
+		asset   = ids.GenerateTestID()
+		p       = New(&Config{
+			ChainID:        chainID,
+			AllowedActions: []uint8{(&actions.Transfer{}).GetTypeID()},
+			MaxFee:         100,
+			SpendLimits:    []*storage.SpendLimit{{Asset: ids.Empty, Amount: 1_000}},
+		})
+	)
+	tests := []struct {
+		name    string
+		tx      *chain.Transaction
+		wantErr error
+	}{
+		{name: "allowed", tx: newTransfer(chainID, ids.Empty, 1_000, 100)},
+		{name: "wrong chain", tx: newTransfer(ids.GenerateTestID(), ids.Empty, 1, 1), wantErr: ErrWrongChain},
+		{
+			name:    "action not allowed",
+			tx:      chain.NewTx(&chain.Base{ChainID: chainID}, nil, &actions.CreateAsset{}),
+			wantErr: ErrActionNotAllowed,
+		},
+		{name: "fee too high", tx: newTransfer(chainID, ids.Empty, 1, 101), wantErr: ErrFeeTooHigh},
+		{name: "spend too high", tx: newTransfer(chainID, ids.Empty, 1_001, 1), wantErr: ErrSpendLimitExceeded},
+		{name: "asset without limit", tx: newTransfer(chainID, asset, 1, 1), wantErr: ErrSpendLimitExceeded},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.ErrorIs(t, p.Allow(tt.tx), tt.wantErr)
+		})
+	}
+}
+
+func TestPolicyWindowSpendLimits(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		chainID = ids.GenerateTestID()
+		asset   = ids.GenerateTestID()
+		p       = New(&Config{
+			ChainID:        chainID,
+			AllowedActions: []uint8{(&actions.Transfer{}).GetTypeID()},
+			SpendLimits: []*storage.SpendLimit{
+				{Asset: ids.Empty, Amount: 1_000},
+				{Asset: asset, Amount: 1_000},
+			},
+			Window:            60,
+			WindowSpendLimits: []*storage.SpendLimit{{Asset: ids.Empty, Amount: 1_500}},
+		})
+		now = time.Unix(1_000, 0)
+	)
+	p.clock.Set(now)
+
+	// Fees are counted against the native asset
+	require.NoError(p.Allow(newTransfer(chainID, ids.Empty, 1_000, 100)))
+	require.ErrorIs(p.Allow(newTransfer(chainID, ids.Empty, 400, 1)), ErrWindowLimitExceeded)
+	require.NoError(p.Allow(newTransfer(chainID, ids.Empty, 200, 100)))
+
+	// Assets without a window limit are only limited per transaction
+	for i := 0; i < 3; i++ {
+		require.NoError(p.Allow(newTransfer(chainID, asset, 1_000, 0)))
+	}
+
+	// Transactions that were not signed are not counted
+	require.ErrorIs(p.Allow(newTransfer(chainID, ids.Empty, 1_001, 0)), ErrSpendLimitExceeded)
+	require.NoError(p.Allow(newTransfer(chainID, ids.Empty, 100, 0)))
+	require.ErrorIs(p.Allow(newTransfer(chainID, ids.Empty, 1, 0)), ErrWindowLimitExceeded)
+
+	// Spends no longer count once they fall out of the window
+	p.clock.Set(now.Add(59 * time.Second))
+	require.ErrorIs(p.Allow(newTransfer(chainID, ids.Empty, 1, 0)), ErrWindowLimitExceeded)
+	p.clock.Set(now.Add(60 * time.Second))
+	require.NoError(p.Allow(newTransfer(chainID, ids.Empty, 1_000, 0)))
+	require.NoError(p.Allow(newTransfer(chainID, ids.Empty, 500, 0)))
+	require.ErrorIs(p.Allow(newTransfer(chainID, ids.Empty, 1, 0)), ErrWindowLimitExceeded)
+
+	// Without a window, spends are limited in total
+	p = New(&Config{
+		ChainID:           chainID,
+		AllowedActions:    []uint8{(&actions.Transfer{}).GetTypeID()},
+		SpendLimits:       []*storage.SpendLimit{{Asset: ids.Empty, Amount: 1_000}},
+		WindowSpendLimits: []*storage.SpendLimit{{Asset: ids.Empty, Amount: 1_000}},
+	})
+	p.clock.Set(now)
+	require.NoError(p.Allow(newTransfer(chainID, ids.Empty, 1_000, 0)))
+	p.clock.Set(now.Add(365 * 24 * time.Hour))
+	require.ErrorIs(p.Allow(newTransfer(chainID, ids.Empty, 1, 0)), ErrWindowLimitExceeded)
+}
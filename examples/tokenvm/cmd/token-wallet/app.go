@@ -165,3 +165,27 @@ func (a *App) GetCommitHash() string {
 	}
 	return ""
 }
+
+func (a *App) Initialized() (bool, error) {
+	return a.b.Initialized()
+}
+
+func (a *App) Unlock(passphrase string) error {
+	return a.b.Unlock(passphrase)
+}
+
+func (a *App) Lock() {
+	a.b.Lock()
+}
+
+func (a *App) Locked() bool {
+	return a.b.Locked()
+}
+
+func (a *App) ExportKey(passphrase string) (string, error) {
+	return a.b.ExportKey(passphrase)
+}
+
+func (a *App) ImportKey(key string, passphrase string) error {
+	return a.b.ImportKey(key, passphrase)
+}
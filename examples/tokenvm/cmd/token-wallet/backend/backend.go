@@ -28,6 +28,7 @@ import (
 	hcli "github.com/ava-labs/hypersdk/cli"
 	"github.com/ava-labs/hypersdk/consts"
 	"github.com/ava-labs/hypersdk/crypto/ed25519"
+	"github.com/ava-labs/hypersdk/crypto/secp256r1"
 	"github.com/ava-labs/hypersdk/examples/tokenvm/actions"
 	"github.com/ava-labs/hypersdk/examples/tokenvm/auth"
 	"github.com/ava-labs/hypersdk/examples/tokenvm/challenge"
@@ -55,8 +56,8 @@ type Backend struct {
 	s *Storage
 	c *Config
 
-	priv    ed25519.PrivateKey
-	factory *auth.ED25519Factory
+	keyLock sync.Mutex
+	factory chain.AuthFactory
 	pk      ed25519.PublicKey
 	addr    string
 
@@ -113,33 +114,6 @@ func (b *Backend) Start(ctx context.Context) error {
 	}
 	b.s = s
 
-	// Generate key
-	key, err := s.GetKey()
-	if err != nil {
-		return err
-	}
-	if key == ed25519.EmptyPrivateKey {
-		// TODO: encrypt key
-		priv, err := ed25519.GeneratePrivateKey()
-		if err != nil {
-			return err
-		}
-		if err := s.StoreKey(priv); err != nil {
-			return err
-		}
-		key = priv
-	}
-	b.priv = key
-	b.factory = auth.NewED25519Factory(b.priv)
-	b.pk = b.priv.PublicKey()
-	b.addr = utils.Address(b.pk)
-	if err := b.AddAddressBook("Me", b.addr); err != nil {
-		return err
-	}
-	if err := b.s.StoreAsset(ids.Empty, false); err != nil {
-		return err
-	}
-
 	// Open config
 	configPath := path.Join(homeDir, configFile)
 	rawConifg, err := os.ReadFile(configPath)
@@ -180,12 +154,142 @@ func (b *Backend) Start(ctx context.Context) error {
 	b.fcli = frpc.NewJSONRPCClient(b.c.FaucetRPC)
 	b.fecli = ferpc.NewJSONRPCClient(b.c.FeedRPC)
 
+	// Start fetching URLs (blocks are fetched once the wallet is unlocked and
+	// its key is loaded)
+	go b.parseURLs()
+	return nil
+}
+
+// Initialized returns whether the passphrase of the wallet has been set (if
+// not, it is set by the first call to [Unlock]).
+func (b *Backend) Initialized() (bool, error) {
+	return b.s.Keystore().Initialized()
+}
+
+// Unlock unlocks the keystore of the wallet with [passphrase].
+//
+// If the keystore has not been initialized, [passphrase] is set as its
+// passphrase and the wallet key is stored in it (either the plaintext key of
+// an older version of the wallet or a newly generated key).
+func (b *Backend) Unlock(passphrase string) error {
+	ks := b.s.Keystore()
+	initialized, err := ks.Initialized()
+	if err != nil {
+		return err
+	}
+	if initialized {
+		if err := ks.Unlock(passphrase); err != nil {
+			return err
+		}
+		return b.loadKey()
+	}
+	keyType, key, err := b.s.GetPlaintextKey()
+	if err != nil {
+		return err
+	}
+	if key == nil {
+		keyType, key, err = generateKey(b.c.KeyType)
+		if err != nil {
+			return err
+		}
+	}
+	return b.initializeKeystore(passphrase, keyType, key)
+}
+
+func (b *Backend) initializeKeystore(passphrase string, keyType hcli.KeyType, key []byte) error {
+	address, err := keyAddress(keyType, key)
+	if err != nil {
+		return err
+	}
+	ks := b.s.Keystore()
+	if err := ks.Initialize(passphrase); err != nil {
+		return err
+	}
+	if err := ks.Put(&hcli.PrivateKey{Type: keyType, Bytes: key, Address: address}); err != nil {
+		return err
+	}
+	if err := b.s.DeletePlaintextKey(); err != nil {
+		return err
+	}
+	return b.loadKey()
+}
+
+// loadKey loads the (public) details of the key in the keystore and starts
+// tracking its activity (if it has not been loaded already).
+func (b *Backend) loadKey() error {
+	b.keyLock.Lock()
+	defer b.keyLock.Unlock()
+
+	if b.factory != nil {
+		return nil
+	}
+	addresses, keyTypes, err := b.s.Keystore().Addresses()
+	if err != nil {
+		return err
+	}
+	if len(addresses) == 0 {
+		return hcli.ErrNoKeys
+	}
+	b.pk = addresses[0]
+	b.addr = utils.Address(b.pk)
+	if err := b.AddAddressBook("Me", b.addr); err != nil {
+		return err
+	}
+	if err := b.s.StoreAsset(ids.Empty, false); err != nil {
+		return err
+	}
+	b.factory = &keystoreFactory{b.s.Keystore(), b.pk, keyTypes[0]}
+
 	// Start fetching blocks
 	go b.collectBlocks()
-	go b.parseURLs()
 	return nil
 }
 
+// Lock locks the keystore of the wallet (after which no transactions can be
+// signed until it is unlocked again).
+func (b *Backend) Lock() {
+	b.s.Keystore().Lock()
+}
+
+func (b *Backend) Locked() bool {
+	return b.s.Keystore().Locked()
+}
+
+// ExportKey returns the key of the wallet encrypted with [passphrase] (see
+// [hcli.EncryptKey]).
+func (b *Backend) ExportKey(passphrase string) (string, error) {
+	priv, err := b.s.Keystore().Get(b.pk)
+	if err != nil {
+		return "", err
+	}
+	if priv == nil {
+		return "", hcli.ErrNoKeys
+	}
+	data, err := hcli.EncryptKey(priv, b.addr, passphrase)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// ImportKey uses [key] (encrypted with [passphrase] by [hcli.EncryptKey]) as
+// the key of the wallet and sets [passphrase] as the passphrase of the
+// wallet. This is only possible before the wallet has been initialized.
+func (b *Backend) ImportKey(key string, passphrase string) error {
+	initialized, err := b.s.Keystore().Initialized()
+	if err != nil {
+		return err
+	}
+	if initialized {
+		return hcli.ErrKeystoreInitialized
+	}
+	keyType, bytes, err := hcli.DecryptKey([]byte(key), passphrase)
+	if err != nil {
+		return err
+	}
+	return b.initializeKeystore(passphrase, keyType, bytes)
+}
+
 func (b *Backend) collectBlocks() {
 	if err := b.scli.RegisterBlocks(); err != nil {
 		b.fatal(err)
@@ -1424,3 +1528,30 @@ func (b *Backend) Message(message string, url string) error {
 	}
 	return nil
 }
+
+// generateKey creates a new private key of [keyType] (defaulting to ed25519).
+func generateKey(keyType string) (hcli.KeyType, []byte, error) {
+	if len(keyType) == 0 {
+		keyType = hcli.ED25519Key.String()
+	}
+	t, err := hcli.ParseKeyType(keyType)
+	if err != nil {
+		return 0, nil, err
+	}
+	switch t {
+	case hcli.ED25519Key:
+		priv, err := ed25519.GeneratePrivateKey()
+		if err != nil {
+			return 0, nil, err
+		}
+		return t, priv[:], nil
+	case hcli.SECP256R1Key:
+		priv, err := secp256r1.GeneratePrivateKey()
+		if err != nil {
+			return 0, nil, err
+		}
+		return t, priv[:], nil
+	default:
+		return 0, nil, hcli.ErrUnknownKeyType
+	}
+}
@@ -8,4 +8,8 @@ type Config struct {
 	FaucetRPC   string `json:"faucetRPC"`
 	SearchCores int    `json:"searchCores"`
 	FeedRPC     string `json:"feedRPC"`
+
+	// KeyType is the type of key generated when the wallet is first started
+	// ("ed25519" or "secp256r1"). Defaults to "ed25519".
+	KeyType string `json:"keyType"`
 }
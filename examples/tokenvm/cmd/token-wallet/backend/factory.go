@@ -0,0 +1,80 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package backend
+
+import (
+	"github.com/ava-labs/hypersdk/chain"
+	hcli "github.com/ava-labs/hypersdk/cli"
+	"github.com/ava-labs/hypersdk/crypto/ed25519"
+	"github.com/ava-labs/hypersdk/crypto/secp256r1"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/auth"
+)
+
+var _ chain.AuthFactory = (*keystoreFactory)(nil)
+
+// keystoreFactory signs with a key held in an [hcli.Keystore], so that no
+// transactions can be signed while the keystore is locked.
+type keystoreFactory struct {
+	ks      *hcli.Keystore
+	address ed25519.PublicKey
+	keyType hcli.KeyType
+}
+
+func (k *keystoreFactory) Sign(msg []byte, action chain.Action) (chain.Auth, error) {
+	priv, err := k.ks.Get(k.address)
+	if err != nil {
+		return nil, err
+	}
+	if priv == nil {
+		return nil, hcli.ErrNoKeys
+	}
+	factory, err := newAuthFactory(priv.Type, priv.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	return factory.Sign(msg, action)
+}
+
+func (k *keystoreFactory) MaxUnits() (uint64, uint64, []uint16) {
+	// The units used by a factory don't depend on its key
+	factory, err := newAuthFactory(k.keyType, nil)
+	if err != nil {
+		return 0, 0, nil
+	}
+	return factory.MaxUnits()
+}
+
+// newAuthFactory returns a [chain.AuthFactory] for [key] of [keyType].
+func newAuthFactory(keyType hcli.KeyType, key []byte) (chain.AuthFactory, error) {
+	switch keyType {
+	case hcli.ED25519Key:
+		var priv ed25519.PrivateKey
+		copy(priv[:], key)
+		return auth.NewED25519Factory(priv), nil
+	case hcli.SECP256R1Key:
+		var priv secp256r1.PrivateKey
+		copy(priv[:], key)
+		return auth.NewSECP256R1Factory(priv), nil
+	default:
+		return nil, hcli.ErrUnknownKeyType
+	}
+}
+
+// keyAddress returns the account controlled by [key] of [keyType].
+func keyAddress(keyType hcli.KeyType, key []byte) (ed25519.PublicKey, error) {
+	switch keyType {
+	case hcli.ED25519Key:
+		if len(key) != ed25519.PrivateKeyLen {
+			return ed25519.EmptyPublicKey, hcli.ErrInvalidKey
+		}
+		return ed25519.PrivateKey(key).PublicKey(), nil
+	case hcli.SECP256R1Key:
+		if len(key) != secp256r1.PrivateKeyLen {
+			return ed25519.EmptyPublicKey, hcli.ErrInvalidKey
+		}
+		return auth.NewSECP256R1Address(secp256r1.PrivateKey(key).PublicKey()), nil
+	default:
+		return ed25519.EmptyPublicKey, hcli.ErrUnknownKeyType
+	}
+}
@@ -12,6 +12,7 @@ import (
 
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/ids"
+	hcli "github.com/ava-labs/hypersdk/cli"
 	"github.com/ava-labs/hypersdk/consts"
 	"github.com/ava-labs/hypersdk/crypto/ed25519"
 	tconsts "github.com/ava-labs/hypersdk/examples/tokenvm/consts"
@@ -21,16 +22,18 @@ import (
 )
 
 const (
-	keyPrefix         = 0x0
+	keyPrefix         = 0x0 // deprecated: keys are now stored in [keystorePrefix]
 	assetsPrefix      = 0x1
 	transactionPrefix = 0x2
 	searchPrefix      = 0x3
 	addressPrefix     = 0x4
 	orderPrefix       = 0x5
+	keystorePrefix    = 0x6
 )
 
 type Storage struct {
 	db database.Database
+	ks *hcli.Keystore
 }
 
 func OpenStorage(databasePath string) (*Storage, error) {
@@ -38,29 +41,37 @@ func OpenStorage(databasePath string) (*Storage, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &Storage{db}, nil
+	return &Storage{db, hcli.NewKeystore(db, keystorePrefix)}, nil
 }
 
-func (s *Storage) StoreKey(privateKey ed25519.PrivateKey) error {
-	has, err := s.db.Has([]byte{keyPrefix})
-	if err != nil {
-		return err
-	}
-	if has {
-		return ErrDuplicate
-	}
-	return s.db.Put([]byte{keyPrefix}, privateKey[:])
+// Keystore returns the [hcli.Keystore] that holds the (encrypted) key of the
+// wallet.
+func (s *Storage) Keystore() *hcli.Keystore {
+	return s.ks
 }
 
-func (s *Storage) GetKey() (ed25519.PrivateKey, error) {
+// GetPlaintextKey returns the key stored (in plaintext) by older versions of
+// the wallet, if any. For backwards compatibility, ed25519 keys were stored as
+// raw bytes and all other keys were prefixed by their [hcli.KeyType].
+func (s *Storage) GetPlaintextKey() (hcli.KeyType, []byte, error) {
 	v, err := s.db.Get([]byte{keyPrefix})
 	if errors.Is(err, database.ErrNotFound) {
-		return ed25519.EmptyPrivateKey, nil
+		return 0, nil, nil
 	}
 	if err != nil {
-		return ed25519.EmptyPrivateKey, err
+		return 0, nil, err
 	}
-	return ed25519.PrivateKey(v), nil
+	if len(v) == ed25519.PrivateKeyLen {
+		return hcli.ED25519Key, v, nil
+	}
+	if len(v) == 0 {
+		return 0, nil, hcli.ErrInvalidKey
+	}
+	return hcli.KeyType(v[0]), v[1:], nil
+}
+
+func (s *Storage) DeletePlaintextKey() error {
+	return s.db.Delete([]byte{keyPrefix})
 }
 
 func (s *Storage) StoreAsset(assetID ids.ID, owned bool) error {
@@ -1,5 +1,6 @@
 import NavBar from "./components/NavBar";
-import { GetCommitHash, OpenLink } from "../wailsjs/go/main/App";
+import Unlock from "./components/Unlock";
+import { GetCommitHash, Locked, OpenLink } from "../wailsjs/go/main/App";
 import { App as AApp, FloatButton, Layout, Row, Typography } from "antd";
 import { useEffect, useState } from "react";
 import { Outlet } from "react-router-dom";
@@ -10,12 +11,18 @@ const { Content } = Layout;
 
 const App = () => {
   const [commit, setCommit] = useState("");
+  const [locked, setLocked] = useState(true);
   useEffect(() => {
     const getCommit = async () => {
       const c = await GetCommitHash();
       setCommit(c);
     };
     getCommit();
+
+    const getLocked = async () => {
+      setLocked(await Locked());
+    };
+    getLocked();
   }, []);
   return (
     <AApp>
@@ -23,22 +30,25 @@ const App = () => {
         style={{
           minHeight: "95vh",
         }}>
-        <NavBar />
-        <Layout className="site-layout">
-          <Content
-            style={{
-              background: "white",
-              padding: "0 50px",
-            }}>
-            <div
+        {locked && <Unlock onUnlock={() => setLocked(false)} />}
+        {!locked && <NavBar onLock={() => setLocked(true)} />}
+        {!locked && (
+          <Layout className="site-layout">
+            <Content
               style={{
-                padding: 24,
+                background: "white",
+                padding: "0 50px",
               }}>
-              <Outlet />
-              <FloatButton.BackTop />
-            </div>
-          </Content>
-        </Layout>
+              <div
+                style={{
+                  padding: 24,
+                }}>
+                <Outlet />
+                <FloatButton.BackTop />
+              </div>
+            </Content>
+          </Layout>
+        )}
         <Row justify="center" style={{ background: "white" }}>
           <a onClick={() => {OpenLink("https://github.com/ava-labs/hypersdk")}}>
           <img src={logo} style={{ width: "300px" }} />
@@ -3,6 +3,8 @@ import {
   GetBalance,
   GetTransactions,
   GetAddress,
+  ExportKey,
+  Lock,
 } from "../../wailsjs/go/main/App";
 import {
   WalletTwoTone,
@@ -14,13 +16,25 @@ import {
   CheckCircleTwoTone,
   CloseCircleTwoTone,
   ContainerOutlined,
+  LockOutlined,
 } from "@ant-design/icons";
-import { App, Layout, Menu, Typography, Drawer, List, Divider } from "antd";
+import {
+  App,
+  Layout,
+  Menu,
+  Typography,
+  Drawer,
+  List,
+  Divider,
+  Button,
+  Input,
+  Space,
+} from "antd";
 const { Text, Link } = Typography;
 import { useLocation, Link as RLink } from "react-router-dom";
 import logo from "../assets/images/logo-universal.png";
 
-const NavBar = () => {
+const NavBar = ({ onLock }) => {
   const location = useLocation();
   const { message } = App.useApp();
   const [balance, setBalance] = useState([]);
@@ -28,6 +42,8 @@ const NavBar = () => {
   const [transactions, setTransactions] = useState([]);
   const [address, setAddress] = useState("");
   const [open, setOpen] = useState(false);
+  const [exportPassphrase, setExportPassphrase] = useState("");
+  const [exportedKey, setExportedKey] = useState("");
 
   const items = [
     {
@@ -68,6 +84,24 @@ const NavBar = () => {
 
   const onClose = () => {
     setOpen(false);
+    setExportedKey("");
+  };
+
+  const lock = async () => {
+    await Lock();
+    onLock();
+  };
+
+  const exportKey = async () => {
+    try {
+      setExportedKey(await ExportKey(exportPassphrase));
+      setExportPassphrase("");
+    } catch (e) {
+      message.open({
+        type: "error",
+        content: e.toString(),
+      });
+    }
   };
 
   useEffect(() => {
@@ -146,7 +180,12 @@ const NavBar = () => {
           size={"large"}
           placement="right"
           onClose={onClose}
-          open={open}>
+          open={open}
+          extra={
+            <Button icon={<LockOutlined />} onClick={lock}>
+              Lock
+            </Button>
+          }>
           {/* use a real data source */}
           <Divider orientation="center">Tokens</Divider>
           <List
@@ -189,6 +228,22 @@ const NavBar = () => {
               </List.Item>
             )}
           />
+          <Divider orientation="center">Export Key</Divider>
+          <Space.Compact style={{ width: "100%" }}>
+            <Input.Password
+              placeholder="Export Passphrase"
+              value={exportPassphrase}
+              onChange={(e) => setExportPassphrase(e.target.value)}
+            />
+            <Button type="primary" onClick={exportKey}>
+              Export
+            </Button>
+          </Space.Compact>
+          {exportedKey.length > 0 && (
+            <Text code copyable style={{ whiteSpace: "pre-wrap" }}>
+              {exportedKey}
+            </Text>
+          )}
         </Drawer>
       </Layout.Header>
     </>
@@ -0,0 +1,100 @@
+import { useEffect, useState } from "react";
+import { App, Button, Card, Form, Input, Row, Typography } from "antd";
+import { LockOutlined } from "@ant-design/icons";
+import {
+  ImportKey,
+  Initialized,
+  Unlock as UnlockWallet,
+} from "../../wailsjs/go/main/App";
+const { Text } = Typography;
+
+const Unlock = ({ onUnlock }) => {
+  const { message } = App.useApp();
+  const [form] = Form.useForm();
+  const [initialized, setInitialized] = useState(null);
+  const [unlocking, setUnlocking] = useState(false);
+
+  useEffect(() => {
+    const getInitialized = async () => {
+      setInitialized(await Initialized());
+    };
+    getInitialized();
+  }, []);
+
+  const onFinish = async (values) => {
+    setUnlocking(true);
+    try {
+      if (values.Key !== undefined && values.Key.length > 0) {
+        await ImportKey(values.Key, values.Passphrase);
+      } else {
+        await UnlockWallet(values.Passphrase);
+      }
+      form.resetFields();
+      onUnlock();
+    } catch (e) {
+      message.open({
+        type: "error",
+        content: e.toString(),
+      });
+    }
+    setUnlocking(false);
+  };
+
+  if (initialized === null) {
+    return null;
+  }
+
+  return (
+    <Row justify="center" style={{ paddingTop: "10vh" }}>
+      <Card
+        title={initialized ? "Unlock Wallet" : "Create Wallet"}
+        style={{ width: "500px" }}>
+        {!initialized && (
+          <Text type="secondary">
+            Your key is encrypted with this passphrase. To use an existing key,
+            paste it (encrypted with the same passphrase) below.
+          </Text>
+        )}
+        <Form form={form} onFinish={onFinish} layout="vertical">
+          <Form.Item
+            name="Passphrase"
+            label="Passphrase"
+            rules={[{ required: true }]}>
+            <Input.Password prefix={<LockOutlined />} />
+          </Form.Item>
+          {!initialized && (
+            <Form.Item
+              name="Confirm"
+              label="Confirm Passphrase"
+              dependencies={["Passphrase"]}
+              rules={[
+                { required: true },
+                ({ getFieldValue }) => ({
+                  validator(_, value) {
+                    if (!value || getFieldValue("Passphrase") === value) {
+                      return Promise.resolve();
+                    }
+                    return Promise.reject(new Error("passphrases do not match"));
+                  },
+                }),
+              ]}>
+              <Input.Password prefix={<LockOutlined />} />
+            </Form.Item>
+          )}
+          {!initialized && (
+            <Form.Item name="Key" label="Encrypted Key (optional)">
+              <Input.TextArea rows={4} />
+            </Form.Item>
+          )}
+          <Form.Item>
+            <Button type="primary" htmlType="submit" loading={unlocking}>
+              {initialized ? "Unlock" : "Create"}
+            </Button>
+          </Form.Item>
+        </Form>
+      </Card>
+    </Row>
+  );
+};
+
+export default Unlock;
@@ -12,6 +12,8 @@ export function CreateAsset(arg1:string,arg2:string,arg3:string):Promise<void>;
 
 export function CreateOrder(arg1:string,arg2:string,arg3:string,arg4:string,arg5:string):Promise<void>;
 
+export function ExportKey(arg1:string):Promise<string>;
+
 export function FillOrder(arg1:string,arg2:string,arg3:string,arg4:string,arg5:string,arg6:string):Promise<void>;
 
 export function GetAccountStats():Promise<Array<backend.GenericInfo>>;
@@ -48,6 +50,14 @@ export function GetTransactions():Promise<backend.Transactions>;
 
 export function GetUnitPrices():Promise<Array<backend.GenericInfo>>;
 
+export function ImportKey(arg1:string,arg2:string):Promise<void>;
+
+export function Initialized():Promise<boolean>;
+
+export function Lock():Promise<void>;
+
+export function Locked():Promise<boolean>;
+
 export function Message(arg1:string,arg2:string):Promise<void>;
 
 export function MintAsset(arg1:string,arg2:string,arg3:string):Promise<void>;
@@ -57,3 +67,5 @@ export function OpenLink(arg1:string):Promise<void>;
 export function StartFaucetSearch():Promise<backend.FaucetSearchInfo>;
 
 export function Transfer(arg1:string,arg2:string,arg3:string,arg4:string):Promise<void>;
+
+export function Unlock(arg1:string):Promise<void>;
@@ -22,6 +22,10 @@ export function CreateOrder(arg1, arg2, arg3, arg4, arg5) {
   return window['go']['main']['App']['CreateOrder'](arg1, arg2, arg3, arg4, arg5);
 }
 
+export function ExportKey(arg1) {
+  return window['go']['main']['App']['ExportKey'](arg1);
+}
+
 export function FillOrder(arg1, arg2, arg3, arg4, arg5, arg6) {
   return window['go']['main']['App']['FillOrder'](arg1, arg2, arg3, arg4, arg5, arg6);
 }
@@ -94,6 +98,22 @@ export function GetUnitPrices() {
   return window['go']['main']['App']['GetUnitPrices']();
 }
 
+export function ImportKey(arg1, arg2) {
+  return window['go']['main']['App']['ImportKey'](arg1, arg2);
+}
+
+export function Initialized() {
+  return window['go']['main']['App']['Initialized']();
+}
+
+export function Lock() {
+  return window['go']['main']['App']['Lock']();
+}
+
+export function Locked() {
+  return window['go']['main']['App']['Locked']();
+}
+
 export function Message(arg1, arg2) {
   return window['go']['main']['App']['Message'](arg1, arg2);
 }
@@ -113,3 +133,7 @@ export function StartFaucetSearch() {
 export function Transfer(arg1, arg2, arg3, arg4) {
   return window['go']['main']['App']['Transfer'](arg1, arg2, arg3, arg4);
 }
+
+export function Unlock(arg1) {
+  return window['go']['main']['App']['Unlock'](arg1);
+}
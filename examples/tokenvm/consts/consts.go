@@ -35,4 +35,6 @@ func init() {
 var (
 	ActionRegistry *codec.TypeParser[chain.Action, *warp.Message, bool]
 	AuthRegistry   *codec.TypeParser[chain.Auth, *warp.Message, bool]
+
+	WarpPayloadRegistry *chain.WarpPayloadRegistry
 )
@@ -17,6 +17,7 @@ import (
 	hrpc "github.com/ava-labs/hypersdk/rpc"
 	hstorage "github.com/ava-labs/hypersdk/storage"
 	"github.com/ava-labs/hypersdk/vm"
+	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
 
 	"github.com/ava-labs/hypersdk/examples/tokenvm/actions"
@@ -25,6 +26,7 @@ import (
 	"github.com/ava-labs/hypersdk/examples/tokenvm/consts"
 	"github.com/ava-labs/hypersdk/examples/tokenvm/genesis"
 	"github.com/ava-labs/hypersdk/examples/tokenvm/orderbook"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/registry"
 	"github.com/ava-labs/hypersdk/examples/tokenvm/rpc"
 	"github.com/ava-labs/hypersdk/examples/tokenvm/storage"
 	"github.com/ava-labs/hypersdk/examples/tokenvm/version"
@@ -89,6 +91,7 @@ func (c *Controller) Initialize(
 	}
 	c.snowCtx.Log.SetLevel(c.config.GetLogLevel())
 	snowCtx.Log.Info("initialized config", zap.Bool("loaded", c.config.Loaded()), zap.Any("contents", c.config))
+	registry.SetSponsorPolicy(c.config.GetSponsorPolicy())
 
 	c.genesis, err = genesis.New(genesisBytes, upgradeBytes)
 	if err != nil {
@@ -111,15 +114,23 @@ func (c *Controller) Initialize(
 	// hypersdk handler are initiatlized automatically, you just need to
 	// initialize custom handlers here.
 	apis := map[string]*common.HTTPHandler{}
-	jsonRPCHandler, err := hrpc.NewJSONRPCHandler(
+	jsonRPCHandler, err := hrpc.NewRateLimitedJSONRPCHandler(
 		consts.Name,
 		rpc.NewJSONRPCServer(c),
 		common.NoLock,
+		inner.NewRPCLimiter(c.config),
 	)
 	if err != nil {
 		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, err
 	}
 	apis[rpc.JSONRPCEndpoint] = jsonRPCHandler
+	if len(c.config.AdminToken) > 0 {
+		adminHandler, err := hrpc.NewAdminHandler(inner, c.config, c.config.AdminToken)
+		if err != nil {
+			return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, err
+		}
+		apis[hrpc.AdminEndpoint] = adminHandler
+	}
 
 	// Create builder and gossiper
 	var (
@@ -138,15 +149,28 @@ func (c *Controller) Initialize(
 		gcfg.GossipProposerDepth = c.config.GossipProposerDepth
 		gcfg.NoGossipBuilderDiff = c.config.NoGossipBuilderDiff
 		gcfg.VerifyTimeout = c.config.VerifyTimeout
+		gcfg.Pull.Frequency = c.config.GossipPullFrequency
+		gcfg.Targets = c.config.GossipTargets
+		gcfg.WarmAuthCache = c.config.WarmAuthCache
 		gossip, err = gossiper.NewProposer(inner, gcfg)
 		if err != nil {
 			return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, err
 		}
 	}
 
+	// Create auth engines
+	authRegistry := prometheus.NewRegistry()
+	authEngines, err := auth.Engines(c.config.AuthCacheSize, authRegistry)
+	if err != nil {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, err
+	}
+	if err := gatherer.Register("auth", authRegistry); err != nil {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, err
+	}
+
 	// Initialize order book used to track all open orders
 	c.orderBook = orderbook.New(c, c.config.TrackedPairs, c.config.MaxOrdersPerPair)
-	return c.config, c.genesis, build, gossip, blockDB, stateDB, apis, consts.ActionRegistry, consts.AuthRegistry, auth.Engines(), nil
+	return c.config, c.genesis, build, gossip, blockDB, stateDB, apis, consts.ActionRegistry, consts.AuthRegistry, authEngines, nil
 }
 
 func (c *Controller) Rules(t int64) chain.Rules {
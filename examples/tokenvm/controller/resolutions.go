@@ -77,3 +77,29 @@ func (c *Controller) GetLoanFromState(
 ) (uint64, error) {
 	return storage.GetLoanFromState(ctx, c.inner.ReadState, asset, destination)
 }
+
+func (c *Controller) GetAccountFromState(
+	ctx context.Context,
+	addr ed25519.PublicKey,
+) (
+	bool, // exists
+	uint8, // threshold
+	[]*storage.AccountSigner, // signers
+	error,
+) {
+	return storage.GetAccountFromState(ctx, c.inner.ReadState, addr)
+}
+
+func (c *Controller) GetSessionFromState(
+	ctx context.Context,
+	actor ed25519.PublicKey,
+	key ed25519.PublicKey,
+) (
+	bool, // exists
+	int64, // expiry
+	[]uint8, // actions
+	[]*storage.SpendLimit, // limits
+	error,
+) {
+	return storage.GetSessionFromState(ctx, c.inner.ReadState, actor, key)
+}
@@ -8,4 +8,6 @@ import "errors"
 var (
 	ErrInvalidHRP    = errors.New("invalid HRP")
 	ErrInvalidTarget = errors.New("invalid target")
+
+	ErrInvalidWarpConfig = errors.New("invalid warp config")
 )
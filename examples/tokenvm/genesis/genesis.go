@@ -65,6 +65,13 @@ type Genesis struct {
 	WarmStorageKeyModificationUnits   uint64 `json:"warmStorageKeyModificationUnits"`
 	WarmStorageValueModificationUnits uint64 `json:"warmStorageValueModificationUnits"` // per chunk
 
+	// Warp Parameters
+	DefaultWarpConfig *WarpConfig              `json:"defaultWarpConfig"`
+	WarpSourceChains  []*SourceChainWarpConfig `json:"warpSourceChains,omitempty"`
+	// WarpUpgrades are populated from the upgrade bytes (and any specified in
+	// genesis) so that clients apply the same rules as validators.
+	WarpUpgrades []*WarpUpgrade `json:"warpUpgrades,omitempty"`
+
 	// Allocations
 	CustomAllocation []*CustomAllocation `json:"customAllocation"`
 }
@@ -108,16 +115,22 @@ func Default() *Genesis {
 		ColdStorageValueModificationUnits: 3,
 		WarmStorageKeyModificationUnits:   5,
 		WarmStorageValueModificationUnits: 3,
+
+		// Warp Parameters
+		DefaultWarpConfig: DefaultWarpConfig(),
 	}
 }
 
-func New(b []byte, _ []byte /* upgradeBytes */) (*Genesis, error) {
+func New(b []byte, upgradeBytes []byte) (*Genesis, error) {
 	g := Default()
 	if len(b) > 0 {
 		if err := json.Unmarshal(b, g); err != nil {
 			return nil, fmt.Errorf("failed to unmarshal config %s: %w", string(b), err)
 		}
 	}
+	if err := g.parseUpgrades(upgradeBytes); err != nil {
+		return nil, err
+	}
 	return g, nil
 }
 
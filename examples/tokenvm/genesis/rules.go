@@ -13,21 +13,18 @@ var _ chain.Rules = (*Rules)(nil)
 type Rules struct {
 	g *Genesis
 
+	t         int64
 	networkID uint32
 	chainID   ids.ID
 }
 
-// TODO: use upgradeBytes
-func (g *Genesis) Rules(_ int64, networkID uint32, chainID ids.ID) *Rules {
-	return &Rules{g, networkID, chainID}
+func (g *Genesis) Rules(t int64, networkID uint32, chainID ids.ID) *Rules {
+	return &Rules{g, t, networkID, chainID}
 }
 
-func (*Rules) GetWarpConfig(ids.ID) (bool, uint64, uint64) {
-	// We allow inbound transfers from all sources as long as 80% of stake has
-	// signed a message.
-	//
-	// This is safe because the tokenvm scopes all assets by their source chain.
-	return true, 4, 5
+func (r *Rules) GetWarpConfig(sourceChainID ids.ID) (bool, uint64, uint64) {
+	w := r.g.WarpConfig(r.t, sourceChainID)
+	return w.Enabled, w.QuorumNumerator, w.QuorumDenominator
 }
 
 func (r *Rules) NetworkID() uint32 {
@@ -0,0 +1,168 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package genesis
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
+)
+
+// WarpConfig determines if messages from a source chain are accepted and the
+// fraction of stake ([QuorumNumerator]/[QuorumDenominator]) that must sign
+// them.
+type WarpConfig struct {
+	Enabled           bool   `json:"enabled"`
+	QuorumNumerator   uint64 `json:"quorumNumerator"`
+	QuorumDenominator uint64 `json:"quorumDenominator"`
+}
+
+func (w *WarpConfig) Verify() error {
+	if w == nil {
+		return fmt.Errorf("%w: missing config", ErrInvalidWarpConfig)
+	}
+	if !w.Enabled {
+		return nil
+	}
+	if w.QuorumNumerator == 0 || w.QuorumDenominator == 0 || w.QuorumNumerator > w.QuorumDenominator {
+		return fmt.Errorf(
+			"%w: quorum %d/%d",
+			ErrInvalidWarpConfig,
+			w.QuorumNumerator,
+			w.QuorumDenominator,
+		)
+	}
+	return nil
+}
+
+// DefaultWarpConfig allows inbound transfers from all sources as long as 80% of
+// stake has signed a message.
+//
+// This is safe because the tokenvm scopes all assets by their source chain.
+func DefaultWarpConfig() *WarpConfig {
+	return &WarpConfig{
+		Enabled:           true,
+		QuorumNumerator:   4,
+		QuorumDenominator: 5,
+	}
+}
+
+// SourceChainWarpConfig overrides the default [WarpConfig] for messages from
+// [SourceChainID].
+type SourceChainWarpConfig struct {
+	SourceChainID ids.ID `json:"sourceChainID"`
+	WarpConfig
+}
+
+func verifySourceChains(configs []*SourceChainWarpConfig) error {
+	seen := set.NewSet[ids.ID](len(configs))
+	for _, c := range configs {
+		if c == nil {
+			return fmt.Errorf("%w: missing config", ErrInvalidWarpConfig)
+		}
+		if seen.Contains(c.SourceChainID) {
+			return fmt.Errorf("%w: duplicate source chain %s", ErrInvalidWarpConfig, c.SourceChainID)
+		}
+		seen.Add(c.SourceChainID)
+		if err := c.Verify(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func findSourceChain(configs []*SourceChainWarpConfig, sourceChainID ids.ID) (*WarpConfig, bool) {
+	for _, c := range configs {
+		if c.SourceChainID == sourceChainID {
+			return &c.WarpConfig, true
+		}
+	}
+	return nil, false
+}
+
+// WarpUpgrade modifies the [WarpConfig] of some source chains (or the
+// default) for all blocks with a timestamp of at least [Timestamp].
+type WarpUpgrade struct {
+	Timestamp int64 `json:"timestamp"` // ms
+
+	// If nil, the default is unchanged.
+	Default *WarpConfig `json:"default,omitempty"`
+	// Any source chains not included are unchanged.
+	SourceChains []*SourceChainWarpConfig `json:"sourceChains,omitempty"`
+}
+
+// Upgrades are provided in the chain's upgrade bytes.
+type Upgrades struct {
+	Warp []*WarpUpgrade `json:"warp"`
+}
+
+// parseUpgrades adds the upgrades in [b] to [g] and sorts all upgrades by
+// timestamp.
+func (g *Genesis) parseUpgrades(b []byte) error {
+	if len(b) > 0 {
+		var u Upgrades
+		if err := json.Unmarshal(b, &u); err != nil {
+			return fmt.Errorf("failed to unmarshal upgrades %s: %w", string(b), err)
+		}
+		g.WarpUpgrades = append(g.WarpUpgrades, u.Warp...)
+	}
+	sort.SliceStable(g.WarpUpgrades, func(i, j int) bool {
+		return g.WarpUpgrades[i].Timestamp < g.WarpUpgrades[j].Timestamp
+	})
+	return g.verifyWarp()
+}
+
+func (g *Genesis) verifyWarp() error {
+	if err := g.DefaultWarpConfig.Verify(); err != nil {
+		return err
+	}
+	if err := verifySourceChains(g.WarpSourceChains); err != nil {
+		return err
+	}
+	for _, u := range g.WarpUpgrades {
+		if u == nil {
+			return fmt.Errorf("%w: missing upgrade", ErrInvalidWarpConfig)
+		}
+		if u.Default != nil {
+			if err := u.Default.Verify(); err != nil {
+				return err
+			}
+		}
+		if err := verifySourceChains(u.SourceChains); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WarpConfig returns the [WarpConfig] for [sourceChainID] at [t].
+//
+// A config for [sourceChainID] (from the most recent activated upgrade or,
+// if no upgrade mentions [sourceChainID], from genesis) always takes
+// precedence over the default.
+func (g *Genesis) WarpConfig(t int64, sourceChainID ids.ID) *WarpConfig {
+	var def *WarpConfig
+	for i := len(g.WarpUpgrades) - 1; i >= 0; i-- {
+		u := g.WarpUpgrades[i]
+		if u.Timestamp > t {
+			continue
+		}
+		if w, ok := findSourceChain(u.SourceChains, sourceChainID); ok {
+			return w
+		}
+		if def == nil && u.Default != nil {
+			def = u.Default
+		}
+	}
+	if w, ok := findSourceChain(g.WarpSourceChains, sourceChainID); ok {
+		return w
+	}
+	if def != nil {
+		return def
+	}
+	return g.DefaultWarpConfig
+}
@@ -0,0 +1,199 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package genesis
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/stretchr/testify/require"
+)
+
+func warpConfig(num, den uint64) *WarpConfig {
+	return &WarpConfig{Enabled: true, QuorumNumerator: num, QuorumDenominator: den}
+}
+
+func sourceChain(sourceChainID ids.ID, w *WarpConfig) *SourceChainWarpConfig {
+	return &SourceChainWarpConfig{SourceChainID: sourceChainID, WarpConfig: *w}
+}
+
+func upgradeBytes(t *testing.T, upgrades ...*WarpUpgrade) []byte {
+	b, err := json.Marshal(&Upgrades{Warp: upgrades})
+	require.NoError(t, err)
+	return b
+}
+
+func TestWarpConfigVerify(t *testing.T) {
+	tests := []struct {
+		name   string
+		config *WarpConfig
+		valid  bool
+	}{
+		{name: "missing", config: nil},
+		{name: "default", config: DefaultWarpConfig(), valid: true},
+		{name: "disabled with invalid quorum", config: &WarpConfig{}, valid: true},
+		{name: "all stake", config: warpConfig(1, 1), valid: true},
+		{name: "zero numerator", config: warpConfig(0, 1)},
+		{name: "zero denominator", config: warpConfig(1, 0)},
+		{name: "numerator above denominator", config: warpConfig(2, 1)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Verify()
+			if tt.valid {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, ErrInvalidWarpConfig)
+		})
+	}
+}
+
+func TestParseUpgradesInvalid(t *testing.T) {
+	sourceChainID := ids.GenerateTestID()
+	tests := []struct {
+		name     string
+		upgrades []byte
+	}{
+		{
+			name:     "missing upgrade",
+			upgrades: []byte(`{"warp":[null]}`),
+		},
+		{
+			name: "invalid default",
+			upgrades: upgradeBytes(t, &WarpUpgrade{
+				Timestamp: 10,
+				Default:   warpConfig(2, 1),
+			}),
+		},
+		{
+			name: "invalid source chain",
+			upgrades: upgradeBytes(t, &WarpUpgrade{
+				Timestamp:    10,
+				SourceChains: []*SourceChainWarpConfig{sourceChain(sourceChainID, warpConfig(0, 1))},
+			}),
+		},
+		{
+			name: "duplicate source chain",
+			upgrades: upgradeBytes(t, &WarpUpgrade{
+				Timestamp: 10,
+				SourceChains: []*SourceChainWarpConfig{
+					sourceChain(sourceChainID, warpConfig(1, 2)),
+					sourceChain(sourceChainID, warpConfig(2, 3)),
+				},
+			}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(nil, tt.upgrades)
+			require.ErrorIs(t, err, ErrInvalidWarpConfig)
+		})
+	}
+
+	_, err := New(nil, []byte("{"))
+	require.Error(t, err)
+}
+
+func TestWarpConfigResolution(t *testing.T) {
+	var (
+		x = ids.GenerateTestID() // configured at genesis
+		y = ids.GenerateTestID() // configured by an upgrade
+		z = ids.GenerateTestID() // never configured
+
+		genesisDefault = warpConfig(4, 5)
+		genesisX       = warpConfig(1, 2)
+		default100     = warpConfig(2, 3)
+		x200           = warpConfig(3, 4)
+		default300     = warpConfig(5, 6)
+		y300           = &WarpConfig{}
+		x300           = warpConfig(6, 7)
+	)
+	genesisBytes, err := json.Marshal(&Genesis{
+		DefaultWarpConfig: genesisDefault,
+		WarpSourceChains:  []*SourceChainWarpConfig{sourceChain(x, genesisX)},
+	})
+	require.NoError(t, err)
+
+	// Upgrades are applied in timestamp order regardless of the order they are
+	// specified in. Upgrades that activate at the same time are applied in the
+	// order they are specified.
+	upgrades := []*WarpUpgrade{
+		{
+			Timestamp:    300,
+			Default:      default300,
+			SourceChains: []*SourceChainWarpConfig{sourceChain(y, y300), sourceChain(x, x200)},
+		},
+		{
+			Timestamp: 100,
+			Default:   default100,
+		},
+		{
+			Timestamp:    300,
+			SourceChains: []*SourceChainWarpConfig{sourceChain(x, x300)},
+		},
+		{
+			Timestamp:    200,
+			SourceChains: []*SourceChainWarpConfig{sourceChain(x, x200)},
+		},
+	}
+	g, err := New(genesisBytes, upgradeBytes(t, upgrades...))
+	require.NoError(t, err)
+	for i := 1; i < len(g.WarpUpgrades); i++ {
+		require.LessOrEqual(t, g.WarpUpgrades[i-1].Timestamp, g.WarpUpgrades[i].Timestamp)
+	}
+
+	tests := []struct {
+		name          string
+		t             int64
+		sourceChainID ids.ID
+		expected      *WarpConfig
+	}{
+		{name: "genesis source chain", t: 0, sourceChainID: x, expected: genesisX},
+		{name: "genesis default", t: 0, sourceChainID: y, expected: genesisDefault},
+		{name: "before first upgrade", t: 99, sourceChainID: z, expected: genesisDefault},
+		{name: "upgraded default", t: 100, sourceChainID: z, expected: default100},
+		{name: "genesis source chain over upgraded default", t: 100, sourceChainID: x, expected: genesisX},
+		{name: "upgraded source chain", t: 200, sourceChainID: x, expected: x200},
+		{name: "default from earlier upgrade", t: 200, sourceChainID: y, expected: default100},
+		{name: "before overlapping upgrades", t: 299, sourceChainID: x, expected: x200},
+		{name: "later upgrade at same timestamp", t: 300, sourceChainID: x, expected: x300},
+		{name: "source chain added by upgrade", t: 300, sourceChainID: y, expected: y300},
+		{name: "default from upgrade that does not mention chain", t: 300, sourceChainID: z, expected: default300},
+		{name: "after all upgrades", t: 1_000, sourceChainID: x, expected: x300},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, g.WarpConfig(tt.t, tt.sourceChainID))
+
+			enabled, num, den := g.Rules(tt.t, 1, ids.Empty).GetWarpConfig(tt.sourceChainID)
+			require.Equal(t, tt.expected.Enabled, enabled)
+			require.Equal(t, tt.expected.QuorumNumerator, num)
+			require.Equal(t, tt.expected.QuorumDenominator, den)
+		})
+	}
+}
+
+func TestWarpUpgradesFromGenesis(t *testing.T) {
+	require := require.New(t)
+
+	// Upgrades specified in genesis are merged with those in the upgrade bytes
+	sourceChainID := ids.GenerateTestID()
+	genesisBytes, err := json.Marshal(&Genesis{
+		DefaultWarpConfig: DefaultWarpConfig(),
+		WarpUpgrades:      []*WarpUpgrade{{Timestamp: 200, Default: warpConfig(1, 1)}},
+	})
+	require.NoError(err)
+	g, err := New(genesisBytes, upgradeBytes(t, &WarpUpgrade{
+		Timestamp:    100,
+		SourceChains: []*SourceChainWarpConfig{sourceChain(sourceChainID, warpConfig(1, 3))},
+	}))
+	require.NoError(err)
+	require.Len(g.WarpUpgrades, 2)
+	require.Equal(int64(100), g.WarpUpgrades[0].Timestamp)
+	require.Equal(DefaultWarpConfig(), g.WarpConfig(100, ids.GenerateTestID()))
+	require.Equal(warpConfig(1, 1), g.WarpConfig(200, ids.GenerateTestID()))
+	require.Equal(warpConfig(1, 3), g.WarpConfig(200, sourceChainID))
+}
@@ -4,20 +4,52 @@
 package registry
 
 import (
+	"context"
+	"sync/atomic"
+
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
 	"github.com/ava-labs/hypersdk/chain"
 	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/crypto/ed25519"
+	"github.com/ava-labs/hypersdk/state"
 
 	"github.com/ava-labs/hypersdk/examples/tokenvm/actions"
 	"github.com/ava-labs/hypersdk/examples/tokenvm/auth"
 	"github.com/ava-labs/hypersdk/examples/tokenvm/consts"
 )
 
+// sponsorPolicy is enforced by all [auth.Sponsored] parsed with
+// [consts.AuthRegistry].
+var sponsorPolicy atomic.Pointer[auth.SponsorPolicy]
+
+// SetSponsorPolicy replaces the [auth.SponsorPolicy] enforced by all
+// [auth.Sponsored] parsed with [consts.AuthRegistry] (which is
+// [auth.DefaultSponsorPolicy] until it is set).
+func SetSponsorPolicy(policy auth.SponsorPolicy) {
+	sponsorPolicy.Store(&policy)
+}
+
+func enforceSponsorPolicy(
+	ctx context.Context,
+	im state.Immutable,
+	sponsor ed25519.PublicKey,
+	actor ed25519.PublicKey,
+	action chain.Action,
+) error {
+	return (*sponsorPolicy.Load())(ctx, im, sponsor, actor, action)
+}
+
 // Setup types
 func init() {
+	SetSponsorPolicy(auth.DefaultSponsorPolicy)
 	consts.ActionRegistry = codec.NewTypeParser[chain.Action, *warp.Message]()
 	consts.AuthRegistry = codec.NewTypeParser[chain.Auth, *warp.Message]()
+	warpPayloadRegistry, err := chain.NewWarpPayloadRegistry()
+	if err != nil {
+		panic(err)
+	}
+	consts.WarpPayloadRegistry = warpPayloadRegistry
 
 	errs := &wrappers.Errs{}
 	errs.Add(
@@ -39,8 +71,26 @@ func init() {
 		consts.ActionRegistry.Register((&actions.GetNFT{}).GetTypeID(), actions.UnmarshalGetNFT, false),
 		consts.ActionRegistry.Register((&actions.ZkTransaction{}).GetTypeID(), actions.UnmarshalZkTransaction, false),
 
+		consts.ActionRegistry.Register((&actions.CreateAccount{}).GetTypeID(), actions.UnmarshalCreateAccount, false),
+		consts.ActionRegistry.Register((&actions.AddAccountSigner{}).GetTypeID(), actions.UnmarshalAddAccountSigner, false),
+		consts.ActionRegistry.Register((&actions.RemoveAccountSigner{}).GetTypeID(), actions.UnmarshalRemoveAccountSigner, false),
+		consts.ActionRegistry.Register((&actions.RotateAccountSigner{}).GetTypeID(), actions.UnmarshalRotateAccountSigner, false),
+
+		consts.ActionRegistry.Register((&actions.RegisterSession{}).GetTypeID(), actions.UnmarshalRegisterSession, false),
+		consts.ActionRegistry.Register((&actions.RevokeSession{}).GetTypeID(), actions.UnmarshalRevokeSession, false),
+
 		// When registering new auth, ALWAYS make sure to append at the end.
 		consts.AuthRegistry.Register((&auth.ED25519{}).GetTypeID(), auth.UnmarshalED25519, false),
+		consts.AuthRegistry.Register((&auth.SECP256R1{}).GetTypeID(), auth.UnmarshalSECP256R1, false),
+		consts.AuthRegistry.Register((&auth.WebAuthn{}).GetTypeID(), auth.UnmarshalWebAuthn, false),
+		consts.AuthRegistry.Register((&auth.Account{}).GetTypeID(), auth.UnmarshalAccount, false),
+		consts.AuthRegistry.Register((&auth.Multisig{}).GetTypeID(), auth.UnmarshalMultisig, false),
+		consts.AuthRegistry.Register((&auth.Sponsored{}).GetTypeID(), auth.NewSponsoredUnmarshaler(enforceSponsorPolicy), false),
+		consts.AuthRegistry.Register((&auth.Session{}).GetTypeID(), auth.UnmarshalSession, false),
+		consts.AuthRegistry.Register((&auth.BLS{}).GetTypeID(), auth.UnmarshalBLS, false),
+
+		// When registering new warp payloads, ALWAYS make sure to append at the end.
+		consts.WarpPayloadRegistry.Register((&actions.WarpTransfer{}).GetTypeID(), actions.UnmarshalWarpTransferPayload),
 	)
 	if errs.Errored() {
 		panic(errs.Err)
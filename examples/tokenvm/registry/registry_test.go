@@ -0,0 +1,70 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package registry
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/crypto/ed25519"
+	"github.com/ava-labs/hypersdk/state"
+
+	"github.com/ava-labs/hypersdk/examples/tokenvm/auth"
+	tconsts "github.com/ava-labs/hypersdk/examples/tokenvm/consts"
+)
+
+func TestSetSponsorPolicy(t *testing.T) {
+	require := require.New(t)
+
+	actorPriv, err := ed25519.GeneratePrivateKey()
+	require.NoError(err)
+	sponsorPriv, err := ed25519.GeneratePrivateKey()
+	require.NoError(err)
+	sponsor := sponsorPriv.PublicKey()
+	factory := auth.NewSponsoredFactory(
+		auth.NewED25519Factory(actorPriv),
+		auth.NewED25519Factory(sponsorPriv),
+		sponsor,
+	)
+	signed, err := factory.Sign([]byte("tx digest"), nil)
+	require.NoError(err)
+	p := codec.NewWriter(signed.Size(), consts.NetworkSizeLimit)
+	signed.Marshal(p)
+	require.NoError(p.Err())
+	parse := func() chain.Auth {
+		unmarshal, _, ok := tconsts.AuthRegistry.LookupIndex(signed.GetTypeID())
+		require.True(ok)
+		rauth, err := unmarshal(codec.NewReader(p.Bytes(), consts.NetworkSizeLimit), nil)
+		require.NoError(err)
+		return rauth
+	}
+
+	// Sponsored auths enforce the policy that is set when they are verified
+	rauth := parse()
+	_, err = rauth.Verify(context.Background(), nil, nil, nil)
+	require.NoError(err)
+
+	errRejected := errors.New("rejected")
+	SetSponsorPolicy(func(
+		_ context.Context,
+		_ state.Immutable,
+		s ed25519.PublicKey,
+		_ ed25519.PublicKey,
+		_ chain.Action,
+	) error {
+		require.Equal(sponsor, s)
+		return errRejected
+	})
+	t.Cleanup(func() { SetSponsorPolicy(auth.DefaultSponsorPolicy) })
+	_, err = rauth.Verify(context.Background(), nil, nil, nil)
+	require.ErrorIs(err, errRejected)
+	_, err = parse().Verify(context.Background(), nil, nil, nil)
+	require.ErrorIs(err, errRejected)
+}
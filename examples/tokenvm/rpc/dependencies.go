@@ -12,6 +12,7 @@ import (
 	"github.com/ava-labs/hypersdk/crypto/ed25519"
 	"github.com/ava-labs/hypersdk/examples/tokenvm/genesis"
 	"github.com/ava-labs/hypersdk/examples/tokenvm/orderbook"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/storage"
 )
 
 type Controller interface {
@@ -32,4 +33,17 @@ type Controller interface {
 		error,
 	)
 	GetLoanFromState(context.Context, ids.ID, ids.ID) (uint64, error)
+	GetAccountFromState(context.Context, ed25519.PublicKey) (
+		bool, // exists
+		uint8, // threshold
+		[]*storage.AccountSigner, // signers
+		error,
+	)
+	GetSessionFromState(context.Context, ed25519.PublicKey, ed25519.PublicKey) (
+		bool, // exists
+		int64, // expiry
+		[]uint8, // actions
+		[]*storage.SpendLimit, // limits
+		error,
+	)
 }
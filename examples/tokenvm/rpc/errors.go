@@ -6,7 +6,9 @@ package rpc
 import "errors"
 
 var (
-	ErrTxNotFound    = errors.New("tx not found")
-	ErrAssetNotFound = errors.New("asset not found")
-	ErrOrderNotFound = errors.New("order not found")
+	ErrTxNotFound      = errors.New("tx not found")
+	ErrAssetNotFound   = errors.New("asset not found")
+	ErrOrderNotFound   = errors.New("order not found")
+	ErrAccountNotFound = errors.New("account not found")
+	ErrSessionNotFound = errors.New("session not found")
 )
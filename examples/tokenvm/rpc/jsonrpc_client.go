@@ -16,6 +16,7 @@ import (
 	"github.com/ava-labs/hypersdk/examples/tokenvm/genesis"
 	"github.com/ava-labs/hypersdk/examples/tokenvm/orderbook"
 	_ "github.com/ava-labs/hypersdk/examples/tokenvm/registry" // ensure registry populated
+	"github.com/ava-labs/hypersdk/examples/tokenvm/storage"
 	"github.com/ava-labs/hypersdk/requester"
 	"github.com/ava-labs/hypersdk/rpc"
 	"github.com/ava-labs/hypersdk/utils"
@@ -174,6 +175,40 @@ func (cli *JSONRPCClient) Loan(
 	return resp.Amount, err
 }
 
+func (cli *JSONRPCClient) Account(
+	ctx context.Context,
+	addr string,
+) (uint8, []*storage.AccountSigner, error) {
+	resp := new(AccountReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"account",
+		&AccountArgs{
+			Address: addr,
+		},
+		resp,
+	)
+	return resp.Threshold, resp.Signers, err
+}
+
+func (cli *JSONRPCClient) Session(
+	ctx context.Context,
+	actor string,
+	key string,
+) (int64, []uint8, []*storage.SpendLimit, error) {
+	resp := new(SessionReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"session",
+		&SessionArgs{
+			Actor: actor,
+			Key:   key,
+		},
+		resp,
+	)
+	return resp.Expiry, resp.Actions, resp.Limits, err
+}
+
 func (cli *JSONRPCClient) WaitForBalance(
 	ctx context.Context,
 	addr string,
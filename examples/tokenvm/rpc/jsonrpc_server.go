@@ -14,6 +14,7 @@ import (
 	"github.com/ava-labs/hypersdk/chain"
 	"github.com/ava-labs/hypersdk/examples/tokenvm/genesis"
 	"github.com/ava-labs/hypersdk/examples/tokenvm/orderbook"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/storage"
 	"github.com/ava-labs/hypersdk/examples/tokenvm/utils"
 )
 
@@ -189,6 +190,71 @@ func (j *JSONRPCServer) Loan(req *http.Request, args *LoanArgs, reply *LoanReply
 	return nil
 }
 
+type AccountArgs struct {
+	Address string `json:"address"`
+}
+
+type AccountReply struct {
+	Threshold uint8                    `json:"threshold"`
+	Signers   []*storage.AccountSigner `json:"signers"`
+}
+
+func (j *JSONRPCServer) Account(req *http.Request, args *AccountArgs, reply *AccountReply) error {
+	ctx, span := j.c.Tracer().Start(req.Context(), "Server.Account")
+	defer span.End()
+
+	addr, err := utils.ParseAddress(args.Address)
+	if err != nil {
+		return err
+	}
+	exists, threshold, signers, err := j.c.GetAccountFromState(ctx, addr)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrAccountNotFound
+	}
+	reply.Threshold = threshold
+	reply.Signers = signers
+	return nil
+}
+
+type SessionArgs struct {
+	Actor string `json:"actor"`
+	Key   string `json:"key"`
+}
+
+type SessionReply struct {
+	Expiry  int64                 `json:"expiry"`
+	Actions []uint8               `json:"actions"`
+	Limits  []*storage.SpendLimit `json:"limits"`
+}
+
+func (j *JSONRPCServer) Session(req *http.Request, args *SessionArgs, reply *SessionReply) error {
+	ctx, span := j.c.Tracer().Start(req.Context(), "Server.Session")
+	defer span.End()
+
+	actor, err := utils.ParseAddress(args.Actor)
+	if err != nil {
+		return err
+	}
+	key, err := utils.ParseAddress(args.Key)
+	if err != nil {
+		return err
+	}
+	exists, expiry, actions, limits, err := j.c.GetSessionFromState(ctx, actor, key)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrSessionNotFound
+	}
+	reply.Expiry = expiry
+	reply.Actions = actions
+	reply.Limits = limits
+	return nil
+}
+
 type MyNFTArgs struct {
 	WalletAddress string `json:"address"`
 	ID            ids.ID `json:"id"`
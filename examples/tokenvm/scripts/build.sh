@@ -53,3 +53,13 @@ FEED_PATH=$TOKENVM_PATH/build/token-feed
 echo "Building token-feed in $FEED_PATH"
 mkdir -p $(dirname $FEED_PATH)
 go build -o $FEED_PATH ./cmd/token-feed
+
+RELAYER_PATH=$TOKENVM_PATH/build/token-relayer
+echo "Building token-relayer in $RELAYER_PATH"
+mkdir -p $(dirname $RELAYER_PATH)
+go build -o $RELAYER_PATH ./cmd/token-relayer
+
+SIGNER_PATH=$TOKENVM_PATH/build/token-signer
+echo "Building token-signer in $SIGNER_PATH"
+mkdir -p $(dirname $SIGNER_PATH)
+go build -o $SIGNER_PATH ./cmd/token-signer
@@ -142,6 +142,7 @@ cat <<EOF > ${DEPLOY_ARTIFACT_PREFIX}/tokenvm-chain-config.json
   "transactionExecutionCores": 4,
   "storeTransactions": false,
   "verifySignatures": true,
+  "warmAuthCache": false,
   "trackedPairs":["*"],
   "continuousProfilerDir":"/data/tokenvm-profiles"
 }
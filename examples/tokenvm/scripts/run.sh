@@ -155,6 +155,7 @@ cat <<EOF > ${TMPDIR}/tokenvm.config
   "rootGenerationCores": 2,
   "transactionExecutionCores": 2,
   "verifySignatures": true,
+  "warmAuthCache": false,
   "storeTransactions": ${STORE_TXS},
   "streamingBacklogSize": 10000000,
   "trackedPairs":["*"],
@@ -14,7 +14,8 @@ rm -rf prometheus-2.43.0.linux-amd64
 
 # Import chains and demo.pk key
 #
-# Assumes token-cli has already been transferred into the machine
+# Assumes token-cli has already been transferred into the machine (set
+# HYPERSDK_KEYSTORE_PASSPHRASE to avoid being prompted for a keystore passphrase)
 /tmp/token-cli chain import-ops aops.yml
 /tmp/token-cli key import demo.pk
 
@@ -5,4 +5,8 @@ package storage
 
 import "errors"
 
-var ErrInvalidBalance = errors.New("invalid balance")
+var (
+	ErrInvalidBalance = errors.New("invalid balance")
+	ErrInvalidAccount = errors.New("invalid account")
+	ErrInvalidSession = errors.New("invalid session")
+)
@@ -18,8 +18,10 @@ import (
 	"github.com/ava-labs/hypersdk/chain"
 	"github.com/ava-labs/hypersdk/consts"
 	"github.com/ava-labs/hypersdk/crypto/ed25519"
+	"github.com/ava-labs/hypersdk/crypto/secp256r1"
 	"github.com/ava-labs/hypersdk/examples/tokenvm/utils"
 	"github.com/ava-labs/hypersdk/state"
+	hutils "github.com/ava-labs/hypersdk/utils"
 	"github.com/boltdb/bolt"
 )
 
@@ -43,6 +45,11 @@ type ReadState func(context.Context, [][]byte) ([][]byte, []error)
 // 0x6/ (hypersdk-fee)
 // 0x7/ (hypersdk-incoming warp)
 // 0x8/ (hypersdk-outgoing warp)
+// 0x9/ (nfts)
+// 0xa/ (accounts)
+//   -> [address] => threshold|signerCount|[keyType|weight|key]...
+// 0xb/ (sessions)
+//   -> [actor|key] => expiry|actionCount|[action]...|limitCount|[asset|remaining]...
 
 const (
 	// metaDB
@@ -59,6 +66,8 @@ const (
 	incomingWarpPrefix = 0x7
 	outgoingWarpPrefix = 0x8
 	nftPrefix          = 0x9
+	accountPrefix      = 0xa
+	sessionPrefix      = 0xb
 )
 
 const (
@@ -67,6 +76,25 @@ const (
 	OrderChunks   uint16 = 2
 	LoanChunks    uint16 = 1
 	NFTChunks     uint16 = 10
+	AccountChunks uint16 = 5
+	SessionChunks uint16 = 6
+)
+
+// Key types that may be registered as an account signer
+const (
+	ED25519Signer   uint8 = 0
+	SECP256R1Signer uint8 = 1
+
+	// MaxAccountSigners bounds the number of signers an account may have so
+	// that it always fits in [AccountChunks].
+	MaxAccountSigners = 8
+)
+
+// MaxSessionActions and MaxSessionLimits bound the size of a session so that
+// it always fits in [SessionChunks].
+const (
+	MaxSessionActions = 16
+	MaxSessionLimits  = 8
 )
 
 var (
@@ -582,6 +610,260 @@ func SubLoan(
 	return SetLoan(ctx, mu, asset, destination, nloan)
 }
 
+// AccountSigner is a key authorized to sign on behalf of an account.
+type AccountSigner struct {
+	KeyType uint8  `json:"keyType"`
+	Key     []byte `json:"key"`
+	Weight  uint8  `json:"weight"`
+}
+
+// SignerKeyLen returns the length of a key of [keyType] and whether
+// [keyType] is supported.
+func SignerKeyLen(keyType uint8) (int, bool) {
+	switch keyType {
+	case ED25519Signer:
+		return ed25519.PublicKeyLen, true
+	case SECP256R1Signer:
+		return secp256r1.PublicKeyLen, true
+	default:
+		return 0, false
+	}
+}
+
+// AccountAddress returns the address of the account created by [txID].
+//
+// The address is derived by hashing [txID] (rather than using it directly)
+// so that it can't be confused with an ed25519 public key.
+func AccountAddress(txID ids.ID) ed25519.PublicKey {
+	b := make([]byte, 1+consts.IDLen)
+	b[0] = accountPrefix
+	copy(b[1:], txID[:])
+	return ed25519.PublicKey(hutils.ToID(b))
+}
+
+// [accountPrefix] + [address]
+func AccountKey(addr ed25519.PublicKey) (k []byte) {
+	k = make([]byte, 1+ed25519.PublicKeyLen+consts.Uint16Len)
+	k[0] = accountPrefix
+	copy(k[1:], addr[:])
+	binary.BigEndian.PutUint16(k[1+ed25519.PublicKeyLen:], AccountChunks)
+	return
+}
+
+func SetAccount(
+	ctx context.Context,
+	mu state.Mutable,
+	addr ed25519.PublicKey,
+	threshold uint8,
+	signers []*AccountSigner,
+) error {
+	k := AccountKey(addr)
+	size := consts.Uint8Len * 2
+	for _, signer := range signers {
+		size += consts.Uint8Len*2 + len(signer.Key)
+	}
+	v := make([]byte, size)
+	v[0] = threshold
+	v[1] = uint8(len(signers))
+	offset := consts.Uint8Len * 2
+	for _, signer := range signers {
+		v[offset] = signer.KeyType
+		v[offset+1] = signer.Weight
+		offset += consts.Uint8Len * 2
+		offset += copy(v[offset:], signer.Key)
+	}
+	return mu.Insert(ctx, k, v)
+}
+
+func GetAccount(
+	ctx context.Context,
+	im state.Immutable,
+	addr ed25519.PublicKey,
+) (
+	bool, // exists
+	uint8, // threshold
+	[]*AccountSigner, // signers
+	error,
+) {
+	k := AccountKey(addr)
+	return innerGetAccount(im.GetValue(ctx, k))
+}
+
+// Used to serve RPC queries
+func GetAccountFromState(
+	ctx context.Context,
+	f ReadState,
+	addr ed25519.PublicKey,
+) (
+	bool, // exists
+	uint8, // threshold
+	[]*AccountSigner, // signers
+	error,
+) {
+	values, errs := f(ctx, [][]byte{AccountKey(addr)})
+	return innerGetAccount(values[0], errs[0])
+}
+
+func innerGetAccount(v []byte, err error) (
+	bool, // exists
+	uint8, // threshold
+	[]*AccountSigner, // signers
+	error,
+) {
+	if errors.Is(err, database.ErrNotFound) {
+		return false, 0, nil, nil
+	}
+	if err != nil {
+		return false, 0, nil, err
+	}
+	if len(v) < consts.Uint8Len*2 {
+		return false, 0, nil, ErrInvalidAccount
+	}
+	threshold := v[0]
+	signers := make([]*AccountSigner, v[1])
+	offset := consts.Uint8Len * 2
+	for i := range signers {
+		if len(v) < offset+consts.Uint8Len*2 {
+			return false, 0, nil, ErrInvalidAccount
+		}
+		keyType := v[offset]
+		weight := v[offset+1]
+		offset += consts.Uint8Len * 2
+		keyLen, ok := SignerKeyLen(keyType)
+		if !ok || len(v) < offset+keyLen {
+			return false, 0, nil, ErrInvalidAccount
+		}
+		key := make([]byte, keyLen)
+		copy(key, v[offset:])
+		offset += keyLen
+		signers[i] = &AccountSigner{keyType, key, weight}
+	}
+	return true, threshold, signers, nil
+}
+
+// SpendLimit is the amount of [Asset] that may still be spent by a session.
+type SpendLimit struct {
+	Asset  ids.ID `json:"asset"`
+	Amount uint64 `json:"amount"`
+}
+
+// [sessionPrefix] + [actor] + [key]
+func SessionKey(actor ed25519.PublicKey, key ed25519.PublicKey) (k []byte) {
+	k = make([]byte, 1+ed25519.PublicKeyLen*2+consts.Uint16Len)
+	k[0] = sessionPrefix
+	copy(k[1:], actor[:])
+	copy(k[1+ed25519.PublicKeyLen:], key[:])
+	binary.BigEndian.PutUint16(k[1+ed25519.PublicKeyLen*2:], SessionChunks)
+	return
+}
+
+func SetSession(
+	ctx context.Context,
+	mu state.Mutable,
+	actor ed25519.PublicKey,
+	key ed25519.PublicKey,
+	expiry int64,
+	actions []uint8,
+	limits []*SpendLimit,
+) error {
+	k := SessionKey(actor, key)
+	v := make([]byte, consts.Int64Len+consts.Uint8Len*2+len(actions)+len(limits)*(consts.IDLen+consts.Uint64Len))
+	binary.BigEndian.PutUint64(v, uint64(expiry))
+	offset := consts.Int64Len
+	v[offset] = uint8(len(actions))
+	offset += consts.Uint8Len
+	offset += copy(v[offset:], actions)
+	v[offset] = uint8(len(limits))
+	offset += consts.Uint8Len
+	for _, limit := range limits {
+		offset += copy(v[offset:], limit.Asset[:])
+		binary.BigEndian.PutUint64(v[offset:], limit.Amount)
+		offset += consts.Uint64Len
+	}
+	return mu.Insert(ctx, k, v)
+}
+
+func GetSession(
+	ctx context.Context,
+	im state.Immutable,
+	actor ed25519.PublicKey,
+	key ed25519.PublicKey,
+) (
+	bool, // exists
+	int64, // expiry
+	[]uint8, // actions
+	[]*SpendLimit, // limits
+	error,
+) {
+	k := SessionKey(actor, key)
+	return innerGetSession(im.GetValue(ctx, k))
+}
+
+// Used to serve RPC queries
+func GetSessionFromState(
+	ctx context.Context,
+	f ReadState,
+	actor ed25519.PublicKey,
+	key ed25519.PublicKey,
+) (
+	bool, // exists
+	int64, // expiry
+	[]uint8, // actions
+	[]*SpendLimit, // limits
+	error,
+) {
+	values, errs := f(ctx, [][]byte{SessionKey(actor, key)})
+	return innerGetSession(values[0], errs[0])
+}
+
+func innerGetSession(v []byte, err error) (
+	bool, // exists
+	int64, // expiry
+	[]uint8, // actions
+	[]*SpendLimit, // limits
+	error,
+) {
+	if errors.Is(err, database.ErrNotFound) {
+		return false, 0, nil, nil, nil
+	}
+	if err != nil {
+		return false, 0, nil, nil, err
+	}
+	if len(v) < consts.Int64Len+consts.Uint8Len {
+		return false, 0, nil, nil, ErrInvalidSession
+	}
+	expiry := int64(binary.BigEndian.Uint64(v))
+	offset := consts.Int64Len
+	actions := make([]uint8, v[offset])
+	offset += consts.Uint8Len
+	if len(v) < offset+len(actions)+consts.Uint8Len {
+		return false, 0, nil, nil, ErrInvalidSession
+	}
+	offset += copy(actions, v[offset:])
+	limits := make([]*SpendLimit, v[offset])
+	offset += consts.Uint8Len
+	if len(v) != offset+len(limits)*(consts.IDLen+consts.Uint64Len) {
+		return false, 0, nil, nil, ErrInvalidSession
+	}
+	for i := range limits {
+		var limit SpendLimit
+		offset += copy(limit.Asset[:], v[offset:])
+		limit.Amount = binary.BigEndian.Uint64(v[offset:])
+		offset += consts.Uint64Len
+		limits[i] = &limit
+	}
+	return true, expiry, actions, limits, nil
+}
+
+func DeleteSession(
+	ctx context.Context,
+	mu state.Mutable,
+	actor ed25519.PublicKey,
+	key ed25519.PublicKey,
+) error {
+	return mu.Remove(ctx, SessionKey(actor, key))
+}
+
 func HeightKey() (k []byte) {
 	return heightKey
 }
@@ -8,6 +8,7 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -20,6 +21,8 @@ import (
 	"github.com/ava-labs/hypersdk/crypto/ed25519"
 	"github.com/ava-labs/hypersdk/examples/tokenvm/actions"
 	"github.com/ava-labs/hypersdk/examples/tokenvm/auth"
+	rconfig "github.com/ava-labs/hypersdk/examples/tokenvm/cmd/token-relayer/config"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/cmd/token-relayer/manager"
 	"github.com/ava-labs/hypersdk/examples/tokenvm/consts"
 	trpc "github.com/ava-labs/hypersdk/examples/tokenvm/rpc"
 	"github.com/ava-labs/hypersdk/examples/tokenvm/utils"
@@ -1320,6 +1323,95 @@ var _ = ginkgo.Describe("[Test]", func() {
 		})
 	})
 
+	ginkgo.It("relays warp transfers with token-relayer", func() {
+		other, err := ed25519.GeneratePrivateKey()
+		gomega.Ω(err).Should(gomega.BeNil())
+		aother := utils.Address(other.PublicKey())
+		source, err := ids.FromString(blockchainIDA)
+		gomega.Ω(err).Should(gomega.BeNil())
+		destination, err := ids.FromString(blockchainIDB)
+		gomega.Ω(err).Should(gomega.BeNil())
+		newAsset := actions.ImportedAssetID(ids.Empty, source)
+
+		export := func(value uint64) {
+			parser, err := instancesA[0].tcli.Parser(context.TODO())
+			gomega.Ω(err).Should(gomega.BeNil())
+			submit, tx, _, err := instancesA[0].cli.GenerateTransaction(
+				context.Background(),
+				parser,
+				nil,
+				&actions.ExportAsset{
+					To:          other.PublicKey(),
+					Asset:       ids.Empty,
+					Value:       value,
+					Destination: destination,
+				},
+				factory,
+			)
+			gomega.Ω(err).Should(gomega.BeNil())
+			gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+			ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+			success, _, err := instancesA[0].tcli.WaitForTransaction(ctx, tx.ID())
+			cancel()
+			gomega.Ω(err).Should(gomega.BeNil())
+			gomega.Ω(success).Should(gomega.BeTrue())
+			hutils.Outf("{{yellow}}exported %d to B:{{/}} %s\n", value, tx.ID())
+		}
+		waitForBalance := func(expected uint64) {
+			gomega.Eventually(func() uint64 {
+				balance, err := instancesB[0].tcli.Balance(context.Background(), aother, newAsset)
+				if err != nil {
+					return 0
+				}
+				return balance
+			}, 2*time.Minute, time.Second).Should(gomega.Equal(expected))
+			hutils.Outf("{{yellow}}relayer imported balance:{{/}} %d\n", expected)
+		}
+
+		dir, err := os.MkdirTemp("", "token-relayer")
+		gomega.Ω(err).Should(gomega.BeNil())
+		defer os.RemoveAll(dir)
+		relayerConfig := &rconfig.Config{
+			PrivateKeyBytes: priv[:],
+			SourceRPC:       instancesA[0].uri,
+			DestinationRPC:  instancesB[0].uri,
+			CheckpointPath:  filepath.Join(dir, "checkpoint.json"),
+			RetryInterval:   1,
+		}
+		relayerConfig.SetDefaults()
+
+		ginkgo.By("exporting while the relayer is offline", func() {
+			// Start the relayer from the current height of A
+			_, height, _, err := instancesA[0].cli.Accepted(context.Background())
+			gomega.Ω(err).Should(gomega.BeNil())
+			checkpoint, err := manager.LoadCheckpoint(relayerConfig.CheckpointPath)
+			gomega.Ω(err).Should(gomega.BeNil())
+			gomega.Ω(checkpoint.Accept(height, nil)).Should(gomega.BeNil())
+
+			export(100)
+		})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan struct{})
+		ginkgo.By("backfilling missed blocks", func() {
+			relayer, err := manager.New(logging.NoLog{}, relayerConfig)
+			gomega.Ω(err).Should(gomega.BeNil())
+			go func() {
+				defer close(done)
+				_ = relayer.Run(ctx)
+			}()
+			waitForBalance(100)
+		})
+
+		ginkgo.By("relaying streamed blocks", func() {
+			export(200)
+			waitForBalance(300)
+		})
+
+		cancel()
+		<-done
+	})
+
 	// TODO: add custom asset test
 	// TODO: test with only part of sig weight
 	// TODO: attempt to mint a warp asset
@@ -9,8 +9,11 @@ import (
 	"encoding/json"
 	"flag"
 	"fmt"
+	"net"
+	"net/http"
 	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -36,18 +39,24 @@ import (
 	"github.com/ava-labs/hypersdk/chain"
 	"github.com/ava-labs/hypersdk/codec"
 	"github.com/ava-labs/hypersdk/consts"
+	hbls "github.com/ava-labs/hypersdk/crypto/bls"
 	"github.com/ava-labs/hypersdk/crypto/ed25519"
+	"github.com/ava-labs/hypersdk/crypto/secp256r1"
 	"github.com/ava-labs/hypersdk/pubsub"
 	"github.com/ava-labs/hypersdk/rpc"
+	"github.com/ava-labs/hypersdk/server"
+	"github.com/ava-labs/hypersdk/signer"
 	hutils "github.com/ava-labs/hypersdk/utils"
 	"github.com/ava-labs/hypersdk/vm"
 
 	"github.com/ava-labs/hypersdk/examples/tokenvm/actions"
 	"github.com/ava-labs/hypersdk/examples/tokenvm/auth"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/cmd/token-signer/policy"
 	tconsts "github.com/ava-labs/hypersdk/examples/tokenvm/consts"
 	"github.com/ava-labs/hypersdk/examples/tokenvm/controller"
 	"github.com/ava-labs/hypersdk/examples/tokenvm/genesis"
 	trpc "github.com/ava-labs/hypersdk/examples/tokenvm/rpc"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/storage"
 	"github.com/ava-labs/hypersdk/examples/tokenvm/utils"
 )
 
@@ -116,6 +125,21 @@ var (
 	asset3Decimals uint8
 	asset3ID       ids.ID
 
+	account     ed25519.PublicKey
+	accountPriv secp256r1.PrivateKey
+	rotatedPriv ed25519.PrivateKey
+
+	multisig        ed25519.PublicKey
+	multisigSigners []*auth.MultisigSigner
+	multisigPriv    secp256r1.PrivateKey
+
+	sponsoredPriv ed25519.PrivateKey
+
+	sessionPriv   ed25519.PrivateKey
+	sessionExpiry int64
+
+	blsPrivs []hbls.PrivateKey
+
 	// when used with embedded VMs
 	genesisBytes []byte
 	instances    []instance
@@ -1753,7 +1777,8 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 	})
 
 	ginkgo.It("import with wrong payload", func() {
-		uwm, err := warp.NewUnsignedMessage(networkID, ids.Empty, []byte("hello"))
+		payload := append([]byte{(&actions.WarpTransfer{}).GetTypeID()}, []byte("hello")...)
+		uwm, err := warp.NewUnsignedMessage(networkID, ids.Empty, payload)
 		gomega.Ω(err).Should(gomega.BeNil())
 		wm, err := warp.NewMessage(uwm, &warp.BitSetSignature{})
 		gomega.Ω(err).Should(gomega.BeNil())
@@ -1785,7 +1810,7 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 
 	ginkgo.It("import with invalid payload", func() {
 		wt := &actions.WarpTransfer{}
-		wtb, err := wt.Marshal()
+		wtb, err := chain.MarshalWarpPayload(wt)
 		gomega.Ω(err).Should(gomega.BeNil())
 		uwm, err := warp.NewUnsignedMessage(networkID, ids.Empty, wtb)
 		gomega.Ω(err).Should(gomega.BeNil())
@@ -1829,7 +1854,7 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 			TxID:               ids.GenerateTestID(),
 			DestinationChainID: ids.GenerateTestID(),
 		}
-		wtb, err := wt.Marshal()
+		wtb, err := chain.MarshalWarpPayload(wt)
 		gomega.Ω(err).Should(gomega.BeNil())
 		uwm, err := warp.NewUnsignedMessage(networkID, ids.Empty, wtb)
 		gomega.Ω(err).Should(gomega.BeNil())
@@ -1912,7 +1937,7 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 			TxID:               tx.ID(),
 			DestinationChainID: dest,
 		}
-		wtb, err := wt.Marshal()
+		wtb, err := chain.MarshalWarpPayload(wt)
 		gomega.Ω(err).Should(gomega.BeNil())
 		wm, err := warp.NewUnsignedMessage(networkID, instances[0].chainID, wtb)
 		gomega.Ω(err).Should(gomega.BeNil())
@@ -1949,7 +1974,1006 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 		gomega.Ω(result.Success).Should(gomega.BeFalse())
 		gomega.Ω(string(result.Output)).Should(gomega.ContainSubstring("not warp asset"))
 	})
-})
+	ginkgo.It("create account", func() {
+		var err error
+		accountPriv, err = secp256r1.GeneratePrivateKey()
+		gomega.Ω(err).Should(gomega.BeNil())
+		accountPk := accountPriv.PublicKey()
+		signers := []*storage.AccountSigner{
+			{KeyType: storage.ED25519Signer, Key: rsender[:], Weight: 1},
+			{KeyType: storage.ED25519Signer, Key: rsender2[:], Weight: 1},
+			{KeyType: storage.SECP256R1Signer, Key: accountPk[:], Weight: 1},
+		}
+		parser, err := instances[0].tcli.Parser(context.Background())
+		gomega.Ω(err).Should(gomega.BeNil())
+		submit, tx, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			&actions.CreateAccount{
+				Threshold: 2,
+				Signers:   signers,
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept(false)
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+
+		account = storage.AccountAddress(tx.ID())
+		threshold, rsigners, err := instances[0].tcli.Account(context.TODO(), utils.Address(account))
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(threshold).Should(gomega.Equal(uint8(2)))
+		gomega.Ω(rsigners).Should(gomega.Equal(signers))
+	})
+
+	ginkgo.It("create account with unreachable threshold", func() {
+		parser, err := instances[0].tcli.Parser(context.Background())
+		gomega.Ω(err).Should(gomega.BeNil())
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			&actions.CreateAccount{
+				Threshold: 2,
+				Signers: []*storage.AccountSigner{
+					{KeyType: storage.ED25519Signer, Key: rsender[:], Weight: 1},
+				},
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept(false)
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		result := results[0]
+		gomega.Ω(result.Success).Should(gomega.BeFalse())
+		gomega.Ω(string(result.Output)).Should(gomega.ContainSubstring("threshold is unreachable"))
+	})
+
+	ginkgo.It("fund account", func() {
+		parser, err := instances[0].tcli.Parser(context.Background())
+		gomega.Ω(err).Should(gomega.BeNil())
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			&actions.Transfer{
+				To:    account,
+				Value: 100_000,
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept(false)
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+	})
+
+	ginkgo.It("reject account tx below threshold", func() {
+		parser, err := instances[0].tcli.Parser(context.Background())
+		gomega.Ω(err).Should(gomega.BeNil())
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			&actions.Transfer{
+				To:    rsender,
+				Value: 1,
+			},
+			auth.NewAccountFactory(account, []ed25519.PrivateKey{priv}, nil),
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background()).Error()).
+			Should(gomega.ContainSubstring(auth.ErrThresholdNotMet.Error()))
+	})
+
+	ginkgo.It("rotate account signer", func() {
+		var err error
+		rotatedPriv, err = ed25519.GeneratePrivateKey()
+		gomega.Ω(err).Should(gomega.BeNil())
+		rotatedPk := rotatedPriv.PublicKey()
+		parser, err := instances[0].tcli.Parser(context.Background())
+		gomega.Ω(err).Should(gomega.BeNil())
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			&actions.RotateAccountSigner{
+				KeyType:    storage.ED25519Signer,
+				Key:        rsender2[:],
+				NewKeyType: storage.ED25519Signer,
+				NewKey:     rotatedPk[:],
+			},
+			auth.NewAccountFactory(account, []ed25519.PrivateKey{priv}, []secp256r1.PrivateKey{accountPriv}),
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept(false)
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+
+		_, signers, err := instances[0].tcli.Account(context.TODO(), utils.Address(account))
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(signers).Should(gomega.HaveLen(3))
+		gomega.Ω(signers[1].Key).Should(gomega.Equal(rotatedPk[:]))
+		gomega.Ω(signers[1].Weight).Should(gomega.Equal(uint8(1)))
+	})
+
+	ginkgo.It("reject rotated account signer", func() {
+		parser, err := instances[0].tcli.Parser(context.Background())
+		gomega.Ω(err).Should(gomega.BeNil())
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			&actions.Transfer{
+				To:    rsender,
+				Value: 1,
+			},
+			auth.NewAccountFactory(account, []ed25519.PrivateKey{priv, priv2}, nil),
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background()).Error()).
+			Should(gomega.ContainSubstring(auth.ErrUnauthorizedSigner.Error()))
+	})
+
+	ginkgo.It("transfer from account", func() {
+		parser, err := instances[0].tcli.Parser(context.Background())
+		gomega.Ω(err).Should(gomega.BeNil())
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			&actions.Transfer{
+				To:    rsender2,
+				Value: 1,
+			},
+			auth.NewAccountFactory(account, []ed25519.PrivateKey{rotatedPriv}, []secp256r1.PrivateKey{accountPriv}),
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept(false)
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+	})
+
+	ginkgo.It("reject replayed account tx", func() {
+		parser, err := instances[0].tcli.Parser(context.Background())
+		gomega.Ω(err).Should(gomega.BeNil())
+		submit, tx, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			&actions.Transfer{
+				To:    rsender2,
+				Value: 1,
+			},
+			auth.NewAccountFactory(account, []ed25519.PrivateKey{rotatedPriv}, []secp256r1.PrivateKey{accountPriv}),
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept(false)
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+
+		// Reordering the signatures of an accepted tx would give it a new ID
+		sigs := tx.Auth.(*auth.Account).Signatures
+		gomega.Ω(sigs).Should(gomega.HaveLen(2))
+		replay := chain.NewTx(tx.Base, nil, tx.Action)
+		replay.Auth = &auth.Account{Address: account, Signatures: []*auth.AccountSignature{sigs[1], sigs[0]}}
+		p := codec.NewWriter(0, consts.NetworkSizeLimit)
+		gomega.Ω(replay.Marshal(p)).Should(gomega.BeNil())
+		_, err = instances[0].cli.SubmitTx(context.Background(), p.Bytes())
+		gomega.Ω(err.Error()).Should(gomega.ContainSubstring(auth.ErrUnsortedSignatures.Error()))
+
+		// Dropping a signature falls below the threshold
+		replay.Auth = &auth.Account{Address: account, Signatures: sigs[:1]}
+		p = codec.NewWriter(0, consts.NetworkSizeLimit)
+		gomega.Ω(replay.Marshal(p)).Should(gomega.BeNil())
+		_, err = instances[0].cli.SubmitTx(context.Background(), p.Bytes())
+		gomega.Ω(err.Error()).Should(gomega.ContainSubstring(auth.ErrThresholdNotMet.Error()))
+
+		// Txs with more signatures than needed (which could be dropped after
+		// the tx is accepted) are rejected
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			&actions.Transfer{
+				To:    rsender2,
+				Value: 1,
+			},
+			auth.NewAccountFactory(account, []ed25519.PrivateKey{priv, rotatedPriv}, []secp256r1.PrivateKey{accountPriv}),
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background()).Error()).
+			Should(gomega.ContainSubstring(auth.ErrUnneededSignature.Error()))
+	})
+
+	ginkgo.It("remove account signer", func() {
+		parser, err := instances[0].tcli.Parser(context.Background())
+		gomega.Ω(err).Should(gomega.BeNil())
+		accountPk := accountPriv.PublicKey()
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			&actions.RemoveAccountSigner{
+				KeyType:   storage.SECP256R1Signer,
+				Key:       accountPk[:],
+				Threshold: 3,
+			},
+			auth.NewAccountFactory(account, []ed25519.PrivateKey{priv}, []secp256r1.PrivateKey{accountPriv}),
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept(false)
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		result := results[0]
+		gomega.Ω(result.Success).Should(gomega.BeFalse())
+		gomega.Ω(string(result.Output)).Should(gomega.ContainSubstring("threshold is unreachable"))
+
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			&actions.RemoveAccountSigner{
+				KeyType:   storage.SECP256R1Signer,
+				Key:       accountPk[:],
+				Threshold: 1,
+			},
+			auth.NewAccountFactory(account, []ed25519.PrivateKey{priv}, []secp256r1.PrivateKey{accountPriv}),
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept = expectBlk(instances[0])
+		results = accept(false)
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+
+		threshold, signers, err := instances[0].tcli.Account(context.TODO(), utils.Address(account))
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(threshold).Should(gomega.Equal(uint8(1)))
+		gomega.Ω(signers).Should(gomega.HaveLen(2))
+	})
+
+	ginkgo.It("fund multisig", func() {
+		var err error
+		multisigPriv, err = secp256r1.GeneratePrivateKey()
+		gomega.Ω(err).Should(gomega.BeNil())
+		multisigPk := multisigPriv.PublicKey()
+		multisigSigners = []*auth.MultisigSigner{
+			{KeyType: storage.ED25519Signer, Key: rsender[:]},
+			{KeyType: storage.ED25519Signer, Key: rsender2[:]},
+			{KeyType: storage.SECP256R1Signer, Key: multisigPk[:]},
+		}
+		multisig, err = auth.NewMultisigAddress(2, multisigSigners)
+		gomega.Ω(err).Should(gomega.BeNil())
+
+		parser, err := instances[0].tcli.Parser(context.Background())
+		gomega.Ω(err).Should(gomega.BeNil())
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			&actions.Transfer{
+				To:    multisig,
+				Value: 100_000,
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept(false)
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+	})
+
+	ginkgo.It("reject multisig tx below threshold", func() {
+		parser, err := instances[0].tcli.Parser(context.Background())
+		gomega.Ω(err).Should(gomega.BeNil())
+		_, _, _, err = instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			&actions.Transfer{
+				To:    rsender,
+				Value: 1,
+			},
+			auth.NewMultisigFactory(2, multisigSigners, []ed25519.PrivateKey{priv}, nil),
+		)
+		gomega.Ω(err).Should(gomega.MatchError(auth.ErrThresholdNotMet))
+
+		rotatedPk := rotatedPriv.PublicKey()
+		f := auth.NewMultisigFactory(2, multisigSigners, []ed25519.PrivateKey{priv}, nil)
+		gomega.Ω(f.AddSignature(storage.ED25519Signer, rotatedPk[:], make([]byte, ed25519.SignatureLen))).
+			Should(gomega.MatchError(auth.ErrUnauthorizedSigner))
+	})
+
+	ginkgo.It("transfer from multisig with offline signature", func() {
+		parser, err := instances[0].tcli.Parser(context.Background())
+		gomega.Ω(err).Should(gomega.BeNil())
+		actionRegistry, authRegistry := parser.Registry()
+		action := &actions.Transfer{
+			To:    rsender2,
+			Value: 1,
+		}
+
+		// Construct unsigned transaction
+		unitPrices, err := instances[0].cli.UnitPrices(context.Background(), false)
+		gomega.Ω(err).Should(gomega.BeNil())
+		maxUnits, err := chain.EstimateMaxUnits(
+			parser.Rules(time.Now().UnixMilli()),
+			action,
+			auth.NewMultisigFactory(2, multisigSigners, nil, nil),
+			nil,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		maxFee, err := chain.MulSum(unitPrices, maxUnits)
+		gomega.Ω(err).Should(gomega.BeNil())
+		now := time.Now().UnixMilli()
+		tx := chain.NewTx(&chain.Base{
+			Timestamp: hutils.UnixRMilli(now, parser.Rules(now).GetValidityWindow()),
+			ChainID:   parser.Rules(now).ChainID(),
+			MaxFee:    maxFee,
+		}, nil, action)
+		digest, err := tx.Digest()
+		gomega.Ω(err).Should(gomega.BeNil())
+
+		// Co-signer signs the exported digest
+		unsigned, err := chain.UnmarshalUnsignedTx(codec.NewReader(digest, consts.NetworkSizeLimit), actionRegistry)
+		gomega.Ω(err).Should(gomega.BeNil())
+		msg, err := unsigned.Digest()
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(msg).Should(gomega.Equal(digest))
+		_, err = chain.UnmarshalUnsignedTx(codec.NewReader(append(digest, 0), consts.NetworkSizeLimit), actionRegistry)
+		gomega.Ω(err).Should(gomega.MatchError(chain.ErrInvalidObject))
+		sig, err := secp256r1.Sign(msg, multisigPriv)
+		gomega.Ω(err).Should(gomega.BeNil())
+
+		// Tampered signatures are rejected
+		f := auth.NewMultisigFactory(2, multisigSigners, []ed25519.PrivateKey{priv}, nil)
+		badSig := make([]byte, len(sig))
+		copy(badSig, sig[:])
+		badSig[0]++
+		gomega.Ω(f.AddSignature(storage.SECP256R1Signer, multisigSigners[2].Key, badSig)).Should(gomega.BeNil())
+		signed, err := unsigned.Sign(f, actionRegistry, authRegistry)
+		gomega.Ω(err).Should(gomega.BeNil())
+		_, err = instances[0].cli.SubmitTx(context.Background(), signed.Bytes())
+		gomega.Ω(err).Should(gomega.Not(gomega.BeNil()))
+
+		// Collected signatures are combined into a multisig
+		gomega.Ω(f.AddSignature(storage.SECP256R1Signer, multisigSigners[2].Key, sig[:])).Should(gomega.BeNil())
+		signed, err = unsigned.Sign(f, actionRegistry, authRegistry)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(signed.Auth.(*auth.Multisig).Signatures).Should(gomega.HaveLen(2))
+		_, err = instances[0].cli.SubmitTx(context.Background(), signed.Bytes())
+		gomega.Ω(err).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept(false)
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+
+		balance, err := instances[0].tcli.Balance(context.TODO(), utils.Address(multisig), ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.BeNumerically("<", 100_000-1))
+	})
+
+	ginkgo.It("create asset with sponsored fees", func() {
+		var err error
+		sponsoredPriv, err = ed25519.GeneratePrivateKey()
+		gomega.Ω(err).Should(gomega.BeNil())
+		sponsoredPk := sponsoredPriv.PublicKey()
+		parser, err := instances[0].tcli.Parser(context.Background())
+		gomega.Ω(err).Should(gomega.BeNil())
+		action := &actions.CreateAsset{
+			Symbol:   []byte("SPON"),
+			Decimals: 0,
+			Metadata: []byte("sponsored"),
+		}
+
+		// Actor can't pay fees on its own
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			action,
+			auth.NewED25519Factory(sponsoredPriv),
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.Not(gomega.BeNil()))
+
+		sponsorBalance, err := instances[0].tcli.Balance(context.TODO(), sender, ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+		submit, tx, fee, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			action,
+			auth.NewSponsoredFactory(auth.NewED25519Factory(sponsoredPriv), factory, rsender),
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept(false)
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+
+		// Action is executed as the actor and fees are paid by the sponsor
+		exists, _, _, _, _, owner, _, err := instances[0].tcli.Asset(context.TODO(), tx.ID(), false)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(exists).Should(gomega.BeTrue())
+		gomega.Ω(owner).Should(gomega.Equal(utils.Address(sponsoredPk)))
+		balance, err := instances[0].tcli.Balance(context.TODO(), sender, ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.BeNumerically(">=", sponsorBalance-fee))
+		gomega.Ω(balance).Should(gomega.BeNumerically("<", sponsorBalance))
+		balance, err = instances[0].tcli.Balance(context.TODO(), utils.Address(sponsoredPk), ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.Equal(uint64(0)))
+	})
+
+	ginkgo.It("reject self-sponsored tx", func() {
+		parser, err := instances[0].tcli.Parser(context.Background())
+		gomega.Ω(err).Should(gomega.BeNil())
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			&actions.Transfer{
+				To:    rsender2,
+				Value: 1,
+			},
+			auth.NewSponsoredFactory(factory, factory, rsender),
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background()).Error()).
+			Should(gomega.ContainSubstring(auth.ErrSelfSponsored.Error()))
+	})
+
+	ginkgo.It("reject sponsored tx with replaced sponsor", func() {
+		parser, err := instances[0].tcli.Parser(context.Background())
+		gomega.Ω(err).Should(gomega.BeNil())
+		actionRegistry, authRegistry := parser.Registry()
+		_, tx, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			&actions.Transfer{
+				To:    rsender,
+				Value: 1,
+			},
+			auth.NewSponsoredFactory(factory2, factory, rsender),
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+
+		// Reuse the signature of the actor with a different sponsor
+		sponsoredPk := sponsoredPriv.PublicKey()
+		digest, err := tx.Digest()
+		gomega.Ω(err).Should(gomega.BeNil())
+		sponsor, err := auth.NewED25519Factory(sponsoredPriv).Sign(auth.SponsoredMessage(digest, sponsoredPk), tx.Action)
+		gomega.Ω(err).Should(gomega.BeNil())
+		replay := chain.NewTx(tx.Base, nil, tx.Action)
+		replay, err = replay.Sign(
+			&presignedFactory{&auth.Sponsored{Actor: tx.Auth.(*auth.Sponsored).Actor, Sponsor: sponsor}},
+			actionRegistry,
+			authRegistry,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		_, err = instances[0].cli.SubmitTx(context.Background(), replay.Bytes())
+		gomega.Ω(err).Should(gomega.Not(gomega.BeNil()))
+	})
+
+	ginkgo.It("register session key", func() {
+		var err error
+		sessionPriv, err = ed25519.GeneratePrivateKey()
+		gomega.Ω(err).Should(gomega.BeNil())
+		sessionExpiry = time.Now().Add(time.Hour).UnixMilli()
+		parser, err := instances[0].tcli.Parser(context.Background())
+		gomega.Ω(err).Should(gomega.BeNil())
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			&actions.RegisterSession{
+				Key:     sessionPriv.PublicKey(),
+				Expiry:  sessionExpiry,
+				Actions: []uint8{(&actions.Transfer{}).GetTypeID()},
+				Limits:  []*storage.SpendLimit{{Asset: ids.Empty, Amount: 1_000_000}},
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept(false)
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+
+		expiry, allowed, limits, err := instances[0].tcli.Session(
+			context.TODO(),
+			sender,
+			utils.Address(sessionPriv.PublicKey()),
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(expiry).Should(gomega.Equal(sessionExpiry))
+		gomega.Ω(allowed).Should(gomega.Equal([]uint8{(&actions.Transfer{}).GetTypeID()}))
+		gomega.Ω(limits).Should(gomega.HaveLen(1))
+		gomega.Ω(limits[0].Amount).Should(gomega.Equal(uint64(1_000_000)))
+	})
+
+	ginkgo.It("transfer with session key", func() {
+		parser, err := instances[0].tcli.Parser(context.Background())
+		gomega.Ω(err).Should(gomega.BeNil())
+		balance, err := instances[0].tcli.Balance(context.TODO(), sender2, ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+		submit, _, fee, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			&actions.Transfer{
+				To:    rsender2,
+				Value: 1000,
+			},
+			auth.NewSessionFactory(rsender, sessionPriv, sessionExpiry),
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept(false)
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+
+		// Transfer and fees are spent from the actor and count towards the limit
+		newBalance, err := instances[0].tcli.Balance(context.TODO(), sender2, ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(newBalance).Should(gomega.Equal(balance + 1000))
+		_, _, limits, err := instances[0].tcli.Session(
+			context.TODO(),
+			sender,
+			utils.Address(sessionPriv.PublicKey()),
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(limits[0].Amount).Should(gomega.BeNumerically("<", 1_000_000-1000))
+		gomega.Ω(limits[0].Amount).Should(gomega.BeNumerically(">=", 1_000_000-1000-fee))
+	})
+
+	ginkgo.It("reject session key outside of its scope", func() {
+		parser, err := instances[0].tcli.Parser(context.Background())
+		gomega.Ω(err).Should(gomega.BeNil())
+		sessionFactory := auth.NewSessionFactory(rsender, sessionPriv, sessionExpiry)
+
+		// Action is not allowed
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			&actions.CreateAsset{
+				Symbol:   []byte("SESS"),
+				Decimals: 0,
+				Metadata: []byte("session"),
+			},
+			sessionFactory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background()).Error()).
+			Should(gomega.ContainSubstring(auth.ErrActionNotAllowed.Error()))
+
+		// Spend limit is exceeded
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			&actions.Transfer{
+				To:    rsender2,
+				Value: 1_000_000,
+			},
+			sessionFactory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background()).Error()).
+			Should(gomega.ContainSubstring(auth.ErrSpendLimitExceeded.Error()))
+
+		// Asset without a limit can't be spent
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			&actions.Transfer{
+				To:    rsender2,
+				Asset: asset1ID,
+				Value: 1,
+			},
+			sessionFactory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background()).Error()).
+			Should(gomega.ContainSubstring(auth.ErrSpendLimitExceeded.Error()))
+
+		// Expiry must match the registered session
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			&actions.Transfer{
+				To:    rsender2,
+				Value: 1,
+			},
+			auth.NewSessionFactory(rsender, sessionPriv, sessionExpiry+1),
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background()).Error()).
+			Should(gomega.ContainSubstring(auth.ErrWrongSessionExpiry.Error()))
+	})
+
+	ginkgo.It("reject account signer changes by session key", func() {
+		parser, err := instances[0].tcli.Parser(context.Background())
+		gomega.Ω(err).Should(gomega.BeNil())
+
+		// Even if the session is allowed to use the action, it can't change who
+		// controls the account
+		priv, err := ed25519.GeneratePrivateKey()
+		gomega.Ω(err).Should(gomega.BeNil())
+		expiry := time.Now().Add(time.Hour).UnixMilli()
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			&actions.RegisterSession{
+				Key:    priv.PublicKey(),
+				Expiry: expiry,
+				Actions: []uint8{
+					(&actions.AddAccountSigner{}).GetTypeID(),
+					(&actions.RemoveAccountSigner{}).GetTypeID(),
+					(&actions.RotateAccountSigner{}).GetTypeID(),
+				},
+				Limits: []*storage.SpendLimit{{Asset: ids.Empty, Amount: 1_000_000}},
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept(false)
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+
+		pk := priv.PublicKey()
+		signer := &storage.AccountSigner{KeyType: storage.ED25519Signer, Key: pk[:], Weight: 1}
+		for _, action := range []chain.Action{
+			&actions.AddAccountSigner{Signer: signer, Threshold: 1},
+			&actions.RemoveAccountSigner{KeyType: signer.KeyType, Key: signer.Key, Threshold: 1},
+			&actions.RotateAccountSigner{KeyType: signer.KeyType, Key: signer.Key, NewKeyType: signer.KeyType, NewKey: signer.Key},
+		} {
+			submit, _, _, err := instances[0].cli.GenerateTransaction(
+				context.Background(),
+				parser,
+				nil,
+				action,
+				auth.NewSessionFactory(rsender, priv, expiry),
+			)
+			gomega.Ω(err).Should(gomega.BeNil())
+			gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+			accept := expectBlk(instances[0])
+			results := accept(false)
+			gomega.Ω(results).Should(gomega.HaveLen(1))
+			gomega.Ω(results[0].Success).Should(gomega.BeFalse())
+			gomega.Ω(results[0].Output).Should(gomega.Equal(actions.OutputSessionNotPermitted))
+		}
+	})
+
+	ginkgo.It("revoke session key", func() {
+		parser, err := instances[0].tcli.Parser(context.Background())
+		gomega.Ω(err).Should(gomega.BeNil())
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			&actions.RevokeSession{
+				Key: sessionPriv.PublicKey(),
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept(false)
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			&actions.Transfer{
+				To:    rsender2,
+				Value: 1,
+			},
+			auth.NewSessionFactory(rsender, sessionPriv, sessionExpiry),
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background()).Error()).
+			Should(gomega.ContainSubstring(auth.ErrSessionMissing.Error()))
+	})
+
+	ginkgo.It("fund bls addresses", func() {
+		parser, err := instances[0].tcli.Parser(context.Background())
+		gomega.Ω(err).Should(gomega.BeNil())
+		blsPrivs = make([]hbls.PrivateKey, 2)
+		for i := range blsPrivs {
+			blsPrivs[i], err = hbls.GeneratePrivateKey()
+			gomega.Ω(err).Should(gomega.BeNil())
+			submit, _, _, err := instances[0].cli.GenerateTransaction(
+				context.Background(),
+				parser,
+				nil,
+				&actions.Transfer{
+					To:    auth.NewBLSAddress(blsPrivs[i].PublicKey()),
+					Value: 100_000,
+				},
+				factory,
+			)
+			gomega.Ω(err).Should(gomega.BeNil())
+			gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+			accept := expectBlk(instances[0])
+			results := accept(false)
+			gomega.Ω(results).Should(gomega.HaveLen(1))
+			gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+		}
+	})
+
+	ginkgo.It("aggregate bls signatures in block", func() {
+		ctx := context.TODO()
+		parser, err := instances[0].tcli.Parser(context.Background())
+		gomega.Ω(err).Should(gomega.BeNil())
+		txs := make([]*chain.Transaction, len(blsPrivs))
+		for i, priv := range blsPrivs {
+			submit, tx, _, err := instances[0].cli.GenerateTransaction(
+				context.Background(),
+				parser,
+				nil,
+				&actions.Transfer{
+					To:    rsender2,
+					Value: 1000,
+				},
+				auth.NewBLSFactory(priv),
+			)
+			gomega.Ω(err).Should(gomega.BeNil())
+			gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+
+			// Signature is not charged for bandwidth
+			gomega.Ω(tx.Size()).Should(gomega.Equal(len(tx.Bytes()) - hbls.SignatureLen))
+			txs[i] = tx
+		}
+
+		gomega.Ω(instances[0].vm.Builder().Force(ctx)).To(gomega.BeNil())
+		<-instances[0].toEngine
+		blk, err := instances[0].vm.BuildBlock(ctx)
+		gomega.Ω(err).To(gomega.BeNil())
+		gomega.Ω(blk.Verify(ctx)).To(gomega.BeNil())
+		sblk := blk.(*chain.StatelessBlock)
+		gomega.Ω(sblk.Txs).Should(gomega.HaveLen(len(txs)))
+		gomega.Ω(sblk.AuthSignature).Should(gomega.HaveLen(hbls.SignatureLen))
+
+		// Signatures are removed from the transactions in the block
+		stateful, err := chain.UnmarshalBlock(blk.Bytes(), instances[0].vm)
+		gomega.Ω(err).Should(gomega.BeNil())
+		for i, tx := range stateful.Txs {
+			gomega.Ω(tx.ID()).Should(gomega.Equal(sblk.Txs[i].ID()))
+			gomega.Ω(tx.Auth.(*auth.BLS).Signature).Should(gomega.BeNil())
+		}
+
+		// Block with an invalid aggregate signature is rejected
+		stateful.AuthSignature = txs[0].Auth.(*auth.BLS).Signature[:]
+		invalidBytes, err := stateful.Marshal()
+		gomega.Ω(err).Should(gomega.BeNil())
+		invalid, err := instances[0].vm.ParseBlock(ctx, invalidBytes)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(invalid.Verify(ctx)).Should(gomega.Not(gomega.BeNil()))
+
+		gomega.Ω(instances[0].vm.SetPreference(ctx, blk.ID())).To(gomega.BeNil())
+		gomega.Ω(blk.Accept(ctx)).To(gomega.BeNil())
+		for _, result := range sblk.Results() {
+			gomega.Ω(result.Success).Should(gomega.BeTrue())
+		}
+		balance, err := instances[0].tcli.Balance(context.TODO(), utils.Address(auth.NewBLSAddress(blsPrivs[0].PublicKey())), ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.BeNumerically("<", 100_000-1000))
+	})
+
+	ginkgo.It("reject peer-built block with bls txs", func() {
+		ctx := context.TODO()
+		parser, err := instances[0].tcli.Parser(context.Background())
+		gomega.Ω(err).Should(gomega.BeNil())
+		txs := make([]*chain.Transaction, 0, 2)
+		for _, f := range []chain.AuthFactory{auth.NewBLSFactory(blsPrivs[0]), factory} {
+			submit, tx, _, err := instances[0].cli.GenerateTransaction(
+				context.Background(),
+				parser,
+				nil,
+				&actions.Transfer{
+					To:    rsender2,
+					Value: 1001,
+				},
+				f,
+			)
+			gomega.Ω(err).Should(gomega.BeNil())
+			gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+			txs = append(txs, tx)
+		}
+
+		// Parsing the block (instead of verifying the one we built) yields the
+		// transactions a peer would see (without BLS signatures)
+		gomega.Ω(instances[0].vm.Builder().Force(ctx)).To(gomega.BeNil())
+		<-instances[0].toEngine
+		built, err := instances[0].vm.BuildBlock(ctx)
+		gomega.Ω(err).To(gomega.BeNil())
+		gomega.Ω(instances[0].vm.Mempool().Len(ctx)).Should(gomega.Equal(0))
+		blk, err := chain.ParseBlock(ctx, built.Bytes(), choices.Processing, instances[0].vm)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(blk.Txs).Should(gomega.HaveLen(len(txs)))
+		gomega.Ω(blk.Txs[0].Auth.(*auth.BLS).Signature).Should(gomega.BeNil())
+		gomega.Ω(blk.Verify(ctx)).To(gomega.BeNil())
+
+		// Only transactions that can be included in another block are
+		// restored to the mempool
+		gomega.Ω(blk.Reject(ctx)).To(gomega.BeNil())
+		restored := instances[0].vm.Mempool().Peek(ctx, 10)
+		gomega.Ω(restored).Should(gomega.HaveLen(1))
+		gomega.Ω(restored[0].ID()).Should(gomega.Equal(txs[1].ID()))
+
+		accept := expectBlk(instances[0])
+		results := accept(false)
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+	})
+
+	ginkgo.It("reject bls tx without signature", func() {
+		parser, err := instances[0].tcli.Parser(context.Background())
+		gomega.Ω(err).Should(gomega.BeNil())
+		actionRegistry, authRegistry := parser.Registry()
+		tx := chain.NewTx(
+			&chain.Base{
+				Timestamp: hutils.UnixRMilli(time.Now().UnixMilli(), parser.Rules(0).GetValidityWindow()),
+				ChainID:   instances[0].chainID,
+				MaxFee:    1_000_000,
+			},
+			nil,
+			&actions.Transfer{
+				To:    rsender2,
+				Value: 1,
+			},
+		)
+		tx, err = tx.Sign(
+			&presignedFactory{&auth.BLS{Signer: blsPrivs[0].PublicKey()}},
+			actionRegistry,
+			authRegistry,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		_, err = instances[0].cli.SubmitTx(context.Background(), tx.Bytes())
+		gomega.Ω(err).Should(gomega.Not(gomega.BeNil()))
+	})
+
+	ginkgo.It("transfer with external signer", func() {
+		dir, err := os.MkdirTemp("", "token-signer")
+		gomega.Ω(err).Should(gomega.BeNil())
+		defer os.RemoveAll(dir)
+		socket := filepath.Join(dir, "signer.sock")
+		listener, err := net.Listen("unix", socket)
+		gomega.Ω(err).Should(gomega.BeNil())
+		handler, err := server.NewHandler(
+			signer.NewJSONRPCServer(signer.New(
+				logging.NoLog{},
+				tconsts.ActionRegistry,
+				factory,
+				rsender,
+				policy.New(&policy.Config{
+					ChainID:        instances[0].chainID,
+					AllowedActions: []uint8{(&actions.Transfer{}).GetTypeID()},
+					SpendLimits:    []*storage.SpendLimit{{Asset: ids.Empty, Amount: 10_000}},
+				}),
+			)),
+			signer.Name,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		token, err := signer.GenerateToken()
+		gomega.Ω(err).Should(gomega.BeNil())
+		handler, err = signer.RequireToken(handler, token)
+		gomega.Ω(err).Should(gomega.BeNil())
+		srv := &http.Server{Handler: handler, ReadHeaderTimeout: requestTimeout}
+		go func() { _ = srv.Serve(listener) }()
+		defer srv.Close()
+
+		// Clients without the token are rejected
+		scli, err := signer.NewJSONRPCClient("unix://"+socket, "wrong token")
+		gomega.Ω(err).Should(gomega.BeNil())
+		_, err = signer.NewFactory(context.Background(), scli, tconsts.AuthRegistry)
+		gomega.Ω(err).Should(gomega.Not(gomega.BeNil()))
+
+		scli, err = signer.NewJSONRPCClient("unix://"+socket, token)
+		gomega.Ω(err).Should(gomega.BeNil())
+		remoteFactory, err := signer.NewFactory(context.Background(), scli, tconsts.AuthRegistry)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(remoteFactory.Address()).Should(gomega.Equal(rsender))
+
+		parser, err := instances[0].tcli.Parser(context.Background())
+		gomega.Ω(err).Should(gomega.BeNil())
+		balance, err := instances[0].tcli.Balance(context.TODO(), sender2, ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			&actions.Transfer{
+				To:    rsender2,
+				Value: 1000,
+			},
+			remoteFactory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept(false)
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+		newBalance, err := instances[0].tcli.Balance(context.TODO(), sender2, ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(newBalance).Should(gomega.Equal(balance + 1000))
+
+		// Action is not allowed by the policy
+		_, _, _, err = instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			&actions.CreateAsset{
+				Symbol:   []byte("SIGN"),
+				Decimals: 0,
+				Metadata: []byte("signer"),
+			},
+			remoteFactory,
+		)
+		gomega.Ω(err.Error()).Should(gomega.ContainSubstring(policy.ErrActionNotAllowed.Error()))
+
+		// Spend limit is exceeded
+		_, _, _, err = instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			&actions.Transfer{
+				To:    rsender2,
+				Value: 10_001,
+			},
+			remoteFactory,
+		)
+		gomega.Ω(err.Error()).Should(gomega.ContainSubstring(policy.ErrSpendLimitExceeded.Error()))
+	})
+})
+
+// presignedFactory returns [auth] regardless of the message it is asked to
+// sign.
+type presignedFactory struct {
+	auth chain.Auth
+}
+
+func (p *presignedFactory) Sign([]byte, chain.Action) (chain.Auth, error) {
+	return p.auth, nil
+}
+
+func (*presignedFactory) MaxUnits() (uint64, uint64, []uint16) {
+	return 0, 0, nil
+}
 
 func expectBlk(i instance) func(bool) []*chain.Result {
 	ctx := context.TODO()
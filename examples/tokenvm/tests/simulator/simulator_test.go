@@ -0,0 +1,180 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simulator_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/crypto/ed25519"
+	"github.com/ava-labs/hypersdk/simulator"
+	hutils "github.com/ava-labs/hypersdk/utils"
+	"github.com/ava-labs/hypersdk/vm"
+
+	"github.com/ava-labs/hypersdk/examples/tokenvm/actions"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/auth"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/controller"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/genesis"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/storage"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/utils"
+)
+
+const initialBalance = 10_000_000
+
+type network struct {
+	*simulator.Network
+
+	factory chain.AuthFactory
+}
+
+func newNetwork(t *testing.T, nodes int) *network {
+	require := require.New(t)
+
+	priv := newKey(t, 0)
+	gen := genesis.Default()
+	gen.MinUnitPrice = chain.Dimensions{1, 1, 1, 1, 1}
+	gen.MinBlockGap = 0
+	gen.CustomAllocation = []*genesis.CustomAllocation{
+		{Address: utils.Address(priv.PublicKey()), Balance: initialBalance},
+	}
+	genesisBytes, err := json.Marshal(gen)
+	require.NoError(err)
+
+	cfg := simulator.DefaultConfig()
+	cfg.Nodes = nodes
+	cfg.Seed = 1
+	cfg.GenesisBytes = genesisBytes
+	cfg.ConfigBytes = []byte(`{"testMode":true,"logLevel":"off"}`)
+	// The VMs compare request deadlines against their own clock
+	cfg.Start = time.Now()
+	n, err := simulator.New(
+		context.Background(),
+		logging.NewFactory(logging.Config{DisplayLevel: logging.Off, LogLevel: logging.Off}),
+		cfg,
+		func() simulator.VM { return controller.New() },
+	)
+	require.NoError(err)
+	t.Cleanup(func() {
+		require.NoError(n.Shutdown(context.Background()))
+	})
+	require.NoError(n.Start(context.Background()))
+
+	// Deliver protocol handshakes
+	n.Advance(context.Background(), time.Second)
+	return &network{n, auth.NewED25519Factory(priv)}
+}
+
+// newKey deterministically derives a key from [i].
+func newKey(t *testing.T, i byte) ed25519.PrivateKey {
+	seed := make([]byte, ed25519.PrivateKeySeedLen)
+	seed[0] = i
+	priv, err := ed25519.PrivateKeyFromSeed(seed)
+	require.NoError(t, err)
+	return priv
+}
+
+func hyperVM(node *simulator.Node) *vm.VM {
+	return node.VM.(*vm.VM)
+}
+
+func (n *network) transfer(t *testing.T, node *simulator.Node, to ed25519.PublicKey, value uint64) *chain.Transaction {
+	require := require.New(t)
+
+	tx := chain.NewTx(
+		&chain.Base{
+			Timestamp: hutils.UnixRMilli(-1, 30*1000),
+			ChainID:   n.ChainID(),
+			MaxFee:    1_000,
+		},
+		nil,
+		&actions.Transfer{To: to, Value: value},
+	)
+	actionRegistry, authRegistry := hyperVM(node).Registry()
+	tx, err := tx.Sign(n.factory, actionRegistry, authRegistry)
+	require.NoError(err)
+	for _, err := range hyperVM(node).Submit(context.Background(), true, []*chain.Transaction{tx}) {
+		require.NoError(err)
+	}
+	return tx
+}
+
+func balance(t *testing.T, node *simulator.Node, pk ed25519.PublicKey) uint64 {
+	bal, err := storage.GetBalanceFromState(context.Background(), hyperVM(node).ReadState, pk, ids.Empty)
+	require.NoError(t, err)
+	return bal
+}
+
+func TestGossipAndBuild(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	n := newNetwork(t, 3)
+	nodes := n.Nodes()
+	recipient := newKey(t, 1)
+
+	// Transactions submitted to one node are gossiped to the others
+	tx := n.transfer(t, nodes[0], recipient.PublicKey(), 100)
+	require.NoError(hyperVM(nodes[0]).Gossiper().Force(ctx))
+	for _, node := range nodes[1:] {
+		require.Zero(hyperVM(node).Mempool().Len(ctx))
+	}
+	n.Advance(ctx, time.Second)
+	for _, node := range nodes[1:] {
+		require.Equal(1, hyperVM(node).Mempool().Len(ctx))
+	}
+
+	// A block built by another node is accepted everywhere
+	blk, err := n.BuildBlock(ctx, nodes[1])
+	require.NoError(err)
+	results := blk.(*chain.StatelessBlock).Results()
+	require.Len(results, 1)
+	require.True(results[0].Success)
+	require.Equal(tx.ID(), blk.(*chain.StatelessBlock).Txs[0].ID())
+	for _, node := range nodes {
+		lastAccepted, err := node.VM.LastAccepted(ctx)
+		require.NoError(err)
+		require.Equal(blk.ID(), lastAccepted)
+		require.Zero(hyperVM(node).Mempool().Len(ctx))
+		require.Equal(uint64(100), balance(t, node, recipient.PublicKey()))
+	}
+}
+
+func TestPartitionAndBootstrap(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	n := newNetwork(t, 3)
+	nodes := n.Nodes()
+	recipient := newKey(t, 1)
+
+	// Nodes that are partitioned don't receive gossip or blocks
+	require.NoError(n.Partition(ctx, []ids.NodeID{nodes[2].ID}))
+	n.transfer(t, nodes[0], recipient.PublicKey(), 100)
+	require.NoError(hyperVM(nodes[0]).Gossiper().Force(ctx))
+	n.Advance(ctx, time.Second)
+	require.Equal(1, hyperVM(nodes[1]).Mempool().Len(ctx))
+	require.Zero(hyperVM(nodes[2]).Mempool().Len(ctx))
+
+	blk, err := n.BuildBlock(ctx, nodes[1])
+	require.NoError(err)
+	lastAccepted, err := nodes[2].VM.LastAccepted(ctx)
+	require.NoError(err)
+	require.NotEqual(blk.ID(), lastAccepted)
+	require.Zero(balance(t, nodes[2], recipient.PublicKey()))
+
+	// Once the partition heals, lagging nodes catch up
+	require.NoError(n.Heal(ctx))
+	require.NoError(n.Bootstrap(ctx, nodes[2], nodes[1]))
+	lastAccepted, err = nodes[2].VM.LastAccepted(ctx)
+	require.NoError(err)
+	require.Equal(blk.ID(), lastAccepted)
+	require.Equal(uint64(100), balance(t, nodes[2], recipient.PublicKey()))
+}
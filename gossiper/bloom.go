@@ -0,0 +1,110 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package gossiper
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"math"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+)
+
+const (
+	minBloomHashes = 1
+	maxBloomHashes = 16
+	minBloomBytes  = 8
+)
+
+// bloomFilter is a set of [ids.ID] that may return false positives.
+//
+// Items are salted before they are added, so a collision between two items
+// in one filter is unlikely to persist in the next.
+type bloomFilter struct {
+	salt   ids.ID
+	hashes uint8
+	bits   []byte
+}
+
+// newBloomFilter returns an empty filter sized to hold [count] items with a
+// false positive probability of [falsePositive] that is no larger than
+// [maxBytes].
+func newBloomFilter(count int, falsePositive float64, maxBytes int) (*bloomFilter, error) {
+	n := math.Max(float64(count), 1)
+	m := math.Ceil(-n * math.Log(falsePositive) / (math.Ln2 * math.Ln2)) // bits
+	size := int(math.Min(math.Max(math.Ceil(m/8), minBloomBytes), float64(maxBytes)))
+	k := math.Round(float64(size*8) / n * math.Ln2)
+	k = math.Min(math.Max(k, minBloomHashes), maxBloomHashes)
+
+	f := &bloomFilter{
+		hashes: uint8(k),
+		bits:   make([]byte, size),
+	}
+	if _, err := rand.Read(f.salt[:]); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// indices returns the [hashes] bits of [id] using double hashing. Because
+// transaction IDs are already uniformly distributed, no further hashing is
+// required.
+func (f *bloomFilter) indices(id ids.ID) []uint64 {
+	var salted ids.ID
+	for i := range id {
+		salted[i] = id[i] ^ f.salt[i]
+	}
+	var (
+		m       = uint64(len(f.bits)) * 8
+		h1      = binary.BigEndian.Uint64(salted[:8])
+		h2      = binary.BigEndian.Uint64(salted[8:16]) | 1
+		indices = make([]uint64, f.hashes)
+	)
+	for i := range indices {
+		indices[i] = (h1 + uint64(i)*h2) % m
+	}
+	return indices
+}
+
+func (f *bloomFilter) Add(id ids.ID) {
+	for _, i := range f.indices(id) {
+		f.bits[i/8] |= 1 << (i % 8)
+	}
+}
+
+func (f *bloomFilter) Has(id ids.ID) bool {
+	for _, i := range f.indices(id) {
+		if f.bits[i/8]&(1<<(i%8)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func (f *bloomFilter) Marshal(p *codec.Packer) {
+	p.PackID(f.salt)
+	p.PackByte(f.hashes)
+	p.PackBytes(f.bits)
+}
+
+// maxBloomFilterLen is the size of a marshaled filter of at most [maxBytes].
+func maxBloomFilterLen(maxBytes int) int {
+	return consts.IDLen + consts.ByteLen + consts.IntLen + maxBytes
+}
+
+func unmarshalBloomFilter(p *codec.Packer, maxBytes int) (*bloomFilter, error) {
+	var f bloomFilter
+	p.UnpackID(false, &f.salt)
+	f.hashes = p.UnpackByte()
+	p.UnpackBytes(maxBytes, true, &f.bits)
+	if err := p.Err(); err != nil {
+		return nil, err
+	}
+	if f.hashes < minBloomHashes || f.hashes > maxBloomHashes {
+		return nil, ErrInvalidBloomFilter
+	}
+	return &f, nil
+}
@@ -0,0 +1,132 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package gossiper
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+)
+
+func TestBloomFilter(t *testing.T) {
+	require := require.New(t)
+
+	const (
+		count         = 10_000
+		falsePositive = 0.01
+	)
+	f, err := newBloomFilter(count, falsePositive, 1024*1024)
+	require.NoError(err)
+	added := make([]ids.ID, count)
+	for i := range added {
+		added[i] = ids.GenerateTestID()
+		f.Add(added[i])
+	}
+
+	// There are never false negatives
+	for _, id := range added {
+		require.True(f.Has(id))
+	}
+
+	// False positives are close to the configured probability
+	var falsePositives int
+	for i := 0; i < count; i++ {
+		if f.Has(ids.GenerateTestID()) {
+			falsePositives++
+		}
+	}
+	require.Less(float64(falsePositives)/count, 2*falsePositive)
+}
+
+func TestBloomFilterSize(t *testing.T) {
+	require := require.New(t)
+
+	// Empty filters are still usable
+	f, err := newBloomFilter(0, 0.01, 1024)
+	require.NoError(err)
+	require.Len(f.bits, minBloomBytes)
+	require.GreaterOrEqual(f.hashes, uint8(minBloomHashes))
+	require.False(f.Has(ids.GenerateTestID()))
+
+	// Filters never exceed [maxBytes] (at the cost of more false positives)
+	f, err = newBloomFilter(1_000_000, 0.0001, 1024)
+	require.NoError(err)
+	require.Len(f.bits, 1024)
+	require.LessOrEqual(f.hashes, uint8(maxBloomHashes))
+}
+
+func TestBloomFilterReset(t *testing.T) {
+	require := require.New(t)
+
+	// Find an ID that is a false positive of a small filter
+	items := make([]ids.ID, 8)
+	for i := range items {
+		items[i] = ids.GenerateTestID()
+	}
+	newFilter := func() *bloomFilter {
+		f, err := newBloomFilter(len(items), 0.5, minBloomBytes)
+		require.NoError(err)
+		for _, id := range items {
+			f.Add(id)
+		}
+		return f
+	}
+	f1 := newFilter()
+	var collision ids.ID
+	for {
+		collision = ids.GenerateTestID()
+		if f1.Has(collision) {
+			break
+		}
+	}
+
+	// Each filter is salted differently, so a collision with the same items
+	// is unlikely to persist in the next filter
+	var persisted int
+	for i := 0; i < 100; i++ {
+		f2 := newFilter()
+		require.NotEqual(f1.salt, f2.salt)
+		if f2.Has(collision) {
+			persisted++
+		}
+	}
+	require.Less(persisted, 25)
+}
+
+func TestBloomFilterMarshal(t *testing.T) {
+	require := require.New(t)
+
+	f, err := newBloomFilter(100, 0.01, 1024)
+	require.NoError(err)
+	id := ids.GenerateTestID()
+	f.Add(id)
+
+	p := codec.NewWriter(maxBloomFilterLen(1024), consts.NetworkSizeLimit)
+	f.Marshal(p)
+	require.NoError(p.Err())
+	require.LessOrEqual(len(p.Bytes()), maxBloomFilterLen(1024))
+
+	parsed, err := unmarshalBloomFilter(codec.NewReader(p.Bytes(), consts.NetworkSizeLimit), 1024)
+	require.NoError(err)
+	require.Equal(f, parsed)
+	require.True(parsed.Has(id))
+
+	// Filters larger than [maxBytes] are rejected
+	_, err = unmarshalBloomFilter(codec.NewReader(p.Bytes(), consts.NetworkSizeLimit), len(f.bits)-1)
+	require.Error(err)
+
+	// Filters with an invalid number of hashes are rejected
+	for _, hashes := range []uint8{0, maxBloomHashes + 1} {
+		invalid := *f
+		invalid.hashes = hashes
+		p := codec.NewWriter(maxBloomFilterLen(1024), consts.NetworkSizeLimit)
+		invalid.Marshal(p)
+		_, err = unmarshalBloomFilter(codec.NewReader(p.Bytes(), consts.NetworkSizeLimit), 1024)
+		require.ErrorIs(err, ErrInvalidBloomFilter)
+	}
+}
@@ -8,21 +8,26 @@ import (
 	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/trace"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/network"
 )
 
 type VM interface {
 	NetworkID() uint32
 	ChainID() ids.ID
 	StopChan() chan struct{}
+	IsBootstrapped() bool
 	Tracer() trace.Tracer
 	Mempool() chain.Mempool
 	GetTargetGossipDuration() time.Duration
 	Proposers(ctx context.Context, diff int, depth int) (set.Set[ids.NodeID], error)
 	IsValidator(context.Context, ids.NodeID) (bool, error)
+	CurrentValidators(context.Context) (map[ids.NodeID]*validators.GetValidatorOutput, map[string]struct{})
+	FastestPeers(limit int) []ids.NodeID
 	Logger() logging.Logger
 	PreferredBlock(context.Context) (*chain.StatelessBlock, error)
 	Registry() (chain.ActionRegistry, chain.AuthRegistry)
@@ -30,9 +35,16 @@ type VM interface {
 	Rules(int64) chain.Rules
 	Submit(ctx context.Context, verify bool, txs []*chain.Transaction) []error
 	GetAuthBatchVerifier(authTypeID uint8, cores int, count int) (chain.AuthBatchVerifier, bool)
+	CacheAuth(chain.Auth)
 	StateManager() chain.StateManager
+	ReportPeer(ids.NodeID, network.Offense)
 
 	RecordTxsGossiped(int)
 	RecordSeenTxsReceived(int)
 	RecordTxsReceived(int)
+	RecordPullRequestsSent(int)
+	RecordPullRequestsRateLimited()
+	RecordTxsPulled(int)
+	RecordTxsPullServed(int)
+	RecordGossipInclusionLatency(strategy string, d time.Duration)
 }
@@ -0,0 +1,11 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package gossiper
+
+import "errors"
+
+var (
+	ErrInvalidBloomFilter = errors.New("invalid bloom filter")
+	ErrUnknownStrategy    = errors.New("unknown target strategy")
+)
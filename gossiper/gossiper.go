@@ -8,6 +8,7 @@ import (
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/hypersdk/chain"
 )
 
 type Gossiper interface {
@@ -16,5 +17,13 @@ type Gossiper interface {
 	Force(context.Context) error // may be triggered by run already
 	HandleAppGossip(ctx context.Context, nodeID ids.NodeID, msg []byte) error
 	BlockVerified(int64)
+	BlockAccepted(*chain.StatelessBlock)
 	Done() // wait after stop
+
+	// Pull gossip is sent and received over a separate handler (see [Pull]).
+	RunPull(common.AppSender)
+	ForcePull(context.Context) error
+	HandleAppRequest(ctx context.Context, nodeID ids.NodeID, requestID uint32, msg []byte) error
+	HandleAppResponse(ctx context.Context, nodeID ids.NodeID, requestID uint32, msg []byte) error
+	HandleAppRequestFailed(ctx context.Context, nodeID ids.NodeID, requestID uint32) error
 }
@@ -11,19 +11,28 @@ import (
 	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/hypersdk/chain"
 	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/network"
 	"go.uber.org/zap"
 )
 
 var _ Gossiper = (*Manual)(nil)
 
 type Manual struct {
+	*Pull
+
 	vm         VM
 	appSender  common.AppSender
 	doneGossip chan struct{}
 }
 
+// NewManual returns a [Manual] gossiper that only pulls when [ForcePull] is
+// called.
 func NewManual(vm VM) *Manual {
+	pcfg := DefaultPullConfig()
+	pcfg.Frequency = 0
 	return &Manual{
+		Pull: NewPull(vm, pcfg),
+
 		vm:         vm,
 		doneGossip: make(chan struct{}),
 	}
@@ -92,6 +101,7 @@ func (g *Manual) HandleAppGossip(ctx context.Context, nodeID ids.NodeID, msg []b
 			zap.Stringer("peerID", nodeID),
 			zap.Error(err),
 		)
+		g.vm.ReportPeer(nodeID, network.OffenseInvalidTx)
 		return nil
 	}
 	g.vm.RecordTxsReceived(len(txs))
@@ -118,8 +128,11 @@ func (g *Manual) HandleAppGossip(ctx context.Context, nodeID ids.NodeID, msg []b
 
 func (*Manual) BlockVerified(int64) {}
 
+func (*Manual) BlockAccepted(*chain.StatelessBlock) {}
+
 func (g *Manual) Done() {
 	<-g.doneGossip
+	g.DonePull()
 }
 
 // Queue is a no-op in [Manual].
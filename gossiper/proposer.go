@@ -12,12 +12,12 @@ import (
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
-	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/utils/timer"
 	"github.com/ava-labs/avalanchego/vms/proposervm/proposer"
 	"github.com/ava-labs/hypersdk/cache"
 	"github.com/ava-labs/hypersdk/chain"
 	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/network"
 	"github.com/ava-labs/hypersdk/workers"
 	"go.uber.org/zap"
 )
@@ -25,6 +25,8 @@ import (
 var _ Gossiper = (*Proposer)(nil)
 
 type Proposer struct {
+	*Pull
+
 	vm         VM
 	cfg        *ProposerConfig
 	appSender  common.AppSender
@@ -40,6 +42,9 @@ type Proposer struct {
 	waiting   atomic.Bool
 
 	// cache is thread-safe
+	//
+	// If we gossiped a transaction, its value is the time (in ms) it was
+	// gossiped.
 	cache *cache.FIFO[ids.ID, any]
 }
 
@@ -52,6 +57,19 @@ type ProposerConfig struct {
 	NoGossipBuilderDiff int
 	VerifyTimeout       int64 // ms
 	SeenCacheSize       int
+
+	// WarmAuthCache adds the auths of gossiped transactions to the auth
+	// engine caches once their signatures are verified and they are added to
+	// the mempool (so they verify faster when they are included in a block).
+	//
+	// This is disabled by default because any peer can gossip valid
+	// transactions from many new signers (which may never be included in a
+	// block) to evict the keys of the signers that are active on-chain from
+	// the caches.
+	WarmAuthCache bool
+
+	Pull    *PullConfig
+	Targets *TargetConfig
 }
 
 func DefaultProposerConfig() *ProposerConfig {
@@ -64,11 +82,19 @@ func DefaultProposerConfig() *ProposerConfig {
 		NoGossipBuilderDiff: 4,
 		VerifyTimeout:       proposer.MaxDelay.Milliseconds(),
 		SeenCacheSize:       2_500_000,
+		WarmAuthCache:       false,
+		Pull:                DefaultPullConfig(),
+		Targets:             DefaultTargetConfig(),
 	}
 }
 
 func NewProposer(vm VM, cfg *ProposerConfig) (*Proposer, error) {
+	if err := cfg.Targets.Verify(); err != nil {
+		return nil, err
+	}
 	g := &Proposer{
+		Pull: NewPull(vm, cfg.Pull),
+
 		vm:         vm,
 		cfg:        cfg,
 		doneGossip: make(chan struct{}),
@@ -140,7 +166,7 @@ func (g *Proposer) Force(ctx context.Context) error {
 			if _, ok := g.cache.Get(txID); ok {
 				return true, true, nil
 			}
-			g.cache.Put(txID, nil)
+			g.cache.Put(txID, now)
 
 			txs = append(txs, next)
 			size += txSize
@@ -168,6 +194,7 @@ func (g *Proposer) HandleAppGossip(ctx context.Context, nodeID ids.NodeID, msg [
 			zap.Stringer("peerID", nodeID),
 			zap.Error(err),
 		)
+		g.vm.ReportPeer(nodeID, network.OffenseInvalidTx)
 		return nil
 	}
 	g.vm.RecordTxsReceived(len(txs))
@@ -197,6 +224,7 @@ func (g *Proposer) HandleAppGossip(ctx context.Context, nodeID ids.NodeID, msg [
 				zap.Stringer("peerID", nodeID),
 				zap.Error(err),
 			)
+			g.vm.ReportPeer(nodeID, network.OffenseInvalidTx)
 			batchVerifier.Done(nil)
 			return nil
 		}
@@ -219,6 +247,7 @@ func (g *Proposer) HandleAppGossip(ctx context.Context, nodeID ids.NodeID, msg [
 			zap.Stringer("peerID", nodeID),
 			zap.Error(err),
 		)
+		g.vm.ReportPeer(nodeID, network.OffenseInvalidSignature)
 		return nil
 	}
 
@@ -234,8 +263,17 @@ func (g *Proposer) HandleAppGossip(ctx context.Context, nodeID ids.NodeID, msg [
 
 	// Submit incoming gossip to mempool
 	start := time.Now()
-	for _, err := range g.vm.Submit(ctx, false, txs) {
-		if err == nil || errors.Is(err, chain.ErrDuplicateTx) {
+	errs := g.vm.Submit(ctx, false, txs)
+	for i, err := range errs {
+		if err == nil {
+			// [Submit] only returns an error per transaction if it was able to
+			// process them (otherwise it returns a single error).
+			if g.cfg.WarmAuthCache && len(errs) == len(txs) {
+				g.vm.CacheAuth(txs[i].Auth)
+			}
+			continue
+		}
+		if errors.Is(err, chain.ErrDuplicateTx) {
 			continue
 		}
 		g.vm.Logger().Debug(
@@ -342,6 +380,7 @@ func (g *Proposer) BlockVerified(t int64) {
 func (g *Proposer) Done() {
 	g.timer.Stop()
 	<-g.doneGossip
+	g.DonePull()
 }
 
 func (g *Proposer) sendTxs(ctx context.Context, txs []*chain.Transaction) error {
@@ -354,26 +393,37 @@ func (g *Proposer) sendTxs(ctx context.Context, txs []*chain.Transaction) error
 		return err
 	}
 
-	// Select next set of proposers and send gossip to them
-	proposers, err := g.vm.Proposers(
-		ctx,
-		g.cfg.GossipProposerDiff,
-		g.cfg.GossipProposerDepth,
-	)
-	if err != nil || proposers.Len() == 0 {
+	// Select next set of recipients and send gossip to them
+	recipients := g.targets(ctx, txs)
+	if recipients.Len() == 0 {
 		g.vm.Logger().Warn(
-			"unable to find any proposers, falling back to all-to-all gossip",
-			zap.Error(err),
+			"unable to find any recipients, falling back to all-to-all gossip",
+			zap.String("strategy", g.cfg.Targets.Strategy),
 		)
 		return g.appSender.SendAppGossip(ctx, b)
 	}
-	recipients := set.NewSet[ids.NodeID](len(proposers))
-	for proposer := range proposers {
-		// Don't gossip to self
-		if proposer == g.vm.NodeID() {
+	return g.appSender.SendAppGossipSpecific(ctx, recipients, b)
+}
+
+// BlockAccepted records how long it took for any transactions we gossiped to
+// be included in [blk].
+func (g *Proposer) BlockAccepted(blk *chain.StatelessBlock) {
+	now := time.Now().UnixMilli()
+	for _, tx := range blk.Txs {
+		txID := tx.ID()
+		v, ok := g.cache.Get(txID)
+		if !ok {
+			continue
+		}
+		gossiped, ok := v.(int64)
+		if !ok {
 			continue
 		}
-		recipients.Add(proposer)
+		// Ensure we only record inclusion once
+		g.cache.Put(txID, nil)
+		g.vm.RecordGossipInclusionLatency(
+			g.cfg.Targets.Strategy,
+			time.Duration(now-gossiped)*time.Millisecond,
+		)
 	}
-	return g.appSender.SendAppGossipSpecific(ctx, recipients, b)
 }
@@ -0,0 +1,310 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package gossiper
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/network"
+	"github.com/ava-labs/hypersdk/ratelimit"
+	"go.uber.org/zap"
+)
+
+// Pull periodically sends a bloom filter of the IDs of the transactions in
+// our mempool to a few peers, who respond with any transactions in their
+// mempool that are missing from the filter.
+//
+// Pull recovers transactions that are lost when push gossip is dropped or
+// the set of proposers changes. It is embedded by all [Gossiper]
+// implementations so that every node responds to pull requests, even if it
+// never sends them.
+type Pull struct {
+	vm        VM
+	cfg       *PullConfig
+	appSender common.AppSender
+	limiter   *ratelimit.Limiter
+	donePull  chan struct{}
+
+	l           sync.Mutex
+	requestID   uint32
+	outstanding map[uint32]ids.NodeID
+}
+
+type PullConfig struct {
+	// Frequency is how often we send pull requests. If 0, we only respond to
+	// pull requests (pulls can still be triggered by [ForcePull]).
+	Frequency time.Duration
+	// Peers is the maximum number of peers we send a pull request to. Peers
+	// are sampled from the next proposers (who are most likely to hold
+	// transactions).
+	Peers         int
+	ProposerDiff  int
+	ProposerDepth int
+	// FalsePositiveProbability of our filter. A false positive means a peer
+	// will not send us a transaction we are missing.
+	FalsePositiveProbability float64
+	MaxFilterSize            int   // bytes
+	MaxResponseSize          int   // bytes
+	MinLife                  int64 // ms
+	// RequestLimit is applied to the pull requests we receive from each peer.
+	RequestLimit ratelimit.Config
+}
+
+func DefaultPullConfig() *PullConfig {
+	return &PullConfig{
+		Frequency:                time.Second,
+		Peers:                    2,
+		ProposerDiff:             4,
+		ProposerDepth:            1,
+		FalsePositiveProbability: 0.01,
+		MaxFilterSize:            128 * 1024,
+		MaxResponseSize:          consts.NetworkSizeLimit / 2,
+		MinLife:                  5 * 1000,
+		RequestLimit:             ratelimit.Config{Rate: 10, Burst: 20},
+	}
+}
+
+func NewPull(vm VM, cfg *PullConfig) *Pull {
+	return &Pull{
+		vm:          vm,
+		cfg:         cfg,
+		limiter:     ratelimit.New(cfg.RequestLimit, nil),
+		donePull:    make(chan struct{}),
+		outstanding: map[uint32]ids.NodeID{},
+	}
+}
+
+// RunPull sends pull requests every [Frequency] until the VM is stopped.
+func (p *Pull) RunPull(appSender common.AppSender) {
+	p.appSender = appSender
+	defer close(p.donePull)
+
+	if p.cfg.Frequency <= 0 {
+		// Only respond to pull requests
+		return
+	}
+
+	t := time.NewTicker(p.cfg.Frequency)
+	defer t.Stop()
+	for {
+		select {
+		case <-t.C:
+			if !p.vm.IsBootstrapped() {
+				continue
+			}
+			if err := p.ForcePull(context.Background()); err != nil {
+				p.vm.Logger().Warn("pull gossip failed", zap.Error(err))
+			}
+		case <-p.vm.StopChan():
+			p.vm.Logger().Info("stopping pull gossip loop")
+			return
+		}
+	}
+}
+
+// ForcePull sends a filter of our mempool to up to [Peers] peers.
+func (p *Pull) ForcePull(ctx context.Context) error {
+	ctx, span := p.vm.Tracer().Start(ctx, "Gossiper.ForcePull")
+	defer span.End()
+
+	proposers, err := p.vm.Proposers(ctx, p.cfg.ProposerDiff, p.cfg.ProposerDepth)
+	if err != nil {
+		return err
+	}
+	recipients := set.NewSet[ids.NodeID](p.cfg.Peers)
+	for proposer := range proposers { // map iteration order is random
+		if recipients.Len() == p.cfg.Peers {
+			break
+		}
+		if proposer == p.vm.NodeID() {
+			continue
+		}
+		recipients.Add(proposer)
+	}
+	if recipients.Len() == 0 {
+		p.vm.Logger().Debug("no peers to pull from")
+		return nil
+	}
+
+	// Create filter of our mempool
+	mempool := p.vm.Mempool()
+	txs := mempool.Peek(ctx, mempool.Len(ctx))
+	filter, err := newBloomFilter(len(txs), p.cfg.FalsePositiveProbability, p.cfg.MaxFilterSize)
+	if err != nil {
+		return err
+	}
+	for _, tx := range txs {
+		filter.Add(tx.ID())
+	}
+	w := codec.NewWriter(consts.IDLen+1+codec.BytesLen(filter.bits), consts.NetworkSizeLimit)
+	filter.Marshal(w)
+	if err := w.Err(); err != nil {
+		return err
+	}
+
+	// Send requests
+	p.l.Lock()
+	for nodeID := range recipients {
+		p.outstanding[p.requestID] = nodeID
+		if err := p.appSender.SendAppRequest(ctx, set.Of(nodeID), p.requestID, w.Bytes()); err != nil {
+			delete(p.outstanding, p.requestID)
+			p.l.Unlock()
+			return err
+		}
+		p.requestID++
+	}
+	p.l.Unlock()
+	p.vm.RecordPullRequestsSent(recipients.Len())
+	p.vm.Logger().Debug(
+		"sent pull requests",
+		zap.Int("peers", recipients.Len()),
+		zap.Int("mempool txs", len(txs)),
+		zap.Int("filter size", len(filter.bits)),
+	)
+	return nil
+}
+
+// HandleAppRequest responds to a pull request from [nodeID] with any
+// transactions in our mempool that are not in their filter.
+func (p *Pull) HandleAppRequest(ctx context.Context, nodeID ids.NodeID, requestID uint32, msg []byte) error {
+	ctx, span := p.vm.Tracer().Start(ctx, "Gossiper.HandleAppRequest")
+	defer span.End()
+
+	// Responding to a request is optional, so we don't need to send
+	// anything if the peer is making too many requests.
+	if !p.limiter.Allow(nodeID.String()) {
+		p.vm.RecordPullRequestsRateLimited()
+		p.vm.Logger().Debug("dropping rate limited pull request", zap.Stringer("nodeID", nodeID))
+		return nil
+	}
+	if len(msg) > maxBloomFilterLen(p.cfg.MaxFilterSize) {
+		p.vm.Logger().Warn(
+			"received oversized pull request",
+			zap.Stringer("nodeID", nodeID),
+			zap.Int("size", len(msg)),
+		)
+		p.vm.ReportPeer(nodeID, network.OffenseOversizedMessage)
+		return nil
+	}
+	filter, err := unmarshalBloomFilter(codec.NewReader(msg, consts.NetworkSizeLimit), p.cfg.MaxFilterSize)
+	if err != nil {
+		p.vm.Logger().Warn(
+			"received invalid pull request",
+			zap.Stringer("nodeID", nodeID),
+			zap.Error(err),
+		)
+		p.vm.ReportPeer(nodeID, network.OffenseInvalidMessage)
+		return nil
+	}
+
+	var (
+		mempool = p.vm.Mempool()
+		now     = time.Now().UnixMilli()
+		size    = 0
+		txs     = []*chain.Transaction{}
+	)
+	for _, tx := range mempool.Peek(ctx, mempool.Len(ctx)) {
+		// Don't send txs that are about to expire
+		if tx.Base.Timestamp-now < p.cfg.MinLife {
+			continue
+		}
+		if filter.Has(tx.ID()) {
+			continue
+		}
+		txSize := tx.Size()
+		if txSize+size > p.cfg.MaxResponseSize {
+			break
+		}
+		txs = append(txs, tx)
+		size += txSize
+	}
+
+	// Always respond so the peer doesn't need to wait for the request to time
+	// out
+	var response []byte
+	if len(txs) > 0 {
+		response, err = chain.MarshalTxs(txs)
+		if err != nil {
+			return err
+		}
+	}
+	p.vm.RecordTxsPullServed(len(txs))
+	return p.appSender.SendAppResponse(ctx, nodeID, requestID, response)
+}
+
+// HandleAppResponse submits the transactions a peer sent in response to our
+// pull request to the mempool.
+func (p *Pull) HandleAppResponse(ctx context.Context, nodeID ids.NodeID, requestID uint32, msg []byte) error {
+	ctx, span := p.vm.Tracer().Start(ctx, "Gossiper.HandleAppResponse")
+	defer span.End()
+
+	if !p.finish(nodeID, requestID) {
+		p.vm.Logger().Debug(
+			"dropping unexpected pull response",
+			zap.Stringer("nodeID", nodeID),
+			zap.Uint32("requestID", requestID),
+		)
+		return nil
+	}
+	if len(msg) == 0 {
+		return nil
+	}
+	actionRegistry, authRegistry := p.vm.Registry()
+	_, txs, err := chain.UnmarshalTxs(msg, initialCapacity, actionRegistry, authRegistry)
+	if err != nil {
+		p.vm.Logger().Warn(
+			"received invalid pull response",
+			zap.Stringer("nodeID", nodeID),
+			zap.Error(err),
+		)
+		p.vm.ReportPeer(nodeID, network.OffenseInvalidTx)
+		return nil
+	}
+	p.vm.RecordTxsPulled(len(txs))
+
+	var added int
+	for _, err := range p.vm.Submit(ctx, true, txs) {
+		if err == nil {
+			added++
+		}
+	}
+	p.vm.Logger().Debug(
+		"pulled txs",
+		zap.Stringer("nodeID", nodeID),
+		zap.Int("txs", len(txs)),
+		zap.Int("added", added),
+	)
+	return nil
+}
+
+func (p *Pull) HandleAppRequestFailed(_ context.Context, nodeID ids.NodeID, requestID uint32) error {
+	p.finish(nodeID, requestID)
+	return nil
+}
+
+// finish returns true if we were waiting on [requestID] from [nodeID].
+func (p *Pull) finish(nodeID ids.NodeID, requestID uint32) bool {
+	p.l.Lock()
+	defer p.l.Unlock()
+
+	expected, ok := p.outstanding[requestID]
+	if !ok || expected != nodeID {
+		return false
+	}
+	delete(p.outstanding, requestID)
+	return true
+}
+
+// DonePull waits for [RunPull] to exit.
+func (p *Pull) DonePull() {
+	<-p.donePull
+}
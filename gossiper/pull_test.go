@@ -0,0 +1,356 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package gossiper
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/trace"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/mempool"
+	"github.com/ava-labs/hypersdk/network"
+	"github.com/ava-labs/hypersdk/state"
+	htrace "github.com/ava-labs/hypersdk/trace"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+type testAction struct {
+	Value uint64
+}
+
+func (*testAction) GetTypeID() uint8                      { return 0 }
+func (*testAction) ValidRange(chain.Rules) (int64, int64) { return -1, -1 }
+func (*testAction) MaxComputeUnits(chain.Rules) uint64    { return 1 }
+func (*testAction) OutputsWarpMessage() bool              { return false }
+func (*testAction) StateKeys(chain.Auth, ids.ID) []string { return nil }
+func (*testAction) StateKeysMaxChunks() []uint16          { return nil }
+func (a *testAction) Marshal(p *codec.Packer)             { p.PackUint64(a.Value) }
+func (*testAction) Size() int                             { return consts.Uint64Len }
+
+func (*testAction) Execute(
+	context.Context,
+	chain.Rules,
+	state.Mutable,
+	int64,
+	chain.Auth,
+	ids.ID,
+	bool,
+) (bool, uint64, []byte, *warp.UnsignedMessage, error) {
+	return true, 1, nil, nil, nil
+}
+
+func unmarshalTestAction(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	return &testAction{p.UnpackUint64(false)}, p.Err()
+}
+
+type testAuth struct{}
+
+func (*testAuth) GetTypeID() uint8                      { return 0 }
+func (*testAuth) ValidRange(chain.Rules) (int64, int64) { return -1, -1 }
+func (*testAuth) MaxComputeUnits(chain.Rules) uint64    { return 1 }
+func (*testAuth) StateKeys() []string                   { return nil }
+func (*testAuth) AsyncVerify([]byte) error              { return nil }
+func (*testAuth) Payer() []byte                         { return nil }
+func (*testAuth) Marshal(*codec.Packer)                 {}
+func (*testAuth) Size() int                             { return 0 }
+
+func (*testAuth) Verify(context.Context, chain.Rules, state.Immutable, chain.Action) (uint64, error) {
+	return 1, nil
+}
+
+func (*testAuth) CanDeduct(context.Context, state.Immutable, uint64) error { return nil }
+func (*testAuth) Deduct(context.Context, state.Mutable, uint64) error      { return nil }
+func (*testAuth) Refund(context.Context, state.Mutable, uint64) error      { return nil }
+
+func unmarshalTestAuth(*codec.Packer, *warp.Message) (chain.Auth, error) {
+	return &testAuth{}, nil
+}
+
+type testAuthFactory struct{}
+
+func (*testAuthFactory) Sign([]byte, chain.Action) (chain.Auth, error) {
+	return &testAuth{}, nil
+}
+
+func (*testAuthFactory) MaxUnits() (uint64, uint64, []uint16) {
+	return 0, 1, nil
+}
+
+type testRules struct {
+	chain.Rules
+
+	validityWindow int64
+}
+
+func (r *testRules) GetValidityWindow() int64 { return r.validityWindow }
+
+// testVM implements the parts of [VM] used by [Pull] and gossip target
+// selection (calling anything else panics).
+type testVM struct {
+	VM
+
+	nodeID         ids.NodeID
+	chainID        ids.ID
+	proposers      set.Set[ids.NodeID]
+	tracer         trace.Tracer
+	mempool        chain.Mempool
+	actionRegistry chain.ActionRegistry
+	authRegistry   chain.AuthRegistry
+	rules          chain.Rules
+	validators     map[ids.NodeID]*validators.GetValidatorOutput
+	fastest        []ids.NodeID
+
+	reported  map[ids.NodeID][]network.Offense
+	submitted []*chain.Transaction
+}
+
+func newTestVM(t *testing.T) *testVM {
+	require := require.New(t)
+
+	tracer, err := htrace.New(&htrace.Config{Enabled: false})
+	require.NoError(err)
+	actionRegistry := codec.NewTypeParser[chain.Action, *warp.Message]()
+	require.NoError(actionRegistry.Register(0, unmarshalTestAction, false))
+	authRegistry := codec.NewTypeParser[chain.Auth, *warp.Message]()
+	require.NoError(authRegistry.Register(0, unmarshalTestAuth, false))
+	return &testVM{
+		nodeID:         ids.GenerateTestNodeID(),
+		chainID:        ids.GenerateTestID(),
+		proposers:      set.Set[ids.NodeID]{},
+		tracer:         tracer,
+		mempool:        mempool.New[*chain.Transaction](tracer, 100, 100, nil),
+		actionRegistry: actionRegistry,
+		authRegistry:   authRegistry,
+		rules:          &testRules{validityWindow: 60 * 1000},
+		validators:     map[ids.NodeID]*validators.GetValidatorOutput{},
+		reported:       map[ids.NodeID][]network.Offense{},
+	}
+}
+
+func (vm *testVM) NodeID() ids.NodeID          { return vm.nodeID }
+func (vm *testVM) Tracer() trace.Tracer        { return vm.tracer }
+func (*testVM) Logger() logging.Logger         { return logging.NoLog{} }
+func (vm *testVM) Mempool() chain.Mempool      { return vm.mempool }
+func (*testVM) RecordPullRequestsSent(int)     {}
+func (*testVM) RecordPullRequestsRateLimited() {}
+func (*testVM) RecordTxsPulled(int)            {}
+func (*testVM) RecordTxsPullServed(int)        {}
+
+func (vm *testVM) Proposers(context.Context, int, int) (set.Set[ids.NodeID], error) {
+	return vm.proposers, nil
+}
+
+func (vm *testVM) Rules(int64) chain.Rules { return vm.rules }
+
+func (vm *testVM) CurrentValidators(
+	context.Context,
+) (map[ids.NodeID]*validators.GetValidatorOutput, map[string]struct{}) {
+	return vm.validators, nil
+}
+
+func (vm *testVM) FastestPeers(limit int) []ids.NodeID {
+	if len(vm.fastest) > limit {
+		return vm.fastest[:limit]
+	}
+	return vm.fastest
+}
+
+func (vm *testVM) Registry() (chain.ActionRegistry, chain.AuthRegistry) {
+	return vm.actionRegistry, vm.authRegistry
+}
+
+func (vm *testVM) ReportPeer(nodeID ids.NodeID, offense network.Offense) {
+	vm.reported[nodeID] = append(vm.reported[nodeID], offense)
+}
+
+func (vm *testVM) Submit(_ context.Context, _ bool, txs []*chain.Transaction) []error {
+	vm.submitted = append(vm.submitted, txs...)
+	vm.mempool.Add(context.Background(), txs)
+	return make([]error, len(txs))
+}
+
+func (vm *testVM) newTx(t *testing.T, value uint64, expiry time.Time) *chain.Transaction {
+	tx := chain.NewTx(
+		&chain.Base{Timestamp: utils.UnixRMilli(expiry.UnixMilli(), 0), ChainID: vm.chainID, MaxFee: 1},
+		nil,
+		&testAction{value},
+	)
+	tx, err := tx.Sign(&testAuthFactory{}, vm.actionRegistry, vm.authRegistry)
+	require.NoError(t, err)
+	return tx
+}
+
+type pullRequest struct {
+	nodeID    ids.NodeID
+	requestID uint32
+	msg       []byte
+}
+
+// newTestPull returns a [Pull] for [vm] whose requests and responses are
+// recorded in the returned channels.
+func newTestPull(vm *testVM, cfg *PullConfig) (*Pull, chan *pullRequest, chan *pullRequest) {
+	var (
+		requests  = make(chan *pullRequest, 16)
+		responses = make(chan *pullRequest, 16)
+		p         = NewPull(vm, cfg)
+	)
+	p.appSender = &common.SenderTest{
+		SendAppRequestF: func(_ context.Context, nodeIDs set.Set[ids.NodeID], requestID uint32, msg []byte) error {
+			for nodeID := range nodeIDs {
+				requests <- &pullRequest{nodeID, requestID, msg}
+			}
+			return nil
+		},
+		SendAppResponseF: func(_ context.Context, nodeID ids.NodeID, requestID uint32, msg []byte) error {
+			responses <- &pullRequest{nodeID, requestID, msg}
+			return nil
+		},
+	}
+	return p, requests, responses
+}
+
+func TestPull(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	expiry := time.Now().Add(time.Minute)
+
+	// [requester] has tx1, [responder] has tx1, tx2, and tx3 (which is about
+	// to expire)
+	requesterVM, responderVM := newTestVM(t), newTestVM(t)
+	requesterVM.proposers.Add(requesterVM.nodeID, responderVM.nodeID)
+	tx1 := requesterVM.newTx(t, 1, expiry)
+	tx2 := requesterVM.newTx(t, 2, expiry)
+	tx3 := requesterVM.newTx(t, 3, time.Now())
+	requesterVM.mempool.Add(ctx, []*chain.Transaction{tx1})
+	responderVM.mempool.Add(ctx, []*chain.Transaction{tx1, tx2, tx3})
+
+	cfg := DefaultPullConfig()
+	requester, requests, _ := newTestPull(requesterVM, cfg)
+	responder, _, responses := newTestPull(responderVM, cfg)
+
+	// Requests are only sent to other proposers
+	require.NoError(requester.ForcePull(ctx))
+	require.Len(requests, 1)
+	req := <-requests
+	require.Equal(responderVM.nodeID, req.nodeID)
+
+	// Only the missing tx that is not about to expire is returned
+	require.NoError(responder.HandleAppRequest(ctx, requesterVM.nodeID, req.requestID, req.msg))
+	require.Len(responses, 1)
+	resp := <-responses
+	require.Equal(requesterVM.nodeID, resp.nodeID)
+	require.Equal(req.requestID, resp.requestID)
+	_, txs, err := chain.UnmarshalTxs(resp.msg, initialCapacity, requesterVM.actionRegistry, requesterVM.authRegistry)
+	require.NoError(err)
+	require.Len(txs, 1)
+	require.Equal(tx2.ID(), txs[0].ID())
+
+	// The response is submitted to the mempool
+	require.NoError(requester.HandleAppResponse(ctx, responderVM.nodeID, resp.requestID, resp.msg))
+	require.Len(requesterVM.submitted, 1)
+	require.Equal(tx2.ID(), requesterVM.submitted[0].ID())
+	require.Equal(2, requesterVM.mempool.Len(ctx))
+
+	// Responses are only handled once (and only from the peer we asked)
+	require.NoError(requester.HandleAppResponse(ctx, responderVM.nodeID, resp.requestID, resp.msg))
+	require.Len(requesterVM.submitted, 1)
+
+	// Once the requester has all txs, the responder sends an empty response
+	require.NoError(requester.ForcePull(ctx))
+	req = <-requests
+	require.NoError(responder.HandleAppRequest(ctx, requesterVM.nodeID, req.requestID, req.msg))
+	resp = <-responses
+	require.Empty(resp.msg)
+	require.NoError(requester.HandleAppResponse(ctx, responderVM.nodeID, resp.requestID, resp.msg))
+	require.Len(requesterVM.submitted, 1)
+	require.Empty(requesterVM.reported)
+	require.Empty(responderVM.reported)
+}
+
+func TestPullUnexpectedResponse(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	vm := newTestVM(t)
+	peer := ids.GenerateTestNodeID()
+	vm.proposers.Add(peer)
+	p, requests, _ := newTestPull(vm, DefaultPullConfig())
+	require.NoError(p.ForcePull(ctx))
+	req := <-requests
+
+	tx := vm.newTx(t, 1, time.Now().Add(time.Minute))
+	msg, err := chain.MarshalTxs([]*chain.Transaction{tx})
+	require.NoError(err)
+
+	// Responses from other peers are dropped
+	require.NoError(p.HandleAppResponse(ctx, ids.GenerateTestNodeID(), req.requestID, msg))
+	require.Empty(vm.submitted)
+
+	// Responses to failed requests are dropped
+	require.NoError(p.HandleAppRequestFailed(ctx, peer, req.requestID))
+	require.NoError(p.HandleAppResponse(ctx, peer, req.requestID, msg))
+	require.Empty(vm.submitted)
+}
+
+func TestPullInvalidMessages(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	vm := newTestVM(t)
+	peer := ids.GenerateTestNodeID()
+	vm.proposers.Add(peer)
+	cfg := DefaultPullConfig()
+	cfg.MaxFilterSize = 1024
+	p, requests, responses := newTestPull(vm, cfg)
+
+	// Oversized filter
+	require.NoError(p.HandleAppRequest(ctx, peer, 0, make([]byte, maxBloomFilterLen(cfg.MaxFilterSize)+1)))
+	require.Equal([]network.Offense{network.OffenseOversizedMessage}, vm.reported[peer])
+
+	// Malformed filter
+	require.NoError(p.HandleAppRequest(ctx, peer, 1, []byte{1, 2, 3}))
+	require.Equal(network.OffenseInvalidMessage, vm.reported[peer][1])
+	require.Empty(responses)
+
+	// Malformed response
+	require.NoError(p.ForcePull(ctx))
+	req := <-requests
+	require.NoError(p.HandleAppResponse(ctx, peer, req.requestID, []byte{1, 2, 3}))
+	require.Equal(network.OffenseInvalidTx, vm.reported[peer][2])
+	require.Empty(vm.submitted)
+}
+
+func TestPullRateLimited(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	vm := newTestVM(t)
+	peer := ids.GenerateTestNodeID()
+	cfg := DefaultPullConfig()
+	cfg.RequestLimit.Burst = 2
+	p, _, responses := newTestPull(vm, cfg)
+
+	f, err := newBloomFilter(0, cfg.FalsePositiveProbability, cfg.MaxFilterSize)
+	require.NoError(err)
+	w := codec.NewWriter(maxBloomFilterLen(cfg.MaxFilterSize), consts.NetworkSizeLimit)
+	f.Marshal(w)
+	require.NoError(w.Err())
+	for i := 0; i < 5; i++ {
+		require.NoError(p.HandleAppRequest(ctx, peer, uint32(i), w.Bytes()))
+	}
+	require.Len(responses, 2)
+	require.Empty(vm.reported)
+}
@@ -0,0 +1,181 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package gossiper
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/sampler"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/hypersdk/chain"
+	"go.uber.org/zap"
+)
+
+// Target strategies determine which peers receive gossip.
+const (
+	// ProposersStrategy only gossips to the predicted proposers of the next
+	// blocks.
+	ProposersStrategy = "proposers"
+	// ValidatorsStrategy gossips to validators sampled by stake weight.
+	ValidatorsStrategy = "validators"
+	// LatencyStrategy gossips to the peers with the lowest observed latency.
+	LatencyStrategy = "latency"
+	// MixedStrategy gossips to the predicted proposers, validators sampled by
+	// stake weight, and the peers with the lowest observed latency.
+	MixedStrategy = "mixed"
+)
+
+type TargetConfig struct {
+	Strategy string `json:"strategy"`
+
+	// RandomValidators is the number of stake-weighted validators to gossip to
+	// when using [ValidatorsStrategy] or [MixedStrategy].
+	RandomValidators int `json:"randomValidators"`
+	// LowLatencyPeers is the number of low latency peers to gossip to when
+	// using [LatencyStrategy] or [MixedStrategy].
+	LowLatencyPeers int `json:"lowLatencyPeers"`
+
+	// For each [FanoutAgeStep] that the oldest gossiped transaction has been
+	// in the mempool, we gossip to one more sampled validator and low latency
+	// peer (up to [MaxExtraFanout]). If 0, fanout is never increased.
+	FanoutAgeStep  int64 `json:"fanoutAgeStep"` // ms
+	MaxExtraFanout int   `json:"maxExtraFanout"`
+}
+
+func DefaultTargetConfig() *TargetConfig {
+	return &TargetConfig{
+		Strategy:         ProposersStrategy,
+		RandomValidators: 4,
+		LowLatencyPeers:  2,
+		FanoutAgeStep:    5 * 1000,
+		MaxExtraFanout:   8,
+	}
+}
+
+func (c *TargetConfig) Verify() error {
+	switch c.Strategy {
+	case ProposersStrategy, ValidatorsStrategy, LatencyStrategy, MixedStrategy:
+		return nil
+	default:
+		return fmt.Errorf("%w: %s", ErrUnknownStrategy, c.Strategy)
+	}
+}
+
+func (c *TargetConfig) useProposers() bool {
+	return c.Strategy == ProposersStrategy || c.Strategy == MixedStrategy
+}
+
+func (c *TargetConfig) useValidators() bool {
+	return c.Strategy == ValidatorsStrategy || c.Strategy == MixedStrategy
+}
+
+func (c *TargetConfig) useLatency() bool {
+	return c.Strategy == LatencyStrategy || c.Strategy == MixedStrategy
+}
+
+// extraFanout returns the number of additional peers to gossip to when the
+// oldest gossiped transaction has been in the mempool for [age] ms.
+func (c *TargetConfig) extraFanout(age int64) int {
+	if c.FanoutAgeStep <= 0 || age <= 0 {
+		return 0
+	}
+	extra := age / c.FanoutAgeStep
+	if extra > int64(c.MaxExtraFanout) {
+		return c.MaxExtraFanout
+	}
+	return int(extra)
+}
+
+// mempoolAge estimates how long the oldest of [txs] has been in the mempool
+// at [now] by assuming it was issued with the maximum validity window.
+func mempoolAge(r chain.Rules, txs []*chain.Transaction, now int64) int64 {
+	var age int64
+	for _, tx := range txs {
+		if txAge := r.GetValidityWindow() - (tx.Base.Timestamp - now); txAge > age {
+			age = txAge
+		}
+	}
+	return age
+}
+
+// targets returns the peers to gossip [txs] to. If no peers can be found, we
+// fall back to all-to-all gossip.
+func (g *Proposer) targets(ctx context.Context, txs []*chain.Transaction) set.Set[ids.NodeID] {
+	var (
+		cfg        = g.cfg.Targets
+		now        = time.Now().UnixMilli()
+		extra      = cfg.extraFanout(mempoolAge(g.vm.Rules(now), txs, now))
+		recipients = set.NewSet[ids.NodeID](cfg.RandomValidators + cfg.LowLatencyPeers + extra)
+	)
+	if cfg.useProposers() {
+		proposers, err := g.vm.Proposers(
+			ctx,
+			g.cfg.GossipProposerDiff,
+			g.cfg.GossipProposerDepth,
+		)
+		if err != nil {
+			g.vm.Logger().Warn("unable to find proposers", zap.Error(err))
+		} else {
+			recipients.Union(proposers)
+		}
+	}
+	if cfg.useValidators() {
+		recipients.Add(g.sampleValidators(ctx, cfg.RandomValidators+extra)...)
+	}
+	if cfg.useLatency() {
+		recipients.Add(g.vm.FastestPeers(cfg.LowLatencyPeers + extra)...)
+	}
+
+	// Don't gossip to self
+	recipients.Remove(g.vm.NodeID())
+	return recipients
+}
+
+// sampleValidators returns up to [count] validators sampled by stake weight.
+// Validators without any stake can't be sampled, so they are ignored.
+//
+// Units of stake (rather than validators) are sampled without replacement, so
+// the same validator may be sampled more than once and fewer than [count]
+// unique validators may be returned.
+func (g *Proposer) sampleValidators(ctx context.Context, count int) []ids.NodeID {
+	validators, _ := g.vm.CurrentValidators(ctx)
+	if len(validators) == 0 || count <= 0 {
+		return nil
+	}
+	var (
+		nodeIDs = make([]ids.NodeID, 0, len(validators))
+		weights = make([]uint64, 0, len(validators))
+	)
+	for nodeID, v := range validators {
+		if nodeID == g.vm.NodeID() || v.Weight == 0 {
+			continue
+		}
+		nodeIDs = append(nodeIDs, nodeID)
+		weights = append(weights, v.Weight)
+	}
+	if count > len(nodeIDs) {
+		count = len(nodeIDs)
+	}
+	if count == 0 {
+		return nil
+	}
+	s := sampler.NewWeightedWithoutReplacement()
+	if err := s.Initialize(weights); err != nil {
+		g.vm.Logger().Warn("unable to initialize validator sampler", zap.Error(err))
+		return nil
+	}
+	indices, err := s.Sample(count)
+	if err != nil {
+		g.vm.Logger().Warn("unable to sample validators", zap.Error(err))
+		return nil
+	}
+	sampled := set.NewSet[ids.NodeID](len(indices))
+	for _, idx := range indices {
+		sampled.Add(nodeIDs[idx])
+	}
+	return sampled.List()
+}
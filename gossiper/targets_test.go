@@ -0,0 +1,193 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package gossiper
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/chain"
+)
+
+func TestTargetConfigVerify(t *testing.T) {
+	for _, strategy := range []string{ProposersStrategy, ValidatorsStrategy, LatencyStrategy, MixedStrategy} {
+		cfg := DefaultTargetConfig()
+		cfg.Strategy = strategy
+		require.NoError(t, cfg.Verify())
+	}
+
+	cfg := DefaultTargetConfig()
+	cfg.Strategy = "random"
+	require.ErrorIs(t, cfg.Verify(), ErrUnknownStrategy)
+	_, err := NewProposer(newTestVM(t), &ProposerConfig{Targets: cfg})
+	require.ErrorIs(t, err, ErrUnknownStrategy)
+}
+
+func TestExtraFanout(t *testing.T) {
+	tests := []struct {
+		name     string
+		step     int64
+		max      int
+		age      int64
+		expected int
+	}{
+		{name: "disabled", step: 0, max: 8, age: 100_000, expected: 0},
+		{name: "negative step", step: -1, max: 8, age: 100_000, expected: 0},
+		{name: "negative age", step: 1_000, max: 8, age: -5_000, expected: 0},
+		{name: "below step", step: 1_000, max: 8, age: 999, expected: 0},
+		{name: "one step", step: 1_000, max: 8, age: 1_000, expected: 1},
+		{name: "partial step", step: 1_000, max: 8, age: 2_500, expected: 2},
+		{name: "capped", step: 1_000, max: 8, age: 100_000, expected: 8},
+		{name: "no extra", step: 1_000, max: 0, age: 100_000, expected: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &TargetConfig{FanoutAgeStep: tt.step, MaxExtraFanout: tt.max}
+			require.Equal(t, tt.expected, cfg.extraFanout(tt.age))
+		})
+	}
+}
+
+func TestMempoolAge(t *testing.T) {
+	require := require.New(t)
+
+	vm := newTestVM(t)
+	var (
+		now    = time.Unix(1_000, 0)
+		rules  = vm.Rules(now.UnixMilli())
+		window = time.Duration(rules.GetValidityWindow()) * time.Millisecond
+	)
+
+	// Transactions issued now expire after the validity window
+	fresh := vm.newTx(t, 0, now.Add(window))
+	require.Zero(mempoolAge(rules, []*chain.Transaction{fresh}, now.UnixMilli()))
+
+	// The oldest transaction determines the age
+	old := vm.newTx(t, 1, now.Add(window-10*time.Second))
+	older := vm.newTx(t, 2, now.Add(window-20*time.Second))
+	require.Equal(int64(20_000), mempoolAge(rules, []*chain.Transaction{fresh, older, old}, now.UnixMilli()))
+
+	// Transactions issued with a shorter validity window don't have a
+	// negative age
+	require.Zero(mempoolAge(rules, []*chain.Transaction{vm.newTx(t, 3, now.Add(2*window))}, now.UnixMilli()))
+	require.Zero(mempoolAge(rules, nil, now.UnixMilli()))
+}
+
+func setValidators(vm *testVM, weights map[ids.NodeID]uint64) {
+	vm.validators = map[ids.NodeID]*validators.GetValidatorOutput{}
+	for nodeID, weight := range weights {
+		vm.validators[nodeID] = &validators.GetValidatorOutput{NodeID: nodeID, Weight: weight}
+	}
+}
+
+func TestSampleValidators(t *testing.T) {
+	require := require.New(t)
+
+	vm := newTestVM(t)
+	g, err := NewProposer(vm, DefaultProposerConfig())
+	require.NoError(err)
+	a, b, c := ids.GenerateTestNodeID(), ids.GenerateTestNodeID(), ids.GenerateTestNodeID()
+
+	// No validators
+	require.Empty(g.sampleValidators(context.Background(), 2))
+
+	// We never sample ourselves or validators without stake
+	setValidators(vm, map[ids.NodeID]uint64{vm.nodeID: 100, a: 10, b: 0})
+	for i := 0; i < 10; i++ {
+		require.Equal([]ids.NodeID{a}, g.sampleValidators(context.Background(), 3))
+	}
+
+	// Sampled validators are unique
+	setValidators(vm, map[ids.NodeID]uint64{vm.nodeID: 100, a: 10, b: 20, c: 30})
+	require.Empty(g.sampleValidators(context.Background(), 0))
+	for i := 0; i < 10; i++ {
+		sampled := g.sampleValidators(context.Background(), 10)
+		require.NotEmpty(sampled)
+		require.LessOrEqual(len(sampled), 3)
+		require.Len(set.Of(sampled...), len(sampled))
+		require.Subset([]ids.NodeID{a, b, c}, sampled)
+	}
+
+	// Validators with equal stake are each sampled at most once
+	setValidators(vm, map[ids.NodeID]uint64{vm.nodeID: 1, a: 1, b: 1, c: 1})
+	require.ElementsMatch([]ids.NodeID{a, b, c}, g.sampleValidators(context.Background(), 10))
+}
+
+func TestTargets(t *testing.T) {
+	var (
+		vm                           = newTestVM(t)
+		proposer, validator, fastest = ids.GenerateTestNodeID(), ids.GenerateTestNodeID(), ids.GenerateTestNodeID()
+	)
+	vm.proposers = set.Of(proposer, vm.nodeID)
+	vm.fastest = []ids.NodeID{fastest, vm.nodeID}
+	setValidators(vm, map[ids.NodeID]uint64{vm.nodeID: 100, validator: 10})
+
+	tests := []struct {
+		strategy string
+		expected set.Set[ids.NodeID]
+	}{
+		{strategy: ProposersStrategy, expected: set.Of(proposer)},
+		{strategy: ValidatorsStrategy, expected: set.Of(validator)},
+		{strategy: LatencyStrategy, expected: set.Of(fastest)},
+		{strategy: MixedStrategy, expected: set.Of(proposer, validator, fastest)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.strategy, func(t *testing.T) {
+			require := require.New(t)
+
+			cfg := DefaultProposerConfig()
+			cfg.Targets.Strategy = tt.strategy
+			cfg.Targets.FanoutAgeStep = 0
+			g, err := NewProposer(vm, cfg)
+			require.NoError(err)
+
+			// We never gossip to ourselves
+			tx := vm.newTx(t, 0, time.Now().Add(time.Minute))
+			require.Equal(tt.expected, g.targets(context.Background(), []*chain.Transaction{tx}))
+		})
+	}
+}
+
+func TestTargetsExtraFanout(t *testing.T) {
+	require := require.New(t)
+
+	vm := newTestVM(t)
+	peers := make([]ids.NodeID, 6)
+	weights := map[ids.NodeID]uint64{}
+	for i := range peers {
+		peers[i] = ids.GenerateTestNodeID()
+		weights[peers[i]] = 1
+	}
+	setValidators(vm, weights)
+	vm.fastest = peers
+
+	cfg := DefaultProposerConfig()
+	cfg.Targets.Strategy = LatencyStrategy
+	cfg.Targets.LowLatencyPeers = 1
+	cfg.Targets.FanoutAgeStep = 10 * 1000
+	cfg.Targets.MaxExtraFanout = 2
+	g, err := NewProposer(vm, cfg)
+	require.NoError(err)
+
+	// Transactions that have been in the mempool longer are gossiped more
+	// widely (up to [MaxExtraFanout])
+	window := time.Duration(vm.rules.GetValidityWindow()) * time.Millisecond
+	fresh := vm.newTx(t, 0, time.Now().Add(window+time.Second))
+	require.Equal(set.Of(peers[0]), g.targets(context.Background(), []*chain.Transaction{fresh}))
+	old := vm.newTx(t, 1, time.Now().Add(window-15*time.Second))
+	require.Equal(set.Of(peers[:2]...), g.targets(context.Background(), []*chain.Transaction{fresh, old}))
+	oldest := vm.newTx(t, 2, time.Now().Add(window-time.Minute))
+	require.Equal(set.Of(peers[:3]...), g.targets(context.Background(), []*chain.Transaction{oldest}))
+
+	// Extra fanout also applies to sampled validators
+	g.cfg.Targets.Strategy = ValidatorsStrategy
+	g.cfg.Targets.RandomValidators = 1
+	require.Len(g.targets(context.Background(), []*chain.Transaction{oldest}), 3)
+}
@@ -255,6 +255,41 @@ func (m *Mempool[T]) SetMinTimestamp(ctx context.Context, t int64) []T {
 	return removed
 }
 
+// Peek returns up to [count] of the highest-valued items in m without
+// removing them.
+func (m *Mempool[T]) Peek(ctx context.Context, count int) []T {
+	_, span := m.tracer.Start(ctx, "Mempool.Peek")
+	defer span.End()
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	items := make([]T, 0, math.Min(count, m.queue.Size()))
+	for elem := m.queue.First(); elem != nil && len(items) < count; elem = elem.Next() {
+		items = append(items, elem.Value())
+	}
+	return items
+}
+
+// Clear removes and returns all items in m.
+func (m *Mempool[T]) Clear(ctx context.Context) []T {
+	_, span := m.tracer.Start(ctx, "Mempool.Clear")
+	defer span.End()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	removed := make([]T, 0, m.queue.Size())
+	for {
+		item, ok := m.popNext()
+		if !ok {
+			break
+		}
+		removed = append(removed, item)
+	}
+	return removed
+}
+
 // Top iterates over the highest-valued items in the mempool.
 func (m *Mempool[T]) Top(
 	ctx context.Context,
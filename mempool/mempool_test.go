@@ -191,3 +191,30 @@ func TestMempoolSetMinTimestamp(t *testing.T) {
 	// Mempool has same length
 	require.Equal(5, txm.Len(ctx), "Mempool has incorrect number of txs.")
 }
+
+func TestMempoolPeekAndClear(t *testing.T) {
+	require := require.New(t)
+	ctx := context.TODO()
+	tracer, _ := trace.New(&trace.Config{Enabled: false})
+
+	txm := New[*TestItem](tracer, 20, 20, nil)
+	for i := int64(0); i < 10; i++ {
+		txm.Add(ctx, []*TestItem{GenerateTestItem(testPayer, i)})
+	}
+	// Peek does not remove items
+	peeked := txm.Peek(ctx, 3)
+	require.Len(peeked, 3)
+	for i, item := range peeked {
+		require.Equal(int64(i), item.Expiry())
+	}
+	require.Len(txm.Peek(ctx, 100), 10)
+	require.Equal(10, txm.Len(ctx))
+
+	// Clear removes everything
+	removed := txm.Clear(ctx)
+	require.Len(removed, 10)
+	require.Equal(0, txm.Len(ctx))
+	require.Equal(0, txm.Size(ctx))
+	require.Empty(txm.owned)
+	require.Empty(txm.Peek(ctx, 1))
+}
@@ -0,0 +1,106 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/version"
+)
+
+// compressedFlag is set on the protocol ID of compressed messages. Protocol
+// IDs must be less than [handshakeID], so this bit is never set on an
+// uncompressed message.
+const compressedFlag uint8 = 0x80
+
+// CompressionConfig describes when "AppGossip" is compressed.
+//
+// Peers that don't support compression would drop compressed messages, so
+// we only compress gossip sent to peers that have sent a protocol handshake
+// (which was introduced alongside compression). Compressed gossip is always
+// accepted, even if [Enabled] is false.
+type CompressionConfig struct {
+	Enabled bool `json:"enabled"`
+	// MinSize is the size (in bytes) below which messages are not compressed.
+	MinSize int `json:"minSize"`
+}
+
+func DefaultCompressionConfig() CompressionConfig {
+	return CompressionConfig{
+		Enabled: false,
+		MinSize: 1024,
+	}
+}
+
+// SetCompression enables compression of "AppGossip" sent to peers that
+// support it.
+//
+// Like [SetHandler], this should be called before any messages are sent.
+func (n *Manager) SetCompression(config CompressionConfig) {
+	n.l.Lock()
+	defer n.l.Unlock()
+
+	n.compression = config
+}
+
+// addPeer records the application version of a newly connected peer.
+func (n *Manager) addPeer(nodeID ids.NodeID, v *version.Application) {
+	n.l.Lock()
+	defer n.l.Unlock()
+
+	n.peers[nodeID] = v
+}
+
+func (n *Manager) removePeer(nodeID ids.NodeID) {
+	n.l.Lock()
+	defer n.l.Unlock()
+
+	delete(n.peers, nodeID)
+	delete(n.peerProtocols, nodeID)
+	delete(n.latencies, nodeID)
+}
+
+// compressionEnabled returns true if gossip sent to peers that have sent a
+// handshake should be compressed.
+func (n *Manager) compressionEnabled() bool {
+	n.l.RLock()
+	defer n.l.RUnlock()
+
+	return n.compression.Enabled
+}
+
+// compress returns a compressed message for [protocol] or false if [msg]
+// should be sent uncompressed.
+func (n *Manager) compress(protocol Protocol, msg []byte) ([]byte, bool) {
+	if len(msg) < n.compression.MinSize {
+		return nil, false
+	}
+	compressed, err := n.compressor.Compress(msg)
+	if err != nil || len(compressed) >= len(msg) {
+		return nil, false
+	}
+	return append([]byte{protocol.ID | compressedFlag, protocol.Version}, compressed...), true
+}
+
+// isCompressed returns true if [msg] has a compressed payload.
+func isCompressed(msg []byte) bool {
+	return len(msg) >= headerLen && msg[0]&compressedFlag != 0
+}
+
+// decompress returns [msg] with its payload decompressed, if it was
+// compressed.
+func (n *Manager) decompress(msg []byte) ([]byte, error) {
+	if !isCompressed(msg) {
+		return msg, nil
+	}
+	decompressed, err := n.compressor.Decompress(msg[headerLen:])
+	if err != nil {
+		return nil, err
+	}
+	if len(decompressed) == 0 {
+		// We never compress empty messages (and invalid input may decompress
+		// to nothing)
+		return nil, ErrEmptyCompressedMessage
+	}
+	return append([]byte{msg[0] &^ compressedFlag, msg[1]}, decompressed...), nil
+}
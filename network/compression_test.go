@@ -0,0 +1,147 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"bytes"
+	"context"
+	"math/rand"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompressionRoundTrip(t *testing.T) {
+	var (
+		compressible = bytes.Repeat([]byte{1, 2, 3, 4}, 512)
+		random       = make([]byte, 2048)
+	)
+	_, _ = rand.New(rand.NewSource(1)).Read(random) //nolint:gosec
+	tests := []struct {
+		name       string
+		msg        []byte
+		compressed bool
+	}{
+		{name: "compressible", msg: compressible, compressed: true},
+		{name: "below min size", msg: compressible[:512]},
+		{name: "empty", msg: []byte{}},
+		{name: "incompressible", msg: random},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			n, _, _, _ := newTestManager(t)
+			n.SetCompression(CompressionConfig{Enabled: true, MinSize: 1024})
+			msg, ok := n.compress(upgradeProtocol, tt.msg)
+			require.Equal(tt.compressed, ok)
+			if !ok {
+				return
+			}
+			require.Less(len(msg), len(tt.msg))
+			require.True(isCompressed(msg))
+			decompressed, err := n.decompress(msg)
+			require.NoError(err)
+			require.Equal(append(upgradeProtocol.header(false), tt.msg...), decompressed)
+		})
+	}
+}
+
+func TestDecompressInvalid(t *testing.T) {
+	require := require.New(t)
+
+	n, _, _, _ := newTestManager(t)
+
+	// Uncompressed messages are returned as-is
+	msg := []byte{upgradeProtocol.ID, upgradeProtocol.Version, 1}
+	decompressed, err := n.decompress(msg)
+	require.NoError(err)
+	require.Equal(msg, decompressed)
+
+	// Invalid payload
+	_, err = n.decompress([]byte{upgradeProtocol.ID | compressedFlag, upgradeProtocol.Version, 1, 2, 3})
+	require.Error(err)
+
+	// Empty payload
+	empty, err := n.compressor.Compress(nil)
+	require.NoError(err)
+	_, err = n.decompress(append([]byte{upgradeProtocol.ID | compressedFlag, upgradeProtocol.Version}, empty...))
+	require.ErrorIs(err, ErrEmptyCompressedMessage)
+}
+
+func TestCompressionNegotiation(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	n, sender, _, _ := newTestManager(t)
+	n.SetCompression(CompressionConfig{Enabled: true, MinSize: 1024})
+	appSender := &WrappedAppSender{n, legacyProtocol}
+	legacy, upgraded := ids.GenerateTestNodeID(), ids.GenerateTestNodeID()
+	require.NoError(n.Connected(ctx, n.nodeID, nil))
+	require.NoError(n.Connected(ctx, legacy, nil))
+	require.NoError(n.Connected(ctx, upgraded, nil))
+	require.NoError(n.AppGossip(ctx, upgraded, handshake(t, legacyProtocol)))
+	sender.specific = map[ids.NodeID][][]byte{}
+
+	// Only peers that have sent a handshake receive compressed messages
+	msg := bytes.Repeat([]byte{1}, 2048)
+	require.NoError(appSender.SendAppGossipSpecific(ctx, set.Of(legacy, upgraded), msg))
+	require.Equal([][]byte{append([]byte{legacyProtocol.ID}, msg...)}, sender.specific[legacy])
+	require.Len(sender.specific[upgraded], 1)
+	require.True(isCompressed(sender.specific[upgraded][0]))
+	decompressed, err := n.decompress(sender.specific[upgraded][0])
+	require.NoError(err)
+	require.Equal(append(legacyProtocol.header(false), msg...), decompressed)
+
+	// Broadcasts are only compressed once all peers have sent a handshake
+	sender.specific = map[ids.NodeID][][]byte{}
+	require.NoError(appSender.SendAppGossip(ctx, msg))
+	require.Empty(sender.gossip)
+	require.False(isCompressed(sender.specific[legacy][0]))
+	require.True(isCompressed(sender.specific[upgraded][0]))
+
+	require.NoError(n.AppGossip(ctx, legacy, handshake(t, legacyProtocol)))
+	sender.specific = map[ids.NodeID][][]byte{}
+	require.NoError(appSender.SendAppGossip(ctx, msg))
+	require.Empty(sender.specific)
+	require.Len(sender.gossip, 1)
+	require.True(isCompressed(sender.gossip[0]))
+
+	// Small messages are sent uncompressed
+	require.NoError(appSender.SendAppGossip(ctx, []byte{1}))
+	require.Equal([]byte{legacyProtocol.ID, legacyProtocol.Version, 1}, sender.gossip[1])
+
+	// Nothing is compressed when disabled
+	n.SetCompression(CompressionConfig{Enabled: false, MinSize: 1024})
+	require.NoError(appSender.SendAppGossipSpecific(ctx, set.Of(upgraded), msg))
+	require.Equal(append(legacyProtocol.header(false), msg...), sender.specific[upgraded][0])
+}
+
+func TestReceiveCompressed(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	// Compressed gossip is accepted even if we don't compress our own
+	n, _, _, handlers := newTestManager(t)
+	nodeID := ids.GenerateTestNodeID()
+	require.NoError(n.Connected(ctx, nodeID, nil))
+	require.NoError(n.AppGossip(ctx, nodeID, handshake(t, upgradeProtocol)))
+
+	n.SetCompression(CompressionConfig{Enabled: true})
+	msg := bytes.Repeat([]byte{2}, 2048)
+	compressed, ok := n.compress(upgradeProtocol, msg)
+	require.True(ok)
+	n.SetCompression(DefaultCompressionConfig())
+	require.NoError(n.AppGossip(ctx, nodeID, compressed))
+	require.Equal([]*testMessage{{nodeID, msg}}, handlers[upgradeProtocol].gossip)
+
+	// Compressed gossip is routed with the 2-byte framing, even before a
+	// handshake
+	legacy := ids.GenerateTestNodeID()
+	require.NoError(n.Connected(ctx, legacy, nil))
+	require.NoError(n.AppGossip(ctx, legacy, compressed))
+	require.Equal([]*testMessage{{nodeID, msg}, {legacy, msg}}, handlers[upgradeProtocol].gossip)
+}
@@ -0,0 +1,14 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import "errors"
+
+var (
+	ErrEmptyCompressedMessage = errors.New("empty compressed message")
+	ErrInvalidProtocol        = errors.New("invalid protocol")
+	ErrDuplicateProtocol      = errors.New("duplicate protocol")
+	ErrInvalidHandshake       = errors.New("invalid handshake")
+	ErrTooManyProtocols       = errors.New("too many protocols")
+)
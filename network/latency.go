@@ -0,0 +1,58 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"sort"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+// latencyAlpha is the weight given to each new observation in the moving
+// average of a peer's latency.
+const latencyAlpha = 0.2
+
+// observeLatency updates the average round-trip time of requests to
+// [nodeID] with a response (or timeout) received now to a request sent at
+// [sent]. Requests that were never sent (zero [sent]) are ignored.
+func (n *Manager) observeLatency(nodeID ids.NodeID, sent time.Time) {
+	if sent.IsZero() {
+		return
+	}
+	rtt := n.clock.Time().Sub(sent)
+
+	n.l.Lock()
+	defer n.l.Unlock()
+
+	if _, ok := n.peers[nodeID]; !ok {
+		// Only track latency of connected peers
+		return
+	}
+	prev, ok := n.latencies[nodeID]
+	if !ok {
+		n.latencies[nodeID] = rtt
+		return
+	}
+	n.latencies[nodeID] = time.Duration(latencyAlpha*float64(rtt) + (1-latencyAlpha)*float64(prev))
+}
+
+// FastestPeers returns up to [limit] connected peers with the lowest average
+// request latency. Peers we have never sent a request to are not included.
+func (n *Manager) FastestPeers(limit int) []ids.NodeID {
+	n.l.RLock()
+	defer n.l.RUnlock()
+
+	peers := make([]ids.NodeID, 0, len(n.latencies))
+	for nodeID := range n.latencies {
+		peers = append(peers, nodeID)
+	}
+	sort.Slice(peers, func(i, j int) bool {
+		return n.latencies[peers[i]] < n.latencies[peers[j]]
+	})
+	if len(peers) > limit {
+		peers = peers[:limit]
+	}
+	return peers
+}
@@ -0,0 +1,111 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/stretchr/testify/require"
+)
+
+func TestObserveLatency(t *testing.T) {
+	require := require.New(t)
+
+	n, _, _, _ := newTestManager(t)
+	start := time.Unix(1_000, 0)
+	n.clock.Set(start)
+	nodeID := ids.GenerateTestNodeID()
+
+	// Latency of disconnected peers and unsent requests is ignored
+	n.observeLatency(nodeID, start.Add(-time.Second))
+	require.Empty(n.latencies)
+	require.NoError(n.Connected(context.Background(), nodeID, nil))
+	n.observeLatency(nodeID, time.Time{})
+	require.Empty(n.latencies)
+
+	// The first observation is used as-is
+	n.observeLatency(nodeID, start.Add(-100*time.Millisecond))
+	require.Equal(100*time.Millisecond, n.latencies[nodeID])
+
+	// Later observations are averaged
+	n.observeLatency(nodeID, start.Add(-600*time.Millisecond))
+	require.Equal(200*time.Millisecond, n.latencies[nodeID])
+
+	// Latency is forgotten when a peer disconnects
+	require.NoError(n.Disconnected(context.Background(), nodeID))
+	require.Empty(n.latencies)
+}
+
+func TestFastestPeers(t *testing.T) {
+	require := require.New(t)
+
+	n, _, _, _ := newTestManager(t)
+	start := time.Unix(1_000, 0)
+	n.clock.Set(start)
+	peers := make([]ids.NodeID, 4)
+	for i := range peers {
+		peers[i] = ids.GenerateTestNodeID()
+		require.NoError(n.Connected(context.Background(), peers[i], nil))
+	}
+
+	// Peers we have never sent a request to are excluded
+	require.Empty(n.FastestPeers(2))
+	n.observeLatency(peers[2], start.Add(-300*time.Millisecond))
+	n.observeLatency(peers[0], start.Add(-100*time.Millisecond))
+	n.observeLatency(peers[1], start.Add(-200*time.Millisecond))
+	require.Equal([]ids.NodeID{peers[0], peers[1]}, n.FastestPeers(2))
+	require.Equal([]ids.NodeID{peers[0], peers[1], peers[2]}, n.FastestPeers(10))
+	require.Empty(n.FastestPeers(0))
+}
+
+func TestRequestLatency(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	n, sender, _, handlers := newTestManager(t)
+	start := time.Unix(1_000, 0)
+	n.clock.Set(start)
+	appSender := &WrappedAppSender{n, legacyProtocol}
+	fast, slow, unsupported := ids.GenerateTestNodeID(), ids.GenerateTestNodeID(), ids.GenerateTestNodeID()
+	for _, nodeID := range []ids.NodeID{fast, slow, unsupported} {
+		require.NoError(n.Connected(ctx, nodeID, nil))
+	}
+	require.NoError(n.AppGossip(ctx, unsupported, handshake(t)))
+
+	var requestIDs map[ids.NodeID]uint32
+	sender.SendAppRequestF = func(_ context.Context, nodeIDs set.Set[ids.NodeID], requestID uint32, _ []byte) error {
+		for nodeID := range nodeIDs {
+			requestIDs[nodeID] = requestID
+		}
+		return nil
+	}
+	requestIDs = map[ids.NodeID]uint32{}
+	require.NoError(appSender.SendAppRequest(ctx, set.Of(fast, slow, unsupported), 1, []byte{1}))
+
+	// Requests that fail without being sent are not recorded
+	require.Equal(uint32(1), <-handlers[legacyProtocol].failed)
+	require.NotContains(requestIDs, unsupported)
+
+	// Responses and timeouts are recorded
+	n.clock.Set(start.Add(50 * time.Millisecond))
+	require.NoError(n.AppResponse(ctx, fast, requestIDs[fast], nil))
+	n.clock.Set(start.Add(10 * time.Second))
+	require.NoError(n.AppRequestFailed(ctx, slow, requestIDs[slow]))
+	require.Equal(uint32(1), <-handlers[legacyProtocol].failed)
+	require.Equal(50*time.Millisecond, n.latencies[fast])
+	require.Equal(10*time.Second, n.latencies[slow])
+	require.Equal([]ids.NodeID{fast, slow}, n.FastestPeers(3))
+
+	// A timeout makes a previously fast peer slower
+	requestIDs = map[ids.NodeID]uint32{}
+	require.NoError(appSender.SendAppRequest(ctx, set.Of(fast), 2, []byte{2}))
+	n.clock.Set(start.Add(20 * time.Second))
+	require.NoError(n.AppRequestFailed(ctx, fast, requestIDs[fast]))
+	require.Equal(uint32(2), <-handlers[legacyProtocol].failed)
+	require.Equal(2*time.Second+40*time.Millisecond, n.latencies[fast])
+}
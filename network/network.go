@@ -5,14 +5,18 @@ package network
 
 import (
 	"context"
+	"errors"
 	"sync"
 	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/utils/compression"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
 	"github.com/ava-labs/avalanchego/version"
+	"github.com/ava-labs/hypersdk/consts"
 	"go.uber.org/zap"
 )
 
@@ -22,31 +26,52 @@ type nodeIDRequester struct {
 }
 
 type request struct {
-	handler   uint8
+	protocol  Protocol
 	requestID uint32
+	sent      time.Time // zero if the request was never sent to a peer
 }
 
 type Manager struct {
-	log    logging.Logger
-	nodeID ids.NodeID
-	sender common.AppSender
-	l      sync.RWMutex
+	log     logging.Logger
+	nodeID  ids.NodeID
+	sender  common.AppSender
+	metrics Metrics
+	l       sync.RWMutex
 
-	handler         uint8
-	pendingHandlers map[uint8]struct{}
-	handlers        map[uint8]Handler
+	pendingHandlers map[Protocol]struct{}
+	handlers        map[Protocol]Handler
+	legacyProtocols set.Set[Protocol]
+	peerProtocols   map[ids.NodeID]set.Set[Protocol] // peers that sent a handshake
 
 	requesters map[ids.NodeID]*nodeIDRequester
+
+	reputation *Reputation
+
+	compressor  compression.Compressor
+	compression CompressionConfig
+	peers       map[ids.NodeID]*version.Application
+
+	clock     mockable.Clock
+	latencies map[ids.NodeID]time.Duration
 }
 
-func NewManager(log logging.Logger, nodeID ids.NodeID, sender common.AppSender) *Manager {
+// NewManager returns a [Manager] that routes messages for [nodeID]. [metrics]
+// may be nil.
+func NewManager(log logging.Logger, nodeID ids.NodeID, sender common.AppSender, metrics Metrics) *Manager {
+	// Can only fail if the max size is [math.MaxInt64]
+	compressor, _ := compression.NewZstdCompressor(consts.NetworkSizeLimit)
 	return &Manager{
 		log:             log,
 		nodeID:          nodeID,
 		sender:          sender,
-		handlers:        map[uint8]Handler{},
-		pendingHandlers: map[uint8]struct{}{},
+		metrics:         metrics,
+		handlers:        map[Protocol]Handler{},
+		pendingHandlers: map[Protocol]struct{}{},
+		peerProtocols:   map[ids.NodeID]set.Set[Protocol]{},
 		requesters:      map[ids.NodeID]*nodeIDRequester{},
+		compressor:      compressor,
+		peers:           map[ids.NodeID]*version.Application{},
+		latencies:       map[ids.NodeID]time.Duration{},
 	}
 }
 
@@ -76,14 +101,25 @@ type Handler interface {
 	CrossChainAppResponse(context.Context, ids.ID, uint32, []byte) error
 }
 
-func (n *Manager) Register() (uint8, common.AppSender) {
+// Register reserves [protocol] and returns a sender that prefixes all messages
+// with it.
+//
+// [protocol.ID] must be less than 0x7f (which is reserved for handshakes).
+func (n *Manager) Register(protocol Protocol) (common.AppSender, error) {
+	if protocol.ID >= handshakeID {
+		return nil, ErrInvalidProtocol
+	}
+
 	n.l.Lock()
 	defer n.l.Unlock()
 
-	newHandler := n.handler
-	n.pendingHandlers[newHandler] = struct{}{}
-	n.handler++
-	return newHandler, &WrappedAppSender{n, newHandler}
+	_, pending := n.pendingHandlers[protocol]
+	_, registered := n.handlers[protocol]
+	if pending || registered {
+		return nil, ErrDuplicateProtocol
+	}
+	n.pendingHandlers[protocol] = struct{}{}
+	return &WrappedAppSender{n, protocol}, nil
 }
 
 // Some callers take a sender before the handler is initialized, so we need to
@@ -92,23 +128,78 @@ func (n *Manager) Register() (uint8, common.AppSender) {
 // TODO: in the future allow for queueing messages during the time between
 // Register and SetHandler (should both happen in init so should not be an
 // issue for standard usage)
-func (n *Manager) SetHandler(handler uint8, h Handler) {
+func (n *Manager) SetHandler(protocol Protocol, h Handler) {
 	n.l.Lock()
 	defer n.l.Unlock()
 
-	_, ok := n.pendingHandlers[handler]
+	_, ok := n.pendingHandlers[protocol]
 	if !ok {
-		n.log.Error("pending handler does not exist", zap.Uint8("id", handler))
+		n.log.Error(
+			"pending handler does not exist",
+			zap.Uint8("id", protocol.ID),
+			zap.Uint8("version", protocol.Version),
+		)
 		return
 	}
-	delete(n.pendingHandlers, handler)
-	n.handlers[handler] = h
+	delete(n.pendingHandlers, protocol)
+	n.handlers[protocol] = h
+}
+
+// SetReputation enables peer scoring. Messages from peers that [r] bans or
+// throttles are dropped before they are routed to a handler.
+//
+// Like [SetHandler], this should be called before any messages are handled.
+func (n *Manager) SetReputation(r *Reputation) {
+	n.reputation = r
+}
+
+// ReportPeer penalizes [nodeID] for [offense].
+func (n *Manager) ReportPeer(nodeID ids.NodeID, offense Offense) {
+	if n.reputation == nil {
+		return
+	}
+	if n.reputation.Report(nodeID, offense) {
+		n.log.Debug(
+			"peer is banned",
+			zap.Stringer("nodeID", nodeID),
+			zap.Stringer("offense", offense),
+		)
+	}
+}
+
+// PeerScores returns the reputation of all peers that have misbehaved.
+func (n *Manager) PeerScores() []*PeerScore {
+	if n.reputation == nil {
+		return nil
+	}
+	return n.reputation.Scores()
+}
+
+// ResetPeer forgets all misbehavior by [nodeID].
+func (n *Manager) ResetPeer(nodeID ids.NodeID) bool {
+	if n.reputation == nil {
+		return false
+	}
+	return n.reputation.Reset(nodeID)
+}
+
+// allowPeer returns false if [msg] from [nodeID] should be dropped.
+func (n *Manager) allowPeer(nodeID ids.NodeID, msg []byte) bool {
+	if n.reputation == nil {
+		return true
+	}
+	if len(msg) > consts.NetworkSizeLimit {
+		n.ReportPeer(nodeID, OffenseOversizedMessage)
+		return false
+	}
+	return n.reputation.Allow(nodeID)
 }
 
 func (n *Manager) getSharedRequestID(
-	handler uint8,
+	protocol Protocol,
 	nodeID ids.NodeID,
 	requestID uint32,
+	sent time.Time,
 ) uint32 {
 	n.l.Lock()
 	defer n.l.Unlock()
@@ -121,41 +212,46 @@ func (n *Manager) getSharedRequestID(
 		n.requesters[nodeID] = obj
 	}
 	newID := obj.requestID
-	obj.requestMapper[newID] = &request{handler, requestID}
+	obj.requestMapper[newID] = &request{protocol, requestID, sent}
 	obj.requestID++
 	return newID
 }
 
-func (n *Manager) routeIncomingMessage(msg []byte) ([]byte, Handler, bool) {
-	n.l.RLock()
-	defer n.l.RUnlock()
+func (n *Manager) routeIncomingMessage(msg []byte, legacy bool) ([]byte, Handler, bool) {
+	protocol, payload, ok := parseHeader(msg, legacy)
+	if !ok {
+		n.recordUnknownMessage()
+		return nil, nil, false
+	}
 
-	l := len(msg)
-	if l == 0 {
+	n.l.RLock()
+	handler, ok := n.handlers[protocol]
+	n.l.RUnlock()
+	if !ok {
+		n.recordUnknownMessage()
 		return nil, nil, false
 	}
-	handlerID := msg[0]
-	handler, ok := n.handlers[handlerID]
-	return msg[1:], handler, ok
+	return payload, handler, true
 }
 
 func (n *Manager) handleSharedRequestID(
 	nodeID ids.NodeID,
 	requestID uint32,
-) (Handler, uint32, bool) {
+) (Handler, *request, bool) {
 	n.l.Lock()
 	defer n.l.Unlock()
 
 	obj, ok := n.requesters[nodeID]
 	if !ok {
-		return nil, 0, false
+		return nil, nil, false
 	}
 	req := obj.requestMapper[requestID]
 	if req == nil {
-		return nil, 0, false
+		return nil, nil, false
 	}
 	delete(obj.requestMapper, requestID)
-	return n.handlers[req.handler], req.requestID, true
+	handler, ok := n.handlers[req.protocol]
+	return handler, req, ok
 }
 
 // Handles incoming "AppGossip" messages, parses them to transactions,
@@ -167,7 +263,35 @@ func (n *Manager) handleSharedRequestID(
 // assume gossip via proposervm has been activated
 // ref. "avalanchego/vms/platformvm/network.AppGossip"
 func (n *Manager) AppGossip(ctx context.Context, nodeID ids.NodeID, msg []byte) error {
-	parsedMsg, handler, ok := n.routeIncomingMessage(msg)
+	if !n.allowPeer(nodeID, msg) {
+		n.log.Debug(
+			"dropping AppGossip from misbehaving peer",
+			zap.Stringer("nodeID", nodeID),
+		)
+		return nil
+	}
+	// Compressed messages are only sent by peers that have sent a handshake
+	// (even if we haven't processed it yet)
+	legacy := !isCompressed(msg) && n.isLegacy(nodeID)
+	msg, err := n.decompress(msg)
+	if err != nil {
+		n.log.Debug(
+			"could not decompress incoming AppGossip",
+			zap.Stringer("nodeID", nodeID),
+			zap.Error(err),
+		)
+		if errors.Is(err, compression.ErrDecompressedMsgTooLarge) {
+			n.ReportPeer(nodeID, OffenseOversizedMessage)
+		} else {
+			n.ReportPeer(nodeID, OffenseInvalidMessage)
+		}
+		return nil
+	}
+	if len(msg) >= headerLen && msg[0] == handshakeID {
+		n.handleHandshake(nodeID, msg[headerLen:])
+		return nil
+	}
+	parsedMsg, handler, ok := n.routeIncomingMessage(msg, legacy)
 	if !ok {
 		n.log.Debug(
 			"could not route incoming AppGossip",
@@ -186,7 +310,15 @@ func (n *Manager) AppRequest(
 	deadline time.Time,
 	request []byte,
 ) error {
-	parsedMsg, handler, ok := n.routeIncomingMessage(request)
+	if !n.allowPeer(nodeID, request) {
+		n.log.Debug(
+			"dropping AppRequest from misbehaving peer",
+			zap.Stringer("nodeID", nodeID),
+			zap.Uint32("requestID", requestID),
+		)
+		return nil
+	}
+	parsedMsg, handler, ok := n.routeIncomingMessage(request, n.isLegacy(nodeID))
 	if !ok {
 		n.log.Debug(
 			"could not route incoming AppRequest",
@@ -204,7 +336,7 @@ func (n *Manager) AppRequestFailed(
 	nodeID ids.NodeID,
 	requestID uint32,
 ) error {
-	handler, cRequestID, ok := n.handleSharedRequestID(nodeID, requestID)
+	handler, req, ok := n.handleSharedRequestID(nodeID, requestID)
 	if !ok {
 		n.log.Debug(
 			"could not handle incoming AppRequestFailed",
@@ -213,7 +345,10 @@ func (n *Manager) AppRequestFailed(
 		)
 		return nil
 	}
-	return handler.AppRequestFailed(ctx, nodeID, cRequestID)
+	// Timeouts are recorded as samples (the round-trip time is at least the
+	// timeout) so that unresponsive peers are not considered low latency.
+	n.observeLatency(nodeID, req.sent)
+	return handler.AppRequestFailed(ctx, nodeID, req.requestID)
 }
 
 // implements "block.ChainVM.commom.VM.AppHandler"
@@ -223,7 +358,7 @@ func (n *Manager) AppResponse(
 	requestID uint32,
 	response []byte,
 ) error {
-	handler, cRequestID, ok := n.handleSharedRequestID(nodeID, requestID)
+	handler, req, ok := n.handleSharedRequestID(nodeID, requestID)
 	if !ok {
 		n.log.Debug(
 			"could not handle incoming AppResponse",
@@ -232,7 +367,8 @@ func (n *Manager) AppResponse(
 		)
 		return nil
 	}
-	return handler.AppResponse(ctx, nodeID, cRequestID, response)
+	n.observeLatency(nodeID, req.sent)
+	return handler.AppResponse(ctx, nodeID, req.requestID, response)
 }
 
 // implements "block.ChainVM.commom.VM.validators.Connector"
@@ -241,6 +377,16 @@ func (n *Manager) Connected(
 	nodeID ids.NodeID,
 	v *version.Application,
 ) error {
+	n.addPeer(nodeID, v)
+	if nodeID == n.nodeID {
+		// We support all of our own protocols
+		n.l.Lock()
+		n.peerProtocols[nodeID] = set.Of(n.protocols()...)
+		n.l.Unlock()
+	} else {
+		n.sendHandshake(ctx, nodeID)
+	}
+
 	n.l.RLock()
 	defer n.l.RUnlock()
 	for k, handler := range n.handlers {
@@ -248,7 +394,8 @@ func (n *Manager) Connected(
 			n.log.Debug(
 				"handler could not hanlde connected message",
 				zap.Stringer("nodeID", nodeID),
-				zap.Uint8("handler", k),
+				zap.Uint8("handler", k.ID),
+				zap.Uint8("version", k.Version),
 				zap.Error(err),
 			)
 		}
@@ -258,6 +405,8 @@ func (n *Manager) Connected(
 
 // implements "block.ChainVM.commom.VM.validators.Connector"
 func (n *Manager) Disconnected(ctx context.Context, nodeID ids.NodeID) error {
+	n.removePeer(nodeID)
+
 	n.l.RLock()
 	defer n.l.RUnlock()
 	for k, handler := range n.handlers {
@@ -265,7 +414,8 @@ func (n *Manager) Disconnected(ctx context.Context, nodeID ids.NodeID) error {
 			n.log.Debug(
 				"handler could not hanlde disconnected message",
 				zap.Stringer("nodeID", nodeID),
-				zap.Uint8("handler", k),
+				zap.Uint8("handler", k.ID),
+				zap.Uint8("version", k.Version),
 				zap.Error(err),
 			)
 		}
@@ -280,7 +430,9 @@ func (n *Manager) CrossChainAppRequest(
 	deadline time.Time,
 	msg []byte,
 ) error {
-	parsedMsg, handler, ok := n.routeIncomingMessage(msg)
+	// Other chains on this node always run the same version, so they never use
+	// the legacy framing
+	parsedMsg, handler, ok := n.routeIncomingMessage(msg, false)
 	if !ok {
 		n.log.Debug(
 			"could not route incoming CrossChainAppRequest",
@@ -297,7 +449,7 @@ func (n *Manager) CrossChainAppRequestFailed(
 	chainID ids.ID,
 	requestID uint32,
 ) error {
-	handler, cRequestID, ok := n.handleSharedRequestID(n.nodeID, requestID)
+	handler, req, ok := n.handleSharedRequestID(n.nodeID, requestID)
 	if !ok {
 		n.log.Debug(
 			"could not handle incoming CrossChainAppRequestFailed",
@@ -306,7 +458,7 @@ func (n *Manager) CrossChainAppRequestFailed(
 		)
 		return nil
 	}
-	return handler.CrossChainAppRequestFailed(ctx, chainID, cRequestID)
+	return handler.CrossChainAppRequestFailed(ctx, chainID, req.requestID)
 }
 
 func (n *Manager) CrossChainAppResponse(
@@ -315,7 +467,7 @@ func (n *Manager) CrossChainAppResponse(
 	requestID uint32,
 	response []byte,
 ) error {
-	handler, cRequestID, ok := n.handleSharedRequestID(n.nodeID, requestID)
+	handler, req, ok := n.handleSharedRequestID(n.nodeID, requestID)
 	if !ok {
 		n.log.Debug(
 			"could not handle incoming CrossChainAppResponse",
@@ -324,14 +476,14 @@ func (n *Manager) CrossChainAppResponse(
 		)
 		return nil
 	}
-	return handler.CrossChainAppResponse(ctx, chainID, cRequestID, response)
+	return handler.CrossChainAppResponse(ctx, chainID, req.requestID, response)
 }
 
 // WrappedAppSender is used to get a shared requestID and to prepend messages
-// with the handler identifier.
+// with the protocol identifier.
 type WrappedAppSender struct {
-	n       *Manager
-	handler uint8
+	n        *Manager
+	protocol Protocol
 }
 
 // Send an application-level request.
@@ -341,20 +493,37 @@ type WrappedAppSender struct {
 // * An AppRequestFailed from nodeID with ID [requestID]
 // Exactly one of the above messages will eventually be received per nodeID.
 // A non-nil error should be considered fatal.
+//
+// Requests to peers that don't support our protocol are not sent and fail
+// immediately.
 func (w *WrappedAppSender) SendAppRequest(
 	ctx context.Context,
 	nodeIDs set.Set[ids.NodeID],
 	requestID uint32,
 	appRequestBytes []byte,
 ) error {
-	appRequestBytes = w.createMessageBytes(appRequestBytes)
+	var (
+		msg       = w.createMessageBytes(appRequestBytes, false)
+		legacyMsg = w.createMessageBytes(appRequestBytes, true)
+	)
 	for nodeID := range nodeIDs {
-		newRequestID := w.n.getSharedRequestID(w.handler, nodeID, requestID)
+		if !w.n.Supports(nodeID, w.protocol) {
+			newRequestID := w.n.getSharedRequestID(w.protocol, nodeID, requestID, time.Time{})
+			go func(nodeID ids.NodeID) {
+				_ = w.n.AppRequestFailed(context.Background(), nodeID, newRequestID)
+			}(nodeID)
+			continue
+		}
+		newRequestID := w.n.getSharedRequestID(w.protocol, nodeID, requestID, w.n.clock.Time())
+		request := msg
+		if w.n.isLegacy(nodeID) {
+			request = legacyMsg
+		}
 		if err := w.n.sender.SendAppRequest(
 			ctx,
 			set.Of(nodeID),
 			newRequestID,
-			appRequestBytes,
+			request,
 		); err != nil {
 			return err
 		}
@@ -384,22 +553,69 @@ func (w *WrappedAppSender) SendAppResponse(
 
 // Gossip an application-level message.
 // A non-nil error should be considered fatal.
+//
+// If any connected peer has not sent a handshake, it can't parse our framing
+// (or decompress our messages), so we instead send the message to each
+// connected peer in the framing it expects. Otherwise, we don't know which
+// peers will receive the message, so it is only compressed if all connected
+// peers have sent a handshake.
+//
+// If we haven't been notified of any connected peers, we haven't sent a
+// handshake to anyone, so any recipient expects the legacy framing.
 func (w *WrappedAppSender) SendAppGossip(ctx context.Context, appGossipBytes []byte) error {
+	peers, legacy := w.n.connectedPeers()
+	if legacy {
+		return w.SendAppGossipSpecific(ctx, peers, appGossipBytes)
+	}
+	if peers.Len() == 0 {
+		if !w.n.isLegacyProtocol(w.protocol) {
+			return nil
+		}
+		return w.n.sender.SendAppGossip(
+			ctx,
+			w.createMessageBytes(appGossipBytes, true),
+		)
+	}
+	if w.n.compressionEnabled() {
+		if msg, ok := w.n.compress(w.protocol, appGossipBytes); ok {
+			return w.n.sender.SendAppGossip(ctx, msg)
+		}
+	}
 	return w.n.sender.SendAppGossip(
 		ctx,
-		w.createMessageBytes(appGossipBytes),
+		w.createMessageBytes(appGossipBytes, false),
 	)
 }
 
+// SendAppGossipSpecific compresses the message sent to [nodeIDs] that have sent
+// a handshake. Peers that don't support our protocol are skipped.
 func (w *WrappedAppSender) SendAppGossipSpecific(
 	ctx context.Context,
 	nodeIDs set.Set[ids.NodeID],
 	appGossipBytes []byte,
 ) error {
+	supported, legacy := w.n.filterSupported(nodeIDs, w.protocol)
+	if legacy.Len() > 0 {
+		if err := w.n.sender.SendAppGossipSpecific(
+			ctx,
+			legacy,
+			w.createMessageBytes(appGossipBytes, true),
+		); err != nil {
+			return err
+		}
+	}
+	if supported.Len() == 0 {
+		return nil
+	}
+	if w.n.compressionEnabled() {
+		if msg, ok := w.n.compress(w.protocol, appGossipBytes); ok {
+			return w.n.sender.SendAppGossipSpecific(ctx, supported, msg)
+		}
+	}
 	return w.n.sender.SendAppGossipSpecific(
 		ctx,
-		nodeIDs,
-		w.createMessageBytes(appGossipBytes),
+		supported,
+		w.createMessageBytes(appGossipBytes, false),
 	)
 }
 
@@ -419,12 +635,13 @@ func (w *WrappedAppSender) SendCrossChainAppRequest(
 	requestID uint32,
 	appRequestBytes []byte,
 ) error {
-	newRequestID := w.n.getSharedRequestID(w.handler, w.n.nodeID, requestID)
+	// We only track the latency of requests to peers
+	newRequestID := w.n.getSharedRequestID(w.protocol, w.n.nodeID, requestID, time.Time{})
 	return w.n.sender.SendCrossChainAppRequest(
 		ctx,
 		chainID,
 		newRequestID,
-		w.createMessageBytes(appRequestBytes),
+		w.createMessageBytes(appRequestBytes, false),
 	)
 }
 
@@ -446,9 +663,10 @@ func (w *WrappedAppSender) SendCrossChainAppResponse(
 	return w.n.sender.SendCrossChainAppResponse(ctx, chainID, requestID, appResponseBytes)
 }
 
-func (w *WrappedAppSender) createMessageBytes(src []byte) []byte {
-	messageBytes := make([]byte, 1+len(src))
-	messageBytes[0] = w.handler
-	copy(messageBytes[1:], src)
+func (w *WrappedAppSender) createMessageBytes(src []byte, legacy bool) []byte {
+	header := w.protocol.header(legacy)
+	messageBytes := make([]byte, len(header)+len(src))
+	copy(messageBytes, header)
+	copy(messageBytes[len(header):], src)
 	return messageBytes
 }
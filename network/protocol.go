@@ -0,0 +1,269 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"context"
+	"sort"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"go.uber.org/zap"
+)
+
+const (
+	// headerLen is the size of the [Protocol] prefixed to every message.
+	headerLen = 2
+	// legacyHeaderLen is the size of the protocol ID prefixed to messages
+	// exchanged with peers that have not sent a handshake (those running a
+	// version of the VM that predates handshakes).
+	legacyHeaderLen = 1
+
+	// handshakeID is reserved for the message each peer sends on [Connected]
+	// to advertise the protocols it supports.
+	handshakeID uint8 = 0x7f
+
+	// maxProtocols is the maximum number of protocols a peer can advertise.
+	maxProtocols = 256
+)
+
+// Protocol identifies the [Handler] for a message. Every message is prefixed
+// with the [ID] and [Version] of the protocol it belongs to (or only the [ID]
+// if the peer has not sent a handshake).
+//
+// IDs must be explicitly assigned and never reused, so that nodes running
+// different versions of the VM can communicate. A node can support multiple
+// versions of the same protocol by registering a [Handler] for each.
+type Protocol struct {
+	ID      uint8 `json:"id"`
+	Version uint8 `json:"version"`
+}
+
+func (p Protocol) header(legacy bool) []byte {
+	if legacy {
+		return []byte{p.ID}
+	}
+	return []byte{p.ID, p.Version}
+}
+
+// Metrics is notified when a message is dropped because it could not be
+// routed to a [Handler].
+type Metrics interface {
+	RecordUnknownMessage()
+}
+
+// parseHeader returns the [Protocol] of [msg] and its payload. Legacy
+// messages only include the protocol ID, so they always have version 0.
+func parseHeader(msg []byte, legacy bool) (Protocol, []byte, bool) {
+	if legacy {
+		if len(msg) < legacyHeaderLen {
+			return Protocol{}, nil, false
+		}
+		return Protocol{ID: msg[0]}, msg[legacyHeaderLen:], true
+	}
+	if len(msg) < headerLen {
+		return Protocol{}, nil, false
+	}
+	return Protocol{ID: msg[0], Version: msg[1]}, msg[headerLen:], true
+}
+
+// protocols returns all registered protocols, sorted by ID and version.
+//
+// Assumes [n.l] is held.
+func (n *Manager) protocols() []Protocol {
+	protocols := make([]Protocol, 0, len(n.handlers)+len(n.pendingHandlers))
+	for p := range n.handlers {
+		protocols = append(protocols, p)
+	}
+	for p := range n.pendingHandlers {
+		protocols = append(protocols, p)
+	}
+	sort.Slice(protocols, func(i, j int) bool {
+		if protocols[i].ID != protocols[j].ID {
+			return protocols[i].ID < protocols[j].ID
+		}
+		return protocols[i].Version < protocols[j].Version
+	})
+	return protocols
+}
+
+func marshalHandshake(protocols []Protocol) ([]byte, error) {
+	p := codec.NewWriter(headerLen+consts.IntLen+len(protocols)*headerLen, consts.NetworkSizeLimit)
+	p.PackFixedBytes(Protocol{ID: handshakeID}.header(false))
+	p.PackInt(len(protocols))
+	for _, protocol := range protocols {
+		p.PackByte(protocol.ID)
+		p.PackByte(protocol.Version)
+	}
+	return p.Bytes(), p.Err()
+}
+
+func unmarshalHandshake(msg []byte) (set.Set[Protocol], error) {
+	p := codec.NewReader(msg, consts.NetworkSizeLimit)
+	count := p.UnpackInt(false)
+	if count > maxProtocols {
+		return nil, ErrTooManyProtocols
+	}
+	protocols := set.NewSet[Protocol](count)
+	for i := 0; i < count; i++ {
+		protocols.Add(Protocol{ID: p.UnpackByte(), Version: p.UnpackByte()})
+	}
+	if !p.Empty() {
+		return nil, ErrInvalidHandshake
+	}
+	return protocols, p.Err()
+}
+
+// sendHandshake advertises our protocols to [nodeID].
+func (n *Manager) sendHandshake(ctx context.Context, nodeID ids.NodeID) {
+	n.l.RLock()
+	protocols := n.protocols()
+	n.l.RUnlock()
+
+	msg, err := marshalHandshake(protocols)
+	if err != nil {
+		n.log.Warn("unable to marshal handshake", zap.Error(err))
+		return
+	}
+	if err := n.sender.SendAppGossipSpecific(ctx, set.Of(nodeID), msg); err != nil {
+		n.log.Warn(
+			"unable to send handshake",
+			zap.Stringer("nodeID", nodeID),
+			zap.Error(err),
+		)
+	}
+}
+
+// handleHandshake records the protocols supported by [nodeID].
+func (n *Manager) handleHandshake(nodeID ids.NodeID, msg []byte) {
+	protocols, err := unmarshalHandshake(msg)
+	if err != nil {
+		n.log.Debug(
+			"could not parse handshake",
+			zap.Stringer("nodeID", nodeID),
+			zap.Error(err),
+		)
+		n.ReportPeer(nodeID, OffenseInvalidMessage)
+		return
+	}
+
+	n.l.Lock()
+	defer n.l.Unlock()
+	if _, ok := n.peers[nodeID]; !ok {
+		// Peer disconnected before we processed the handshake
+		return
+	}
+	n.peerProtocols[nodeID] = protocols
+}
+
+// SetLegacyProtocols sets the protocols supported by peers that have not sent
+// a handshake. Messages for these protocols are exchanged with such peers
+// using the legacy framing (only the protocol ID).
+//
+// Like [SetHandler], this should be called before any messages are handled.
+func (n *Manager) SetLegacyProtocols(protocols ...Protocol) error {
+	n.l.Lock()
+	defer n.l.Unlock()
+
+	legacy := set.NewSet[Protocol](len(protocols))
+	for _, protocol := range protocols {
+		if protocol.Version != 0 {
+			return ErrInvalidProtocol
+		}
+		legacy.Add(protocol)
+	}
+	n.legacyProtocols = legacy
+	return nil
+}
+
+// Supports returns true if [nodeID] supports [protocol]. Peers that have not
+// yet sent a handshake are assumed to only support the legacy protocols.
+func (n *Manager) Supports(nodeID ids.NodeID, protocol Protocol) bool {
+	n.l.RLock()
+	defer n.l.RUnlock()
+
+	return n.supports(nodeID, protocol)
+}
+
+// Assumes [n.l] is held.
+func (n *Manager) supports(nodeID ids.NodeID, protocol Protocol) bool {
+	if protocols, ok := n.peerProtocols[nodeID]; ok {
+		return protocols.Contains(protocol)
+	}
+	return n.legacyProtocols.Contains(protocol)
+}
+
+// isLegacyProtocol returns true if [protocol] is supported by peers that
+// don't send a handshake.
+func (n *Manager) isLegacyProtocol(protocol Protocol) bool {
+	n.l.RLock()
+	defer n.l.RUnlock()
+
+	return n.legacyProtocols.Contains(protocol)
+}
+
+// isLegacy returns true if messages exchanged with [nodeID] use the legacy
+// framing because it has not sent a handshake.
+func (n *Manager) isLegacy(nodeID ids.NodeID) bool {
+	n.l.RLock()
+	defer n.l.RUnlock()
+
+	_, ok := n.peerProtocols[nodeID]
+	return !ok
+}
+
+// recordUnknownMessage counts a message that could not be routed.
+func (n *Manager) recordUnknownMessage() {
+	if n.metrics != nil {
+		n.metrics.RecordUnknownMessage()
+	}
+}
+
+// filterSupported returns the subsets of [nodeIDs] that support [protocol]
+// and have or have not sent a handshake.
+func (n *Manager) filterSupported(
+	nodeIDs set.Set[ids.NodeID],
+	protocol Protocol,
+) (set.Set[ids.NodeID], set.Set[ids.NodeID]) {
+	n.l.RLock()
+	defer n.l.RUnlock()
+
+	var (
+		supported = set.NewSet[ids.NodeID](nodeIDs.Len())
+		legacy    = set.NewSet[ids.NodeID](0)
+	)
+	for nodeID := range nodeIDs {
+		if !n.supports(nodeID, protocol) {
+			continue
+		}
+		if _, ok := n.peerProtocols[nodeID]; ok {
+			supported.Add(nodeID)
+		} else {
+			legacy.Add(nodeID)
+		}
+	}
+	return supported, legacy
+}
+
+// connectedPeers returns all connected peers (other than us) and whether any
+// of them have not sent a handshake.
+func (n *Manager) connectedPeers() (set.Set[ids.NodeID], bool) {
+	n.l.RLock()
+	defer n.l.RUnlock()
+
+	var legacy bool
+	peers := set.NewSet[ids.NodeID](len(n.peers))
+	for nodeID := range n.peers {
+		if nodeID == n.nodeID {
+			continue
+		}
+		if _, ok := n.peerProtocols[nodeID]; !ok {
+			legacy = true
+		}
+		peers.Add(nodeID)
+	}
+	return peers, legacy
+}
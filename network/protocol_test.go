@@ -0,0 +1,374 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/version"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+)
+
+var (
+	legacyProtocol  = Protocol{ID: 0, Version: 0}
+	upgradeProtocol = Protocol{ID: 0, Version: 1}
+	newProtocol     = Protocol{ID: 1, Version: 0}
+)
+
+type testMessage struct {
+	nodeID ids.NodeID
+	msg    []byte
+}
+
+type testHandler struct {
+	gossip   []*testMessage
+	requests []*testMessage
+	failed   chan uint32
+}
+
+func newTestHandler() *testHandler {
+	return &testHandler{failed: make(chan uint32, 8)}
+}
+
+func (*testHandler) Connected(context.Context, ids.NodeID, *version.Application) error {
+	return nil
+}
+
+func (*testHandler) Disconnected(context.Context, ids.NodeID) error { return nil }
+
+func (h *testHandler) AppGossip(_ context.Context, nodeID ids.NodeID, msg []byte) error {
+	h.gossip = append(h.gossip, &testMessage{nodeID, msg})
+	return nil
+}
+
+func (h *testHandler) AppRequest(_ context.Context, nodeID ids.NodeID, _ uint32, _ time.Time, msg []byte) error {
+	h.requests = append(h.requests, &testMessage{nodeID, msg})
+	return nil
+}
+
+func (h *testHandler) AppRequestFailed(_ context.Context, _ ids.NodeID, requestID uint32) error {
+	h.failed <- requestID
+	return nil
+}
+
+func (*testHandler) AppResponse(context.Context, ids.NodeID, uint32, []byte) error { return nil }
+
+func (*testHandler) CrossChainAppRequest(context.Context, ids.ID, uint32, time.Time, []byte) error {
+	return nil
+}
+
+func (*testHandler) CrossChainAppRequestFailed(context.Context, ids.ID, uint32) error {
+	return nil
+}
+
+func (*testHandler) CrossChainAppResponse(context.Context, ids.ID, uint32, []byte) error {
+	return nil
+}
+
+type testNetworkMetrics struct {
+	unknown int
+}
+
+func (m *testNetworkMetrics) RecordUnknownMessage() { m.unknown++ }
+
+// testSender records all messages sent by a [Manager].
+type testSender struct {
+	*common.SenderTest
+
+	gossip   [][]byte
+	specific map[ids.NodeID][][]byte
+	requests map[ids.NodeID][][]byte
+}
+
+func newTestSender(t *testing.T) *testSender {
+	s := &testSender{
+		specific: map[ids.NodeID][][]byte{},
+		requests: map[ids.NodeID][][]byte{},
+	}
+	s.SenderTest = &common.SenderTest{
+		T: t,
+		SendAppGossipF: func(_ context.Context, msg []byte) error {
+			s.gossip = append(s.gossip, msg)
+			return nil
+		},
+		SendAppGossipSpecificF: func(_ context.Context, nodeIDs set.Set[ids.NodeID], msg []byte) error {
+			for nodeID := range nodeIDs {
+				s.specific[nodeID] = append(s.specific[nodeID], msg)
+			}
+			return nil
+		},
+		SendAppRequestF: func(_ context.Context, nodeIDs set.Set[ids.NodeID], _ uint32, msg []byte) error {
+			for nodeID := range nodeIDs {
+				s.requests[nodeID] = append(s.requests[nodeID], msg)
+			}
+			return nil
+		},
+	}
+	return s
+}
+
+// newTestManager returns a [Manager] with a [testHandler] registered for each
+// of [legacyProtocol], [upgradeProtocol], and [newProtocol]. Only
+// [legacyProtocol] is supported by legacy peers.
+func newTestManager(t *testing.T) (*Manager, *testSender, *testNetworkMetrics, map[Protocol]*testHandler) {
+	require := require.New(t)
+
+	sender := newTestSender(t)
+	metrics := &testNetworkMetrics{}
+	n := NewManager(logging.NoLog{}, ids.GenerateTestNodeID(), sender, metrics)
+	handlers := map[Protocol]*testHandler{}
+	for _, protocol := range []Protocol{legacyProtocol, upgradeProtocol, newProtocol} {
+		_, err := n.Register(protocol)
+		require.NoError(err)
+		handlers[protocol] = newTestHandler()
+		n.SetHandler(protocol, handlers[protocol])
+	}
+	require.NoError(n.SetLegacyProtocols(legacyProtocol))
+	return n, sender, metrics, handlers
+}
+
+func handshake(t *testing.T, protocols ...Protocol) []byte {
+	msg, err := marshalHandshake(protocols)
+	require.NoError(t, err)
+	return msg
+}
+
+func TestHandshakeMarshal(t *testing.T) {
+	require := require.New(t)
+
+	protocols := []Protocol{legacyProtocol, upgradeProtocol, newProtocol}
+	msg := handshake(t, protocols...)
+	require.Equal([]byte{handshakeID, 0}, msg[:headerLen])
+	parsed, err := unmarshalHandshake(msg[headerLen:])
+	require.NoError(err)
+	require.Equal(set.Of(protocols...), parsed)
+
+	// Peers may not support any protocols
+	parsed, err = unmarshalHandshake(handshake(t)[headerLen:])
+	require.NoError(err)
+	require.Zero(parsed.Len())
+
+	// Trailing bytes
+	_, err = unmarshalHandshake(append(msg[headerLen:], 0))
+	require.ErrorIs(err, ErrInvalidHandshake)
+
+	// Truncated
+	_, err = unmarshalHandshake(msg[headerLen : len(msg)-1])
+	require.Error(err)
+
+	// Too many protocols
+	p := codec.NewWriter(consts.IntLen, consts.IntLen)
+	p.PackInt(maxProtocols + 1)
+	_, err = unmarshalHandshake(p.Bytes())
+	require.ErrorIs(err, ErrTooManyProtocols)
+}
+
+func TestParseHeader(t *testing.T) {
+	tests := []struct {
+		name     string
+		msg      []byte
+		legacy   bool
+		protocol Protocol
+		payload  []byte
+		ok       bool
+	}{
+		{name: "empty", msg: []byte{}},
+		{name: "empty legacy", msg: []byte{}, legacy: true},
+		{name: "missing version", msg: []byte{1}},
+		{name: "no payload", msg: []byte{1, 2}, protocol: Protocol{ID: 1, Version: 2}, payload: []byte{}, ok: true},
+		{name: "payload", msg: []byte{1, 2, 3}, protocol: Protocol{ID: 1, Version: 2}, payload: []byte{3}, ok: true},
+		{name: "legacy no payload", msg: []byte{1}, legacy: true, protocol: Protocol{ID: 1}, payload: []byte{}, ok: true},
+		{name: "legacy payload", msg: []byte{1, 2, 3}, legacy: true, protocol: Protocol{ID: 1}, payload: []byte{2, 3}, ok: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			protocol, payload, ok := parseHeader(tt.msg, tt.legacy)
+			require.Equal(tt.ok, ok)
+			if !ok {
+				return
+			}
+			require.Equal(tt.protocol, protocol)
+			require.Equal(tt.payload, payload)
+			require.Equal(tt.msg, append(tt.protocol.header(tt.legacy), payload...))
+		})
+	}
+}
+
+func TestSetLegacyProtocols(t *testing.T) {
+	n := NewManager(logging.NoLog{}, ids.GenerateTestNodeID(), nil, nil)
+	require.ErrorIs(t, n.SetLegacyProtocols(legacyProtocol, upgradeProtocol), ErrInvalidProtocol)
+}
+
+func TestSupports(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	n, sender, _, _ := newTestManager(t)
+	legacy, upgraded, disconnected := ids.GenerateTestNodeID(), ids.GenerateTestNodeID(), ids.GenerateTestNodeID()
+	require.NoError(n.Connected(ctx, n.nodeID, nil))
+	require.NoError(n.Connected(ctx, legacy, nil))
+	require.NoError(n.Connected(ctx, upgraded, nil))
+
+	// We send a handshake to every peer (but not ourselves)
+	expected := handshake(t, legacyProtocol, upgradeProtocol, newProtocol)
+	require.Equal([][]byte{expected}, sender.specific[legacy])
+	require.Equal([][]byte{expected}, sender.specific[upgraded])
+	require.Empty(sender.specific[n.nodeID])
+
+	// Before a handshake, peers only support the legacy protocols
+	require.NoError(n.AppGossip(ctx, upgraded, handshake(t, upgradeProtocol)))
+	tests := []struct {
+		nodeID   ids.NodeID
+		protocol Protocol
+		expected bool
+	}{
+		{nodeID: n.nodeID, protocol: legacyProtocol, expected: true},
+		{nodeID: n.nodeID, protocol: upgradeProtocol, expected: true},
+		{nodeID: n.nodeID, protocol: newProtocol, expected: true},
+		{nodeID: legacy, protocol: legacyProtocol, expected: true},
+		{nodeID: legacy, protocol: upgradeProtocol},
+		{nodeID: legacy, protocol: newProtocol},
+		{nodeID: upgraded, protocol: legacyProtocol},
+		{nodeID: upgraded, protocol: upgradeProtocol, expected: true},
+		{nodeID: upgraded, protocol: newProtocol},
+	}
+	for _, tt := range tests {
+		require.Equal(tt.expected, n.Supports(tt.nodeID, tt.protocol))
+	}
+
+	// Peers that support a protocol are split by framing
+	nodeIDs := set.Of(n.nodeID, legacy, upgraded)
+	supported, legacyPeers := n.filterSupported(nodeIDs, legacyProtocol)
+	require.Equal(set.Of(n.nodeID), supported)
+	require.Equal(set.Of(legacy), legacyPeers)
+	supported, legacyPeers = n.filterSupported(nodeIDs, upgradeProtocol)
+	require.Equal(set.Of(n.nodeID, upgraded), supported)
+	require.Zero(legacyPeers.Len())
+	supported, legacyPeers = n.filterSupported(nodeIDs, newProtocol)
+	require.Equal(set.Of(n.nodeID), supported)
+	require.Zero(legacyPeers.Len())
+
+	// Handshakes from peers that aren't connected are ignored
+	require.NoError(n.AppGossip(ctx, disconnected, handshake(t, upgradeProtocol)))
+	require.False(n.Supports(disconnected, upgradeProtocol))
+
+	// Handshakes are forgotten when a peer disconnects
+	require.NoError(n.Disconnected(ctx, upgraded))
+	require.True(n.Supports(upgraded, legacyProtocol))
+	require.False(n.Supports(upgraded, upgradeProtocol))
+}
+
+func TestRouteIncomingMessages(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	n, _, metrics, handlers := newTestManager(t)
+	nodeID := ids.GenerateTestNodeID()
+	require.NoError(n.Connected(ctx, nodeID, nil))
+
+	// Before a handshake, messages use the legacy framing
+	require.NoError(n.AppGossip(ctx, nodeID, []byte{legacyProtocol.ID, 1}))
+	require.NoError(n.AppRequest(ctx, nodeID, 1, time.Time{}, []byte{legacyProtocol.ID, 2}))
+	require.Equal([]*testMessage{{nodeID, []byte{1}}}, handlers[legacyProtocol].gossip)
+	require.Equal([]*testMessage{{nodeID, []byte{2}}}, handlers[legacyProtocol].requests)
+	require.NoError(n.AppGossip(ctx, nodeID, []byte{0x10, 1}))
+	require.Equal(1, metrics.unknown)
+
+	// After a handshake, messages include the protocol version
+	require.NoError(n.AppGossip(ctx, nodeID, handshake(t, legacyProtocol, upgradeProtocol)))
+	require.NoError(n.AppGossip(ctx, nodeID, []byte{upgradeProtocol.ID, upgradeProtocol.Version, 3}))
+	require.NoError(n.AppRequest(ctx, nodeID, 2, time.Time{}, []byte{newProtocol.ID, newProtocol.Version, 4}))
+	require.NoError(n.AppGossip(ctx, nodeID, []byte{legacyProtocol.ID, legacyProtocol.Version, 5}))
+	require.Equal([]*testMessage{{nodeID, []byte{3}}}, handlers[upgradeProtocol].gossip)
+	require.Equal([]*testMessage{{nodeID, []byte{4}}}, handlers[newProtocol].requests)
+	require.Equal([]*testMessage{{nodeID, []byte{1}}, {nodeID, []byte{5}}}, handlers[legacyProtocol].gossip)
+
+	// Unknown versions and truncated messages are dropped
+	require.NoError(n.AppGossip(ctx, nodeID, []byte{upgradeProtocol.ID, 9, 6}))
+	require.NoError(n.AppGossip(ctx, nodeID, []byte{upgradeProtocol.ID}))
+	require.Equal(3, metrics.unknown)
+	require.Len(handlers[upgradeProtocol].gossip, 1)
+
+	// Invalid handshakes are dropped
+	require.NoError(n.AppGossip(ctx, nodeID, []byte{handshakeID, 0}))
+	require.True(n.Supports(nodeID, upgradeProtocol))
+}
+
+func TestSendFraming(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	n, sender, _, handlers := newTestManager(t)
+	legacySender, err := n.Register(Protocol{ID: 2})
+	require.NoError(err)
+	require.NoError(n.SetLegacyProtocols(legacyProtocol, Protocol{ID: 2}))
+	newSender := &WrappedAppSender{n, newProtocol}
+
+	legacy, upgraded := ids.GenerateTestNodeID(), ids.GenerateTestNodeID()
+	require.NoError(n.Connected(ctx, n.nodeID, nil))
+	require.NoError(n.Connected(ctx, legacy, nil))
+	require.NoError(n.Connected(ctx, upgraded, nil))
+	require.NoError(n.AppGossip(ctx, upgraded, handshake(t, Protocol{ID: 2}, newProtocol)))
+	sender.specific = map[ids.NodeID][][]byte{}
+
+	// Each peer receives the framing it expects
+	require.NoError(legacySender.SendAppGossipSpecific(ctx, set.Of(legacy, upgraded), []byte{1}))
+	require.Equal([][]byte{{2, 1}}, sender.specific[legacy])
+	require.Equal([][]byte{{2, 0, 1}}, sender.specific[upgraded])
+
+	// Broadcasts are sent to each peer while any peer uses the legacy framing
+	sender.specific = map[ids.NodeID][][]byte{}
+	require.NoError(legacySender.SendAppGossip(ctx, []byte{2}))
+	require.Empty(sender.gossip)
+	require.Equal([][]byte{{2, 2}}, sender.specific[legacy])
+	require.Equal([][]byte{{2, 0, 2}}, sender.specific[upgraded])
+
+	// Peers that don't support a protocol are skipped
+	sender.specific = map[ids.NodeID][][]byte{}
+	require.NoError(newSender.SendAppGossip(ctx, []byte{3}))
+	require.Empty(sender.specific[legacy])
+	require.Equal([][]byte{{1, 0, 3}}, sender.specific[upgraded])
+
+	// Requests use the framing of each peer and requests to peers that don't
+	// support a protocol fail immediately
+	require.NoError(legacySender.SendAppRequest(ctx, set.Of(legacy, upgraded), 1, []byte{4}))
+	require.Equal([][]byte{{2, 4}}, sender.requests[legacy])
+	require.Equal([][]byte{{2, 0, 4}}, sender.requests[upgraded])
+	require.NoError(newSender.SendAppRequest(ctx, set.Of(legacy), 7, []byte{5}))
+	require.Equal(uint32(7), <-handlers[newProtocol].failed)
+	require.Len(sender.requests[legacy], 1)
+
+	// Once all peers have sent a handshake, broadcasts are sent to everyone
+	require.NoError(n.AppGossip(ctx, legacy, handshake(t, Protocol{ID: 2})))
+	sender.specific = map[ids.NodeID][][]byte{}
+	require.NoError(legacySender.SendAppGossip(ctx, []byte{6}))
+	require.Equal([][]byte{{2, 0, 6}}, sender.gossip)
+	require.Empty(sender.specific)
+}
+
+func TestSendFramingWithoutPeers(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	// We haven't sent a handshake to anyone, so broadcasts use the legacy
+	// framing
+	n, sender, _, _ := newTestManager(t)
+	require.NoError((&WrappedAppSender{n, legacyProtocol}).SendAppGossip(ctx, []byte{1}))
+	require.Equal([][]byte{{legacyProtocol.ID, 1}}, sender.gossip)
+
+	// Protocols that legacy peers don't support are not broadcast
+	require.NoError((&WrappedAppSender{n, newProtocol}).SendAppGossip(ctx, []byte{2}))
+	require.Len(sender.gossip, 1)
+}
@@ -0,0 +1,311 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+	"github.com/ava-labs/hypersdk/ratelimit"
+)
+
+const (
+	// reputationPruneInterval is how often we remove peers whose score has
+	// decayed to (approximately) zero.
+	reputationPruneInterval = time.Minute
+
+	// minScore is the score below which a peer is considered to have no
+	// history.
+	minScore = 0.01
+)
+
+// Offense is misbehavior by a peer that lowers its reputation.
+type Offense uint8
+
+const (
+	// OffenseInvalidTx is sent when a peer sends transactions that can't be
+	// parsed.
+	OffenseInvalidTx Offense = iota
+	// OffenseInvalidSignature is sent when a peer sends transactions that
+	// fail signature verification.
+	OffenseInvalidSignature
+	// OffenseOversizedMessage is sent when a peer sends a message that is
+	// larger than we allow.
+	OffenseOversizedMessage
+	// OffenseWarpResponseFailure is sent when a peer responds to a warp
+	// signature request with an invalid signature.
+	OffenseWarpResponseFailure
+	// OffenseInvalidMessage is sent when a peer sends any other message that
+	// can't be parsed.
+	OffenseInvalidMessage
+)
+
+func (o Offense) String() string {
+	switch o {
+	case OffenseInvalidTx:
+		return "invalid_tx"
+	case OffenseInvalidSignature:
+		return "invalid_signature"
+	case OffenseOversizedMessage:
+		return "oversized_message"
+	case OffenseWarpResponseFailure:
+		return "warp_response_failure"
+	case OffenseInvalidMessage:
+		return "invalid_message"
+	default:
+		return "unknown"
+	}
+}
+
+// ReputationConfig describes how peers are penalized for misbehavior.
+//
+// Each offense adds a penalty to the score of a peer, which halves every
+// [HalfLife]. Messages from a peer whose score is at least
+// [ThrottleThreshold] are rate limited by [ThrottleLimit] and all messages
+// from a peer whose score reaches [BanThreshold] are dropped for
+// [BanDuration].
+type ReputationConfig struct {
+	InvalidTxPenalty           float64 `json:"invalidTxPenalty"`
+	InvalidSignaturePenalty    float64 `json:"invalidSignaturePenalty"`
+	OversizedMessagePenalty    float64 `json:"oversizedMessagePenalty"`
+	WarpResponseFailurePenalty float64 `json:"warpResponseFailurePenalty"`
+	InvalidMessagePenalty      float64 `json:"invalidMessagePenalty"`
+
+	HalfLife time.Duration `json:"halfLife"`
+
+	// If <= 0, peers are never throttled.
+	ThrottleThreshold float64          `json:"throttleThreshold"`
+	ThrottleLimit     ratelimit.Config `json:"throttleLimit"`
+
+	// If <= 0, peers are never banned.
+	BanThreshold float64       `json:"banThreshold"`
+	BanDuration  time.Duration `json:"banDuration"`
+}
+
+func DefaultReputationConfig() ReputationConfig {
+	return ReputationConfig{
+		InvalidTxPenalty:           10,
+		InvalidSignaturePenalty:    25,
+		OversizedMessagePenalty:    25,
+		WarpResponseFailurePenalty: 10,
+		InvalidMessagePenalty:      10,
+		HalfLife:                   5 * time.Minute,
+		ThrottleThreshold:          30,
+		ThrottleLimit:              ratelimit.Config{Rate: 1, Burst: 5},
+		BanThreshold:               100,
+		BanDuration:                10 * time.Minute,
+	}
+}
+
+func (c ReputationConfig) penalty(o Offense) float64 {
+	switch o {
+	case OffenseInvalidTx:
+		return c.InvalidTxPenalty
+	case OffenseInvalidSignature:
+		return c.InvalidSignaturePenalty
+	case OffenseOversizedMessage:
+		return c.OversizedMessagePenalty
+	case OffenseWarpResponseFailure:
+		return c.WarpResponseFailurePenalty
+	case OffenseInvalidMessage:
+		return c.InvalidMessagePenalty
+	default:
+		return 0
+	}
+}
+
+// ReputationMetrics is notified whenever the reputation of a peer changes.
+type ReputationMetrics interface {
+	RecordOffense(Offense)
+	RecordPeerScore(ids.NodeID, float64)
+	RemovePeerScore(ids.NodeID)
+	RecordPeerBanned()
+	RecordMessageDropped()
+}
+
+// PeerScore is the reputation of a single peer.
+type PeerScore struct {
+	NodeID      ids.NodeID `json:"nodeID"`
+	Score       float64    `json:"score"`
+	Throttled   bool       `json:"throttled"`
+	BannedUntil time.Time  `json:"bannedUntil"`
+}
+
+type peerReputation struct {
+	score       float64
+	updated     time.Time
+	bannedUntil time.Time
+}
+
+// decay updates [score] to [now].
+func (p *peerReputation) decay(halfLife time.Duration, now time.Time) {
+	if halfLife > 0 && now.After(p.updated) {
+		p.score *= math.Exp2(-float64(now.Sub(p.updated)) / float64(halfLife))
+	}
+	p.updated = now
+}
+
+// Reputation tracks misbehavior by each peer.
+type Reputation struct {
+	config  ReputationConfig
+	metrics ReputationMetrics
+	limiter *ratelimit.Limiter
+	clock   mockable.Clock
+
+	l         sync.Mutex
+	peers     map[ids.NodeID]*peerReputation
+	lastPrune time.Time
+}
+
+// NewReputation returns a [Reputation] for [config]. [metrics] may be nil.
+func NewReputation(config ReputationConfig, metrics ReputationMetrics) *Reputation {
+	r := &Reputation{
+		config:  config,
+		metrics: metrics,
+		limiter: ratelimit.New(config.ThrottleLimit, nil),
+		peers:   map[ids.NodeID]*peerReputation{},
+	}
+	r.lastPrune = r.clock.Time()
+	return r
+}
+
+// Report penalizes [nodeID] for [offense] and returns true if [nodeID] is
+// now banned.
+func (r *Reputation) Report(nodeID ids.NodeID, offense Offense) bool {
+	r.l.Lock()
+	defer r.l.Unlock()
+
+	now := r.clock.Time()
+	p, ok := r.peers[nodeID]
+	if !ok {
+		p = &peerReputation{updated: now}
+		r.peers[nodeID] = p
+	}
+	p.decay(r.config.HalfLife, now)
+	p.score += r.config.penalty(offense)
+	if r.metrics != nil {
+		r.metrics.RecordOffense(offense)
+		r.metrics.RecordPeerScore(nodeID, p.score)
+	}
+	if now.Before(p.bannedUntil) {
+		// Already banned
+		return true
+	}
+	if r.config.BanThreshold <= 0 || p.score < r.config.BanThreshold {
+		return false
+	}
+	p.bannedUntil = now.Add(r.config.BanDuration)
+	if r.metrics != nil {
+		r.metrics.RecordPeerBanned()
+	}
+	return true
+}
+
+// Allow returns false if a message from [nodeID] should be dropped, either
+// because [nodeID] is banned or because it is throttled and has sent too
+// many messages.
+func (r *Reputation) Allow(nodeID ids.NodeID) bool {
+	allowed := r.allow(nodeID)
+	if !allowed && r.metrics != nil {
+		r.metrics.RecordMessageDropped()
+	}
+	return allowed
+}
+
+func (r *Reputation) allow(nodeID ids.NodeID) bool {
+	r.l.Lock()
+	now := r.clock.Time()
+	if now.Sub(r.lastPrune) > reputationPruneInterval {
+		r.prune(now)
+	}
+	p, ok := r.peers[nodeID]
+	if !ok {
+		r.l.Unlock()
+		return true
+	}
+	if now.Before(p.bannedUntil) {
+		r.l.Unlock()
+		return false
+	}
+	p.decay(r.config.HalfLife, now)
+	throttled := r.throttled(p)
+	r.l.Unlock()
+
+	if !throttled {
+		return true
+	}
+	return r.limiter.Allow(nodeID.String())
+}
+
+func (r *Reputation) throttled(p *peerReputation) bool {
+	return r.config.ThrottleThreshold > 0 && p.score >= r.config.ThrottleThreshold
+}
+
+// prune removes peers that are no longer banned and whose score has decayed
+// to zero.
+func (r *Reputation) prune(now time.Time) {
+	for nodeID, p := range r.peers {
+		if now.Before(p.bannedUntil) {
+			continue
+		}
+		p.decay(r.config.HalfLife, now)
+		if p.score >= minScore {
+			if r.metrics != nil {
+				r.metrics.RecordPeerScore(nodeID, p.score)
+			}
+			continue
+		}
+		delete(r.peers, nodeID)
+		if r.metrics != nil {
+			r.metrics.RemovePeerScore(nodeID)
+		}
+	}
+	r.lastPrune = now
+}
+
+// Reset forgets all misbehavior by [nodeID] and returns false if [nodeID]
+// had no history.
+func (r *Reputation) Reset(nodeID ids.NodeID) bool {
+	r.l.Lock()
+	defer r.l.Unlock()
+
+	if _, ok := r.peers[nodeID]; !ok {
+		return false
+	}
+	delete(r.peers, nodeID)
+	if r.metrics != nil {
+		r.metrics.RemovePeerScore(nodeID)
+	}
+	return true
+}
+
+// Scores returns the reputation of all peers with a history of misbehavior,
+// sorted from worst to best.
+func (r *Reputation) Scores() []*PeerScore {
+	r.l.Lock()
+	defer r.l.Unlock()
+
+	now := r.clock.Time()
+	scores := make([]*PeerScore, 0, len(r.peers))
+	for nodeID, p := range r.peers {
+		p.decay(r.config.HalfLife, now)
+		score := &PeerScore{
+			NodeID:    nodeID,
+			Score:     p.score,
+			Throttled: r.throttled(p),
+		}
+		if now.Before(p.bannedUntil) {
+			score.BannedUntil = p.bannedUntil
+		}
+		scores = append(scores, score)
+	}
+	sort.Slice(scores, func(i, j int) bool {
+		return scores[i].Score > scores[j].Score
+	})
+	return scores
+}
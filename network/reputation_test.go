@@ -0,0 +1,270 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/stretchr/testify/require"
+)
+
+type testReputationMetrics struct {
+	offenses map[Offense]int
+	scores   map[ids.NodeID]float64
+	banned   int
+	dropped  int
+}
+
+func newTestReputationMetrics() *testReputationMetrics {
+	return &testReputationMetrics{
+		offenses: map[Offense]int{},
+		scores:   map[ids.NodeID]float64{},
+	}
+}
+
+func (m *testReputationMetrics) RecordOffense(o Offense) { m.offenses[o]++ }
+func (m *testReputationMetrics) RecordPeerBanned()       { m.banned++ }
+func (m *testReputationMetrics) RecordMessageDropped()   { m.dropped++ }
+
+func (m *testReputationMetrics) RecordPeerScore(nodeID ids.NodeID, score float64) {
+	m.scores[nodeID] = score
+}
+
+func (m *testReputationMetrics) RemovePeerScore(nodeID ids.NodeID) {
+	delete(m.scores, nodeID)
+}
+
+func TestReputationScoring(t *testing.T) {
+	config := DefaultReputationConfig()
+	tests := []struct {
+		name      string
+		offenses  []Offense
+		score     float64
+		throttled bool
+		banned    bool
+	}{
+		{
+			name:     "invalid tx",
+			offenses: []Offense{OffenseInvalidTx},
+			score:    config.InvalidTxPenalty,
+		},
+		{
+			name:     "invalid message",
+			offenses: []Offense{OffenseInvalidMessage},
+			score:    config.InvalidMessagePenalty,
+		},
+		{
+			name:     "warp response failure",
+			offenses: []Offense{OffenseWarpResponseFailure},
+			score:    config.WarpResponseFailurePenalty,
+		},
+		{
+			name:     "unknown offense",
+			offenses: []Offense{OffenseInvalidMessage + 1},
+			score:    0,
+		},
+		{
+			name:      "throttled",
+			offenses:  []Offense{OffenseInvalidSignature, OffenseOversizedMessage},
+			score:     config.InvalidSignaturePenalty + config.OversizedMessagePenalty,
+			throttled: true,
+		},
+		{
+			name: "banned",
+			offenses: []Offense{
+				OffenseInvalidSignature,
+				OffenseInvalidSignature,
+				OffenseInvalidSignature,
+				OffenseInvalidSignature,
+			},
+			score:     4 * config.InvalidSignaturePenalty,
+			throttled: true,
+			banned:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			metrics := newTestReputationMetrics()
+			r := NewReputation(config, metrics)
+			r.clock.Set(time.Unix(1_000, 0))
+			nodeID := ids.GenerateTestNodeID()
+			var banned bool
+			for _, offense := range tt.offenses {
+				banned = r.Report(nodeID, offense)
+			}
+			require.Equal(tt.banned, banned)
+			var recorded int
+			for _, count := range metrics.offenses {
+				recorded += count
+			}
+			require.Equal(len(tt.offenses), recorded)
+			require.InDelta(tt.score, metrics.scores[nodeID], 0.0001)
+
+			scores := r.Scores()
+			require.Len(scores, 1)
+			require.Equal(nodeID, scores[0].NodeID)
+			require.InDelta(tt.score, scores[0].Score, 0.0001)
+			require.Equal(tt.throttled, scores[0].Throttled)
+			require.Equal(tt.banned, !scores[0].BannedUntil.IsZero())
+			require.Equal(!tt.banned, r.Allow(nodeID))
+			if tt.banned {
+				require.Equal(1, metrics.banned)
+				require.Equal(1, metrics.dropped)
+			}
+		})
+	}
+}
+
+func TestReputationDecay(t *testing.T) {
+	config := DefaultReputationConfig()
+	tests := []struct {
+		name    string
+		elapsed time.Duration
+		score   float64
+	}{
+		{
+			name:    "no time elapsed",
+			elapsed: 0,
+			score:   75,
+		},
+		{
+			name:    "one half life",
+			elapsed: config.HalfLife,
+			score:   37.5,
+		},
+		{
+			name:    "two half lives",
+			elapsed: 2 * config.HalfLife,
+			score:   18.75,
+		},
+		{
+			name:    "half a half life",
+			elapsed: config.HalfLife / 2,
+			score:   75 / math.Sqrt2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			r := NewReputation(config, nil)
+			start := time.Unix(1_000, 0)
+			r.clock.Set(start)
+			nodeID := ids.GenerateTestNodeID()
+			r.Report(nodeID, OffenseInvalidSignature)
+			r.Report(nodeID, OffenseInvalidTx)
+			r.Report(nodeID, OffenseInvalidTx)
+			r.Report(nodeID, OffenseInvalidTx)
+			r.Report(nodeID, OffenseInvalidTx)
+			r.Report(nodeID, OffenseInvalidTx)
+
+			r.clock.Set(start.Add(tt.elapsed))
+			require.InDelta(tt.score, r.Scores()[0].Score, 0.0001)
+		})
+	}
+}
+
+func TestReputationBanExpiry(t *testing.T) {
+	require := require.New(t)
+
+	config := DefaultReputationConfig()
+	metrics := newTestReputationMetrics()
+	r := NewReputation(config, metrics)
+	start := time.Unix(1_000, 0)
+	r.clock.Set(start)
+	nodeID := ids.GenerateTestNodeID()
+	for i := 0; i < 3; i++ {
+		require.False(r.Report(nodeID, OffenseInvalidSignature))
+	}
+	require.True(r.Report(nodeID, OffenseInvalidSignature))
+	require.False(r.Allow(nodeID))
+
+	// Further offenses while banned don't extend the ban (even once the score
+	// has decayed below [BanThreshold])
+	r.clock.Set(start.Add(config.BanDuration / 2))
+	require.True(r.Report(nodeID, OffenseInvalidSignature))
+	require.Less(r.Scores()[0].Score, config.BanThreshold)
+	require.Equal(start.Add(config.BanDuration), r.Scores()[0].BannedUntil)
+	require.Equal(1, metrics.banned)
+
+	// Still banned until [BanDuration] elapses
+	r.clock.Set(start.Add(config.BanDuration - time.Second))
+	require.False(r.Allow(nodeID))
+
+	// Once the ban expires, the peer is only throttled
+	r.clock.Set(start.Add(config.BanDuration))
+	require.True(r.Allow(nodeID))
+	scores := r.Scores()
+	require.True(scores[0].BannedUntil.IsZero())
+	require.True(scores[0].Throttled)
+	require.Equal(2, metrics.dropped)
+
+	// Once the score decays below [ThrottleThreshold], the peer is no longer
+	// throttled
+	r.clock.Set(start.Add(config.BanDuration + config.HalfLife))
+	require.False(r.Scores()[0].Throttled)
+}
+
+func TestReputationThrottle(t *testing.T) {
+	require := require.New(t)
+
+	config := DefaultReputationConfig()
+	config.ThrottleLimit.Rate = 0.001
+	r := NewReputation(config, nil)
+	r.clock.Set(time.Unix(1_000, 0))
+	nodeID := ids.GenerateTestNodeID()
+	r.Report(nodeID, OffenseInvalidSignature)
+	r.Report(nodeID, OffenseInvalidSignature)
+
+	// Throttled peers can send up to [ThrottleLimit.Burst] messages
+	for i := 0; i < config.ThrottleLimit.Burst; i++ {
+		require.True(r.Allow(nodeID))
+	}
+	require.False(r.Allow(nodeID))
+
+	// Other peers are unaffected
+	require.True(r.Allow(ids.GenerateTestNodeID()))
+}
+
+func TestReputationDisabled(t *testing.T) {
+	require := require.New(t)
+
+	config := DefaultReputationConfig()
+	config.ThrottleThreshold = 0
+	config.BanThreshold = 0
+	r := NewReputation(config, nil)
+	nodeID := ids.GenerateTestNodeID()
+	for i := 0; i < 100; i++ {
+		require.False(r.Report(nodeID, OffenseInvalidSignature))
+		require.True(r.Allow(nodeID))
+	}
+}
+
+func TestReputationPruneAndReset(t *testing.T) {
+	require := require.New(t)
+
+	config := DefaultReputationConfig()
+	metrics := newTestReputationMetrics()
+	r := NewReputation(config, metrics)
+	start := time.Unix(1_000, 0)
+	r.clock.Set(start)
+	r.lastPrune = start
+	pruned, reset := ids.GenerateTestNodeID(), ids.GenerateTestNodeID()
+	r.Report(pruned, OffenseInvalidTx)
+	r.Report(reset, OffenseInvalidTx)
+
+	// Peers are forgotten once their score decays to zero
+	r.clock.Set(start.Add(20 * config.HalfLife))
+	require.True(r.Reset(reset))
+	require.False(r.Reset(reset))
+	require.True(r.Allow(pruned))
+	require.Empty(r.Scores())
+	require.Empty(metrics.scores)
+	require.False(r.Reset(pruned))
+}
@@ -0,0 +1,11 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package openrpc
+
+import "errors"
+
+var (
+	ErrNoMethods       = errors.New("service has no methods")
+	ErrDuplicateMethod = errors.New("duplicate method")
+)
@@ -0,0 +1,287 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package openrpc
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	schemaRefPrefix    = "#/components/schemas/"
+	paramStructureName = "by-name"
+	byteSliceEncoding  = "base64"
+	defaultResultName  = "result"
+)
+
+var (
+	errorType         = reflect.TypeOf((*error)(nil)).Elem()
+	requestType       = reflect.TypeOf((*http.Request)(nil))
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+)
+
+// Generator builds a [Document] by reflecting over the args and reply types
+// of JSON-RPC services.
+//
+// A service method is described if it would be registered by
+// "github.com/gorilla/rpc/v2": it is exported, takes an [*http.Request] and
+// pointers to its args and reply, and returns an error.
+type Generator struct {
+	info    Info
+	methods map[string]*Method
+
+	schemas map[string]*Schema
+	names   map[reflect.Type]string
+}
+
+func NewGenerator(info Info) *Generator {
+	return &Generator{
+		info:    info,
+		methods: map[string]*Method{},
+		schemas: map[string]*Schema{},
+		names:   map[reflect.Type]string{},
+	}
+}
+
+// AddService describes all methods of [service] registered as [name] and
+// served at [endpoint].
+func (g *Generator) AddService(name string, endpoint string, service interface{}) error {
+	var (
+		typ     = reflect.TypeOf(service)
+		methods = []*Method{}
+	)
+	for i := 0; i < typ.NumMethod(); i++ {
+		m := typ.Method(i)
+		args, reply, ok := signature(m)
+		if !ok {
+			continue
+		}
+		methodName := fmt.Sprintf("%s.%s", name, lowerFirst(m.Name))
+		if _, ok := g.methods[methodName]; ok {
+			return fmt.Errorf("%w: %s", ErrDuplicateMethod, methodName)
+		}
+		methods = append(methods, &Method{
+			Name:           methodName,
+			Servers:        []*Server{{Name: name, URL: endpoint}},
+			ParamStructure: paramStructureName,
+			Params:         g.params(args),
+			Result:         g.result(reply),
+		})
+	}
+	if len(methods) == 0 {
+		return fmt.Errorf("%w: %s", ErrNoMethods, name)
+	}
+	for _, m := range methods {
+		g.methods[m.Name] = m
+	}
+	return nil
+}
+
+// Document returns the description of all added services, sorted by method
+// name.
+func (g *Generator) Document() *Document {
+	doc := &Document{
+		OpenRPC: Version,
+		Info:    g.info,
+		Methods: make([]*Method, 0, len(g.methods)),
+	}
+	for _, m := range g.methods {
+		doc.Methods = append(doc.Methods, m)
+	}
+	sort.Slice(doc.Methods, func(i, j int) bool {
+		return doc.Methods[i].Name < doc.Methods[j].Name
+	})
+	if len(g.schemas) > 0 {
+		doc.Components = &Components{Schemas: g.schemas}
+	}
+	return doc
+}
+
+// signature returns the args and reply types of [m] if it can be served over
+// JSON-RPC.
+func signature(m reflect.Method) (reflect.Type, reflect.Type, bool) {
+	mtype := m.Type
+	if !m.IsExported() || mtype.NumIn() != 4 || mtype.NumOut() != 1 {
+		return nil, nil, false
+	}
+	if mtype.In(1) != requestType || mtype.Out(0) != errorType {
+		return nil, nil, false
+	}
+	args, reply := mtype.In(2), mtype.In(3)
+	if args.Kind() != reflect.Pointer || reply.Kind() != reflect.Pointer {
+		return nil, nil, false
+	}
+	return args.Elem(), reply.Elem(), true
+}
+
+// params describes each field of [args] as a named parameter.
+func (g *Generator) params(args reflect.Type) []*ContentDescriptor {
+	if args.Kind() != reflect.Struct {
+		return []*ContentDescriptor{{Name: "args", Required: true, Schema: g.schema(args)}}
+	}
+	params := []*ContentDescriptor{}
+	for _, f := range fields(args) {
+		params = append(params, &ContentDescriptor{
+			Name:     f.name,
+			Required: f.required,
+			Schema:   g.fieldSchema(f),
+		})
+	}
+	return params
+}
+
+func (g *Generator) result(reply reflect.Type) *ContentDescriptor {
+	name := defaultResultName
+	if len(reply.Name()) > 0 {
+		name = lowerFirst(reply.Name())
+	}
+	return &ContentDescriptor{Name: name, Schema: g.schema(reply)}
+}
+
+func (g *Generator) schema(t reflect.Type) *Schema {
+	if implements(t, textMarshalerType) {
+		return &Schema{Type: "string"}
+	}
+	if implements(t, jsonMarshalerType) {
+		// We can't know the format of arbitrary JSON
+		return &Schema{}
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return &Schema{Type: "integer", Format: t.Kind().String()}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number", Format: t.Kind().String()}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Pointer:
+		return g.schema(t.Elem())
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 && !implements(t.Elem(), textMarshalerType) {
+			return &Schema{Type: "string", ContentEncoding: byteSliceEncoding}
+		}
+		return &Schema{Type: "array", Items: g.schema(t.Elem())}
+	case reflect.Array:
+		l := t.Len()
+		return &Schema{Type: "array", Items: g.schema(t.Elem()), MinItems: &l, MaxItems: &l}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: g.schema(t.Elem())}
+	case reflect.Struct:
+		return g.structSchema(t)
+	default:
+		// Interfaces may hold any value
+		return &Schema{}
+	}
+}
+
+// structSchema adds the schema of [t] to the components of the [Document] (if
+// [t] is named) and returns a reference to it.
+func (g *Generator) structSchema(t reflect.Type) *Schema {
+	if len(t.Name()) == 0 {
+		return g.objectSchema(t)
+	}
+	name, ok := g.names[t]
+	if !ok {
+		name = t.Name()
+		if _, ok := g.schemas[name]; ok {
+			// Disambiguate types with the same name from different packages
+			name = fmt.Sprintf("%s.%s", path.Base(t.PkgPath()), t.Name())
+		}
+		g.names[t] = name
+		g.schemas[name] = &Schema{} // placeholder in case [t] is recursive
+		g.schemas[name] = g.objectSchema(t)
+	}
+	return &Schema{Ref: schemaRefPrefix + name}
+}
+
+func (g *Generator) objectSchema(t reflect.Type) *Schema {
+	s := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	for _, f := range fields(t) {
+		s.Properties[f.name] = g.fieldSchema(f)
+		if f.required {
+			s.Required = append(s.Required, f.name)
+		}
+	}
+	return s
+}
+
+func (g *Generator) fieldSchema(f *field) *Schema {
+	if f.quoted {
+		return &Schema{Type: "string"}
+	}
+	return g.schema(f.typ)
+}
+
+type field struct {
+	name     string
+	typ      reflect.Type
+	required bool
+	quoted   bool
+}
+
+// fields returns the fields of [t] in the order they are encoded by
+// "encoding/json". Untagged embedded structs are flattened.
+func fields(t reflect.Type) []*field {
+	fs := []*field{}
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag, hasTag := sf.Tag.Lookup("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		ft := sf.Type
+		if sf.Anonymous {
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if !hasTag && ft.Kind() == reflect.Struct {
+				fs = append(fs, fields(ft)...)
+				continue
+			}
+		}
+		if !sf.IsExported() {
+			continue
+		}
+		if len(name) == 0 {
+			name = sf.Name
+		}
+		fs = append(fs, &field{
+			name:     name,
+			typ:      sf.Type,
+			required: !hasOption(opts, "omitempty"),
+			quoted:   hasOption(opts, "string"),
+		})
+	}
+	return fs
+}
+
+func hasOption(opts string, option string) bool {
+	for _, opt := range strings.Split(opts, ",") {
+		if opt == option {
+			return true
+		}
+	}
+	return false
+}
+
+func implements(t reflect.Type, iface reflect.Type) bool {
+	return t.Implements(iface) || reflect.PointerTo(t).Implements(iface)
+}
+
+func lowerFirst(s string) string {
+	r, n := utf8.DecodeRuneInString(s)
+	return string(unicode.ToLower(r)) + s[n:]
+}
@@ -0,0 +1,97 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package openrpc
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/stretchr/testify/require"
+)
+
+type testEmbedded struct {
+	Height uint64 `json:"height"`
+}
+
+type testNode struct {
+	Value    int         `json:"value"`
+	Children []*testNode `json:"children,omitempty"`
+}
+
+type testArgs struct {
+	TxID   ids.ID `json:"txId"`
+	Amount uint64 `json:"amount,string"`
+	Memo   []byte `json:"memo,omitempty"`
+	Hidden string `json:"-"`
+
+	unexported int //nolint:unused
+}
+
+type testReply struct {
+	testEmbedded
+
+	Tags  map[string]bool `json:"tags"`
+	Root  [3]byte         `json:"root"`
+	Node  *testNode       `json:"node"`
+	Extra interface{}     `json:"extra"`
+	Plain string
+}
+
+type testService struct{}
+
+func (*testService) Get(_ *http.Request, _ *testArgs, _ *testReply) error { return nil }
+
+func (*testService) Ping(_ *http.Request, _ *struct{}, _ *struct{}) error { return nil }
+
+func (*testService) NotRPC(_ *testArgs) error { return nil }
+
+func TestGenerator(t *testing.T) {
+	require := require.New(t)
+
+	g := NewGenerator(Info{Title: "test", Version: "v0.0.1"})
+	require.NoError(g.AddService("test", "/api", &testService{}))
+	require.ErrorIs(g.AddService("test", "/api", &testService{}), ErrDuplicateMethod)
+	require.ErrorIs(g.AddService("empty", "/empty", &struct{}{}), ErrNoMethods)
+
+	doc := g.Document()
+	require.Equal(Version, doc.OpenRPC)
+	require.Equal("test", doc.Info.Title)
+	require.Len(doc.Methods, 2)
+
+	// Methods are sorted and named like gorilla expects
+	get := doc.Methods[0]
+	require.Equal("test.get", get.Name)
+	require.Equal([]*Server{{Name: "test", URL: "/api"}}, get.Servers)
+	require.Equal("test.ping", doc.Methods[1].Name)
+	require.Empty(doc.Methods[1].Params)
+	require.Equal(defaultResultName, doc.Methods[1].Result.Name)
+
+	// Params
+	require.Len(get.Params, 3)
+	require.Equal(&ContentDescriptor{Name: "txId", Required: true, Schema: &Schema{Type: "string"}}, get.Params[0])
+	require.Equal(&ContentDescriptor{Name: "amount", Required: true, Schema: &Schema{Type: "string"}}, get.Params[1])
+	require.Equal(&ContentDescriptor{
+		Name:   "memo",
+		Schema: &Schema{Type: "string", ContentEncoding: byteSliceEncoding},
+	}, get.Params[2])
+
+	// Result
+	require.Equal("testReply", get.Result.Name)
+	require.Equal(schemaRefPrefix+"testReply", get.Result.Schema.Ref)
+	reply := doc.Components.Schemas["testReply"]
+	require.ElementsMatch([]string{"height", "tags", "root", "node", "extra", "Plain"}, reply.Required)
+	require.Equal(&Schema{Type: "integer", Format: "uint64"}, reply.Properties["height"])
+	require.Equal(&Schema{Type: "object", AdditionalProperties: &Schema{Type: "boolean"}}, reply.Properties["tags"])
+	require.Equal("array", reply.Properties["root"].Type)
+	require.Equal(3, *reply.Properties["root"].MaxItems)
+	require.Equal(&Schema{}, reply.Properties["extra"])
+	require.Equal(&Schema{Type: "string"}, reply.Properties["Plain"])
+
+	// Recursive types are referenced
+	require.Equal(schemaRefPrefix+"testNode", reply.Properties["node"].Ref)
+	node := doc.Components.Schemas["testNode"]
+	require.Equal([]string{"value"}, node.Required)
+	require.Equal(schemaRefPrefix+"testNode", node.Properties["children"].Items.Ref)
+}
@@ -181,8 +181,10 @@ func (b *batch) Delete(key []byte) error {
 func (b *batch) Size() int { return b.size }
 
 // Write flushes any accumulated data to disk.
+//
+// The batch is not closed (which would return it to pebble's pool) because
+// callers may [Reset] or [Replay] it after writing.
 func (b *batch) Write() error {
-	defer b.batch.Close()
 	return updateError(b.batch.Commit(pebble.Sync))
 }
 
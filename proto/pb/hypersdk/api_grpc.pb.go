@@ -0,0 +1,431 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: hypersdk/api.proto
+
+package hypersdk
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	API_Ping_FullMethodName              = "/hypersdk.API/Ping"
+	API_Network_FullMethodName           = "/hypersdk.API/Network"
+	API_SubmitTx_FullMethodName          = "/hypersdk.API/SubmitTx"
+	API_LastAccepted_FullMethodName      = "/hypersdk.API/LastAccepted"
+	API_UnitPrices_FullMethodName        = "/hypersdk.API/UnitPrices"
+	API_GetWarpSignatures_FullMethodName = "/hypersdk.API/GetWarpSignatures"
+	API_StreamBlocks_FullMethodName      = "/hypersdk.API/StreamBlocks"
+	API_StreamTxResults_FullMethodName   = "/hypersdk.API/StreamTxResults"
+)
+
+// APIClient is the client API for API service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type APIClient interface {
+	Ping(ctx context.Context, in *PingRequest, opts ...grpc.CallOption) (*PingResponse, error)
+	Network(ctx context.Context, in *NetworkRequest, opts ...grpc.CallOption) (*NetworkResponse, error)
+	SubmitTx(ctx context.Context, in *SubmitTxRequest, opts ...grpc.CallOption) (*SubmitTxResponse, error)
+	LastAccepted(ctx context.Context, in *LastAcceptedRequest, opts ...grpc.CallOption) (*LastAcceptedResponse, error)
+	UnitPrices(ctx context.Context, in *UnitPricesRequest, opts ...grpc.CallOption) (*UnitPricesResponse, error)
+	GetWarpSignatures(ctx context.Context, in *GetWarpSignaturesRequest, opts ...grpc.CallOption) (*GetWarpSignaturesResponse, error)
+	// StreamBlocks sends every block accepted after the stream is opened.
+	StreamBlocks(ctx context.Context, in *StreamBlocksRequest, opts ...grpc.CallOption) (API_StreamBlocksClient, error)
+	// StreamTxResults submits [txs] and sends a result for each of them once it
+	// is accepted, expired, or dropped. The stream is closed after all results
+	// have been sent.
+	StreamTxResults(ctx context.Context, in *StreamTxResultsRequest, opts ...grpc.CallOption) (API_StreamTxResultsClient, error)
+}
+
+type aPIClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAPIClient(cc grpc.ClientConnInterface) APIClient {
+	return &aPIClient{cc}
+}
+
+func (c *aPIClient) Ping(ctx context.Context, in *PingRequest, opts ...grpc.CallOption) (*PingResponse, error) {
+	out := new(PingResponse)
+	err := c.cc.Invoke(ctx, API_Ping_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) Network(ctx context.Context, in *NetworkRequest, opts ...grpc.CallOption) (*NetworkResponse, error) {
+	out := new(NetworkResponse)
+	err := c.cc.Invoke(ctx, API_Network_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) SubmitTx(ctx context.Context, in *SubmitTxRequest, opts ...grpc.CallOption) (*SubmitTxResponse, error) {
+	out := new(SubmitTxResponse)
+	err := c.cc.Invoke(ctx, API_SubmitTx_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) LastAccepted(ctx context.Context, in *LastAcceptedRequest, opts ...grpc.CallOption) (*LastAcceptedResponse, error) {
+	out := new(LastAcceptedResponse)
+	err := c.cc.Invoke(ctx, API_LastAccepted_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) UnitPrices(ctx context.Context, in *UnitPricesRequest, opts ...grpc.CallOption) (*UnitPricesResponse, error) {
+	out := new(UnitPricesResponse)
+	err := c.cc.Invoke(ctx, API_UnitPrices_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) GetWarpSignatures(ctx context.Context, in *GetWarpSignaturesRequest, opts ...grpc.CallOption) (*GetWarpSignaturesResponse, error) {
+	out := new(GetWarpSignaturesResponse)
+	err := c.cc.Invoke(ctx, API_GetWarpSignatures_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) StreamBlocks(ctx context.Context, in *StreamBlocksRequest, opts ...grpc.CallOption) (API_StreamBlocksClient, error) {
+	stream, err := c.cc.NewStream(ctx, &API_ServiceDesc.Streams[0], API_StreamBlocks_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &aPIStreamBlocksClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type API_StreamBlocksClient interface {
+	Recv() (*StreamBlocksResponse, error)
+	grpc.ClientStream
+}
+
+type aPIStreamBlocksClient struct {
+	grpc.ClientStream
+}
+
+func (x *aPIStreamBlocksClient) Recv() (*StreamBlocksResponse, error) {
+	m := new(StreamBlocksResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *aPIClient) StreamTxResults(ctx context.Context, in *StreamTxResultsRequest, opts ...grpc.CallOption) (API_StreamTxResultsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &API_ServiceDesc.Streams[1], API_StreamTxResults_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &aPIStreamTxResultsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type API_StreamTxResultsClient interface {
+	Recv() (*StreamTxResultsResponse, error)
+	grpc.ClientStream
+}
+
+type aPIStreamTxResultsClient struct {
+	grpc.ClientStream
+}
+
+func (x *aPIStreamTxResultsClient) Recv() (*StreamTxResultsResponse, error) {
+	m := new(StreamTxResultsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// APIServer is the server API for API service.
+// All implementations must embed UnimplementedAPIServer
+// for forward compatibility
+type APIServer interface {
+	Ping(context.Context, *PingRequest) (*PingResponse, error)
+	Network(context.Context, *NetworkRequest) (*NetworkResponse, error)
+	SubmitTx(context.Context, *SubmitTxRequest) (*SubmitTxResponse, error)
+	LastAccepted(context.Context, *LastAcceptedRequest) (*LastAcceptedResponse, error)
+	UnitPrices(context.Context, *UnitPricesRequest) (*UnitPricesResponse, error)
+	GetWarpSignatures(context.Context, *GetWarpSignaturesRequest) (*GetWarpSignaturesResponse, error)
+	// StreamBlocks sends every block accepted after the stream is opened.
+	StreamBlocks(*StreamBlocksRequest, API_StreamBlocksServer) error
+	// StreamTxResults submits [txs] and sends a result for each of them once it
+	// is accepted, expired, or dropped. The stream is closed after all results
+	// have been sent.
+	StreamTxResults(*StreamTxResultsRequest, API_StreamTxResultsServer) error
+	mustEmbedUnimplementedAPIServer()
+}
+
+// UnimplementedAPIServer must be embedded to have forward compatible implementations.
+type UnimplementedAPIServer struct {
+}
+
+func (UnimplementedAPIServer) Ping(context.Context, *PingRequest) (*PingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Ping not implemented")
+}
+func (UnimplementedAPIServer) Network(context.Context, *NetworkRequest) (*NetworkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Network not implemented")
+}
+func (UnimplementedAPIServer) SubmitTx(context.Context, *SubmitTxRequest) (*SubmitTxResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitTx not implemented")
+}
+func (UnimplementedAPIServer) LastAccepted(context.Context, *LastAcceptedRequest) (*LastAcceptedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LastAccepted not implemented")
+}
+func (UnimplementedAPIServer) UnitPrices(context.Context, *UnitPricesRequest) (*UnitPricesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnitPrices not implemented")
+}
+func (UnimplementedAPIServer) GetWarpSignatures(context.Context, *GetWarpSignaturesRequest) (*GetWarpSignaturesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWarpSignatures not implemented")
+}
+func (UnimplementedAPIServer) StreamBlocks(*StreamBlocksRequest, API_StreamBlocksServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamBlocks not implemented")
+}
+func (UnimplementedAPIServer) StreamTxResults(*StreamTxResultsRequest, API_StreamTxResultsServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamTxResults not implemented")
+}
+func (UnimplementedAPIServer) mustEmbedUnimplementedAPIServer() {}
+
+// UnsafeAPIServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to APIServer will
+// result in compilation errors.
+type UnsafeAPIServer interface {
+	mustEmbedUnimplementedAPIServer()
+}
+
+func RegisterAPIServer(s grpc.ServiceRegistrar, srv APIServer) {
+	s.RegisterService(&API_ServiceDesc, srv)
+}
+
+func _API_Ping_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).Ping(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_Ping_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).Ping(ctx, req.(*PingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_Network_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NetworkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).Network(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_Network_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).Network(ctx, req.(*NetworkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_SubmitTx_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitTxRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).SubmitTx(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_SubmitTx_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).SubmitTx(ctx, req.(*SubmitTxRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_LastAccepted_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LastAcceptedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).LastAccepted(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_LastAccepted_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).LastAccepted(ctx, req.(*LastAcceptedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_UnitPrices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnitPricesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).UnitPrices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_UnitPrices_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).UnitPrices(ctx, req.(*UnitPricesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_GetWarpSignatures_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetWarpSignaturesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).GetWarpSignatures(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_GetWarpSignatures_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).GetWarpSignatures(ctx, req.(*GetWarpSignaturesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_StreamBlocks_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamBlocksRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(APIServer).StreamBlocks(m, &aPIStreamBlocksServer{stream})
+}
+
+type API_StreamBlocksServer interface {
+	Send(*StreamBlocksResponse) error
+	grpc.ServerStream
+}
+
+type aPIStreamBlocksServer struct {
+	grpc.ServerStream
+}
+
+func (x *aPIStreamBlocksServer) Send(m *StreamBlocksResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _API_StreamTxResults_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamTxResultsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(APIServer).StreamTxResults(m, &aPIStreamTxResultsServer{stream})
+}
+
+type API_StreamTxResultsServer interface {
+	Send(*StreamTxResultsResponse) error
+	grpc.ServerStream
+}
+
+type aPIStreamTxResultsServer struct {
+	grpc.ServerStream
+}
+
+func (x *aPIStreamTxResultsServer) Send(m *StreamTxResultsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// API_ServiceDesc is the grpc.ServiceDesc for API service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var API_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "hypersdk.API",
+	HandlerType: (*APIServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Ping",
+			Handler:    _API_Ping_Handler,
+		},
+		{
+			MethodName: "Network",
+			Handler:    _API_Network_Handler,
+		},
+		{
+			MethodName: "SubmitTx",
+			Handler:    _API_SubmitTx_Handler,
+		},
+		{
+			MethodName: "LastAccepted",
+			Handler:    _API_LastAccepted_Handler,
+		},
+		{
+			MethodName: "UnitPrices",
+			Handler:    _API_UnitPrices_Handler,
+		},
+		{
+			MethodName: "GetWarpSignatures",
+			Handler:    _API_GetWarpSignatures_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamBlocks",
+			Handler:       _API_StreamBlocks_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamTxResults",
+			Handler:       _API_StreamTxResults_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "hypersdk/api.proto",
+}
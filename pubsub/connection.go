@@ -10,6 +10,8 @@ import (
 
 	"github.com/gorilla/websocket"
 	"go.uber.org/zap"
+
+	"github.com/ava-labs/hypersdk/ratelimit"
 )
 
 // Callback type is used as a callback function for the
@@ -24,6 +26,9 @@ type Connection struct {
 	// The websocket connection.
 	conn *websocket.Conn
 
+	// Identifies the client for rate limiting
+	key string
+
 	// Buffered channel of outbound messages.
 	mb *MessageBuffer
 
@@ -62,6 +67,7 @@ func (c *Connection) Send(msg []byte) bool {
 func (c *Connection) readPump() {
 	defer func() {
 		c.s.removeConnection(c)
+		c.s.releaseClient(c.key)
 		c.deactivate()
 
 		// close is called by both the writePump and the readPump so one of them
@@ -109,6 +115,21 @@ func (c *Connection) readPump() {
 			return
 		}
 		for _, msg := range msgs {
+			if !c.s.messageLimiter.Allow(c.key) {
+				c.s.log.Debug("closing the connection",
+					zap.String("reason", "rate limited"),
+					zap.String("client", c.key),
+				)
+				if c.s.config.Metrics != nil {
+					c.s.config.Metrics.RecordRateLimitedMessage()
+				}
+				_ = c.conn.WriteControl(
+					websocket.CloseMessage,
+					websocket.FormatCloseMessage(websocket.ClosePolicyViolation, ratelimit.ErrRateLimited.Error()),
+					time.Now().Add(c.s.config.WriteWait),
+				)
+				return
+			}
 			c.s.callback(msg, c)
 		}
 	}
@@ -146,6 +167,9 @@ func (c *Connection) writePump() {
 				_ = c.conn.WriteMessage(websocket.CloseMessage, nil)
 				return
 			}
+			// Small messages (like tx results) aren't worth compressing. This
+			// is a no-op if compression wasn't negotiated.
+			c.conn.EnableWriteCompression(c.s.config.CompressionThreshold > 0 && len(message) >= c.s.config.CompressionThreshold)
 			if err := c.conn.WriteMessage(websocket.BinaryMessage, message); err != nil {
 				c.s.log.Debug("closing the connection",
 					zap.String("reason", "failed to write message"),
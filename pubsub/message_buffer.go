@@ -25,6 +25,9 @@ type MessageBuffer struct {
 	timeout      time.Duration
 	pendingTimer *timer.Timer
 	closed       bool
+
+	// onDrop is called if a batch is dropped because [Queue] is full
+	onDrop func()
 }
 
 func NewMessageBuffer(log logging.Logger, pending int, maxSize int, timeout time.Duration) *MessageBuffer {
@@ -90,6 +93,9 @@ func (m *MessageBuffer) clearPending() error {
 	case m.Queue <- bm:
 	default:
 		m.log.Debug("dropped pending message")
+		if m.onDrop != nil {
+			m.onDrop()
+		}
 	}
 
 	m.pendingSize = 0
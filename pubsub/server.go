@@ -5,6 +5,7 @@ package pubsub
 
 import (
 	"net/http"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -13,8 +14,18 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/ava-labs/avalanchego/utils/logging"
+
+	"github.com/ava-labs/hypersdk/ratelimit"
 )
 
+// Metrics is notified whenever the server rejects a connection or drops a
+// message. All methods must be safe to call concurrently.
+type Metrics interface {
+	RecordRejectedConnection()
+	RecordRateLimitedMessage()
+	RecordDroppedMessage()
+}
+
 type ServerConfig struct {
 	// Size of the ws read buffer
 	ReadBufferSize int
@@ -34,6 +45,21 @@ type ServerConfig struct {
 	PongWait time.Duration
 	// Send pings to peer with this period. Must be less than pongWait.
 	PingPeriod time.Duration
+	// Header used to identify clients. If empty (or missing from a request),
+	// clients are identified by IP.
+	ClientKeyHeader string
+	// Maximum number of open connections per client. If 0, there is no limit.
+	MaxClientConnections int
+	// Rate at which a client may send messages (across all of its
+	// connections). Connections that exceed this rate are closed.
+	ClientMessageLimit ratelimit.Config
+	// If > 0, compression (permessage-deflate) is offered to clients and
+	// message batches of at least this many bytes are compressed on
+	// connections where the client accepted it. Clients that don't support
+	// compression are unaffected.
+	CompressionThreshold int
+	// Notified of rejected connections and messages. May be nil.
+	Metrics Metrics
 }
 
 func NewDefaultServerConfig() *ServerConfig {
@@ -59,6 +85,10 @@ type Server struct {
 	callback Callback
 	upgrader *websocket.Upgrader
 	conns    *Connections
+
+	clientsL       sync.Mutex
+	clients        map[string]int // number of open connections per client
+	messageLimiter *ratelimit.Limiter
 }
 
 // New returns a new Server instance. The callback function [f] is called
@@ -76,34 +106,83 @@ func New(
 			CheckOrigin: func(*http.Request) bool {
 				return true
 			},
-			ReadBufferSize:  config.ReadBufferSize,
-			WriteBufferSize: config.WriteBufferSize,
+			ReadBufferSize:    config.ReadBufferSize,
+			WriteBufferSize:   config.WriteBufferSize,
+			EnableCompression: config.CompressionThreshold > 0,
 		},
-		conns: NewConnections(),
+		conns:          NewConnections(),
+		clients:        map[string]int{},
+		messageLimiter: ratelimit.New(config.ClientMessageLimit, nil),
 	}
 }
 
 // ServeHTTP adds a connection to the server, and starts go routines for
 // reading and writing.
 func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	key := ratelimit.ClientKey(r, s.config.ClientKeyHeader)
+	if !s.reserveClient(key) {
+		s.log.Debug("rejected pubsub connection",
+			zap.String("client", key),
+			zap.Error(ratelimit.ErrTooManyConnections),
+		)
+		if s.config.Metrics != nil {
+			s.config.Metrics.RecordRejectedConnection()
+		}
+		http.Error(w, ratelimit.ErrTooManyConnections.Error(), http.StatusTooManyRequests)
+		return
+	}
+
 	// Upgrader.upgrade() is called to upgrade the HTTP connection.
 	// No nead to set any headers so we pass nil as the last argument.
 	wsConn, err := s.upgrader.Upgrade(w, r, nil)
 	if err != nil {
+		s.releaseClient(key)
 		s.log.Warn("failed to upgrade",
 			zap.Error(err),
 		)
 		return
 	}
+	mb := NewMessageBuffer(s.log, s.config.MaxPendingMessages, s.config.MaxWriteMessageSize, s.config.MaxMessageWait)
+	mb.onDrop = s.recordDropped
 	s.addConnection(&Connection{
 		s:      s,
 		conn:   wsConn,
-		mb:     NewMessageBuffer(s.log, s.config.MaxPendingMessages, s.config.MaxWriteMessageSize, s.config.MaxMessageWait),
+		key:    key,
+		mb:     mb,
 		active: atomic.Bool{},
 	})
 	s.log.Debug("added pubsub connection", zap.Stringer("addr", wsConn.RemoteAddr()))
 }
 
+// reserveClient returns false if [key] already has [MaxClientConnections]
+// open.
+func (s *Server) reserveClient(key string) bool {
+	s.clientsL.Lock()
+	defer s.clientsL.Unlock()
+
+	if s.config.MaxClientConnections > 0 && s.clients[key] >= s.config.MaxClientConnections {
+		return false
+	}
+	s.clients[key]++
+	return true
+}
+
+func (s *Server) releaseClient(key string) {
+	s.clientsL.Lock()
+	defer s.clientsL.Unlock()
+
+	s.clients[key]--
+	if s.clients[key] <= 0 {
+		delete(s.clients, key)
+	}
+}
+
+func (s *Server) recordDropped() {
+	if s.config.Metrics != nil {
+		s.config.Metrics.RecordDroppedMessage()
+	}
+}
+
 // Publish sends msg from [s] to [toConns].
 func (s *Server) Publish(msg []byte, conns *Connections) []*Connection {
 	inactiveConnections := []*Connection{}
@@ -7,14 +7,17 @@ import (
 	"context"
 	"net"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/ratelimit"
 	"github.com/gorilla/websocket"
 	"github.com/stretchr/testify/require"
 )
@@ -270,3 +273,63 @@ func TestServerPublishSpecific(t *testing.T) {
 	// Wait for the server to finish shutting down
 	<-serverDone
 }
+
+type testMetrics struct {
+	rejectedConnections atomic.Int64
+	rateLimitedMessages atomic.Int64
+	droppedMessages     atomic.Int64
+}
+
+func (m *testMetrics) RecordRejectedConnection() { m.rejectedConnections.Add(1) }
+func (m *testMetrics) RecordRateLimitedMessage() { m.rateLimitedMessages.Add(1) }
+func (m *testMetrics) RecordDroppedMessage()     { m.droppedMessages.Add(1) }
+
+// TestServerClientLimits ensures a client cannot open more than
+// [MaxClientConnections] and is disconnected if it sends messages faster than
+// [ClientMessageLimit].
+func TestServerClientLimits(t *testing.T) {
+	require := require.New(t)
+	counter := &counter{}
+	metrics := &testMetrics{}
+	config := NewDefaultServerConfig()
+	config.MaxClientConnections = 1
+	config.ClientMessageLimit = ratelimit.Config{Rate: 0.001, Burst: 1}
+	config.Metrics = metrics
+	handler := New(logging.NoLog{}, config, counter.dummyProcessTXCallback)
+	server := httptest.NewServer(handler)
+	defer server.Close()
+	u := url.URL{Scheme: "ws", Host: server.Listener.Addr().String()}
+
+	// Only a single connection is allowed
+	webCon, resp, err := websocket.DefaultDialer.Dial(u.String(), nil)
+	require.NoError(err)
+	defer resp.Body.Close()
+	_, resp2, err := websocket.DefaultDialer.Dial(u.String(), nil)
+	require.ErrorIs(err, websocket.ErrBadHandshake)
+	defer resp2.Body.Close()
+	require.Equal(http.StatusTooManyRequests, resp2.StatusCode)
+	require.Equal(int64(1), metrics.rejectedConnections.Load())
+
+	// The second message exceeds the limit
+	id := ids.GenerateTestID()
+	batchMsg, err := CreateBatchMessage(consts.NetworkSizeLimit, [][]byte{id[:], id[:]})
+	require.NoError(err)
+	require.NoError(webCon.WriteMessage(websocket.BinaryMessage, batchMsg))
+	_, _, err = webCon.ReadMessage()
+	require.True(websocket.IsCloseError(err, websocket.ClosePolicyViolation))
+	require.Equal(int64(1), metrics.rateLimitedMessages.Load())
+	counter.l.Lock()
+	require.Equal(2, counter.val)
+	counter.l.Unlock()
+
+	// Client can reconnect once the connection is closed
+	require.Eventually(func() bool {
+		handler.clientsL.Lock()
+		defer handler.clientsL.Unlock()
+		return len(handler.clients) == 0
+	}, time.Second, 10*time.Millisecond)
+	webCon3, resp3, err := websocket.DefaultDialer.Dial(u.String(), nil)
+	require.NoError(err)
+	defer resp3.Body.Close()
+	require.NoError(webCon3.Close())
+}
@@ -0,0 +1,11 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ratelimit
+
+import "errors"
+
+var (
+	ErrRateLimited        = errors.New("rate limit exceeded")
+	ErrTooManyConnections = errors.New("too many connections")
+)
@@ -0,0 +1,33 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ratelimit
+
+import (
+	"net"
+	"net/http"
+)
+
+// ClientKey identifies the client that sent [r].
+//
+// If [keyHeader] is non-empty and present in [r], its value (e.g. an API key)
+// is used. Otherwise, the remote IP is used. Nodes that accept [keyHeader]
+// should sit behind a proxy that authenticates it, otherwise clients can
+// rotate keys to avoid limits.
+func ClientKey(r *http.Request, keyHeader string) string {
+	if len(keyHeader) > 0 {
+		if key := r.Header.Get(keyHeader); len(key) > 0 {
+			return "key:" + key
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return "ip:" + host
+}
+
+// AllowRequest returns whether the client that sent [r] may call [method].
+func (m *MethodLimiter) AllowRequest(method string, r *http.Request) bool {
+	return m.Allow(method, ClientKey(r, m.keyHeader))
+}
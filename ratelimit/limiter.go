@@ -0,0 +1,144 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ratelimit
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// pruneInterval is how often we remove full buckets from a [Limiter].
+const pruneInterval = time.Minute
+
+// Config describes a token bucket that is maintained for each client.
+type Config struct {
+	// Rate is the number of tokens added to a bucket per second. If <= 0,
+	// limiting is disabled.
+	Rate float64 `json:"rate"`
+	// Burst is the maximum number of tokens a bucket can hold.
+	Burst int `json:"burst"`
+}
+
+func (c Config) Enabled() bool {
+	return c.Rate > 0
+}
+
+// Metrics is notified whenever a request is rejected.
+type Metrics interface {
+	RecordRejected()
+}
+
+// Limiter maintains a token bucket for each client key.
+//
+// Buckets that have refilled completely are indistinguishable from new ones,
+// so they are periodically pruned to bound memory usage.
+type Limiter struct {
+	config  Config
+	metrics Metrics
+
+	l         sync.Mutex
+	buckets   map[string]*rate.Limiter
+	lastPrune time.Time
+}
+
+// New returns a [Limiter] for [config]. [metrics] may be nil.
+func New(config Config, metrics Metrics) *Limiter {
+	return &Limiter{
+		config:    config,
+		metrics:   metrics,
+		buckets:   map[string]*rate.Limiter{},
+		lastPrune: time.Now(),
+	}
+}
+
+// Allow consumes a token from the bucket of [key] and returns false if none
+// were available.
+func (l *Limiter) Allow(key string) bool {
+	if !l.config.Enabled() {
+		return true
+	}
+
+	l.l.Lock()
+	defer l.l.Unlock()
+
+	now := time.Now()
+	if now.Sub(l.lastPrune) > pruneInterval {
+		l.prune(now)
+	}
+	bucket, ok := l.buckets[key]
+	if !ok {
+		bucket = rate.NewLimiter(rate.Limit(l.config.Rate), l.config.Burst)
+		l.buckets[key] = bucket
+	}
+	if bucket.AllowN(now, 1) {
+		return true
+	}
+	if l.metrics != nil {
+		l.metrics.RecordRejected()
+	}
+	return false
+}
+
+func (l *Limiter) prune(now time.Time) {
+	for key, bucket := range l.buckets {
+		if bucket.TokensAt(now) >= float64(l.config.Burst) {
+			delete(l.buckets, key)
+		}
+	}
+	l.lastPrune = now
+}
+
+// Len returns the number of clients currently tracked.
+func (l *Limiter) Len() int {
+	l.l.Lock()
+	defer l.l.Unlock()
+
+	return len(l.buckets)
+}
+
+// MethodLimiter maintains a separate [Limiter] for each method.
+//
+// Method names are case-insensitive (to match the behavior of the JSON-RPC
+// codec).
+type MethodLimiter struct {
+	keyHeader string
+
+	defaultLimiter *Limiter
+	methods        map[string]*Limiter
+}
+
+// NewMethodLimiter returns a [MethodLimiter] that applies [methods] to
+// specific methods and [defaultConfig] to all others. Clients are identified
+// by [keyHeader], if provided, otherwise by IP (see [ClientKey]).
+func NewMethodLimiter(
+	keyHeader string,
+	defaultConfig Config,
+	methods map[string]Config,
+	metrics Metrics,
+) *MethodLimiter {
+	m := &MethodLimiter{
+		keyHeader:      keyHeader,
+		defaultLimiter: New(defaultConfig, metrics),
+		methods:        make(map[string]*Limiter, len(methods)),
+	}
+	for method, config := range methods {
+		m.methods[strings.ToLower(method)] = New(config, metrics)
+	}
+	return m
+}
+
+func (m *MethodLimiter) Allow(method string, key string) bool {
+	method = strings.ToLower(method)
+	if limiter, ok := m.methods[method]; ok {
+		return limiter.Allow(key)
+	}
+	if !m.defaultLimiter.config.Enabled() {
+		return true
+	}
+	// Each method has its own bucket
+	return m.defaultLimiter.Allow(method + "/" + key)
+}
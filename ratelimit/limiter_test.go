@@ -0,0 +1,90 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ratelimit
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testMetrics struct {
+	rejected int
+}
+
+func (t *testMetrics) RecordRejected() {
+	t.rejected++
+}
+
+func TestLimiterDisabled(t *testing.T) {
+	require := require.New(t)
+
+	l := New(Config{}, nil)
+	for i := 0; i < 100; i++ {
+		require.True(l.Allow("a"))
+	}
+	require.Zero(l.Len())
+}
+
+func TestLimiterBurst(t *testing.T) {
+	require := require.New(t)
+
+	m := &testMetrics{}
+	l := New(Config{Rate: 0.001, Burst: 2}, m)
+	require.True(l.Allow("a"))
+	require.True(l.Allow("a"))
+	require.False(l.Allow("a"))
+	require.True(l.Allow("b"))
+	require.Equal(1, m.rejected)
+	require.Equal(2, l.Len())
+}
+
+func TestLimiterPrune(t *testing.T) {
+	require := require.New(t)
+
+	l := New(Config{Rate: 1_000, Burst: 1}, nil)
+	require.True(l.Allow("a"))
+	require.Equal(1, l.Len())
+
+	// Bucket of [a] will be full after 1ms
+	l.lastPrune = time.Now().Add(-2 * pruneInterval)
+	time.Sleep(5 * time.Millisecond)
+	require.True(l.Allow("b"))
+	require.Equal(1, l.Len())
+}
+
+func TestMethodLimiter(t *testing.T) {
+	require := require.New(t)
+
+	m := NewMethodLimiter(
+		"",
+		Config{Rate: 0.001, Burst: 1},
+		map[string]Config{"hypersdk.submitTx": {Rate: 0.001, Burst: 2}},
+		nil,
+	)
+
+	// Each method has a separate bucket
+	require.True(m.Allow("hypersdk.ping", "a"))
+	require.False(m.Allow("hypersdk.ping", "a"))
+	require.True(m.Allow("hypersdk.network", "a"))
+
+	// Overrides are case-insensitive
+	require.True(m.Allow("hypersdk.submitTx", "a"))
+	require.True(m.Allow("hypersdk.SubmitTx", "a"))
+	require.False(m.Allow("hypersdk.submittx", "a"))
+}
+
+func TestClientKey(t *testing.T) {
+	require := require.New(t)
+
+	r := httptest.NewRequest("POST", "/", nil)
+	r.RemoteAddr = "1.2.3.4:5678"
+	require.Equal("ip:1.2.3.4", ClientKey(r, ""))
+	require.Equal("ip:1.2.3.4", ClientKey(r, "X-API-Key"))
+	r.Header.Set("X-API-Key", "secret")
+	require.Equal("ip:1.2.3.4", ClientKey(r, ""))
+	require.Equal("key:secret", ClientKey(r, "X-API-Key"))
+}
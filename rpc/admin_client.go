@@ -0,0 +1,133 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpc
+
+import (
+	"context"
+	"strings"
+
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/hypersdk/network"
+	"github.com/ava-labs/hypersdk/requester"
+)
+
+type AdminClient struct {
+	requester *requester.EndpointRequester
+	auth      requester.Option
+}
+
+// NewAdminClient returns a client for the admin API of the chain served at
+// [uri] that authenticates with [token].
+func NewAdminClient(uri string, token string) *AdminClient {
+	uri = strings.TrimSuffix(uri, "/")
+	uri += AdminEndpoint
+	req := requester.New(uri, AdminName)
+	return &AdminClient{
+		requester: req,
+		auth:      requester.WithHeader("Authorization", "Bearer "+token),
+	}
+}
+
+func (cli *AdminClient) FlushMempool(ctx context.Context) (int, error) {
+	resp := new(FlushMempoolReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"flushMempool",
+		nil,
+		resp,
+		cli.auth,
+	)
+	return resp.Removed, err
+}
+
+func (cli *AdminClient) SetLogLevel(ctx context.Context, level string) error {
+	return cli.requester.SendRequest(
+		ctx,
+		"setLogLevel",
+		&SetLogLevelArgs{Level: level},
+		new(struct{}),
+		cli.auth,
+	)
+}
+
+func (cli *AdminClient) ForceBuild(ctx context.Context) error {
+	return cli.requester.SendRequest(
+		ctx,
+		"forceBuild",
+		nil,
+		new(struct{}),
+		cli.auth,
+	)
+}
+
+func (cli *AdminClient) ForceGossip(ctx context.Context) error {
+	return cli.requester.SendRequest(
+		ctx,
+		"forceGossip",
+		nil,
+		new(struct{}),
+		cli.auth,
+	)
+}
+
+func (cli *AdminClient) Config(ctx context.Context) (any, error) {
+	resp := new(ConfigReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"config",
+		nil,
+		resp,
+		cli.auth,
+	)
+	return resp.Config, err
+}
+
+func (cli *AdminClient) Mempool(ctx context.Context, limit int) (*MempoolReply, error) {
+	resp := new(MempoolReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"mempool",
+		&MempoolArgs{Limit: limit},
+		resp,
+		cli.auth,
+	)
+	return resp, err
+}
+
+func (cli *AdminClient) Prune(ctx context.Context, mempool bool, blocks bool) (*PruneReply, error) {
+	resp := new(PruneReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"prune",
+		&PruneArgs{Mempool: mempool, Blocks: blocks},
+		resp,
+		cli.auth,
+	)
+	return resp, err
+}
+
+func (cli *AdminClient) Peers(ctx context.Context) ([]*network.PeerScore, error) {
+	resp := new(PeersReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"peers",
+		nil,
+		resp,
+		cli.auth,
+	)
+	return resp.Peers, err
+}
+
+func (cli *AdminClient) ResetPeer(ctx context.Context, nodeID ids.NodeID) (bool, error) {
+	resp := new(ResetPeerReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"resetPeer",
+		&ResetPeerArgs{NodeID: nodeID},
+		resp,
+		cli.auth,
+	)
+	return resp.Reset, err
+}
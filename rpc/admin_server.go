@@ -0,0 +1,264 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpc
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/hypersdk/network"
+)
+
+const (
+	defaultMempoolPeek = 100
+	maxMempoolPeek     = 10_000
+
+	redacted = "<redacted>"
+)
+
+// AdminServer exposes runtime operations to node operators. It should only
+// be served behind [NewAdminHandler], which requires requests to present the
+// admin token.
+type AdminServer struct {
+	vm     AdminVM
+	config any
+	token  string
+}
+
+// NewAdminServer returns an admin server for [vm]. [config] is returned by
+// [Config] with any value equal to [token] redacted.
+func NewAdminServer(vm AdminVM, config any, token string) *AdminServer {
+	return &AdminServer{vm, config, token}
+}
+
+// NewAdminHandler returns a JSON-RPC handler for an [AdminServer] that
+// rejects any request that does not include an "Authorization: Bearer
+// <token>" header.
+func NewAdminHandler(vm AdminVM, config any, token string) (*common.HTTPHandler, error) {
+	if len(token) < MinAdminTokenLen {
+		return nil, ErrWeakAdminToken
+	}
+	handler, err := NewJSONRPCHandler(AdminName, NewAdminServer(vm, config, token), common.NoLock)
+	if err != nil {
+		return nil, err
+	}
+	handler.Handler = &adminAuthHandler{
+		log:     vm.Logger(),
+		token:   []byte(token),
+		handler: handler.Handler,
+	}
+	return handler, nil
+}
+
+type adminAuthHandler struct {
+	log     logging.Logger
+	token   []byte
+	handler http.Handler
+}
+
+func (a *adminAuthHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || subtle.ConstantTimeCompare([]byte(token), a.token) != 1 {
+		a.log.Warn("rejected admin request", zap.String("remote", r.RemoteAddr))
+		http.Error(w, ErrUnauthorized.Error(), http.StatusUnauthorized)
+		return
+	}
+	a.handler.ServeHTTP(w, r)
+}
+
+type FlushMempoolReply struct {
+	Removed int `json:"removed"`
+}
+
+func (a *AdminServer) FlushMempool(req *http.Request, _ *struct{}, reply *FlushMempoolReply) error {
+	ctx, span := a.vm.Tracer().Start(req.Context(), "AdminServer.FlushMempool")
+	defer span.End()
+
+	reply.Removed = a.vm.FlushMempool(ctx)
+	return nil
+}
+
+type SetLogLevelArgs struct {
+	Level string `json:"level"`
+}
+
+func (a *AdminServer) SetLogLevel(_ *http.Request, args *SetLogLevelArgs, _ *struct{}) error {
+	level, err := logging.ToLevel(args.Level)
+	if err != nil {
+		return err
+	}
+	a.vm.Logger().SetLevel(level)
+	a.vm.Logger().Info("set log level", zap.Stringer("level", level))
+	return nil
+}
+
+func (a *AdminServer) ForceBuild(req *http.Request, _ *struct{}, _ *struct{}) error {
+	ctx, span := a.vm.Tracer().Start(req.Context(), "AdminServer.ForceBuild")
+	defer span.End()
+
+	return a.vm.Builder().Force(ctx)
+}
+
+func (a *AdminServer) ForceGossip(req *http.Request, _ *struct{}, _ *struct{}) error {
+	ctx, span := a.vm.Tracer().Start(req.Context(), "AdminServer.ForceGossip")
+	defer span.End()
+
+	return a.vm.Gossiper().Force(ctx)
+}
+
+type ConfigReply struct {
+	Config any `json:"config"`
+}
+
+func (a *AdminServer) Config(_ *http.Request, _ *struct{}, reply *ConfigReply) error {
+	b, err := json.Marshal(a.config)
+	if err != nil {
+		return err
+	}
+	var config any
+	if err := json.Unmarshal(b, &config); err != nil {
+		return err
+	}
+	reply.Config = redact(config, a.token)
+	return nil
+}
+
+// redact replaces all strings in [v] equal to [secret].
+func redact(v any, secret string) any {
+	switch t := v.(type) {
+	case string:
+		if t == secret {
+			return redacted
+		}
+	case map[string]any:
+		for k, e := range t {
+			t[k] = redact(e, secret)
+		}
+	case []any:
+		for i, e := range t {
+			t[i] = redact(e, secret)
+		}
+	}
+	return v
+}
+
+type MempoolArgs struct {
+	// Limit is the maximum number of transactions to return (defaults to
+	// 100).
+	Limit int `json:"limit"`
+}
+
+type MempoolTx struct {
+	TxID     ids.ID `json:"txId"`
+	Payer    []byte `json:"payer"`
+	Expiry   int64  `json:"expiry"`
+	MaxFee   uint64 `json:"maxFee"`
+	Size     int    `json:"size"`
+	ActionID uint8  `json:"actionId"`
+	AuthID   uint8  `json:"authId"`
+}
+
+type MempoolReply struct {
+	Len  int          `json:"len"`
+	Size int          `json:"size"`
+	Txs  []*MempoolTx `json:"txs"`
+}
+
+func (a *AdminServer) Mempool(req *http.Request, args *MempoolArgs, reply *MempoolReply) error {
+	ctx, span := a.vm.Tracer().Start(req.Context(), "AdminServer.Mempool")
+	defer span.End()
+
+	limit := args.Limit
+	switch {
+	case limit <= 0:
+		limit = defaultMempoolPeek
+	case limit > maxMempoolPeek:
+		limit = maxMempoolPeek
+	}
+	mempool := a.vm.Mempool()
+	reply.Len = mempool.Len(ctx)
+	reply.Size = mempool.Size(ctx)
+	txs := a.vm.PeekMempool(ctx, limit)
+	reply.Txs = make([]*MempoolTx, len(txs))
+	for i, tx := range txs {
+		reply.Txs[i] = &MempoolTx{
+			TxID:     tx.ID(),
+			Payer:    tx.Auth.Payer(),
+			Expiry:   tx.Expiry(),
+			MaxFee:   tx.MaxFee(),
+			Size:     tx.Size(),
+			ActionID: tx.Action.GetTypeID(),
+			AuthID:   tx.Auth.GetTypeID(),
+		}
+	}
+	return nil
+}
+
+type PruneArgs struct {
+	// Mempool removes expired transactions from the mempool.
+	Mempool bool `json:"mempool"`
+	// Blocks compacts blocks that have fallen out of the accepted block
+	// window on disk.
+	Blocks bool `json:"blocks"`
+}
+
+type PruneReply struct {
+	MempoolRemoved   int    `json:"mempoolRemoved"`
+	LastExpiredBlock uint64 `json:"lastExpiredBlock"`
+}
+
+func (a *AdminServer) Prune(req *http.Request, args *PruneArgs, reply *PruneReply) error {
+	ctx, span := a.vm.Tracer().Start(req.Context(), "AdminServer.Prune")
+	defer span.End()
+
+	if args.Mempool {
+		reply.MempoolRemoved = a.vm.PruneMempool(ctx)
+	}
+	if args.Blocks {
+		lastExpired, err := a.vm.CompactExpiredBlocks()
+		if err != nil {
+			return err
+		}
+		reply.LastExpiredBlock = lastExpired
+	}
+	return nil
+}
+
+type PeersReply struct {
+	Peers []*network.PeerScore `json:"peers"`
+}
+
+// Peers returns the reputation of all peers that have misbehaved, sorted from
+// worst to best.
+func (a *AdminServer) Peers(req *http.Request, _ *struct{}, reply *PeersReply) error {
+	_, span := a.vm.Tracer().Start(req.Context(), "AdminServer.Peers")
+	defer span.End()
+
+	reply.Peers = a.vm.PeerScores()
+	return nil
+}
+
+type ResetPeerArgs struct {
+	NodeID ids.NodeID `json:"nodeID"`
+}
+
+type ResetPeerReply struct {
+	Reset bool `json:"reset"`
+}
+
+// ResetPeer forgets all misbehavior by a peer, lifting any throttle or ban.
+func (a *AdminServer) ResetPeer(req *http.Request, args *ResetPeerArgs, reply *ResetPeerReply) error {
+	_, span := a.vm.Tracer().Start(req.Context(), "AdminServer.ResetPeer")
+	defer span.End()
+
+	reply.Reset = a.vm.ResetPeer(args.NodeID)
+	return nil
+}
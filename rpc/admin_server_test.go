@@ -0,0 +1,132 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/trace"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/builder"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/gossiper"
+	"github.com/ava-labs/hypersdk/network"
+	htrace "github.com/ava-labs/hypersdk/trace"
+)
+
+const testAdminToken = "0123456789abcdef0123456789abcdef"
+
+type testAdminVM struct {
+	tracer  trace.Tracer
+	flushed int
+}
+
+func (vm *testAdminVM) Tracer() trace.Tracer                               { return vm.tracer }
+func (*testAdminVM) Logger() logging.Logger                                { return logging.NoLog{} }
+func (*testAdminVM) Builder() builder.Builder                              { return nil }
+func (*testAdminVM) Gossiper() gossiper.Gossiper                           { return nil }
+func (*testAdminVM) Mempool() chain.Mempool                                { return nil }
+func (*testAdminVM) PeekMempool(context.Context, int) []*chain.Transaction { return nil }
+func (*testAdminVM) PruneMempool(context.Context) int                      { return 0 }
+func (*testAdminVM) CompactExpiredBlocks() (uint64, error)                 { return 0, nil }
+func (*testAdminVM) PeerScores() []*network.PeerScore                      { return nil }
+func (*testAdminVM) ResetPeer(ids.NodeID) bool                             { return false }
+
+func (vm *testAdminVM) FlushMempool(context.Context) int {
+	vm.flushed++
+	return 3
+}
+
+type testAdminConfig struct {
+	AdminToken string            `json:"adminToken"`
+	LogLevel   string            `json:"logLevel"`
+	Nested     map[string]string `json:"nested"`
+	Tokens     []string          `json:"tokens"`
+}
+
+func newTestAdminServer(t *testing.T, config any) (*testAdminVM, string) {
+	require := require.New(t)
+
+	tracer, err := htrace.New(&htrace.Config{Enabled: false})
+	require.NoError(err)
+	vm := &testAdminVM{tracer: tracer}
+	handler, err := NewAdminHandler(vm, config, testAdminToken)
+	require.NoError(err)
+	mux := http.NewServeMux()
+	mux.Handle(AdminEndpoint, handler.Handler)
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return vm, srv.URL
+}
+
+func TestAdminHandlerWeakToken(t *testing.T) {
+	_, err := NewAdminHandler(&testAdminVM{}, nil, "short")
+	require.ErrorIs(t, err, ErrWeakAdminToken)
+}
+
+func TestAdminHandlerAuthorization(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	vm, uri := newTestAdminServer(t, nil)
+
+	// Missing token and token without the bearer scheme
+	for _, authorization := range []string{"", testAdminToken} {
+		body := `{"jsonrpc":"2.0","method":"hypersdkadmin.flushMempool","params":{},"id":1}`
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, uri+AdminEndpoint, strings.NewReader(body))
+		require.NoError(err)
+		req.Header.Set("Content-Type", "application/json")
+		if len(authorization) > 0 {
+			req.Header.Set("Authorization", authorization)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(err)
+		require.NoError(resp.Body.Close())
+		require.Equal(http.StatusUnauthorized, resp.StatusCode)
+	}
+
+	// Wrong token
+	_, err := NewAdminClient(uri, strings.Repeat("x", MinAdminTokenLen)).FlushMempool(ctx)
+	require.Error(err)
+	_, err = NewAdminClient(uri, testAdminToken[1:]).FlushMempool(ctx)
+	require.Error(err)
+	require.Zero(vm.flushed)
+
+	// Correct token
+	removed, err := NewAdminClient(uri, testAdminToken).FlushMempool(ctx)
+	require.NoError(err)
+	require.Equal(3, removed)
+	require.Equal(1, vm.flushed)
+}
+
+func TestAdminConfigRedacted(t *testing.T) {
+	require := require.New(t)
+
+	config := &testAdminConfig{
+		AdminToken: testAdminToken,
+		LogLevel:   "INFO",
+		Nested:     map[string]string{"token": testAdminToken, "other": "value"},
+		Tokens:     []string{"public", testAdminToken},
+	}
+	_, uri := newTestAdminServer(t, config)
+	reply, err := NewAdminClient(uri, testAdminToken).Config(context.Background())
+	require.NoError(err)
+	require.Equal(map[string]any{
+		"adminToken": redacted,
+		"logLevel":   "INFO",
+		"nested":     map[string]any{"token": redacted, "other": "value"},
+		"tokens":     []any{"public", redacted},
+	}, reply)
+
+	// The original config is not modified
+	require.Equal(testAdminToken, config.AdminToken)
+	require.Equal(testAdminToken, config.Nested["token"])
+	require.Equal(testAdminToken, config.Tokens[1])
+}
@@ -9,6 +9,20 @@ const (
 	Name              = "hypersdk"
 	JSONRPCEndpoint   = "/coreapi"
 	WebSocketEndpoint = "/corews"
+	GRPCEndpoint      = "/coregrpc"
+	AdminName         = "hypersdkadmin"
+	AdminEndpoint     = "/coreadmin"
+	HealthEndpoint    = "/corehealth"
+	SchemaEndpoint    = "/coreschema"
+
+	// ReadinessPath and LivenessPath are appended to [HealthEndpoint] to
+	// only check readiness or liveness.
+	ReadinessPath = "/readiness"
+	LivenessPath  = "/liveness"
+
+	// MinAdminTokenLen is the minimum length of the token protecting the
+	// admin API.
+	MinAdminTokenLen = 32
 
 	DefaultHandshakeTimeout = 10 * time.Second
 )
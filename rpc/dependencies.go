@@ -11,7 +11,10 @@ import (
 	"github.com/ava-labs/avalanchego/trace"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/builder"
 	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/gossiper"
+	"github.com/ava-labs/hypersdk/network"
 )
 
 type VM interface {
@@ -27,6 +30,7 @@ type VM interface {
 		txs []*chain.Transaction,
 	) (errs []error)
 	LastAcceptedBlock() *chain.StatelessBlock
+	GetDiskBlock(context.Context, uint64) (*chain.StatelessBlock, error)
 	UnitPrices(context.Context) (chain.Dimensions, error)
 	GetOutgoingWarpMessage(ids.ID) (*warp.UnsignedMessage, error)
 	GetWarpSignatures(ids.ID) ([]*chain.WarpSignature, error)
@@ -34,5 +38,20 @@ type VM interface {
 		context.Context,
 	) (map[ids.NodeID]*validators.GetValidatorOutput, map[string]struct{})
 	GatherSignatures(context.Context, ids.ID, []byte)
+	AggregateWarpMessage(context.Context, ids.ID) (*warp.Message, uint64, uint64, error)
 	GetVerifySignatures() bool
 }
+
+type AdminVM interface {
+	Tracer() trace.Tracer
+	Logger() logging.Logger
+	Builder() builder.Builder
+	Gossiper() gossiper.Gossiper
+	Mempool() chain.Mempool
+	PeekMempool(context.Context, int) []*chain.Transaction
+	FlushMempool(context.Context) int
+	PruneMempool(context.Context) int
+	CompactExpiredBlocks() (uint64, error)
+	PeerScores() []*network.PeerScore
+	ResetPeer(ids.NodeID) bool
+}
@@ -6,7 +6,10 @@ package rpc
 import "errors"
 
 var (
-	ErrClosed         = errors.New("closed")
-	ErrExpired        = errors.New("expired")
-	ErrMessageMissing = errors.New("message missing")
+	ErrClosed          = errors.New("closed")
+	ErrExpired         = errors.New("expired")
+	ErrMessageMissing  = errors.New("message missing")
+	ErrInvalidGRPCPath = errors.New("invalid grpc path")
+	ErrWeakAdminToken  = errors.New("admin token is too short")
+	ErrUnauthorized    = errors.New("unauthorized")
 )
@@ -0,0 +1,77 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpc
+
+import (
+	"context"
+	"crypto/tls"
+	"net/url"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+
+	pb "github.com/ava-labs/hypersdk/proto/pb/hypersdk"
+)
+
+var _ grpc.ClientConnInterface = (*prefixConn)(nil)
+
+// prefixConn adds [prefix] to the path of all method invocations. This
+// allows a standard gRPC client to reach the handlers registered by
+// [NewGRPCHandlers] under a chain's URI.
+type prefixConn struct {
+	conn   *grpc.ClientConn
+	prefix string
+}
+
+func (p *prefixConn) Invoke(ctx context.Context, method string, args any, reply any, opts ...grpc.CallOption) error {
+	return p.conn.Invoke(ctx, p.prefix+method, args, reply, opts...)
+}
+
+func (p *prefixConn) NewStream(
+	ctx context.Context,
+	desc *grpc.StreamDesc,
+	method string,
+	opts ...grpc.CallOption,
+) (grpc.ClientStream, error) {
+	return p.conn.NewStream(ctx, desc, p.prefix+method, opts...)
+}
+
+type GRPCClient struct {
+	pb.APIClient
+
+	conn *grpc.ClientConn
+}
+
+// NewGRPCClient connects to the gRPC API of the chain served at [uri] (the
+// same URI provided to [NewJSONRPCClient]).
+//
+// If no [opts] are provided, TLS is used for "https" URIs and an insecure
+// connection is used otherwise.
+func NewGRPCClient(uri string, opts ...grpc.DialOption) (*GRPCClient, error) {
+	u, err := url.Parse(strings.TrimSuffix(uri, "/"))
+	if err != nil {
+		return nil, err
+	}
+	if len(opts) == 0 {
+		if u.Scheme == "https" {
+			opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{MinVersion: tls.VersionTLS12})))
+		} else {
+			opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		}
+	}
+	conn, err := grpc.Dial(u.Host, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &GRPCClient{
+		APIClient: pb.NewAPIClient(&prefixConn{conn, u.Path + GRPCEndpoint}),
+		conn:      conn,
+	}, nil
+}
+
+func (cli *GRPCClient) Close() error {
+	return cli.conn.Close()
+}
@@ -0,0 +1,438 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpc
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/emap"
+	"github.com/ava-labs/hypersdk/ratelimit"
+
+	pb "github.com/ava-labs/hypersdk/proto/pb/hypersdk"
+)
+
+var _ pb.APIServer = (*GRPCServer)(nil)
+
+// GRPCServer serves the same functionality as [JSONRPCServer] and
+// [WebSocketServer] over gRPC.
+//
+// Streams that fall more than [maxPendingMessages] behind are closed with
+// [codes.ResourceExhausted].
+type GRPCServer struct {
+	pb.UnimplementedAPIServer
+
+	vm                 VM
+	maxPendingMessages int
+
+	blockL         sync.Mutex
+	blockListeners map[chan *pb.StreamBlocksResponse]struct{}
+
+	txL         sync.Mutex
+	txListeners map[ids.ID]map[chan *pb.StreamTxResultsResponse]struct{}
+	expiringTxs *emap.EMap[*chain.Transaction] // ensures all tx listeners are eventually responded to
+}
+
+func NewGRPCServer(vm VM, maxPendingMessages int) *GRPCServer {
+	return &GRPCServer{
+		vm:                 vm,
+		maxPendingMessages: maxPendingMessages,
+		blockListeners:     map[chan *pb.StreamBlocksResponse]struct{}{},
+		txListeners:        map[ids.ID]map[chan *pb.StreamTxResultsResponse]struct{}{},
+		expiringTxs:        emap.NewEMap[*chain.Transaction](),
+	}
+}
+
+func (*GRPCServer) Ping(context.Context, *pb.PingRequest) (*pb.PingResponse, error) {
+	return &pb.PingResponse{Success: true}, nil
+}
+
+func (g *GRPCServer) Network(context.Context, *pb.NetworkRequest) (*pb.NetworkResponse, error) {
+	subnetID := g.vm.SubnetID()
+	chainID := g.vm.ChainID()
+	return &pb.NetworkResponse{
+		NetworkId: g.vm.NetworkID(),
+		SubnetId:  subnetID[:],
+		ChainId:   chainID[:],
+	}, nil
+}
+
+func (g *GRPCServer) parseTx(b []byte) (*chain.Transaction, error) {
+	actionRegistry, authRegistry := g.vm.Registry()
+	rtx := codec.NewReader(b, consts.NetworkSizeLimit) // will likely be much smaller than this
+	tx, err := chain.UnmarshalTx(rtx, actionRegistry, authRegistry)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "unable to unmarshal tx: %v", err)
+	}
+	if !rtx.Empty() {
+		return nil, status.Error(codes.InvalidArgument, "tx has extra bytes")
+	}
+	if err := tx.AuthAsyncVerify()(); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid signature: %v", err)
+	}
+	return tx, nil
+}
+
+func (g *GRPCServer) SubmitTx(ctx context.Context, req *pb.SubmitTxRequest) (*pb.SubmitTxResponse, error) {
+	ctx, span := g.vm.Tracer().Start(ctx, "GRPCServer.SubmitTx")
+	defer span.End()
+
+	tx, err := g.parseTx(req.Tx)
+	if err != nil {
+		return nil, err
+	}
+	if err := g.vm.Submit(ctx, false, []*chain.Transaction{tx})[0]; err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	txID := tx.ID()
+	return &pb.SubmitTxResponse{TxId: txID[:]}, nil
+}
+
+func (g *GRPCServer) LastAccepted(context.Context, *pb.LastAcceptedRequest) (*pb.LastAcceptedResponse, error) {
+	blk := g.vm.LastAcceptedBlock()
+	blkID := blk.ID()
+	return &pb.LastAcceptedResponse{
+		Height:    blk.Hght,
+		BlockId:   blkID[:],
+		Timestamp: blk.Tmstmp,
+	}, nil
+}
+
+func (g *GRPCServer) UnitPrices(ctx context.Context, _ *pb.UnitPricesRequest) (*pb.UnitPricesResponse, error) {
+	ctx, span := g.vm.Tracer().Start(ctx, "GRPCServer.UnitPrices")
+	defer span.End()
+
+	unitPrices, err := g.vm.UnitPrices(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+	return &pb.UnitPricesResponse{UnitPrices: unitPrices[:]}, nil
+}
+
+func (g *GRPCServer) GetWarpSignatures(
+	ctx context.Context,
+	req *pb.GetWarpSignaturesRequest,
+) (*pb.GetWarpSignaturesResponse, error) {
+	ctx, span := g.vm.Tracer().Start(ctx, "GRPCServer.GetWarpSignatures")
+	defer span.End()
+
+	txID, err := ids.ToID(req.TxId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	message, err := g.vm.GetOutgoingWarpMessage(txID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if message == nil {
+		return nil, status.Error(codes.NotFound, ErrMessageMissing.Error())
+	}
+	signatures, err := g.vm.GetWarpSignatures(txID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	// Ensure we only return valid signatures
+	resp := &pb.GetWarpSignaturesResponse{Message: message.Bytes()}
+	validators, publicKeys := g.vm.CurrentValidators(ctx)
+	for _, sig := range signatures {
+		if _, ok := publicKeys[string(sig.PublicKey)]; !ok {
+			continue
+		}
+		resp.Signatures = append(resp.Signatures, &pb.WarpSignature{
+			PublicKey: sig.PublicKey,
+			Signature: sig.Signature,
+		})
+	}
+	for _, vdr := range validators {
+		wv := &pb.WarpValidator{
+			NodeId: vdr.NodeID.Bytes(),
+			Weight: vdr.Weight,
+		}
+		if vdr.PublicKey != nil {
+			wv.PublicKey = bls.PublicKeyToBytes(vdr.PublicKey)
+		}
+		resp.Validators = append(resp.Validators, wv)
+	}
+
+	// Optimistically request that we gather signatures if we don't have all of them
+	if len(resp.Signatures) < len(publicKeys) {
+		g.vm.Logger().Info(
+			"fetching missing signatures",
+			zap.Stringer("txID", txID),
+			zap.Int("previously collected", len(signatures)),
+			zap.Int("valid", len(resp.Signatures)),
+			zap.Int("current public key count", len(publicKeys)),
+		)
+		g.vm.GatherSignatures(context.TODO(), txID, message.Bytes())
+	}
+	return resp, nil
+}
+
+func (g *GRPCServer) StreamBlocks(_ *pb.StreamBlocksRequest, stream pb.API_StreamBlocksServer) error {
+	listener := make(chan *pb.StreamBlocksResponse, g.maxPendingMessages)
+	g.blockL.Lock()
+	g.blockListeners[listener] = struct{}{}
+	g.blockL.Unlock()
+	defer func() {
+		g.blockL.Lock()
+		delete(g.blockListeners, listener)
+		g.blockL.Unlock()
+	}()
+
+	ctx := stream.Context()
+	for {
+		select {
+		case msg, ok := <-listener:
+			if !ok {
+				return status.Error(codes.ResourceExhausted, "too many pending messages")
+			}
+			if err := stream.Send(msg); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (g *GRPCServer) StreamTxResults(req *pb.StreamTxResultsRequest, stream pb.API_StreamTxResultsServer) error {
+	ctx, span := g.vm.Tracer().Start(stream.Context(), "GRPCServer.StreamTxResults")
+	defer span.End()
+
+	var (
+		txs     = make([]*chain.Transaction, 0, len(req.Txs))
+		pending = set.NewSet[ids.ID](len(req.Txs))
+	)
+	for _, b := range req.Txs {
+		tx, err := g.parseTx(b)
+		if err != nil {
+			return err
+		}
+		txID := tx.ID()
+		if pending.Contains(txID) {
+			return status.Errorf(codes.InvalidArgument, "duplicate tx %s", txID)
+		}
+		pending.Add(txID)
+		txs = append(txs, tx)
+	}
+
+	// [listener] can never fill up because each pending tx is sent at most
+	// once.
+	listener := make(chan *pb.StreamTxResultsResponse, len(txs))
+	g.addTxListeners(txs, listener)
+	defer g.removeTxListeners(pending, listener)
+
+	for i, err := range g.vm.Submit(ctx, false, txs) {
+		if err == nil {
+			continue
+		}
+		if err := g.RemoveTx(txs[i].ID(), err); err != nil {
+			return err
+		}
+	}
+
+	for pending.Len() > 0 {
+		select {
+		case msg := <-listener:
+			txID, err := ids.ToID(msg.TxId)
+			if err != nil {
+				return err
+			}
+			pending.Remove(txID)
+			if err := stream.Send(msg); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+func (g *GRPCServer) addTxListeners(txs []*chain.Transaction, listener chan *pb.StreamTxResultsResponse) {
+	g.txL.Lock()
+	defer g.txL.Unlock()
+
+	for _, tx := range txs {
+		txID := tx.ID()
+		if _, ok := g.txListeners[txID]; !ok {
+			g.txListeners[txID] = map[chan *pb.StreamTxResultsResponse]struct{}{}
+		}
+		g.txListeners[txID][listener] = struct{}{}
+	}
+	g.expiringTxs.Add(txs)
+}
+
+func (g *GRPCServer) removeTxListeners(txIDs set.Set[ids.ID], listener chan *pb.StreamTxResultsResponse) {
+	g.txL.Lock()
+	defer g.txL.Unlock()
+
+	for txID := range txIDs {
+		listeners, ok := g.txListeners[txID]
+		if !ok {
+			continue
+		}
+		delete(listeners, listener)
+		if len(listeners) == 0 {
+			delete(g.txListeners, txID)
+		}
+	}
+}
+
+func (g *GRPCServer) publishTx(txID ids.ID, msg *pb.StreamTxResultsResponse) {
+	for listener := range g.txListeners[txID] {
+		listener <- msg
+	}
+	delete(g.txListeners, txID)
+	// [expiringTxs] will be cleared eventually (does not support removal)
+}
+
+// If never possible for a tx to enter mempool, call this
+func (g *GRPCServer) RemoveTx(txID ids.ID, err error) error {
+	g.txL.Lock()
+	defer g.txL.Unlock()
+
+	g.publishTx(txID, &pb.StreamTxResultsResponse{TxId: txID[:], Error: err.Error()})
+	return nil
+}
+
+func (g *GRPCServer) SetMinTx(t int64) error {
+	g.txL.Lock()
+	defer g.txL.Unlock()
+
+	expired := g.expiringTxs.SetMin(t)
+	for _, txID := range expired {
+		g.publishTx(txID, &pb.StreamTxResultsResponse{TxId: txID[:], Error: ErrExpired.Error()})
+	}
+	if exp := len(expired); exp > 0 {
+		g.vm.Logger().Debug("expired grpc listeners", zap.Int("count", exp))
+	}
+	return nil
+}
+
+func (g *GRPCServer) AcceptBlock(b *chain.StatelessBlock) error {
+	results := b.Results()
+
+	g.blockL.Lock()
+	if len(g.blockListeners) > 0 {
+		msg := newStreamBlocksResponse(b, results)
+		for listener := range g.blockListeners {
+			select {
+			case listener <- msg:
+			default:
+				// Closing [listener] terminates the stream, which will then
+				// remove it from [blockListeners].
+				close(listener)
+				delete(g.blockListeners, listener)
+			}
+		}
+	}
+	g.blockL.Unlock()
+
+	g.txL.Lock()
+	defer g.txL.Unlock()
+	for i, tx := range b.Txs {
+		txID := tx.ID()
+		if _, ok := g.txListeners[txID]; !ok {
+			continue
+		}
+		g.publishTx(txID, &pb.StreamTxResultsResponse{TxId: txID[:], Result: newResult(results[i])})
+	}
+	return nil
+}
+
+func newResult(r *chain.Result) *pb.Result {
+	consumed := r.Consumed
+	res := &pb.Result{
+		Success:  r.Success,
+		Output:   r.Output,
+		Consumed: consumed[:],
+		Fee:      r.Fee,
+	}
+	if r.WarpMessage != nil {
+		res.WarpMessage = r.WarpMessage.Bytes()
+	}
+	return res
+}
+
+func newStreamBlocksResponse(b *chain.StatelessBlock, results []*chain.Result) *pb.StreamBlocksResponse {
+	var (
+		blkID      = b.ID()
+		unitPrices = b.FeeManager().UnitPrices()
+		resp       = &pb.StreamBlocksResponse{
+			BlockId:    blkID[:],
+			ParentId:   b.Prnt[:],
+			Height:     b.Hght,
+			Timestamp:  b.Tmstmp,
+			TxIds:      make([][]byte, len(b.Txs)),
+			Results:    make([]*pb.Result, len(results)),
+			UnitPrices: unitPrices[:],
+			Block:      b.Bytes(),
+		}
+	)
+	for i, tx := range b.Txs {
+		txID := tx.ID()
+		resp.TxIds[i] = txID[:]
+	}
+	for i, result := range results {
+		resp.Results[i] = newResult(result)
+	}
+	return resp
+}
+
+// NewGRPCHandlers returns a handler for each method of [server], keyed by
+// [GRPCEndpoint] joined with the method's full name.
+//
+// AvalancheGo only routes exact paths to VM handlers, so each method must be
+// registered separately. Clients must prefix method names with the chain's
+// URI and [GRPCEndpoint] (see [NewGRPCClient]). gRPC requires HTTP/2, so the
+// node's API server must be served over TLS.
+//
+// Calls that exceed the limits in [limiter] are rejected with
+// [http.StatusTooManyRequests]. If [limiter] is nil, calls are never rejected.
+func NewGRPCHandlers(server *GRPCServer, limiter *ratelimit.MethodLimiter) map[string]*common.HTTPHandler {
+	s := grpc.NewServer()
+	pb.RegisterAPIServer(s, server)
+	handler := &common.HTTPHandler{
+		LockOptions: common.NoLock,
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// gRPC looks up methods by path, so we must remove any prefix
+			// before [GRPCEndpoint] added by the router.
+			idx := strings.LastIndex(r.URL.Path, GRPCEndpoint+"/")
+			if idx < 0 {
+				http.Error(w, ErrInvalidGRPCPath.Error(), http.StatusNotFound)
+				return
+			}
+			r.URL.Path = r.URL.Path[idx+len(GRPCEndpoint):]
+			if limiter != nil && !limiter.AllowRequest(r.URL.Path, r) {
+				http.Error(w, ratelimit.ErrRateLimited.Error(), http.StatusTooManyRequests)
+				return
+			}
+			s.ServeHTTP(w, r)
+		}),
+	}
+	handlers := map[string]*common.HTTPHandler{}
+	for _, method := range pb.API_ServiceDesc.Methods {
+		handlers[GRPCEndpoint+"/"+pb.API_ServiceDesc.ServiceName+"/"+method.MethodName] = handler
+	}
+	for _, stream := range pb.API_ServiceDesc.Streams {
+		handlers[GRPCEndpoint+"/"+pb.API_ServiceDesc.ServiceName+"/"+stream.StreamName] = handler
+	}
+	return handlers
+}
@@ -0,0 +1,377 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpc
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/trace"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/state"
+	htrace "github.com/ava-labs/hypersdk/trace"
+
+	pb "github.com/ava-labs/hypersdk/proto/pb/hypersdk"
+)
+
+const testChainPath = "/ext/bc/test"
+
+var errTestSubmit = errors.New("test submit error")
+
+type testAction struct {
+	Value uint64
+}
+
+func (*testAction) GetTypeID() uint8                      { return 0 }
+func (*testAction) ValidRange(chain.Rules) (int64, int64) { return -1, -1 }
+func (*testAction) MaxComputeUnits(chain.Rules) uint64    { return 1 }
+func (*testAction) OutputsWarpMessage() bool              { return false }
+func (*testAction) StateKeys(chain.Auth, ids.ID) []string { return nil }
+func (*testAction) StateKeysMaxChunks() []uint16          { return nil }
+func (a *testAction) Marshal(p *codec.Packer)             { p.PackUint64(a.Value) }
+func (*testAction) Size() int                             { return consts.Uint64Len }
+func unmarshalTestAction(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	return &testAction{p.UnpackUint64(false)}, p.Err()
+}
+
+func (*testAction) Execute(
+	context.Context,
+	chain.Rules,
+	state.Mutable,
+	int64,
+	chain.Auth,
+	ids.ID,
+	bool,
+) (bool, uint64, []byte, *warp.UnsignedMessage, error) {
+	return true, 1, nil, nil, nil
+}
+
+// testAuth is only valid if [Valid] is true.
+type testAuth struct {
+	Valid bool
+}
+
+func (*testAuth) GetTypeID() uint8                      { return 0 }
+func (*testAuth) ValidRange(chain.Rules) (int64, int64) { return -1, -1 }
+func (*testAuth) MaxComputeUnits(chain.Rules) uint64    { return 1 }
+func (*testAuth) StateKeys() []string                   { return nil }
+func (*testAuth) Payer() []byte                         { return nil }
+func (a *testAuth) Marshal(p *codec.Packer)             { p.PackBool(a.Valid) }
+func (*testAuth) Size() int                             { return consts.BoolLen }
+
+func (a *testAuth) AsyncVerify([]byte) error {
+	if !a.Valid {
+		return errTestSubmit
+	}
+	return nil
+}
+
+func (*testAuth) Verify(context.Context, chain.Rules, state.Immutable, chain.Action) (uint64, error) {
+	return 1, nil
+}
+
+func (*testAuth) CanDeduct(context.Context, state.Immutable, uint64) error { return nil }
+func (*testAuth) Deduct(context.Context, state.Mutable, uint64) error      { return nil }
+func (*testAuth) Refund(context.Context, state.Mutable, uint64) error      { return nil }
+
+func unmarshalTestAuth(p *codec.Packer, _ *warp.Message) (chain.Auth, error) {
+	return &testAuth{p.UnpackBool()}, p.Err()
+}
+
+type testAuthFactory struct {
+	valid bool
+}
+
+func (f *testAuthFactory) Sign([]byte, chain.Action) (chain.Auth, error) {
+	return &testAuth{f.valid}, nil
+}
+
+func (*testAuthFactory) MaxUnits() (uint64, uint64, []uint16) {
+	return consts.BoolLen, 1, nil
+}
+
+type testVM struct {
+	actionRegistry chain.ActionRegistry
+	authRegistry   chain.AuthRegistry
+	tracer         trace.Tracer
+
+	chainID    ids.ID
+	submitErr  error
+	submitted  []*chain.Transaction
+	unitPrices chain.Dimensions
+}
+
+func newTestVM(t *testing.T) *testVM {
+	require := require.New(t)
+
+	actionRegistry := codec.NewTypeParser[chain.Action, *warp.Message]()
+	require.NoError(actionRegistry.Register(0, unmarshalTestAction, false))
+	authRegistry := codec.NewTypeParser[chain.Auth, *warp.Message]()
+	require.NoError(authRegistry.Register(0, unmarshalTestAuth, false))
+	tracer, err := htrace.New(&htrace.Config{Enabled: false})
+	require.NoError(err)
+	return &testVM{
+		actionRegistry: actionRegistry,
+		authRegistry:   authRegistry,
+		tracer:         tracer,
+		chainID:        ids.GenerateTestID(),
+		unitPrices:     chain.Dimensions{1, 2, 3, 4, 5},
+	}
+}
+
+func (vm *testVM) ChainID() ids.ID        { return vm.chainID }
+func (*testVM) NetworkID() uint32         { return 1337 }
+func (*testVM) SubnetID() ids.ID          { return ids.Empty }
+func (vm *testVM) Tracer() trace.Tracer   { return vm.tracer }
+func (*testVM) Logger() logging.Logger    { return logging.NoLog{} }
+func (*testVM) GetVerifySignatures() bool { return true }
+
+func (vm *testVM) Registry() (chain.ActionRegistry, chain.AuthRegistry) {
+	return vm.actionRegistry, vm.authRegistry
+}
+
+func (vm *testVM) Submit(_ context.Context, _ bool, txs []*chain.Transaction) []error {
+	errs := make([]error, len(txs))
+	for i, tx := range txs {
+		if vm.submitErr != nil {
+			errs[i] = vm.submitErr
+			continue
+		}
+		vm.submitted = append(vm.submitted, tx)
+	}
+	return errs
+}
+
+func (*testVM) LastAcceptedBlock() *chain.StatelessBlock {
+	return &chain.StatelessBlock{
+		StatefulBlock: &chain.StatefulBlock{
+			Prnt:   ids.GenerateTestID(),
+			Tmstmp: 10,
+			Hght:   5,
+		},
+	}
+}
+
+func (*testVM) GetDiskBlock(context.Context, uint64) (*chain.StatelessBlock, error) {
+	return nil, database.ErrNotFound
+}
+
+func (vm *testVM) UnitPrices(context.Context) (chain.Dimensions, error) {
+	return vm.unitPrices, nil
+}
+
+func (*testVM) GetOutgoingWarpMessage(ids.ID) (*warp.UnsignedMessage, error) {
+	return nil, nil
+}
+
+func (*testVM) GetWarpSignatures(ids.ID) ([]*chain.WarpSignature, error) {
+	return nil, nil
+}
+
+func (*testVM) CurrentValidators(
+	context.Context,
+) (map[ids.NodeID]*validators.GetValidatorOutput, map[string]struct{}) {
+	return nil, nil
+}
+
+func (*testVM) GatherSignatures(context.Context, ids.ID, []byte) {}
+
+func (*testVM) AggregateWarpMessage(context.Context, ids.ID) (*warp.Message, uint64, uint64, error) {
+	return nil, 0, 0, ErrMessageMissing
+}
+
+func (vm *testVM) newTx(t *testing.T, valid bool, value uint64) *chain.Transaction {
+	tx := chain.NewTx(
+		&chain.Base{Timestamp: 1_000, ChainID: vm.chainID, MaxFee: 10},
+		nil,
+		&testAction{value},
+	)
+	tx, err := tx.Sign(&testAuthFactory{valid}, vm.actionRegistry, vm.authRegistry)
+	require.NoError(t, err)
+	return tx
+}
+
+// newTestGRPCClient serves [server] under [testChainPath] (the same way the
+// node routes VM handlers) and returns a client connected to it.
+func newTestGRPCClient(t *testing.T, server *GRPCServer) *GRPCClient {
+	require := require.New(t)
+
+	mux := http.NewServeMux()
+	for endpoint, handler := range NewGRPCHandlers(server, nil) {
+		mux.Handle(testChainPath+endpoint, handler.Handler)
+	}
+	srv := httptest.NewUnstartedServer(mux)
+	srv.EnableHTTP2 = true
+	srv.StartTLS()
+	t.Cleanup(srv.Close)
+
+	pool := x509.NewCertPool()
+	pool.AddCert(srv.Certificate())
+	cli, err := NewGRPCClient(
+		srv.URL+testChainPath,
+		grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{
+			RootCAs:    pool,
+			MinVersion: tls.VersionTLS12,
+		})),
+	)
+	require.NoError(err)
+	t.Cleanup(func() { _ = cli.Close() })
+	return cli
+}
+
+func TestGRPCServer(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	vm := newTestVM(t)
+	cli := newTestGRPCClient(t, NewGRPCServer(vm, 8))
+
+	ping, err := cli.Ping(ctx, &pb.PingRequest{})
+	require.NoError(err)
+	require.True(ping.Success)
+
+	network, err := cli.Network(ctx, &pb.NetworkRequest{})
+	require.NoError(err)
+	require.Equal(uint32(1337), network.NetworkId)
+	require.Equal(vm.chainID[:], network.ChainId)
+	require.Equal(ids.Empty[:], network.SubnetId)
+
+	lastAccepted, err := cli.LastAccepted(ctx, &pb.LastAcceptedRequest{})
+	require.NoError(err)
+	require.Equal(uint64(5), lastAccepted.Height)
+	require.Equal(int64(10), lastAccepted.Timestamp)
+
+	unitPrices, err := cli.UnitPrices(ctx, &pb.UnitPricesRequest{})
+	require.NoError(err)
+	require.Equal(vm.unitPrices[:], unitPrices.UnitPrices)
+}
+
+func TestGRPCServerSubmitTx(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	vm := newTestVM(t)
+	cli := newTestGRPCClient(t, NewGRPCServer(vm, 8))
+
+	// Valid transaction
+	tx := vm.newTx(t, true, 1)
+	resp, err := cli.SubmitTx(ctx, &pb.SubmitTxRequest{Tx: tx.Bytes()})
+	require.NoError(err)
+	txID := tx.ID()
+	require.Equal(txID[:], resp.TxId)
+	require.Len(vm.submitted, 1)
+	require.Equal(txID, vm.submitted[0].ID())
+
+	// Malformed transaction
+	_, err = cli.SubmitTx(ctx, &pb.SubmitTxRequest{Tx: []byte{1, 2, 3}})
+	require.Equal(codes.InvalidArgument, status.Code(err))
+
+	// Extra bytes
+	_, err = cli.SubmitTx(ctx, &pb.SubmitTxRequest{Tx: append(tx.Bytes(), 0)})
+	require.Equal(codes.InvalidArgument, status.Code(err))
+
+	// Invalid signature
+	invalid := vm.newTx(t, false, 2)
+	_, err = cli.SubmitTx(ctx, &pb.SubmitTxRequest{Tx: invalid.Bytes()})
+	require.Equal(codes.InvalidArgument, status.Code(err))
+
+	// Rejected by the mempool
+	vm.submitErr = errTestSubmit
+	_, err = cli.SubmitTx(ctx, &pb.SubmitTxRequest{Tx: vm.newTx(t, true, 3).Bytes()})
+	require.Equal(codes.FailedPrecondition, status.Code(err))
+	require.Contains(status.Convert(err).Message(), errTestSubmit.Error())
+	require.Len(vm.submitted, 1)
+}
+
+func TestGRPCServerGetWarpSignaturesMissing(t *testing.T) {
+	require := require.New(t)
+	vm := newTestVM(t)
+	cli := newTestGRPCClient(t, NewGRPCServer(vm, 8))
+
+	txID := ids.GenerateTestID()
+	_, err := cli.GetWarpSignatures(context.Background(), &pb.GetWarpSignaturesRequest{TxId: txID[:]})
+	require.Equal(codes.NotFound, status.Code(err))
+
+	_, err = cli.GetWarpSignatures(context.Background(), &pb.GetWarpSignaturesRequest{TxId: []byte{1}})
+	require.Equal(codes.InvalidArgument, status.Code(err))
+}
+
+func TestGRPCServerStreamTxResults(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	vm := newTestVM(t)
+	server := NewGRPCServer(vm, 8)
+	cli := newTestGRPCClient(t, server)
+
+	// Transactions that can't enter the mempool are responded to immediately
+	vm.submitErr = errTestSubmit
+	rejected := vm.newTx(t, true, 1)
+	stream, err := cli.StreamTxResults(ctx, &pb.StreamTxResultsRequest{Txs: [][]byte{rejected.Bytes()}})
+	require.NoError(err)
+	msg, err := stream.Recv()
+	require.NoError(err)
+	rejectedID := rejected.ID()
+	require.Equal(rejectedID[:], msg.TxId)
+	require.Equal(errTestSubmit.Error(), msg.Error)
+	require.Nil(msg.Result)
+
+	// Transactions that are never accepted are responded to once they expire
+	vm.submitErr = nil
+	pending := vm.newTx(t, true, 2)
+	stream, err = cli.StreamTxResults(ctx, &pb.StreamTxResultsRequest{Txs: [][]byte{pending.Bytes()}})
+	require.NoError(err)
+	require.Eventually(func() bool {
+		server.txL.Lock()
+		defer server.txL.Unlock()
+		return len(server.txListeners) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	require.NoError(server.SetMinTx(pending.Expiry() + 1))
+	msg, err = stream.Recv()
+	require.NoError(err)
+	pendingID := pending.ID()
+	require.Equal(pendingID[:], msg.TxId)
+	require.Equal(ErrExpired.Error(), msg.Error)
+
+	// Duplicate transactions are rejected
+	stream, err = cli.StreamTxResults(ctx, &pb.StreamTxResultsRequest{Txs: [][]byte{pending.Bytes(), pending.Bytes()}})
+	require.NoError(err)
+	_, err = stream.Recv()
+	require.Equal(codes.InvalidArgument, status.Code(err))
+}
+
+func TestGRPCHandlersInvalidPath(t *testing.T) {
+	require := require.New(t)
+
+	handlers := NewGRPCHandlers(NewGRPCServer(newTestVM(t), 8), nil)
+	require.Len(handlers, len(pb.API_ServiceDesc.Methods)+len(pb.API_ServiceDesc.Streams))
+	for endpoint := range handlers {
+		require.Contains(endpoint, GRPCEndpoint+"/"+pb.API_ServiceDesc.ServiceName+"/")
+	}
+
+	var handler http.Handler
+	for _, h := range handlers {
+		handler = h.Handler
+		break
+	}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/unknown", nil))
+	require.Equal(http.StatusNotFound, rec.Code)
+}
@@ -88,6 +88,20 @@ func (cli *JSONRPCClient) Accepted(ctx context.Context) (ids.ID, uint64, int64,
 	return resp.BlockID, resp.Height, resp.Timestamp, err
 }
 
+func (cli *JSONRPCClient) GetBlock(ctx context.Context, parser chain.Parser, height uint64) (*chain.StatefulBlock, error) {
+	resp := new(GetBlockReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"getBlock",
+		&GetBlockArgs{Height: height},
+		resp,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return chain.UnmarshalBlock(resp.Block, parser)
+}
+
 func (cli *JSONRPCClient) UnitPrices(ctx context.Context, useCache bool) (chain.Dimensions, error) {
 	if useCache && time.Since(cli.lastUnitPrices) < unitPricesCacheRefresh {
 		return cli.unitPrices, nil
@@ -156,6 +170,29 @@ func (cli *JSONRPCClient) GetWarpSignatures(
 	return resp.Message, m, resp.Signatures, nil
 }
 
+// GetAggregateWarpMessage returns the warp message produced by [txID] with
+// the signatures the node has aggregated, the total weight of the subnet, and
+// the weight that signed the message.
+func (cli *JSONRPCClient) GetAggregateWarpMessage(
+	ctx context.Context,
+	txID ids.ID,
+) (*warp.Message, uint64, uint64, error) {
+	resp := new(GetAggregateWarpMessageReply)
+	if err := cli.requester.SendRequest(
+		ctx,
+		"getAggregateWarpMessage",
+		&GetWarpSignaturesArgs{TxID: txID},
+		resp,
+	); err != nil {
+		return nil, 0, 0, err
+	}
+	message, err := warp.ParseMessage(resp.Message)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	return message, resp.Weight, resp.SignatureWeight, nil
+}
+
 type Modifier interface {
 	Base(*chain.Base)
 }
@@ -96,6 +96,28 @@ func (j *JSONRPCServer) LastAccepted(_ *http.Request, _ *struct{}, reply *LastAc
 	return nil
 }
 
+type GetBlockArgs struct {
+	Height uint64 `json:"height"`
+}
+
+type GetBlockReply struct {
+	Block []byte `json:"block"`
+}
+
+// GetBlock returns the accepted block at [args.Height] if it has not yet been
+// pruned. Results are not persisted, so they are not included.
+func (j *JSONRPCServer) GetBlock(req *http.Request, args *GetBlockArgs, reply *GetBlockReply) error {
+	ctx, span := j.vm.Tracer().Start(req.Context(), "JSONRPCServer.GetBlock")
+	defer span.End()
+
+	blk, err := j.vm.GetDiskBlock(ctx, args.Height)
+	if err != nil {
+		return err
+	}
+	reply.Block = blk.Bytes()
+	return nil
+}
+
 type UnitPricesReply struct {
 	UnitPrices chain.Dimensions `json:"unitPrices"`
 }
@@ -194,3 +216,34 @@ func (j *JSONRPCServer) GetWarpSignatures(
 	reply.Signatures = validSignatures
 	return nil
 }
+
+type GetAggregateWarpMessageReply struct {
+	Message         []byte `json:"message"`
+	Weight          uint64 `json:"weight"`
+	SignatureWeight uint64 `json:"signatureWeight"`
+}
+
+// GetAggregateWarpMessage returns the warp message produced by a transaction
+// signed by all validators that we have collected signatures from. If the
+// signature weight is too low, the node will continue gathering signatures in
+// the background.
+func (j *JSONRPCServer) GetAggregateWarpMessage(
+	req *http.Request,
+	args *GetWarpSignaturesArgs,
+	reply *GetAggregateWarpMessageReply,
+) error {
+	ctx, span := j.vm.Tracer().Start(req.Context(), "JSONRPCServer.GetAggregateWarpMessage")
+	defer span.End()
+
+	message, weight, signatureWeight, err := j.vm.AggregateWarpMessage(ctx, args.TxID)
+	if err != nil {
+		return err
+	}
+	if message == nil {
+		return ErrMessageMissing
+	}
+	reply.Message = message.Bytes()
+	reply.Weight = weight
+	reply.SignatureWeight = signatureWeight
+	return nil
+}
@@ -0,0 +1,73 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpc
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/gorilla/rpc/v2"
+
+	"github.com/ava-labs/hypersdk/openrpc"
+)
+
+// jsonRPCServer is a JSON-RPC server that remembers the service registered
+// with it so that it can be described by [NewSchemaHandler].
+type jsonRPCServer struct {
+	*rpc.Server
+
+	name    string
+	service interface{}
+}
+
+// NewSchema describes all JSON-RPC methods served by [handlers] that were
+// created with [NewJSONRPCHandler] or [NewRateLimitedJSONRPCHandler].
+// Handlers that wrap the JSON-RPC server (like the admin API) are not
+// described.
+func NewSchema(info openrpc.Info, handlers map[string]*common.HTTPHandler) (*openrpc.Document, error) {
+	endpoints := make([]string, 0, len(handlers))
+	for endpoint := range handlers {
+		endpoints = append(endpoints, endpoint)
+	}
+	sort.Strings(endpoints)
+
+	g := openrpc.NewGenerator(info)
+	for _, endpoint := range endpoints {
+		server, ok := handlers[endpoint].Handler.(*jsonRPCServer)
+		if !ok {
+			continue
+		}
+		if err := g.AddService(server.name, endpoint, server.service); err != nil {
+			return nil, err
+		}
+	}
+	return g.Document(), nil
+}
+
+// NewSchemaHandler serves the [openrpc.Document] returned by [NewSchema].
+func NewSchemaHandler(info openrpc.Info, handlers map[string]*common.HTTPHandler) (*common.HTTPHandler, error) {
+	doc, err := NewSchema(info, handlers)
+	if err != nil {
+		return nil, err
+	}
+	b, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	return &common.HTTPHandler{LockOptions: common.NoLock, Handler: schemaHandler(b)}, nil
+}
+
+type schemaHandler []byte
+
+func (s schemaHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(s)
+}
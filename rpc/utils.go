@@ -9,20 +9,45 @@ import (
 	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/utils/json"
 	"github.com/gorilla/rpc/v2"
+
+	"github.com/ava-labs/hypersdk/ratelimit"
 )
 
 func NewJSONRPCHandler(
 	name string,
 	service interface{},
 	lockOption common.LockOption,
+) (*common.HTTPHandler, error) {
+	return NewRateLimitedJSONRPCHandler(name, service, lockOption, nil)
+}
+
+// NewRateLimitedJSONRPCHandler returns a JSON-RPC handler that rejects calls
+// with [ratelimit.ErrRateLimited] once a client exceeds the limit of a method
+// in [limiter]. If [limiter] is nil, calls are never rejected.
+func NewRateLimitedJSONRPCHandler(
+	name string,
+	service interface{},
+	lockOption common.LockOption,
+	limiter *ratelimit.MethodLimiter,
 ) (*common.HTTPHandler, error) {
 	server := rpc.NewServer()
+	if limiter != nil {
+		server.RegisterValidateRequestFunc(func(i *rpc.RequestInfo, _ interface{}) error {
+			if !limiter.AllowRequest(i.Method, i.Request) {
+				return ratelimit.ErrRateLimited
+			}
+			return nil
+		})
+	}
 	server.RegisterCodec(json.NewCodec(), "application/json")
 	server.RegisterCodec(json.NewCodec(), "application/json;charset=UTF-8")
 	if err := server.RegisterService(service, name); err != nil {
 		return nil, err
 	}
-	return &common.HTTPHandler{LockOptions: lockOption, Handler: server}, nil
+	return &common.HTTPHandler{
+		LockOptions: lockOption,
+		Handler:     &jsonRPCServer{Server: server, name: name, service: service},
+	}, nil
 }
 
 func NewWebSocketHandler(server http.Handler) *common.HTTPHandler {
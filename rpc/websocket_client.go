@@ -49,6 +49,8 @@ func NewWebSocketClient(uri string, handshakeTimeout time.Duration, pending int,
 	dialer := &websocket.Dialer{
 		Proxy:            http.ProxyFromEnvironment,
 		HandshakeTimeout: handshakeTimeout,
+		// Servers only compress messages if they enable compression
+		EnableCompression: true,
 	}
 	conn, resp, err := dialer.Dial(uri, nil)
 	if err != nil {
@@ -29,15 +29,13 @@ type WebSocketServer struct {
 	expiringTxs *emap.EMap[*chain.Transaction] // ensures all tx listeners are eventually responded to
 }
 
-func NewWebSocketServer(vm VM, maxPendingMessages int) (*WebSocketServer, *pubsub.Server) {
+func NewWebSocketServer(vm VM, cfg *pubsub.ServerConfig) (*WebSocketServer, *pubsub.Server) {
 	w := &WebSocketServer{
 		logger:         vm.Logger(),
 		blockListeners: pubsub.NewConnections(),
 		txListeners:    map[ids.ID]*pubsub.Connections{},
 		expiringTxs:    emap.NewEMap[*chain.Transaction](),
 	}
-	cfg := pubsub.NewDefaultServerConfig()
-	cfg.MaxPendingMessages = maxPendingMessages
 	w.s = pubsub.New(w.logger, cfg, w.MessageCallback(vm))
 	return w, w.s
 }
@@ -0,0 +1,47 @@
+#!/usr/bin/env bash
+# Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+# See the file LICENSE for licensing terms.
+
+set -euo pipefail
+
+if ! [[ "$0" =~ scripts/protobuf_codegen.sh ]]; then
+  echo "must be run from repository root"
+  exit 255
+fi
+
+## install "buf"
+# ref. https://docs.buf.build/installation
+BUF_VERSION='1.26.1'
+if [[ $(buf --version | cut -f2 -d' ') != "${BUF_VERSION}" ]]; then
+  echo "could not find buf ${BUF_VERSION}, is it installed + in PATH?"
+  exit 255
+fi
+
+## install "protoc-gen-go"
+# ref. https://github.com/protocolbuffers/protobuf-go/releases
+PROTOC_GEN_GO_VERSION='v1.30.0'
+go install -v google.golang.org/protobuf/cmd/protoc-gen-go@${PROTOC_GEN_GO_VERSION}
+if [[ $(protoc-gen-go --version | cut -f2 -d' ') != "${PROTOC_GEN_GO_VERSION}" ]]; then
+  echo "could not find protoc-gen-go ${PROTOC_GEN_GO_VERSION}, is it installed + in PATH?"
+  exit 255
+fi
+
+## install "protoc-gen-go-grpc"
+# ref. https://pkg.go.dev/google.golang.org/grpc/cmd/protoc-gen-go-grpc
+PROTOC_GEN_GO_GRPC_VERSION='1.3.0'
+go install -v google.golang.org/grpc/cmd/protoc-gen-go-grpc@v${PROTOC_GEN_GO_GRPC_VERSION}
+if [[ $(protoc-gen-go-grpc --version | cut -f2 -d' ') != "${PROTOC_GEN_GO_GRPC_VERSION}" ]]; then
+  echo "could not find protoc-gen-go-grpc ${PROTOC_GEN_GO_GRPC_VERSION}, is it installed + in PATH?"
+  exit 255
+fi
+
+cd "$PWD"/proto
+
+echo "Running protobuf fmt..."
+buf format -w
+
+echo "Running protobuf lint check..."
+buf lint
+
+echo "Re-generating protobuf..."
+buf generate
@@ -67,6 +67,9 @@ type server struct {
 }
 
 // New returns an instance of a Server.
+//
+// If [allowedOrigins] is empty, CORS is not enabled (so browsers will not
+// allow other origins to call the Server).
 func New(
 	baseURL string,
 	log logging.Logger,
@@ -79,10 +82,14 @@ func New(
 ) (Server, error) {
 	router := newRouter()
 	allowedHostsHandler := filterInvalidHosts(router, allowedHosts)
-	corsHandler := cors.New(cors.Options{
-		AllowedOrigins:   allowedOrigins,
-		AllowCredentials: true,
-	}).Handler(allowedHostsHandler)
+	corsHandler := allowedHostsHandler
+	if len(allowedOrigins) > 0 {
+		// [cors] allows all origins if none are provided
+		corsHandler = cors.New(cors.Options{
+			AllowedOrigins:   allowedOrigins,
+			AllowCredentials: true,
+		}).Handler(allowedHostsHandler)
+	}
 	gzipHandler := gziphandler.GzipHandler(corsHandler)
 	var handler http.Handler = http.HandlerFunc(
 		func(w http.ResponseWriter, r *http.Request) {
@@ -0,0 +1,16 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package signer
+
+import "errors"
+
+var (
+	ErrInvalidTransaction = errors.New("invalid transaction")
+	ErrPolicyViolation    = errors.New("transaction not allowed by policy")
+	ErrInvalidAuth        = errors.New("invalid auth")
+	ErrInvalidAddress     = errors.New("invalid address")
+	ErrInvalidURI         = errors.New("invalid signer uri")
+	ErrMissingToken       = errors.New("missing signer token")
+	ErrInvalidToken       = errors.New("invalid signer token")
+)
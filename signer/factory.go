@@ -0,0 +1,85 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package signer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/crypto/ed25519"
+)
+
+var _ chain.AuthFactory = (*Factory)(nil)
+
+// Factory is a [chain.AuthFactory] that delegates signing to a remote
+// [Signer].
+type Factory struct {
+	cli          *JSONRPCClient
+	authRegistry *codec.TypeParser[chain.Auth, *warp.Message, bool]
+
+	address        ed25519.PublicKey
+	bandwidth      uint64
+	compute        uint64
+	stateKeysCount []uint16
+}
+
+// NewFactory returns a [Factory] that signs with the [Signer] behind [cli]
+// and parses the [chain.Auth] it returns with [authRegistry].
+func NewFactory(
+	ctx context.Context,
+	cli *JSONRPCClient,
+	authRegistry *codec.TypeParser[chain.Auth, *warp.Message, bool],
+) (*Factory, error) {
+	address, bandwidth, compute, stateKeysCount, err := cli.Info(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &Factory{
+		cli:            cli,
+		authRegistry:   authRegistry,
+		address:        address,
+		bandwidth:      bandwidth,
+		compute:        compute,
+		stateKeysCount: stateKeysCount,
+	}, nil
+}
+
+// Address returns the address the [Signer] authorizes transactions on behalf
+// of.
+func (f *Factory) Address() ed25519.PublicKey {
+	return f.address
+}
+
+func (f *Factory) Sign(msg []byte, _ chain.Action) (chain.Auth, error) {
+	authBytes, err := f.cli.Sign(context.Background(), msg)
+	if err != nil {
+		return nil, err
+	}
+	p := codec.NewReader(authBytes, consts.NetworkSizeLimit)
+	authType := p.UnpackByte()
+	unmarshalAuth, _, ok := f.authRegistry.LookupIndex(authType)
+	if !ok {
+		return nil, fmt.Errorf("%w: %d is unknown auth type", ErrInvalidAuth, authType)
+	}
+	auth, err := unmarshalAuth(p, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidAuth, err) //nolint:errorlint
+	}
+	if !p.Empty() {
+		return nil, fmt.Errorf("%w: trailing bytes", ErrInvalidAuth)
+	}
+	if err := p.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidAuth, err) //nolint:errorlint
+	}
+	return auth, nil
+}
+
+func (f *Factory) MaxUnits() (uint64, uint64, []uint16) {
+	return f.bandwidth, f.compute, f.stateKeysCount
+}
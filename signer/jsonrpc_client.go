@@ -0,0 +1,113 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package signer
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ava-labs/hypersdk/crypto/ed25519"
+	"github.com/ava-labs/hypersdk/requester"
+)
+
+const (
+	JSONRPCEndpoint = "/signer"
+
+	// UnixScheme is the scheme of the URI of a [Signer] listening on a unix
+	// socket (like unix:///var/run/signer.sock).
+	UnixScheme = "unix"
+
+	// RequestTimeout is generous to leave time for signers that require
+	// confirmation out-of-band.
+	RequestTimeout = 60 * time.Second
+)
+
+type JSONRPCClient struct {
+	cli   *http.Client
+	uri   string
+	token string
+}
+
+// NewJSONRPCClient creates a new client object for the [Signer] at [uri]
+// (either unix://[path] or http(s)://[host]), which requires [token] (see
+// [RequireToken]).
+func NewJSONRPCClient(uri string, token string) (*JSONRPCClient, error) {
+	if len(token) == 0 {
+		return nil, ErrMissingToken
+	}
+	u, err := url.Parse(uri)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidURI, err) //nolint:errorlint
+	}
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	switch u.Scheme {
+	case UnixScheme:
+		if len(u.Path) == 0 {
+			return nil, fmt.Errorf("%w: missing socket path", ErrInvalidURI)
+		}
+		path := u.Path
+		t.DialContext = func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, UnixScheme, path)
+		}
+		// The host is ignored when dialing the socket (but must be allowed by
+		// the server)
+		uri = "http://localhost" + JSONRPCEndpoint
+	case "http", "https":
+		uri = strings.TrimSuffix(uri, "/") + JSONRPCEndpoint
+	default:
+		return nil, fmt.Errorf("%w: unsupported scheme %q", ErrInvalidURI, u.Scheme)
+	}
+	return &JSONRPCClient{
+		cli: &http.Client{
+			Timeout:   RequestTimeout,
+			Transport: t,
+		},
+		uri:   uri,
+		token: token,
+	}, nil
+}
+
+func (cli *JSONRPCClient) sendRequest(ctx context.Context, method string, params interface{}, reply interface{}) error {
+	uri, err := url.Parse(cli.uri)
+	if err != nil {
+		return err
+	}
+	return requester.SendJSONRequest(
+		ctx,
+		cli.cli,
+		uri,
+		fmt.Sprintf("%s.%s", Name, method),
+		params,
+		reply,
+		requester.WithHeader("Authorization", bearerPrefix+cli.token),
+	)
+}
+
+func (cli *JSONRPCClient) Info(ctx context.Context) (ed25519.PublicKey, uint64, uint64, []uint16, error) {
+	resp := new(InfoReply)
+	if err := cli.sendRequest(ctx, "info", nil, resp); err != nil {
+		return ed25519.EmptyPublicKey, 0, 0, nil, err
+	}
+	if len(resp.Address) != ed25519.PublicKeyLen {
+		return ed25519.EmptyPublicKey, 0, 0, nil, ErrInvalidAddress
+	}
+	return ed25519.PublicKey(resp.Address), resp.MaxBandwidth, resp.MaxCompute, resp.MaxStateKeyCount, nil
+}
+
+func (cli *JSONRPCClient) Sign(ctx context.Context, digest []byte) ([]byte, error) {
+	resp := new(SignReply)
+	err := cli.sendRequest(
+		ctx,
+		"sign",
+		&SignArgs{Digest: digest},
+		resp,
+	)
+	return resp.Auth, err
+}
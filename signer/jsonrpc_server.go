@@ -0,0 +1,49 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package signer
+
+import (
+	"net/http"
+)
+
+const Name = "signer"
+
+type JSONRPCServer struct {
+	s *Signer
+}
+
+func NewJSONRPCServer(s *Signer) *JSONRPCServer {
+	return &JSONRPCServer{s}
+}
+
+type InfoReply struct {
+	Address          []byte   `json:"address"`
+	MaxBandwidth     uint64   `json:"maxBandwidth"`
+	MaxCompute       uint64   `json:"maxCompute"`
+	MaxStateKeyCount []uint16 `json:"maxStateKeyCount"`
+}
+
+func (j *JSONRPCServer) Info(_ *http.Request, _ *struct{}, reply *InfoReply) error {
+	addr := j.s.Address()
+	reply.Address = addr[:]
+	reply.MaxBandwidth, reply.MaxCompute, reply.MaxStateKeyCount = j.s.MaxUnits()
+	return nil
+}
+
+type SignArgs struct {
+	Digest []byte `json:"digest"`
+}
+
+type SignReply struct {
+	Auth []byte `json:"auth"`
+}
+
+func (j *JSONRPCServer) Sign(_ *http.Request, args *SignArgs, reply *SignReply) error {
+	auth, err := j.s.Sign(args.Digest)
+	if err != nil {
+		return err
+	}
+	reply.Auth = auth
+	return nil
+}
@@ -0,0 +1,98 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package signer signs transactions with a key held by a separate process
+// (like a custody service or an HSM) instead of in the memory of the process
+// that builds them.
+//
+// The process holding the key runs a [Signer] behind a [JSONRPCServer] (on a
+// unix socket or over HTTP), which checks each transaction against a [Policy]
+// before signing it. Clients sign with a [Factory], which implements
+// [chain.AuthFactory] by forwarding the digest of each transaction to the
+// [Signer].
+package signer
+
+import (
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/crypto/ed25519"
+)
+
+// Policy decides which transactions a [Signer] signs.
+type Policy interface {
+	// Allow returns an error if [tx] should not be signed.
+	Allow(tx *chain.Transaction) error
+}
+
+// Signer signs the transactions allowed by its [Policy] with [factory].
+type Signer struct {
+	log            logging.Logger
+	actionRegistry chain.ActionRegistry
+	factory        chain.AuthFactory
+	address        ed25519.PublicKey
+	policy         Policy
+}
+
+// New returns a [Signer] that signs the transactions allowed by [policy] with
+// [factory] (which authorizes transactions on behalf of [address]).
+func New(
+	log logging.Logger,
+	actionRegistry chain.ActionRegistry,
+	factory chain.AuthFactory,
+	address ed25519.PublicKey,
+	policy Policy,
+) *Signer {
+	return &Signer{
+		log:            log,
+		actionRegistry: actionRegistry,
+		factory:        factory,
+		address:        address,
+		policy:         policy,
+	}
+}
+
+// Address returns the address transactions are signed on behalf of.
+func (s *Signer) Address() ed25519.PublicKey {
+	return s.address
+}
+
+// MaxUnits returns the maximum units of the [chain.Auth] produced by [Sign].
+func (s *Signer) MaxUnits() (uint64, uint64, []uint16) {
+	return s.factory.MaxUnits()
+}
+
+// Sign signs [digest] (as produced by [chain.Transaction.Digest]) if the
+// transaction it encodes is allowed by the [Policy] and returns the marshaled
+// [chain.Auth] (prefixed by its type ID).
+func (s *Signer) Sign(digest []byte) ([]byte, error) {
+	tx, err := chain.UnmarshalUnsignedTx(codec.NewReader(digest, consts.NetworkSizeLimit), s.actionRegistry)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidTransaction, err) //nolint:errorlint
+	}
+	if err := s.policy.Allow(tx); err != nil {
+		s.log.Warn("rejected transaction",
+			zap.Uint8("action", tx.Action.GetTypeID()),
+			zap.Uint64("maxFee", tx.MaxFee()),
+			zap.Error(err),
+		)
+		return nil, fmt.Errorf("%w: %v", ErrPolicyViolation, err) //nolint:errorlint
+	}
+	auth, err := s.factory.Sign(digest, tx.Action)
+	if err != nil {
+		return nil, err
+	}
+	s.log.Info("signed transaction",
+		zap.Uint8("action", tx.Action.GetTypeID()),
+		zap.Uint64("maxFee", tx.MaxFee()),
+	)
+	w := codec.NewWriter(consts.ByteLen+auth.Size(), consts.NetworkSizeLimit)
+	w.PackByte(auth.GetTypeID())
+	auth.Marshal(w)
+	return w.Bytes(), w.Err()
+}
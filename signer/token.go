@@ -0,0 +1,51 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package signer
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"net/http"
+)
+
+const (
+	// TokenEnv is the environment variable clients read the token of the
+	// [Signer] from.
+	TokenEnv = "HYPERSDK_SIGNER_TOKEN"
+
+	tokenLen     = 32
+	bearerPrefix = "Bearer "
+)
+
+// GenerateToken returns a new random token that clients must present to use a
+// [Signer] (see [RequireToken]).
+func GenerateToken() (string, error) {
+	b := make([]byte, tokenLen)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// RequireToken wraps [handler] so that it only serves requests that present
+// [token] as a bearer token.
+//
+// Any local process can connect to a [Signer] listening on TCP (and any
+// process of its owner can connect to a unix socket), so the token is what
+// limits who can request signatures.
+func RequireToken(handler http.Handler, token string) (http.Handler, error) {
+	if len(token) == 0 {
+		return nil, ErrMissingToken
+	}
+	expected := []byte(bearerPrefix + token)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), expected) != 1 {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			http.Error(w, ErrInvalidToken.Error(), http.StatusUnauthorized)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	}), nil
+}
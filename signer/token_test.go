@@ -0,0 +1,51 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package signer
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequireToken(t *testing.T) {
+	token, err := GenerateToken()
+	require.NoError(t, err)
+	other, err := GenerateToken()
+	require.NoError(t, err)
+	require.NotEqual(t, token, other)
+
+	_, err = RequireToken(http.NotFoundHandler(), "")
+	require.ErrorIs(t, err, ErrMissingToken)
+	_, err = NewJSONRPCClient("unix:///tmp/signer.sock", "")
+	require.ErrorIs(t, err, ErrMissingToken)
+
+	handler, err := RequireToken(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}), token)
+	require.NoError(t, err)
+	tests := []struct {
+		name       string
+		header     string
+		wantStatus int
+	}{
+		{name: "valid token", header: "Bearer " + token, wantStatus: http.StatusOK},
+		{name: "missing token", wantStatus: http.StatusUnauthorized},
+		{name: "wrong token", header: "Bearer " + other, wantStatus: http.StatusUnauthorized},
+		{name: "missing scheme", header: token, wantStatus: http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/signer", nil)
+			if len(tt.header) > 0 {
+				r.Header.Set("Authorization", tt.header)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+			require.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simulator
+
+import "time"
+
+type Config struct {
+	NetworkID uint32
+	Nodes     int
+	Weight    uint64 // stake of each node
+
+	GenesisBytes []byte
+	UpgradeBytes []byte
+	ConfigBytes  []byte
+
+	// Seed determines the IDs of all nodes and the messages that are delayed
+	// or dropped. Running the same sequence of sends with the same [Seed] will
+	// always result in the same drops and delays.
+	Seed int64
+
+	// Link is used for all links that have not been overridden with
+	// [Network.SetLink].
+	Link LinkConfig
+
+	// RequestTimeout is how long a node waits for a response before
+	// AppRequestFailed is invoked.
+	RequestTimeout time.Duration
+
+	// Start is the simulated time when the network is created. The deadlines
+	// of requests are in simulated time, so VMs that compare them against
+	// their own clock (like the hypersdk state sync server) should be
+	// simulated from close to the current time.
+	Start time.Time
+}
+
+func DefaultConfig() *Config {
+	return &Config{
+		NetworkID:      1,
+		Nodes:          5,
+		Weight:         100,
+		Link:           LinkConfig{Latency: 5 * time.Millisecond},
+		RequestTimeout: 2 * time.Second,
+	}
+}
+
+// LinkConfig determines how messages sent from one node to another are
+// delivered.
+type LinkConfig struct {
+	Latency time.Duration `json:"latency"`
+	// Each message is delayed by an additional random duration in
+	// [0, Jitter], so messages on the same link may be reordered.
+	Jitter time.Duration `json:"jitter"`
+	// DropRate is the probability in [0, 1] that a message is lost.
+	DropRate float64 `json:"dropRate"`
+}
+
+func (c *Config) Verify() error {
+	if c.Nodes <= 0 {
+		return ErrNoNodes
+	}
+	if c.Weight == 0 {
+		return ErrZeroWeight
+	}
+	return c.Link.Verify()
+}
+
+func (l *LinkConfig) Verify() error {
+	if l.Latency < 0 || l.Jitter < 0 || l.DropRate < 0 || l.DropRate > 1 {
+		return ErrInvalidLink
+	}
+	return nil
+}
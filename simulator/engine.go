@@ -0,0 +1,215 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simulator
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+	"go.uber.org/zap"
+)
+
+// listen handles messages sent by [node] to its consensus engine.
+func (n *Network) listen(node *Node) {
+	defer n.engines.Done()
+
+	for {
+		select {
+		case msg := <-node.toEngine:
+			switch msg {
+			case common.PendingTxs:
+				select {
+				case n.pending <- node:
+				default:
+				}
+			case common.StateSyncDone:
+				node.syncedOnce.Do(func() {
+					close(node.synced)
+				})
+			}
+		case <-n.stopEngine:
+			return
+		}
+	}
+}
+
+// Run builds a block on any node that notifies its engine of pending
+// transactions until [ctx] is cancelled.
+func (n *Network) Run(ctx context.Context) error {
+	for {
+		select {
+		case node := <-n.pending:
+			if _, err := n.BuildBlock(ctx, node); err != nil {
+				n.log.Debug("unable to build block",
+					zap.Stringer("nodeID", node.ID),
+					zap.Error(err),
+				)
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// BuildBlock builds a block on [proposer] and accepts it on all started nodes
+// that [proposer] can reach. Nodes that have not accepted the parent of the
+// block (because they were previously partitioned) are skipped and must catch
+// up with [Bootstrap].
+func (n *Network) BuildBlock(ctx context.Context, proposer *Node) (snowman.Block, error) {
+	n.engineLock.Lock()
+	defer n.engineLock.Unlock()
+
+	blk, err := proposer.VM.BuildBlock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	blks := []snowman.Block{blk}
+	if err := blk.Verify(ctx); err != nil {
+		return nil, err
+	}
+	if err := proposer.VM.SetPreference(ctx, blk.ID()); err != nil {
+		return nil, err
+	}
+	for _, node := range n.nodes {
+		if node == proposer || !node.started || !n.Connected(proposer.ID, node.ID) {
+			continue
+		}
+		lastAccepted, err := node.VM.LastAccepted(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if lastAccepted != blk.Parent() {
+			n.log.Debug("skipping node that is behind",
+				zap.Stringer("nodeID", node.ID),
+				zap.Stringer("lastAccepted", lastAccepted),
+				zap.Stringer("parent", blk.Parent()),
+			)
+			continue
+		}
+		nblk, err := node.VM.ParseBlock(ctx, blk.Bytes())
+		if err != nil {
+			return nil, err
+		}
+		if err := nblk.Verify(ctx); err != nil {
+			return nil, err
+		}
+		if err := node.VM.SetPreference(ctx, nblk.ID()); err != nil {
+			return nil, err
+		}
+		blks = append(blks, nblk)
+	}
+	for _, b := range blks {
+		if err := b.Accept(ctx); err != nil {
+			return nil, err
+		}
+	}
+	return blk, nil
+}
+
+// Bootstrap accepts all blocks that [from] has accepted but [node] has not.
+func (n *Network) Bootstrap(ctx context.Context, node *Node, from *Node) error {
+	n.engineLock.Lock()
+	defer n.engineLock.Unlock()
+
+	return n.bootstrap(ctx, node, from)
+}
+
+// Assumes [n.engineLock] is held.
+func (n *Network) bootstrap(ctx context.Context, node *Node, from *Node) error {
+	height, err := lastAcceptedHeight(ctx, node.VM)
+	if err != nil {
+		return err
+	}
+	target, err := lastAcceptedHeight(ctx, from.VM)
+	if err != nil {
+		return err
+	}
+	for h := height + 1; h <= target; h++ {
+		blkID, err := from.VM.GetBlockIDAtHeight(ctx, h)
+		if err != nil {
+			return err
+		}
+		blk, err := from.VM.GetBlock(ctx, blkID)
+		if err != nil {
+			return err
+		}
+		nblk, err := node.VM.ParseBlock(ctx, blk.Bytes())
+		if err != nil {
+			return err
+		}
+		if err := nblk.Verify(ctx); err != nil {
+			return err
+		}
+		if err := node.VM.SetPreference(ctx, nblk.ID()); err != nil {
+			return err
+		}
+		if err := nblk.Accept(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func lastAcceptedHeight(ctx context.Context, vm VM) (uint64, error) {
+	blkID, err := vm.LastAccepted(ctx)
+	if err != nil {
+		return 0, err
+	}
+	blk, err := vm.GetBlock(ctx, blkID)
+	if err != nil {
+		return 0, err
+	}
+	return blk.Height(), nil
+}
+
+// StateSync syncs [node] to the last accepted state summary of [from],
+// bootstraps any blocks accepted by [from] since, and transitions [node] to
+// normal operation.
+//
+// If the returned mode is [block.StateSyncDynamic], the VM may still be
+// syncing in the background (use [WaitSynced] to wait for it to finish).
+func (n *Network) StateSync(ctx context.Context, node *Node, from *Node) (block.StateSyncMode, error) {
+	n.engineLock.Lock()
+	defer n.engineLock.Unlock()
+
+	if err := node.VM.SetState(ctx, snow.StateSyncing); err != nil {
+		return block.StateSyncSkipped, err
+	}
+	summary, err := from.VM.GetLastStateSummary(ctx)
+	if err != nil {
+		return block.StateSyncSkipped, err
+	}
+	parsed, err := node.VM.ParseStateSummary(ctx, summary.Bytes())
+	if err != nil {
+		return block.StateSyncSkipped, err
+	}
+	mode, err := parsed.Accept(ctx)
+	if err != nil {
+		return block.StateSyncSkipped, err
+	}
+	if err := node.VM.SetState(ctx, snow.Bootstrapping); err != nil {
+		return mode, err
+	}
+	if err := n.bootstrap(ctx, node, from); err != nil {
+		return mode, err
+	}
+	if err := node.VM.SetState(ctx, snow.NormalOp); err != nil {
+		return mode, err
+	}
+	node.started = true
+	return mode, nil
+}
+
+// WaitSynced blocks until [node] notifies its engine that state sync is done.
+func (*Network) WaitSynced(ctx context.Context, node *Node) error {
+	select {
+	case <-node.synced:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
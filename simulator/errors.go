@@ -0,0 +1,13 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simulator
+
+import "errors"
+
+var (
+	ErrNoNodes     = errors.New("no nodes")
+	ErrZeroWeight  = errors.New("validator weight must be non-zero")
+	ErrInvalidLink = errors.New("invalid link config")
+	ErrUnknownNode = errors.New("unknown node")
+)
@@ -0,0 +1,136 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simulator
+
+import (
+	"container/heap"
+	"context"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"go.uber.org/zap"
+)
+
+type op uint8
+
+const (
+	gossipOp op = iota
+	requestOp
+	responseOp
+	timeoutOp
+)
+
+type message struct {
+	op        op
+	from      ids.NodeID
+	to        ids.NodeID
+	requestID uint32
+	deadline  time.Time
+	bytes     []byte
+
+	deliverAt time.Time
+	seq       uint64
+}
+
+// messageHeap orders messages by delivery time and then by the order in which
+// they were sent.
+type messageHeap []*message
+
+func (h messageHeap) Len() int { return len(h) }
+
+func (h messageHeap) Less(i, j int) bool {
+	if !h[i].deliverAt.Equal(h[j].deliverAt) {
+		return h[i].deliverAt.Before(h[j].deliverAt)
+	}
+	return h[i].seq < h[j].seq
+}
+
+func (h messageHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *messageHeap) Push(x any) { *h = append(*h, x.(*message)) }
+
+func (h *messageHeap) Pop() any {
+	old := *h
+	n := len(old)
+	m := old[n-1]
+	old[n-1] = nil
+	*h = old[:n-1]
+	return m
+}
+
+// Advance moves the simulated clock forward by [d], delivering all messages
+// scheduled to arrive in the meantime (including any sent by the nodes that
+// receive them) in the order they arrive. It returns the number of messages
+// delivered.
+func (n *Network) Advance(ctx context.Context, d time.Duration) int {
+	n.l.Lock()
+	end := n.clock.Time().Add(d)
+	n.l.Unlock()
+
+	var delivered int
+	for {
+		n.l.Lock()
+		if len(n.queue) == 0 || n.queue[0].deliverAt.After(end) {
+			n.clock.Set(end)
+			n.l.Unlock()
+			return delivered
+		}
+		m := heap.Pop(&n.queue).(*message)
+		if m.deliverAt.After(n.clock.Time()) {
+			n.clock.Set(m.deliverAt)
+		}
+		n.l.Unlock()
+
+		if n.deliver(ctx, m) {
+			delivered++
+		}
+	}
+}
+
+// Pending returns the number of messages that have not been delivered yet.
+func (n *Network) Pending() int {
+	n.l.Lock()
+	defer n.l.Unlock()
+
+	return len(n.queue)
+}
+
+// deliver returns true if [m] was delivered to the VM of [m.to].
+func (n *Network) deliver(ctx context.Context, m *message) bool {
+	node := n.nodeMap[m.to]
+
+	// Messages in flight when nodes are partitioned are lost.
+	if m.op != timeoutOp && !n.Connected(m.from, node.ID) {
+		return false
+	}
+
+	var err error
+	switch m.op {
+	case gossipOp:
+		err = node.VM.AppGossip(ctx, m.from, m.bytes)
+	case requestOp:
+		err = node.VM.AppRequest(ctx, m.from, m.requestID, m.deadline, m.bytes)
+	case responseOp:
+		if !n.completeRequest(node.ID, m.from, m.requestID) {
+			// Request already timed out
+			return false
+		}
+		err = node.VM.AppResponse(ctx, m.from, m.requestID, m.bytes)
+	case timeoutOp:
+		if !n.completeRequest(node.ID, m.from, m.requestID) {
+			// Response already delivered
+			return false
+		}
+		err = node.VM.AppRequestFailed(ctx, m.from, m.requestID)
+	}
+	if err != nil {
+		n.log.Warn("unable to deliver message",
+			zap.Stringer("from", m.from),
+			zap.Stringer("to", node.ID),
+			zap.Uint8("op", uint8(m.op)),
+			zap.Error(err),
+		)
+	}
+	return true
+}
@@ -0,0 +1,75 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simulator
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/utils/set"
+)
+
+var _ common.AppSender = (*appSender)(nil)
+
+// appSender routes all messages sent by [nodeID] through the simulated
+// network.
+type appSender struct {
+	n      *Network
+	nodeID ids.NodeID
+}
+
+func (s *appSender) SendAppGossip(_ context.Context, msg []byte) error {
+	for _, node := range s.n.nodes {
+		if node.ID == s.nodeID {
+			continue
+		}
+		s.n.send(s.nodeID, node.ID, &message{op: gossipOp, bytes: msg})
+	}
+	return nil
+}
+
+func (s *appSender) SendAppGossipSpecific(_ context.Context, nodeIDs set.Set[ids.NodeID], msg []byte) error {
+	for nodeID := range nodeIDs {
+		s.n.send(s.nodeID, nodeID, &message{op: gossipOp, bytes: msg})
+	}
+	return nil
+}
+
+func (s *appSender) SendAppRequest(_ context.Context, nodeIDs set.Set[ids.NodeID], requestID uint32, msg []byte) error {
+	for nodeID := range nodeIDs {
+		timeout := s.n.cfg.RequestTimeout
+		s.n.startRequest(s.nodeID, nodeID, requestID)
+		if !s.n.Connected(s.nodeID, nodeID) {
+			// Requests to unreachable nodes fail immediately
+			timeout = 0
+		}
+		s.n.schedule(s.nodeID, timeout, &message{
+			op:        timeoutOp,
+			from:      nodeID,
+			requestID: requestID,
+		})
+		s.n.send(s.nodeID, nodeID, &message{
+			op:        requestOp,
+			requestID: requestID,
+			deadline:  s.n.Now().Add(timeout),
+			bytes:     msg,
+		})
+	}
+	return nil
+}
+
+func (s *appSender) SendAppResponse(_ context.Context, nodeID ids.NodeID, requestID uint32, msg []byte) error {
+	s.n.send(s.nodeID, nodeID, &message{op: responseOp, requestID: requestID, bytes: msg})
+	return nil
+}
+
+// Cross-chain messages are not simulated and are dropped.
+func (*appSender) SendCrossChainAppRequest(context.Context, ids.ID, uint32, []byte) error {
+	return nil
+}
+
+func (*appSender) SendCrossChainAppResponse(context.Context, ids.ID, uint32, []byte) error {
+	return nil
+}
@@ -0,0 +1,444 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package simulator runs multiple VMs in a single process and connects them
+// with a simulated network, so that gossip, warp signature collection, and
+// state sync can be tested on one machine.
+package simulator
+
+import (
+	"container/heap"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math/rand"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/api/metrics"
+	"github.com/ava-labs/avalanchego/database/manager"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+	"github.com/ava-labs/avalanchego/version"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+)
+
+// VM is run by each node in the simulation.
+type VM interface {
+	block.ChainVM
+	block.StateSyncableVM
+}
+
+// readyVM is implemented by VMs that can skip bootstrapping (like the
+// hypersdk VM in integration tests). [Ready] is closed once the VM is ready.
+type readyVM interface {
+	ForceReady()
+	Ready() <-chan struct{}
+}
+
+type Node struct {
+	ID        ids.NodeID
+	VM        VM
+	PublicKey *bls.PublicKey
+
+	dir        string
+	started    bool // protected by [Network.engineLock]
+	toEngine   chan common.Message
+	synced     chan struct{}
+	syncedOnce sync.Once
+}
+
+type link struct {
+	from ids.NodeID
+	to   ids.NodeID
+}
+
+type request struct {
+	requester ids.NodeID
+	responder ids.NodeID
+	requestID uint32
+}
+
+type Network struct {
+	cfg      *Config
+	log      logging.Logger
+	subnetID ids.ID
+	chainID  ids.ID
+
+	nodes   []*Node
+	nodeMap map[ids.NodeID]*Node
+
+	l        sync.Mutex
+	clock    mockable.Clock // simulated time (only moved by [Advance])
+	rng      *rand.Rand
+	seq      uint64
+	queue    messageHeap
+	links    map[link]LinkConfig
+	groups   map[ids.NodeID]int
+	requests set.Set[request]
+
+	engineLock sync.Mutex
+	pending    chan *Node
+
+	stopEngine chan struct{}
+	engines    sync.WaitGroup
+}
+
+// New initializes [cfg.Nodes] VMs created by [newVM] and connects them to
+// each other. Nodes must be started with [Start] or [StateSync] before they
+// will build or verify blocks.
+//
+// Messages sent between nodes are only delivered when the simulated clock is
+// moved forward with [Advance].
+func New(
+	ctx context.Context,
+	logFactory logging.Factory,
+	cfg *Config,
+	newVM func() VM,
+) (*Network, error) {
+	if err := cfg.Verify(); err != nil {
+		return nil, err
+	}
+	log, err := logFactory.Make("simulator")
+	if err != nil {
+		return nil, err
+	}
+	n := &Network{
+		cfg:        cfg,
+		log:        log,
+		subnetID:   seededID(cfg.Seed, "subnet"),
+		chainID:    seededID(cfg.Seed, "chain"),
+		nodes:      make([]*Node, cfg.Nodes),
+		nodeMap:    make(map[ids.NodeID]*Node, cfg.Nodes),
+		rng:        rand.New(rand.NewSource(cfg.Seed)), //nolint:gosec
+		links:      map[link]LinkConfig{},
+		groups:     make(map[ids.NodeID]int, cfg.Nodes),
+		requests:   set.Set[request]{},
+		pending:    make(chan *Node, cfg.Nodes),
+		stopEngine: make(chan struct{}),
+	}
+	n.clock.Set(cfg.Start)
+
+	// Generate all validators before initializing any VMs, so the validator
+	// set is complete when the VMs first read it.
+	var (
+		vdrs    = make(map[ids.NodeID]*validators.GetValidatorOutput, cfg.Nodes)
+		signers = make([]warp.Signer, cfg.Nodes)
+	)
+	for i := range n.nodes {
+		sk, err := seededSecretKey(cfg.Seed, i)
+		if err != nil {
+			return nil, err
+		}
+		node := &Node{
+			ID:        seededNodeID(cfg.Seed, i),
+			PublicKey: bls.PublicFromSecretKey(sk),
+			toEngine:  make(chan common.Message, 1),
+			synced:    make(chan struct{}),
+		}
+		n.nodes[i] = node
+		n.nodeMap[node.ID] = node
+		vdrs[node.ID] = &validators.GetValidatorOutput{
+			NodeID:    node.ID,
+			PublicKey: node.PublicKey,
+			Weight:    cfg.Weight,
+		}
+		signers[i] = warp.NewSigner(sk, cfg.NetworkID, n.chainID)
+	}
+	vdrState := &validatorState{subnetID: n.subnetID, validators: vdrs}
+
+	for i, node := range n.nodes {
+		l, err := logFactory.Make(node.ID.String())
+		if err != nil {
+			return nil, err
+		}
+		dir, err := os.MkdirTemp("", fmt.Sprintf("%s-chainData", node.ID))
+		if err != nil {
+			return nil, err
+		}
+		node.dir = dir
+		snowCtx := &snow.Context{
+			NetworkID:      cfg.NetworkID,
+			SubnetID:       n.subnetID,
+			ChainID:        n.chainID,
+			NodeID:         node.ID,
+			Log:            l,
+			ChainDataDir:   dir,
+			Metrics:        metrics.NewOptionalGatherer(),
+			PublicKey:      node.PublicKey,
+			WarpSigner:     signers[i],
+			ValidatorState: vdrState,
+		}
+		node.VM = newVM()
+		if err := node.VM.Initialize(
+			ctx,
+			snowCtx,
+			manager.NewMemDB(version.CurrentDatabase),
+			cfg.GenesisBytes,
+			cfg.UpgradeBytes,
+			cfg.ConfigBytes,
+			node.toEngine,
+			nil,
+			&appSender{n: n, nodeID: node.ID},
+		); err != nil {
+			return nil, err
+		}
+
+		n.engines.Add(1)
+		go n.listen(node)
+	}
+
+	// Connect all nodes to each other
+	for _, node := range n.nodes {
+		for _, peer := range n.nodes {
+			if node == peer {
+				continue
+			}
+			if err := node.VM.Connected(ctx, peer.ID, version.CurrentApp); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return n, nil
+}
+
+func seededID(seed int64, name string) ids.ID {
+	b := binary.BigEndian.AppendUint64(nil, uint64(seed))
+	return ids.ID(hashing.ComputeHash256Array(append(b, name...)))
+}
+
+func seededNodeID(seed int64, i int) ids.NodeID {
+	b := binary.BigEndian.AppendUint64(nil, uint64(seed))
+	b = binary.BigEndian.AppendUint64(b, uint64(i))
+	return ids.NodeID(hashing.ComputeHash160Array(b))
+}
+
+// seededSecretKey deterministically derives the BLS key of the [i]th node.
+func seededSecretKey(seed int64, i int) (*bls.SecretKey, error) {
+	b := binary.BigEndian.AppendUint64(nil, uint64(seed))
+	b = binary.BigEndian.AppendUint64(b, uint64(i))
+	skBytes := hashing.ComputeHash256(append(b, "bls"...))
+	// Ensure the key is less than the order of the curve
+	skBytes[0] &= 0x3f
+	return bls.SecretKeyFromBytes(skBytes)
+}
+
+func (n *Network) Nodes() []*Node {
+	return n.nodes
+}
+
+func (n *Network) Node(nodeID ids.NodeID) (*Node, bool) {
+	node, ok := n.nodeMap[nodeID]
+	return node, ok
+}
+
+func (n *Network) ChainID() ids.ID {
+	return n.chainID
+}
+
+func (n *Network) SubnetID() ids.ID {
+	return n.subnetID
+}
+
+// Now returns the current simulated time.
+func (n *Network) Now() time.Time {
+	n.l.Lock()
+	defer n.l.Unlock()
+
+	return n.clock.Time()
+}
+
+// SetLink overrides how messages sent from [from] to [to] are delivered.
+func (n *Network) SetLink(from ids.NodeID, to ids.NodeID, cfg LinkConfig) error {
+	if err := cfg.Verify(); err != nil {
+		return err
+	}
+	n.l.Lock()
+	defer n.l.Unlock()
+
+	n.links[link{from, to}] = cfg
+	return nil
+}
+
+// Connected returns true if messages can be sent between [a] and [b].
+func (n *Network) Connected(a ids.NodeID, b ids.NodeID) bool {
+	n.l.Lock()
+	defer n.l.Unlock()
+
+	return n.connected(a, b)
+}
+
+// Assumes [n.l] is held.
+func (n *Network) connected(a ids.NodeID, b ids.NodeID) bool {
+	_, aok := n.nodeMap[a]
+	_, bok := n.nodeMap[b]
+	return aok && bok && n.groups[a] == n.groups[b]
+}
+
+// Partition splits the network into [groups] that can't communicate with each
+// other. Any nodes not included in a group form an additional group. Nodes
+// are notified (via Connected and Disconnected) of any peers they can now or
+// can no longer reach.
+func (n *Network) Partition(ctx context.Context, groups ...[]ids.NodeID) error {
+	next := make(map[ids.NodeID]int, len(n.nodes))
+	for i, group := range groups {
+		for _, nodeID := range group {
+			if _, ok := n.nodeMap[nodeID]; !ok {
+				return fmt.Errorf("%w: %s", ErrUnknownNode, nodeID)
+			}
+			next[nodeID] = i + 1
+		}
+	}
+
+	n.l.Lock()
+	prev := n.groups
+	n.groups = next
+	n.l.Unlock()
+
+	for _, node := range n.nodes {
+		for _, peer := range n.nodes {
+			if node == peer {
+				continue
+			}
+			wasConnected := prev[node.ID] == prev[peer.ID]
+			isConnected := next[node.ID] == next[peer.ID]
+			switch {
+			case wasConnected && !isConnected:
+				if err := node.VM.Disconnected(ctx, peer.ID); err != nil {
+					return err
+				}
+			case !wasConnected && isConnected:
+				if err := node.VM.Connected(ctx, peer.ID, version.CurrentApp); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// Heal removes all partitions.
+func (n *Network) Heal(ctx context.Context) error {
+	return n.Partition(ctx)
+}
+
+// send schedules [m] for delivery from [from] to [to], unless it is lost.
+func (n *Network) send(from ids.NodeID, to ids.NodeID, m *message) {
+	if _, ok := n.nodeMap[to]; !ok || from == to {
+		return
+	}
+
+	n.l.Lock()
+	defer n.l.Unlock()
+
+	if !n.connected(from, to) {
+		return
+	}
+	cfg, ok := n.links[link{from, to}]
+	if !ok {
+		cfg = n.cfg.Link
+	}
+	if cfg.DropRate > 0 && n.rng.Float64() < cfg.DropRate {
+		return
+	}
+	delay := cfg.Latency
+	if cfg.Jitter > 0 {
+		delay += time.Duration(n.rng.Int63n(int64(cfg.Jitter) + 1))
+	}
+	m.from = from
+	m.to = to
+	m.deliverAt = n.clock.Time().Add(delay)
+	n.push(m)
+}
+
+// schedule delivers [m] to [to] after [delay], regardless of network
+// conditions.
+func (n *Network) schedule(to ids.NodeID, delay time.Duration, m *message) {
+	n.l.Lock()
+	defer n.l.Unlock()
+
+	m.to = to
+	m.deliverAt = n.clock.Time().Add(delay)
+	n.push(m)
+}
+
+// Assumes [n.l] is held.
+func (n *Network) push(m *message) {
+	m.seq = n.seq
+	n.seq++
+	heap.Push(&n.queue, m)
+}
+
+func (n *Network) startRequest(requester ids.NodeID, responder ids.NodeID, requestID uint32) {
+	n.l.Lock()
+	defer n.l.Unlock()
+
+	n.requests.Add(request{requester, responder, requestID})
+}
+
+// completeRequest returns true if the request was outstanding. Each request
+// is completed exactly once (by a response or a timeout).
+func (n *Network) completeRequest(requester ids.NodeID, responder ids.NodeID, requestID uint32) bool {
+	n.l.Lock()
+	defer n.l.Unlock()
+
+	r := request{requester, responder, requestID}
+	if !n.requests.Contains(r) {
+		return false
+	}
+	n.requests.Remove(r)
+	return true
+}
+
+// Start transitions [nodes] (or all nodes, if none are provided) to normal
+// operation without syncing.
+func (n *Network) Start(ctx context.Context, nodes ...*Node) error {
+	n.engineLock.Lock()
+	defer n.engineLock.Unlock()
+
+	if len(nodes) == 0 {
+		nodes = n.nodes
+	}
+	for _, node := range nodes {
+		if err := node.VM.SetState(ctx, snow.Bootstrapping); err != nil {
+			return err
+		}
+		if err := node.VM.SetState(ctx, snow.NormalOp); err != nil {
+			return err
+		}
+		if v, ok := node.VM.(readyVM); ok {
+			v.ForceReady()
+			select {
+			case <-v.Ready():
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		node.started = true
+	}
+	return nil
+}
+
+// Shutdown shuts down all VMs. Any undelivered messages are dropped.
+func (n *Network) Shutdown(ctx context.Context) error {
+	errs := wrappers.Errs{}
+	for _, node := range n.nodes {
+		errs.Add(node.VM.Shutdown(ctx))
+	}
+	close(n.stopEngine)
+	n.engines.Wait()
+	for _, node := range n.nodes {
+		errs.Add(os.RemoveAll(node.dir))
+	}
+	return errs.Err
+}
@@ -0,0 +1,312 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simulator
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/database/manager"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/version"
+	"github.com/stretchr/testify/require"
+)
+
+type testVM struct {
+	block.TestVM
+	block.TestStateSyncableVM
+
+	l            sync.Mutex
+	sender       common.AppSender
+	gossip       map[ids.NodeID][][]byte
+	responses    set.Set[uint32]
+	failed       set.Set[uint32]
+	disconnected set.Set[ids.NodeID]
+	connected    set.Set[ids.NodeID]
+}
+
+func newTestVM() VM {
+	vm := &testVM{
+		gossip:       map[ids.NodeID][][]byte{},
+		responses:    set.Set[uint32]{},
+		failed:       set.Set[uint32]{},
+		disconnected: set.Set[ids.NodeID]{},
+		connected:    set.Set[ids.NodeID]{},
+	}
+	vm.TestVM.Default(false)
+	vm.InitializeF = func(_ context.Context, _ *snow.Context, _ manager.Manager, _, _, _ []byte, _ chan<- common.Message, _ []*common.Fx, sender common.AppSender) error {
+		vm.sender = sender
+		return nil
+	}
+	vm.AppGossipF = func(_ context.Context, nodeID ids.NodeID, msg []byte) error {
+		vm.l.Lock()
+		defer vm.l.Unlock()
+		vm.gossip[nodeID] = append(vm.gossip[nodeID], msg)
+		return nil
+	}
+	vm.AppRequestF = func(ctx context.Context, nodeID ids.NodeID, requestID uint32, _ time.Time, msg []byte) error {
+		return vm.sender.SendAppResponse(ctx, nodeID, requestID, msg)
+	}
+	vm.AppResponseF = func(_ context.Context, _ ids.NodeID, requestID uint32, _ []byte) error {
+		vm.l.Lock()
+		defer vm.l.Unlock()
+		vm.responses.Add(requestID)
+		return nil
+	}
+	vm.AppRequestFailedF = func(_ context.Context, _ ids.NodeID, requestID uint32) error {
+		vm.l.Lock()
+		defer vm.l.Unlock()
+		vm.failed.Add(requestID)
+		return nil
+	}
+	vm.ConnectedF = func(_ context.Context, nodeID ids.NodeID, _ *version.Application) error {
+		vm.l.Lock()
+		defer vm.l.Unlock()
+		vm.connected.Add(nodeID)
+		return nil
+	}
+	vm.DisconnectedF = func(_ context.Context, nodeID ids.NodeID) error {
+		vm.l.Lock()
+		defer vm.l.Unlock()
+		vm.disconnected.Add(nodeID)
+		return nil
+	}
+	return vm
+}
+
+func (vm *testVM) received(nodeID ids.NodeID) [][]byte {
+	vm.l.Lock()
+	defer vm.l.Unlock()
+	return vm.gossip[nodeID]
+}
+
+func testVMs(n *Network) []*testVM {
+	vms := make([]*testVM, len(n.Nodes()))
+	for i, node := range n.Nodes() {
+		vms[i] = node.VM.(*testVM)
+	}
+	return vms
+}
+
+func newTestNetwork(t *testing.T, cfg *Config) *Network {
+	n, err := New(context.Background(), logging.NewFactory(logging.Config{DisplayLevel: logging.Off, LogLevel: logging.Off}), cfg, newTestVM)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, n.Shutdown(context.Background()))
+	})
+	return n
+}
+
+func TestSeededNodes(t *testing.T) {
+	require := require.New(t)
+
+	cfg := DefaultConfig()
+	cfg.Nodes = 2
+	cfg.Seed = 7
+	a, b := newTestNetwork(t, cfg), newTestNetwork(t, cfg)
+	cfg.Seed = 8
+	c := newTestNetwork(t, cfg)
+
+	// Networks with the same seed have the same nodes and keys
+	require.Equal(a.ChainID(), b.ChainID())
+	require.Equal(a.SubnetID(), b.SubnetID())
+	require.NotEqual(a.ChainID(), c.ChainID())
+	for i := range a.Nodes() {
+		require.Equal(a.Nodes()[i].ID, b.Nodes()[i].ID)
+		require.Equal(bls.PublicKeyToBytes(a.Nodes()[i].PublicKey), bls.PublicKeyToBytes(b.Nodes()[i].PublicKey))
+		require.NotEqual(a.Nodes()[i].ID, c.Nodes()[i].ID)
+		require.NotEqual(bls.PublicKeyToBytes(a.Nodes()[i].PublicKey), bls.PublicKeyToBytes(c.Nodes()[i].PublicKey))
+	}
+	require.NotEqual(a.Nodes()[0].ID, a.Nodes()[1].ID)
+	require.NotEqual(bls.PublicKeyToBytes(a.Nodes()[0].PublicKey), bls.PublicKeyToBytes(a.Nodes()[1].PublicKey))
+}
+
+func TestGossipLatency(t *testing.T) {
+	require := require.New(t)
+
+	cfg := DefaultConfig()
+	cfg.Nodes = 3
+	cfg.Link = LinkConfig{Latency: 50 * time.Millisecond}
+	cfg.Start = time.Unix(1_000, 0)
+	n := newTestNetwork(t, cfg)
+	nodes, vms := n.Nodes(), testVMs(n)
+	ctx := context.Background()
+	for _, vm := range vms[1:] {
+		require.True(vm.connected.Contains(nodes[0].ID))
+	}
+	require.Equal(cfg.Start, n.Now())
+
+	require.NoError(vms[0].sender.SendAppGossip(ctx, []byte{1}))
+	require.Equal(2, n.Pending())
+
+	// Gossip is only delivered once the link latency has elapsed
+	require.Zero(n.Advance(ctx, cfg.Link.Latency-time.Millisecond))
+	for _, vm := range vms {
+		require.Empty(vm.received(nodes[0].ID))
+	}
+	require.Equal(2, n.Advance(ctx, time.Millisecond))
+	require.Equal(cfg.Start.Add(cfg.Link.Latency), n.Now())
+	for _, vm := range vms[1:] {
+		require.Equal([][]byte{{1}}, vm.received(nodes[0].ID))
+	}
+	require.Empty(vms[0].received(nodes[0].ID))
+	require.Zero(n.Pending())
+
+	// Links can be overridden
+	require.NoError(n.SetLink(nodes[0].ID, nodes[1].ID, LinkConfig{Latency: time.Second}))
+	require.NoError(vms[0].sender.SendAppGossipSpecific(ctx, set.Of(nodes[1].ID, nodes[2].ID), []byte{2}))
+	require.Equal(1, n.Advance(ctx, cfg.Link.Latency))
+	require.Len(vms[1].received(nodes[0].ID), 1)
+	require.Len(vms[2].received(nodes[0].ID), 2)
+	require.Equal(1, n.Advance(ctx, time.Second))
+	require.Equal([][]byte{{1}, {2}}, vms[1].received(nodes[0].ID))
+	require.ErrorIs(n.SetLink(nodes[0].ID, nodes[1].ID, LinkConfig{DropRate: 2}), ErrInvalidLink)
+}
+
+func TestJitter(t *testing.T) {
+	require := require.New(t)
+
+	cfg := DefaultConfig()
+	cfg.Nodes = 2
+	cfg.Seed = 3
+	cfg.Link = LinkConfig{Latency: 10 * time.Millisecond, Jitter: 100 * time.Millisecond}
+	ctx := context.Background()
+
+	// Jitter reorders messages, but the same seed always results in the same
+	// order
+	var received [2][][]byte
+	for i := range received {
+		n := newTestNetwork(t, cfg)
+		vms := testVMs(n)
+		for j := 0; j < 20; j++ {
+			require.NoError(vms[0].sender.SendAppGossip(ctx, []byte{byte(j)}))
+		}
+		require.Zero(n.Advance(ctx, cfg.Link.Latency-time.Millisecond))
+		require.Equal(20, n.Advance(ctx, cfg.Link.Jitter+time.Millisecond))
+		received[i] = vms[1].received(n.Nodes()[0].ID)
+	}
+	require.Equal(received[0], received[1])
+	var reordered bool
+	for i, msg := range received[0] {
+		if msg[0] != byte(i) {
+			reordered = true
+		}
+	}
+	require.True(reordered)
+}
+
+func TestPartition(t *testing.T) {
+	require := require.New(t)
+
+	cfg := DefaultConfig()
+	cfg.Nodes = 3
+	cfg.Link = LinkConfig{}
+	n := newTestNetwork(t, cfg)
+	nodes, vms := n.Nodes(), testVMs(n)
+	ctx := context.Background()
+
+	// Messages in flight when a partition occurs are lost
+	require.NoError(vms[1].sender.SendAppGossip(ctx, []byte{1}))
+	require.NoError(n.Partition(ctx, []ids.NodeID{nodes[0].ID}))
+	require.Equal(1, n.Advance(ctx, 0))
+	require.Empty(vms[0].received(nodes[1].ID))
+	require.Len(vms[2].received(nodes[1].ID), 1)
+
+	require.False(n.Connected(nodes[0].ID, nodes[1].ID))
+	require.True(n.Connected(nodes[1].ID, nodes[2].ID))
+	require.True(vms[0].disconnected.Contains(nodes[1].ID))
+	require.True(vms[1].disconnected.Contains(nodes[0].ID))
+	require.False(vms[1].disconnected.Contains(nodes[2].ID))
+	require.ErrorIs(n.Partition(ctx, []ids.NodeID{ids.GenerateTestNodeID()}), ErrUnknownNode)
+
+	// Gossip only reaches nodes in the same partition
+	require.NoError(vms[1].sender.SendAppGossip(ctx, []byte{2}))
+	require.Equal(1, n.Advance(ctx, 0))
+	require.Len(vms[2].received(nodes[1].ID), 2)
+	require.Empty(vms[0].received(nodes[1].ID))
+
+	// Requests across partitions fail immediately
+	require.NoError(vms[0].sender.SendAppRequest(ctx, set.Of(nodes[1].ID), 1, []byte{1}))
+	require.Equal(1, n.Advance(ctx, 0))
+	require.True(vms[0].failed.Contains(1))
+
+	require.NoError(n.Heal(ctx))
+	require.True(n.Connected(nodes[0].ID, nodes[1].ID))
+	require.Len(vms[0].connected, 2)
+	require.NoError(vms[1].sender.SendAppGossip(ctx, []byte{3}))
+	require.Equal(2, n.Advance(ctx, 0))
+	require.Equal([][]byte{{3}}, vms[0].received(nodes[1].ID))
+}
+
+func TestRequests(t *testing.T) {
+	require := require.New(t)
+
+	cfg := DefaultConfig()
+	cfg.Nodes = 2
+	cfg.RequestTimeout = 100 * time.Millisecond
+	n := newTestNetwork(t, cfg)
+	nodes, vm := n.Nodes(), testVMs(n)[0]
+	ctx := context.Background()
+
+	// Responses take a round trip
+	require.NoError(vm.sender.SendAppRequest(ctx, set.Of(nodes[1].ID), 1, []byte{1}))
+	require.Equal(1, n.Advance(ctx, cfg.Link.Latency))
+	require.False(vm.responses.Contains(1))
+	require.Equal(1, n.Advance(ctx, cfg.Link.Latency))
+	require.True(vm.responses.Contains(1))
+
+	// Lost requests time out
+	require.NoError(n.SetLink(nodes[0].ID, nodes[1].ID, LinkConfig{DropRate: 1}))
+	require.NoError(vm.sender.SendAppRequest(ctx, set.Of(nodes[1].ID), 2, []byte{1}))
+	require.Zero(n.Advance(ctx, cfg.RequestTimeout-time.Millisecond))
+	require.False(vm.failed.Contains(2))
+	require.Equal(1, n.Advance(ctx, time.Millisecond))
+	require.True(vm.failed.Contains(2))
+
+	// Requests are only completed once
+	require.Zero(n.Advance(ctx, 2*cfg.RequestTimeout))
+	require.Zero(n.Pending())
+	require.False(vm.failed.Contains(1))
+	require.False(vm.responses.Contains(2))
+
+	// Responses that arrive after the timeout are dropped
+	require.NoError(n.SetLink(nodes[0].ID, nodes[1].ID, LinkConfig{}))
+	require.NoError(n.SetLink(nodes[1].ID, nodes[0].ID, LinkConfig{Latency: cfg.RequestTimeout}))
+	require.NoError(vm.sender.SendAppRequest(ctx, set.Of(nodes[1].ID), 3, []byte{1}))
+	require.Equal(2, n.Advance(ctx, 2*cfg.RequestTimeout))
+	require.True(vm.failed.Contains(3))
+	require.False(vm.responses.Contains(3))
+}
+
+func TestDeterministicDrops(t *testing.T) {
+	require := require.New(t)
+
+	cfg := DefaultConfig()
+	cfg.Nodes = 2
+	cfg.Seed = 42
+	cfg.Link = LinkConfig{DropRate: 0.5}
+	ctx := context.Background()
+
+	var received [2][][]byte
+	for i := range received {
+		n := newTestNetwork(t, cfg)
+		vms := testVMs(n)
+		for j := 0; j < 100; j++ {
+			require.NoError(vms[0].sender.SendAppGossip(ctx, []byte{byte(j)}))
+		}
+		n.Advance(ctx, cfg.Link.Latency)
+		received[i] = vms[1].received(n.Nodes()[0].ID)
+	}
+	require.NotEmpty(received[0])
+	require.Less(len(received[0]), 100)
+	require.Equal(received[0], received[1])
+}
@@ -0,0 +1,44 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simulator
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+)
+
+// pChainHeight is the P-Chain height reported to all nodes. The validator set
+// never changes during a simulation.
+const pChainHeight = 1
+
+var _ validators.State = (*validatorState)(nil)
+
+// validatorState reports all nodes in the simulation as validators of
+// [subnetID].
+type validatorState struct {
+	subnetID   ids.ID
+	validators map[ids.NodeID]*validators.GetValidatorOutput
+}
+
+func (*validatorState) GetMinimumHeight(context.Context) (uint64, error) {
+	return 0, nil
+}
+
+func (*validatorState) GetCurrentHeight(context.Context) (uint64, error) {
+	return pChainHeight, nil
+}
+
+func (s *validatorState) GetSubnetID(context.Context, ids.ID) (ids.ID, error) {
+	return s.subnetID, nil
+}
+
+func (s *validatorState) GetValidatorSet(
+	context.Context,
+	uint64,
+	ids.ID,
+) (map[ids.NodeID]*validators.GetValidatorOutput, error) {
+	return s.validators, nil
+}
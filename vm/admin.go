@@ -0,0 +1,74 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/network"
+)
+
+// PeekMempool returns up to [count] of the highest-valued transactions in the
+// mempool without removing them.
+func (vm *VM) PeekMempool(ctx context.Context, count int) []*chain.Transaction {
+	return vm.mempool.Peek(ctx, count)
+}
+
+// FlushMempool removes all transactions from the mempool and returns how
+// many were removed.
+//
+// Listeners are not notified of removed transactions because they may have
+// already been gossiped and could still be included in a block.
+func (vm *VM) FlushMempool(ctx context.Context) int {
+	removed := vm.mempool.Clear(ctx)
+	vm.metrics.mempoolSize.Set(float64(vm.mempool.Len(ctx)))
+	vm.snowCtx.Log.Info("flushed mempool", zap.Int("txs", len(removed)))
+	return len(removed)
+}
+
+// PruneMempool removes all transactions from the mempool that expire before
+// the timestamp of the last accepted block. This happens automatically
+// whenever a block is accepted.
+func (vm *VM) PruneMempool(ctx context.Context) int {
+	removed := vm.mempool.SetMinTimestamp(ctx, vm.LastAcceptedBlock().Tmstmp)
+	vm.metrics.mempoolSize.Set(float64(vm.mempool.Len(ctx)))
+	vm.snowCtx.Log.Info("pruned mempool", zap.Int("txs", len(removed)))
+	return len(removed)
+}
+
+// CompactExpiredBlocks forces compaction of all blocks that have fallen out
+// of the accepted block window and returns the height of the last expired
+// block. If no blocks have expired, it returns 0 and does nothing.
+func (vm *VM) CompactExpiredBlocks() (uint64, error) {
+	height := vm.LastAcceptedBlock().Hght
+	window := uint64(vm.config.GetAcceptedBlockWindow())
+	if height <= window {
+		return 0, nil
+	}
+	lastExpired := height - window
+	if err := vm.CompactDiskBlocks(lastExpired); err != nil {
+		return 0, err
+	}
+	vm.snowCtx.Log.Info("compacted expired blocks", zap.Uint64("height", lastExpired))
+	return lastExpired, nil
+}
+
+// PeerScores returns the reputation of all peers that have misbehaved.
+func (vm *VM) PeerScores() []*network.PeerScore {
+	return vm.networkManager.PeerScores()
+}
+
+// ResetPeer forgets all misbehavior by [nodeID] (lifting any ban) and returns
+// false if [nodeID] had no history.
+func (vm *VM) ResetPeer(nodeID ids.NodeID) bool {
+	reset := vm.networkManager.ResetPeer(nodeID)
+	if reset {
+		vm.snowCtx.Log.Info("reset peer reputation", zap.Stringer("nodeID", nodeID))
+	}
+	return reset
+}
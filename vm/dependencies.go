@@ -18,6 +18,8 @@ import (
 	"github.com/ava-labs/hypersdk/builder"
 	"github.com/ava-labs/hypersdk/chain"
 	"github.com/ava-labs/hypersdk/gossiper"
+	"github.com/ava-labs/hypersdk/network"
+	"github.com/ava-labs/hypersdk/ratelimit"
 	"github.com/ava-labs/hypersdk/state"
 	trace "github.com/ava-labs/hypersdk/trace"
 )
@@ -50,6 +52,20 @@ type Config interface {
 	GetProcessingBuildSkip() int
 	GetTargetGossipDuration() time.Duration
 	GetBlockCompactionFrequency() int
+	GetRPCRateLimit() ratelimit.Config                   // applied to each method for each client
+	GetRPCMethodRateLimits() map[string]ratelimit.Config // overrides [GetRPCRateLimit] for specific methods
+	GetRateLimitKeyHeader() string                       // header used to identify clients (IP is used if empty)
+	GetStreamingClientConnections() int                  // max concurrent streaming connections per client
+	GetStreamingClientMessageLimit() ratelimit.Config    // rate of streaming messages each client can send
+	GetStreamingCompressionThreshold() int               // min size of streaming messages to compress (disabled if 0)
+	GetHealthAcceptedQueueThreshold() float64            // fraction of [GetAcceptorSize] before the node is not ready
+	GetHealthMempoolThreshold() float64                  // fraction of [GetMempoolSize] before the node is not ready
+	GetHealthMaxAcceptDelay() time.Duration              // max time without accepting a block while txs are pending
+	GetHealthWarpBacklogThreshold() int                  // max warp signature requests queued before the node is not live
+	GetPeerReputation() network.ReputationConfig         // penalties for misbehaving peers
+	GetGossipCompression() network.CompressionConfig     // when to compress gossip sent to peers
+	GetWarpSignatureThreshold() uint64                   // percent of stake that must sign before we stop gathering signatures
+	GetWarpMaxOutstanding() int                          // max warp signature requests awaiting a response
 }
 
 type Genesis interface {
@@ -60,6 +76,11 @@ type Genesis interface {
 
 type AuthEngine interface {
 	GetBatchVerifier(cores int, count int) chain.AuthBatchVerifier
+
+	// Cache is called with auths that have been verified (in accepted blocks
+	// or, if enabled, in gossip that was added to the mempool), so that the
+	// engine can cache anything that speeds up verifying the signatures of the
+	// same signers again (like their parsed public keys).
 	Cache(auth chain.Auth)
 }
 
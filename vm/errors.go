@@ -11,8 +11,10 @@ var (
 	ErrNotAdded            = errors.New("not added")
 	ErrDropped             = errors.New("dropped")
 	ErrNotReady            = errors.New("not ready")
+	ErrNotLive             = errors.New("not live")
 	ErrStateMissing        = errors.New("state missing")
 	ErrStateSyncing        = errors.New("state still syncing")
 	ErrUnexpectedStateRoot = errors.New("unexpected state root")
 	ErrTooManyProcessing   = errors.New("too many processing")
+	ErrNoWarpSignatures    = errors.New("no warp signatures")
 )
@@ -0,0 +1,157 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/hypersdk/rpc"
+)
+
+// HealthReport describes the status of each subsystem of the [VM].
+//
+// A node is "ready" when it should receive traffic: it has finished syncing,
+// is keeping up with accepted blocks, and has room in its mempool. A node is
+// "live" when it is making progress: it is including pending transactions in
+// blocks and is not backlogged on warp signature requests.
+type HealthReport struct {
+	Ready bool `json:"ready"`
+	Live  bool `json:"live"`
+
+	Sync          SyncHealth          `json:"sync"`
+	AcceptedQueue AcceptedQueueHealth `json:"acceptedQueue"`
+	LastAccepted  LastAcceptedHealth  `json:"lastAccepted"`
+	Mempool       MempoolHealth       `json:"mempool"`
+	Warp          WarpHealth          `json:"warp"`
+}
+
+type SyncHealth struct {
+	Healthy      bool `json:"healthy"`
+	Bootstrapped bool `json:"bootstrapped"`
+	StateSynced  bool `json:"stateSynced"` // state sync was started and is complete
+	StateReady   bool `json:"stateReady"`
+	Ready        bool `json:"ready"` // a full validity window has been observed
+}
+
+type AcceptedQueueHealth struct {
+	Healthy  bool `json:"healthy"`
+	Depth    int  `json:"depth"`
+	Capacity int  `json:"capacity"`
+}
+
+type LastAcceptedHealth struct {
+	Healthy   bool          `json:"healthy"`
+	Height    uint64        `json:"height"`
+	Timestamp int64         `json:"timestamp"`
+	Delay     time.Duration `json:"delay"`
+}
+
+type MempoolHealth struct {
+	Healthy  bool `json:"healthy"`
+	Len      int  `json:"len"`
+	Capacity int  `json:"capacity"`
+}
+
+type WarpHealth struct {
+	Healthy     bool `json:"healthy"`
+	Pending     int  `json:"pending"`
+	Outstanding int  `json:"outstanding"`
+}
+
+// Health returns a [HealthReport] using the thresholds in [Config].
+func (vm *VM) Health(ctx context.Context) *HealthReport {
+	r := &HealthReport{}
+
+	// Sync
+	r.Sync.Bootstrapped = vm.bootstrapped.Get()
+	r.Sync.StateReady = vm.StateReady()
+	r.Sync.StateSynced = r.Sync.StateReady && vm.stateSyncClient.Started()
+	select {
+	case <-vm.ready:
+		r.Sync.Ready = true
+	default:
+	}
+	r.Sync.Healthy = r.Sync.Bootstrapped && r.Sync.StateReady && r.Sync.Ready
+
+	// Accepted queue
+	r.AcceptedQueue.Depth = len(vm.acceptedQueue)
+	r.AcceptedQueue.Capacity = cap(vm.acceptedQueue)
+	r.AcceptedQueue.Healthy = belowThreshold(
+		r.AcceptedQueue.Depth,
+		r.AcceptedQueue.Capacity,
+		vm.config.GetHealthAcceptedQueueThreshold(),
+	)
+
+	// Mempool
+	r.Mempool.Len = vm.mempool.Len(ctx)
+	r.Mempool.Capacity = vm.config.GetMempoolSize()
+	r.Mempool.Healthy = belowThreshold(
+		r.Mempool.Len,
+		r.Mempool.Capacity,
+		vm.config.GetHealthMempoolThreshold(),
+	)
+
+	// Last accepted
+	//
+	// Blocks are only built when there are transactions to include, so we only
+	// consider a delay unhealthy if transactions are pending.
+	lastAccepted := vm.LastAcceptedBlock()
+	r.LastAccepted.Height = lastAccepted.Hght
+	r.LastAccepted.Timestamp = lastAccepted.Tmstmp
+	r.LastAccepted.Delay = time.Since(time.UnixMilli(lastAccepted.Tmstmp))
+	r.LastAccepted.Healthy = !r.Sync.Healthy || r.Mempool.Len == 0 ||
+		r.LastAccepted.Delay <= vm.config.GetHealthMaxAcceptDelay()
+
+	// Warp
+	r.Warp.Pending, r.Warp.Outstanding = vm.warpManager.Backlog()
+	r.Warp.Healthy = r.Warp.Pending <= vm.config.GetHealthWarpBacklogThreshold()
+
+	r.Ready = r.Sync.Healthy && r.AcceptedQueue.Healthy && r.Mempool.Healthy
+	r.Live = r.LastAccepted.Healthy && r.Warp.Healthy
+	return r
+}
+
+// belowThreshold returns true if [v] is less than [threshold] of [capacity].
+func belowThreshold(v int, capacity int, threshold float64) bool {
+	if capacity == 0 {
+		return true
+	}
+	return float64(v)/float64(capacity) < threshold
+}
+
+// healthHandler serves the [HealthReport] of a [VM]. [check] determines which
+// part of the report must be healthy for the handler to respond with
+// [http.StatusOK].
+type healthHandler struct {
+	vm    *VM
+	check func(*HealthReport) bool
+}
+
+func (h *healthHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	report := h.vm.Health(r.Context())
+	w.Header().Set("Content-Type", "application/json")
+	if !h.check(report) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		h.vm.Logger().Warn("unable to write health report", zap.Error(err))
+	}
+}
+
+func (vm *VM) healthHandlers() map[string]*common.HTTPHandler {
+	handler := func(check func(*HealthReport) bool) *common.HTTPHandler {
+		return &common.HTTPHandler{LockOptions: common.NoLock, Handler: &healthHandler{vm, check}}
+	}
+	return map[string]*common.HTTPHandler{
+		rpc.HealthEndpoint:                     handler(func(r *HealthReport) bool { return r.Ready && r.Live }),
+		rpc.HealthEndpoint + rpc.ReadinessPath: handler(func(r *HealthReport) bool { return r.Ready }),
+		rpc.HealthEndpoint + rpc.LivenessPath:  handler(func(r *HealthReport) bool { return r.Live }),
+	}
+}
@@ -4,9 +4,13 @@
 package vm
 
 import (
+	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/metric"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 	"github.com/ava-labs/hypersdk/executor"
+	"github.com/ava-labs/hypersdk/network"
+	"github.com/ava-labs/hypersdk/pubsub"
+	"github.com/ava-labs/hypersdk/ratelimit"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
@@ -23,11 +27,76 @@ func (em *executorMetrics) RecordExecutable() {
 	em.executable.Inc()
 }
 
+type rateLimitMetrics struct {
+	rejected prometheus.Counter
+}
+
+func (rm *rateLimitMetrics) RecordRejected() {
+	rm.rejected.Inc()
+}
+
+type streamingMetrics struct {
+	rejectedConnections prometheus.Counter
+	rateLimitedMessages prometheus.Counter
+	droppedMessages     prometheus.Counter
+}
+
+func (sm *streamingMetrics) RecordRejectedConnection() {
+	sm.rejectedConnections.Inc()
+}
+
+func (sm *streamingMetrics) RecordRateLimitedMessage() {
+	sm.rateLimitedMessages.Inc()
+}
+
+func (sm *streamingMetrics) RecordDroppedMessage() {
+	sm.droppedMessages.Inc()
+}
+
+type reputationMetrics struct {
+	offenses        *prometheus.CounterVec
+	scores          *prometheus.GaugeVec
+	banned          prometheus.Counter
+	droppedMessages prometheus.Counter
+}
+
+func (rm *reputationMetrics) RecordOffense(o network.Offense) {
+	rm.offenses.WithLabelValues(o.String()).Inc()
+}
+
+func (rm *reputationMetrics) RecordPeerScore(nodeID ids.NodeID, score float64) {
+	rm.scores.WithLabelValues(nodeID.String()).Set(score)
+}
+
+func (rm *reputationMetrics) RemovePeerScore(nodeID ids.NodeID) {
+	rm.scores.DeleteLabelValues(nodeID.String())
+}
+
+func (rm *reputationMetrics) RecordPeerBanned() {
+	rm.banned.Inc()
+}
+
+func (rm *reputationMetrics) RecordMessageDropped() {
+	rm.droppedMessages.Inc()
+}
+
+type networkMetrics struct {
+	unknownMessages prometheus.Counter
+}
+
+func (nm *networkMetrics) RecordUnknownMessage() {
+	nm.unknownMessages.Inc()
+}
+
 type Metrics struct {
 	txsSubmitted             prometheus.Counter // includes gossip
 	txsReceived              prometheus.Counter
 	seenTxsReceived          prometheus.Counter
 	txsGossiped              prometheus.Counter
+	pullRequestsSent         prometheus.Counter
+	pullRequestsRateLimited  prometheus.Counter
+	txsPulled                prometheus.Counter
+	txsPullServed            prometheus.Counter
 	txsVerified              prometheus.Counter
 	txsAccepted              prometheus.Counter
 	stateChanges             prometheus.Counter
@@ -48,6 +117,16 @@ type Metrics struct {
 	storageReadPrice         prometheus.Gauge
 	storageCreatePrice       prometheus.Gauge
 	storageModifyPrice       prometheus.Gauge
+	rpcRateLimited           prometheus.Counter
+	streamingRejectedConns   prometheus.Counter
+	streamingRateLimited     prometheus.Counter
+	streamingDropped         prometheus.Counter
+	peerOffenses             *prometheus.CounterVec
+	peerScores               *prometheus.GaugeVec
+	peersBanned              prometheus.Counter
+	peerMessagesDropped      prometheus.Counter
+	unknownMessages          prometheus.Counter
+	gossipInclusionLatency   *prometheus.HistogramVec
 	rootCalculated           metric.Averager
 	waitRoot                 metric.Averager
 	waitSignatures           metric.Averager
@@ -59,6 +138,10 @@ type Metrics struct {
 
 	executorBuildRecorder  executor.Metrics
 	executorVerifyRecorder executor.Metrics
+	rpcRecorder            ratelimit.Metrics
+	streamingRecorder      pubsub.Metrics
+	reputationRecorder     network.ReputationMetrics
+	networkRecorder        network.Metrics
 }
 
 func newMetrics() (*prometheus.Registry, *Metrics, error) {
@@ -158,6 +241,26 @@ func newMetrics() (*prometheus.Registry, *Metrics, error) {
 			Name:      "txs_gossiped",
 			Help:      "number of txs gossiped by vm",
 		}),
+		pullRequestsSent: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "vm",
+			Name:      "pull_requests_sent",
+			Help:      "number of pull gossip requests sent by vm",
+		}),
+		pullRequestsRateLimited: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "vm",
+			Name:      "pull_requests_rate_limited",
+			Help:      "number of pull gossip requests dropped because a peer exceeded its rate limit",
+		}),
+		txsPulled: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "vm",
+			Name:      "txs_pulled",
+			Help:      "number of txs received in response to pull gossip requests",
+		}),
+		txsPullServed: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "vm",
+			Name:      "txs_pull_served",
+			Help:      "number of txs sent in response to pull gossip requests",
+		}),
 		txsVerified: prometheus.NewCounter(prometheus.CounterOpts{
 			Namespace: "vm",
 			Name:      "txs_verified",
@@ -258,6 +361,57 @@ func newMetrics() (*prometheus.Registry, *Metrics, error) {
 			Name:      "storage_modify_price",
 			Help:      "unit price of storage modifications",
 		}),
+		rpcRateLimited: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "vm",
+			Name:      "rpc_rate_limited",
+			Help:      "number of rpc calls rejected by rate limits",
+		}),
+		streamingRejectedConns: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "vm",
+			Name:      "streaming_rejected_connections",
+			Help:      "number of streaming connections rejected by client limits",
+		}),
+		streamingRateLimited: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "vm",
+			Name:      "streaming_rate_limited",
+			Help:      "number of streaming messages rejected by rate limits",
+		}),
+		streamingDropped: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "vm",
+			Name:      "streaming_dropped",
+			Help:      "number of streaming message batches dropped because too many were pending",
+		}),
+		peerOffenses: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "vm",
+			Name:      "peer_offenses",
+			Help:      "number of offenses committed by peers",
+		}, []string{"offense"}),
+		peerScores: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "vm",
+			Name:      "peer_score",
+			Help:      "misbehavior score of peers that have committed offenses",
+		}, []string{"nodeID"}),
+		peersBanned: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "vm",
+			Name:      "peers_banned",
+			Help:      "number of times a peer was banned",
+		}),
+		peerMessagesDropped: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "vm",
+			Name:      "peer_messages_dropped",
+			Help:      "number of messages dropped from banned or throttled peers",
+		}),
+		unknownMessages: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "vm",
+			Name:      "unknown_messages",
+			Help:      "number of messages dropped because their protocol is not supported",
+		}),
+		gossipInclusionLatency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "vm",
+			Name:      "gossip_inclusion_latency",
+			Help:      "time between gossiping a transaction and its acceptance in ms",
+			Buckets:   prometheus.ExponentialBuckets(50, 2, 12),
+		}, []string{"strategy"}),
 		rootCalculated: rootCalculated,
 		waitRoot:       waitRoot,
 		waitSignatures: waitSignatures,
@@ -269,6 +423,19 @@ func newMetrics() (*prometheus.Registry, *Metrics, error) {
 	}
 	m.executorBuildRecorder = &executorMetrics{blocked: m.executorBuildBlocked, executable: m.executorBuildExecutable}
 	m.executorVerifyRecorder = &executorMetrics{blocked: m.executorVerifyBlocked, executable: m.executorVerifyExecutable}
+	m.rpcRecorder = &rateLimitMetrics{rejected: m.rpcRateLimited}
+	m.streamingRecorder = &streamingMetrics{
+		rejectedConnections: m.streamingRejectedConns,
+		rateLimitedMessages: m.streamingRateLimited,
+		droppedMessages:     m.streamingDropped,
+	}
+	m.reputationRecorder = &reputationMetrics{
+		offenses:        m.peerOffenses,
+		scores:          m.peerScores,
+		banned:          m.peersBanned,
+		droppedMessages: m.peerMessagesDropped,
+	}
+	m.networkRecorder = &networkMetrics{unknownMessages: m.unknownMessages}
 
 	errs := wrappers.Errs{}
 	errs.Add(
@@ -276,6 +443,10 @@ func newMetrics() (*prometheus.Registry, *Metrics, error) {
 		r.Register(m.txsReceived),
 		r.Register(m.seenTxsReceived),
 		r.Register(m.txsGossiped),
+		r.Register(m.pullRequestsSent),
+		r.Register(m.pullRequestsRateLimited),
+		r.Register(m.txsPulled),
+		r.Register(m.txsPullServed),
 		r.Register(m.txsVerified),
 		r.Register(m.txsAccepted),
 		r.Register(m.stateChanges),
@@ -296,6 +467,16 @@ func newMetrics() (*prometheus.Registry, *Metrics, error) {
 		r.Register(m.storageReadPrice),
 		r.Register(m.storageCreatePrice),
 		r.Register(m.storageModifyPrice),
+		r.Register(m.rpcRateLimited),
+		r.Register(m.streamingRejectedConns),
+		r.Register(m.streamingRateLimited),
+		r.Register(m.streamingDropped),
+		r.Register(m.peerOffenses),
+		r.Register(m.peerScores),
+		r.Register(m.peersBanned),
+		r.Register(m.peerMessagesDropped),
+		r.Register(m.unknownMessages),
+		r.Register(m.gossipInclusionLatency),
 	)
 	return r, m, errs.Err
 }
@@ -0,0 +1,27 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import "github.com/ava-labs/hypersdk/network"
+
+// Protocol IDs are part of the wire format and must never be reused or
+// reordered. To change a protocol, register a new version alongside the
+// existing one until all peers have upgraded.
+const (
+	warpProtocolID         uint8 = 0x0
+	stateSyncProtocolID    uint8 = 0x1
+	txGossipProtocolID     uint8 = 0x2
+	txPullGossipProtocolID uint8 = 0x3
+)
+
+var (
+	warpProtocol         = network.Protocol{ID: warpProtocolID, Version: 0}
+	stateSyncProtocol    = network.Protocol{ID: stateSyncProtocolID, Version: 0}
+	txGossipProtocol     = network.Protocol{ID: txGossipProtocolID, Version: 0}
+	txPullGossipProtocol = network.Protocol{ID: txPullGossipProtocolID, Version: 0}
+
+	// legacyProtocols are supported by peers running a version of the VM
+	// that predates protocol handshakes.
+	legacyProtocols = []network.Protocol{warpProtocol, stateSyncProtocol, txGossipProtocol}
+)
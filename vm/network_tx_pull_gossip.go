@@ -0,0 +1,77 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"context"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/version"
+	"go.uber.org/zap"
+)
+
+type TxPullGossipHandler struct {
+	vm *VM
+}
+
+func NewTxPullGossipHandler(vm *VM) *TxPullGossipHandler {
+	return &TxPullGossipHandler{vm}
+}
+
+func (*TxPullGossipHandler) Connected(context.Context, ids.NodeID, *version.Application) error {
+	return nil
+}
+
+func (*TxPullGossipHandler) Disconnected(context.Context, ids.NodeID) error {
+	return nil
+}
+
+func (*TxPullGossipHandler) AppGossip(context.Context, ids.NodeID, []byte) error {
+	return nil
+}
+
+func (t *TxPullGossipHandler) AppRequest(
+	ctx context.Context,
+	nodeID ids.NodeID,
+	requestID uint32,
+	_ time.Time,
+	request []byte,
+) error {
+	if !t.vm.isReady() {
+		t.vm.snowCtx.Log.Warn("handle pull gossip request failed", zap.Error(ErrNotReady))
+		return nil
+	}
+
+	return t.vm.gossiper.HandleAppRequest(ctx, nodeID, requestID, request)
+}
+
+func (t *TxPullGossipHandler) AppRequestFailed(
+	ctx context.Context,
+	nodeID ids.NodeID,
+	requestID uint32,
+) error {
+	return t.vm.gossiper.HandleAppRequestFailed(ctx, nodeID, requestID)
+}
+
+func (t *TxPullGossipHandler) AppResponse(
+	ctx context.Context,
+	nodeID ids.NodeID,
+	requestID uint32,
+	response []byte,
+) error {
+	return t.vm.gossiper.HandleAppResponse(ctx, nodeID, requestID, response)
+}
+
+func (*TxPullGossipHandler) CrossChainAppRequest(context.Context, ids.ID, uint32, time.Time, []byte) error {
+	return nil
+}
+
+func (*TxPullGossipHandler) CrossChainAppRequestFailed(context.Context, ids.ID, uint32) error {
+	return nil
+}
+
+func (*TxPullGossipHandler) CrossChainAppResponse(context.Context, ids.ID, uint32, []byte) error {
+	return nil
+}
@@ -22,6 +22,8 @@ import (
 	"github.com/ava-labs/hypersdk/chain"
 	"github.com/ava-labs/hypersdk/executor"
 	"github.com/ava-labs/hypersdk/gossiper"
+	"github.com/ava-labs/hypersdk/network"
+	"github.com/ava-labs/hypersdk/ratelimit"
 	"github.com/ava-labs/hypersdk/workers"
 )
 
@@ -143,7 +145,18 @@ func (vm *VM) Rejected(ctx context.Context, b *chain.StatelessBlock) {
 	vm.verifiedL.Lock()
 	delete(vm.verifiedBlocks, b.ID())
 	vm.verifiedL.Unlock()
-	vm.mempool.Add(ctx, b.Txs)
+
+	// Transactions authorized by an [chain.AggregateAuth] can't be included
+	// in another block if their signature was removed when this block was
+	// built (by a peer), so we don't add them back to the mempool.
+	restorable := make([]*chain.Transaction, 0, len(b.Txs))
+	for _, tx := range b.Txs {
+		if aauth, ok := tx.Auth.(chain.AggregateAuth); ok && aauth.AggregateSignature() == nil {
+			continue
+		}
+		restorable = append(restorable, tx)
+	}
+	vm.mempool.Add(ctx, restorable)
 
 	if err := vm.c.Rejected(ctx, b); err != nil {
 		vm.Fatal("rejected processing failed", zap.Error(err))
@@ -175,11 +188,18 @@ func (vm *VM) processAcceptedBlock(b *chain.StatelessBlock) {
 		vm.Fatal("accepted processing failed", zap.Error(err))
 	}
 
+	// Record inclusion latency of any txs we gossiped
+	vm.gossiper.BlockAccepted(b)
+
 	// Sign and store any warp messages (regardless if validator now, may become one)
 	results := b.Results()
 	for i, tx := range b.Txs {
-		// Only cache auth for accepted blocks to prevent cache manipulation from RPC submissions
-		vm.cacheAuth(tx.Auth)
+		// Auths are only cached once they are accepted (or, if
+		// [gossiper.ProposerConfig.WarmAuthCache] is set, once they are
+		// gossiped to us and added to the mempool), so that transactions
+		// submitted over RPC can't evict the keys of active signers from the
+		// cache.
+		vm.CacheAuth(tx.Auth)
 
 		result := results[i]
 		if result.WarpMessage == nil {
@@ -216,6 +236,12 @@ func (vm *VM) processAcceptedBlock(b *chain.StatelessBlock) {
 	if err := vm.webSocketServer.SetMinTx(b.Tmstmp); err != nil {
 		vm.Fatal("unable to set min tx in websocket server", zap.Error(err))
 	}
+	if err := vm.grpcServer.AcceptBlock(b); err != nil {
+		vm.Fatal("unable to accept block in grpc server", zap.Error(err))
+	}
+	if err := vm.grpcServer.SetMinTx(b.Tmstmp); err != nil {
+		vm.Fatal("unable to set min tx in grpc server", zap.Error(err))
+	}
 
 	// Update price metrics
 	feeManager := b.FeeManager()
@@ -337,6 +363,10 @@ func (vm *VM) GatherSignatures(ctx context.Context, txID ids.ID, msg []byte) {
 	vm.warpManager.GatherSignatures(ctx, txID, msg)
 }
 
+func (vm *VM) FastestPeers(limit int) []ids.NodeID {
+	return vm.networkManager.FastestPeers(limit)
+}
+
 func (vm *VM) NodeID() ids.NodeID {
 	return vm.snowCtx.NodeID
 }
@@ -429,6 +459,30 @@ func (vm *VM) RecordSeenTxsReceived(c int) {
 	vm.metrics.seenTxsReceived.Add(float64(c))
 }
 
+func (vm *VM) RecordPullRequestsSent(c int) {
+	vm.metrics.pullRequestsSent.Add(float64(c))
+}
+
+func (vm *VM) RecordPullRequestsRateLimited() {
+	vm.metrics.pullRequestsRateLimited.Inc()
+}
+
+func (vm *VM) RecordTxsPulled(c int) {
+	vm.metrics.txsPulled.Add(float64(c))
+}
+
+func (vm *VM) RecordTxsPullServed(c int) {
+	vm.metrics.txsPullServed.Add(float64(c))
+}
+
+func (vm *VM) RecordGossipInclusionLatency(strategy string, d time.Duration) {
+	vm.metrics.gossipInclusionLatency.WithLabelValues(strategy).Observe(float64(d.Milliseconds()))
+}
+
+func (vm *VM) ReportPeer(nodeID ids.NodeID, offense network.Offense) {
+	vm.networkManager.ReportPeer(nodeID, offense)
+}
+
 func (vm *VM) RecordBuildCapped() {
 	vm.metrics.buildCapped.Inc()
 }
@@ -453,7 +507,7 @@ func (vm *VM) GetAuthBatchVerifier(authTypeID uint8, cores int, count int) (chai
 	return bv.GetBatchVerifier(cores, count), ok
 }
 
-func (vm *VM) cacheAuth(auth chain.Auth) {
+func (vm *VM) CacheAuth(auth chain.Auth) {
 	bv, ok := vm.authEngine[auth.GetTypeID()]
 	if !ok {
 		return
@@ -492,3 +546,15 @@ func (vm *VM) GetExecutorBuildRecorder() executor.Metrics {
 func (vm *VM) GetExecutorVerifyRecorder() executor.Metrics {
 	return vm.metrics.executorVerifyRecorder
 }
+
+// NewRPCLimiter returns a limiter for RPC methods configured by [config]
+// that records rejections in the VM's metrics. Controllers can use it to rate
+// limit their own methods.
+func (vm *VM) NewRPCLimiter(config Config) *ratelimit.MethodLimiter {
+	return ratelimit.NewMethodLimiter(
+		config.GetRateLimitKeyHeader(),
+		config.GetRPCRateLimit(),
+		config.GetRPCMethodRateLimits(),
+		vm.metrics.rpcRecorder,
+	)
+}
@@ -21,6 +21,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
 	"github.com/ava-labs/hypersdk/consts"
 	"github.com/ava-labs/hypersdk/keys"
 )
@@ -40,6 +41,7 @@ const (
 	blockHeightIDPrefix = 0x2 // Height -> ID (don't always need full block from disk)
 	warpSignaturePrefix = 0x3
 	warpFetchPrefix     = 0x4
+	warpJobPrefix       = 0x5
 )
 
 var (
@@ -297,9 +299,11 @@ func PrefixWarpFetchKey(txID ids.ID) []byte {
 	return k
 }
 
-func (vm *VM) StoreWarpFetch(txID ids.ID) error {
+// StoreWarpFetch records that we last tried to gather signatures for [txID] at
+// [timestamp] (unix ms).
+func (vm *VM) StoreWarpFetch(txID ids.ID, timestamp int64) error {
 	k := PrefixWarpFetchKey(txID)
-	return vm.vmDB.Put(k, binary.BigEndian.AppendUint64(nil, uint64(time.Now().UnixMilli())))
+	return vm.vmDB.Put(k, binary.BigEndian.AppendUint64(nil, uint64(timestamp)))
 }
 
 func (vm *VM) GetWarpFetch(txID ids.ID) (int64, error) {
@@ -313,3 +317,48 @@ func (vm *VM) GetWarpFetch(txID ids.ID) (int64, error) {
 	}
 	return int64(binary.BigEndian.Uint64(v)), nil
 }
+
+func PrefixWarpJobKey(txID ids.ID) []byte {
+	k := make([]byte, 1+consts.IDLen)
+	k[0] = warpJobPrefix
+	copy(k[1:], txID[:])
+	return k
+}
+
+// StoreWarpJob persists [job] so that signature aggregation resumes after a
+// restart.
+func (vm *VM) StoreWarpJob(job *warpJob) error {
+	w := codec.NewWriter(consts.IntLen+consts.Int64Len+codec.BytesLen(job.msg), consts.NetworkSizeLimit)
+	w.PackInt(job.attempts)
+	w.PackInt64(job.next)
+	w.PackBytes(job.msg)
+	if err := w.Err(); err != nil {
+		return err
+	}
+	return vm.vmDB.Put(PrefixWarpJobKey(job.txID), w.Bytes())
+}
+
+func (vm *VM) DeleteWarpJob(txID ids.ID) error {
+	return vm.vmDB.Delete(PrefixWarpJobKey(txID))
+}
+
+// GetWarpJobs returns all persisted signature aggregation jobs.
+func (vm *VM) GetWarpJobs() ([]*warpJob, error) {
+	iter := vm.vmDB.NewIteratorWithPrefix([]byte{warpJobPrefix})
+	defer iter.Release()
+
+	jobs := []*warpJob{}
+	for iter.Next() {
+		job := &warpJob{}
+		copy(job.txID[:], iter.Key()[1:])
+		r := codec.NewReader(iter.Value(), consts.NetworkSizeLimit)
+		job.attempts = r.UnpackInt(false)
+		job.next = r.UnpackInt64(false)
+		r.UnpackBytes(consts.NetworkSizeLimit, true, &job.msg)
+		if err := r.Err(); err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, iter.Error()
+}
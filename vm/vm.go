@@ -7,7 +7,6 @@ import (
 	"context"
 	"encoding/binary"
 	"fmt"
-	"net/http"
 	"sync"
 	"time"
 
@@ -40,6 +39,9 @@ import (
 	"github.com/ava-labs/hypersdk/gossiper"
 	"github.com/ava-labs/hypersdk/mempool"
 	"github.com/ava-labs/hypersdk/network"
+	"github.com/ava-labs/hypersdk/openrpc"
+	"github.com/ava-labs/hypersdk/pubsub"
+	"github.com/ava-labs/hypersdk/ratelimit"
 	"github.com/ava-labs/hypersdk/rpc"
 	"github.com/ava-labs/hypersdk/state"
 	htrace "github.com/ava-labs/hypersdk/trace"
@@ -96,6 +98,10 @@ type VM struct {
 
 	// Transactions that streaming users are currently subscribed to
 	webSocketServer *rpc.WebSocketServer
+	grpcServer      *rpc.GRPCServer
+
+	// Limits calls to RPC methods by each client
+	rpcLimiter *ratelimit.MethodLimiter
 
 	// sigWorkers are used to verify signatures in parallel
 	// with limited parallelism
@@ -165,12 +171,17 @@ func (vm *VM) Initialize(
 	}
 	vm.metrics = metrics
 	vm.proposerMonitor = NewProposerMonitor(vm)
-	vm.networkManager = network.NewManager(vm.snowCtx.Log, vm.snowCtx.NodeID, appSender)
+	vm.networkManager = network.NewManager(vm.snowCtx.Log, vm.snowCtx.NodeID, appSender, vm.metrics.networkRecorder)
+	if err := vm.networkManager.SetLegacyProtocols(legacyProtocols...); err != nil {
+		return err
+	}
 
-	warpHandler, warpSender := vm.networkManager.Register()
+	warpSender, err := vm.networkManager.Register(warpProtocol)
+	if err != nil {
+		return err
+	}
 	vm.warpManager = NewWarpManager(vm)
-	vm.networkManager.SetHandler(warpHandler, NewWarpHandler(vm))
-	go vm.warpManager.Run(warpSender)
+	vm.networkManager.SetHandler(warpProtocol, NewWarpHandler(vm))
 	vm.manager = manager
 
 	// Always initialize implementation first
@@ -186,6 +197,8 @@ func (vm *VM) Initialize(
 	if err != nil {
 		return fmt.Errorf("implementation initialization failed: %w", err)
 	}
+	vm.networkManager.SetReputation(network.NewReputation(vm.config.GetPeerReputation(), vm.metrics.reputationRecorder))
+	vm.networkManager.SetCompression(vm.config.GetGossipCompression())
 
 	// Setup tracer
 	vm.tracer, err = htrace.New(vm.config.GetTraceConfig())
@@ -358,7 +371,10 @@ func (vm *VM) Initialize(
 	go vm.processAcceptedBlocks()
 
 	// Setup state syncing
-	stateSyncHandler, stateSyncSender := vm.networkManager.Register()
+	stateSyncSender, err := vm.networkManager.Register(stateSyncProtocol)
+	if err != nil {
+		return err
+	}
 	syncRegistry := prometheus.NewRegistry()
 	vm.stateSyncNetworkClient, err = syncEng.NewNetworkClient(
 		stateSyncSender,
@@ -376,21 +392,33 @@ func (vm *VM) Initialize(
 	}
 	vm.stateSyncClient = vm.NewStateSyncClient(gatherer)
 	vm.stateSyncNetworkServer = syncEng.NewNetworkServer(stateSyncSender, vm.stateDB, vm.Logger())
-	vm.networkManager.SetHandler(stateSyncHandler, NewStateSyncHandler(vm))
+	vm.networkManager.SetHandler(stateSyncProtocol, NewStateSyncHandler(vm))
 
 	// Setup gossip networking
-	gossipHandler, gossipSender := vm.networkManager.Register()
-	vm.networkManager.SetHandler(gossipHandler, NewTxGossipHandler(vm))
+	gossipSender, err := vm.networkManager.Register(txGossipProtocol)
+	if err != nil {
+		return err
+	}
+	vm.networkManager.SetHandler(txGossipProtocol, NewTxGossipHandler(vm))
+	pullGossipSender, err := vm.networkManager.Register(txPullGossipProtocol)
+	if err != nil {
+		return err
+	}
+	vm.networkManager.SetHandler(txPullGossipProtocol, NewTxPullGossipHandler(vm))
 
-	// Startup block builder and gossiper
+	// Startup warp manager (after [vm.vmDB] is initialized, so we can resume
+	// any persisted jobs), block builder, and gossiper
+	go vm.warpManager.Run(warpSender)
 	go vm.builder.Run()
 	go vm.gossiper.Run(gossipSender)
+	go vm.gossiper.RunPull(pullGossipSender)
 
 	// Wait until VM is ready and then send a state sync message to engine
 	go vm.markReady()
 
 	// Setup handlers
-	jsonRPCHandler, err := rpc.NewJSONRPCHandler(rpc.Name, rpc.NewJSONRPCServer(vm), common.NoLock)
+	vm.rpcLimiter = vm.NewRPCLimiter(vm.config)
+	jsonRPCHandler, err := rpc.NewRateLimitedJSONRPCHandler(rpc.Name, rpc.NewJSONRPCServer(vm), common.NoLock, vm.rpcLimiter)
 	if err != nil {
 		return fmt.Errorf("unable to create handler: %w", err)
 	}
@@ -401,9 +429,39 @@ func (vm *VM) Initialize(
 	if _, ok := vm.handlers[rpc.WebSocketEndpoint]; ok {
 		return fmt.Errorf("duplicate WebSocket handler found: %s", rpc.WebSocketEndpoint)
 	}
-	webSocketServer, pubsubServer := rpc.NewWebSocketServer(vm, vm.config.GetStreamingBacklogSize())
+	pubsubConfig := pubsub.NewDefaultServerConfig()
+	pubsubConfig.MaxPendingMessages = vm.config.GetStreamingBacklogSize()
+	pubsubConfig.ClientKeyHeader = vm.config.GetRateLimitKeyHeader()
+	pubsubConfig.MaxClientConnections = vm.config.GetStreamingClientConnections()
+	pubsubConfig.ClientMessageLimit = vm.config.GetStreamingClientMessageLimit()
+	pubsubConfig.CompressionThreshold = vm.config.GetStreamingCompressionThreshold()
+	pubsubConfig.Metrics = vm.metrics.streamingRecorder
+	webSocketServer, pubsubServer := rpc.NewWebSocketServer(vm, pubsubConfig)
 	vm.webSocketServer = webSocketServer
 	vm.handlers[rpc.WebSocketEndpoint] = rpc.NewWebSocketHandler(pubsubServer)
+	vm.grpcServer = rpc.NewGRPCServer(vm, vm.config.GetStreamingBacklogSize())
+	for endpoint, handler := range rpc.NewGRPCHandlers(vm.grpcServer, vm.rpcLimiter) {
+		if _, ok := vm.handlers[endpoint]; ok {
+			return fmt.Errorf("duplicate gRPC handler found: %s", endpoint)
+		}
+		vm.handlers[endpoint] = handler
+	}
+	for endpoint, handler := range vm.healthHandlers() {
+		if _, ok := vm.handlers[endpoint]; ok {
+			return fmt.Errorf("duplicate health handler found: %s", endpoint)
+		}
+		vm.handlers[endpoint] = handler
+	}
+
+	// Describe all JSON-RPC handlers (must be registered last)
+	if _, ok := vm.handlers[rpc.SchemaEndpoint]; ok {
+		return fmt.Errorf("duplicate schema handler found: %s", rpc.SchemaEndpoint)
+	}
+	schemaHandler, err := rpc.NewSchemaHandler(openrpc.Info{Title: rpc.Name, Version: vm.v.String()}, vm.handlers)
+	if err != nil {
+		return fmt.Errorf("unable to create schema handler: %w", err)
+	}
+	vm.handlers[rpc.SchemaEndpoint] = schemaHandler
 	return nil
 }
 
@@ -446,6 +504,12 @@ func (vm *VM) markReady() {
 	vm.checkActivity(context.TODO())
 }
 
+// Ready returns a channel that is closed once the VM has synced and observed a
+// full [ValidityWindow].
+func (vm *VM) Ready() <-chan struct{} {
+	return vm.ready
+}
+
 func (vm *VM) isReady() bool {
 	select {
 	case <-vm.ready:
@@ -602,16 +666,20 @@ func (*VM) CreateStaticHandlers(_ context.Context) (map[string]*common.HTTPHandl
 }
 
 // implements "block.ChainVM.commom.VM.health.Checkable"
-func (vm *VM) HealthCheck(context.Context) (interface{}, error) {
+func (vm *VM) HealthCheck(ctx context.Context) (interface{}, error) {
 	// TODO: engine will mark VM as ready when we return
 	// [block.StateSyncDynamic]. This should change in v1.9.11.
 	//
 	// We return "unhealthy" here until synced to block RPC traffic in the
-	// meantime.
-	if !vm.isReady() {
-		return http.StatusServiceUnavailable, ErrNotReady
+	// meantime. The full [HealthReport] is returned as details.
+	report := vm.Health(ctx)
+	if !report.Ready {
+		return report, ErrNotReady
+	}
+	if !report.Live {
+		return report, ErrNotLive
 	}
-	return http.StatusOK, nil
+	return report, nil
 }
 
 // implements "block.ChainVM.commom.VM.Getter"
@@ -855,6 +923,9 @@ func (vm *VM) Submit(
 				if err := vm.webSocketServer.RemoveTx(txID, err); err != nil {
 					vm.snowCtx.Log.Warn("unable to remove tx from webSocketServer", zap.Error(err))
 				}
+				if err := vm.grpcServer.RemoveTx(txID, err); err != nil {
+					vm.snowCtx.Log.Warn("unable to remove tx from grpcServer", zap.Error(err))
+				}
 				errs = append(errs, err)
 				continue
 			}
@@ -6,6 +6,7 @@ package vm
 import (
 	"context"
 	"testing"
+	"time"
 
 	ametrics "github.com/ava-labs/avalanchego/api/metrics"
 	"github.com/ava-labs/avalanchego/database/manager"
@@ -13,12 +14,16 @@ import (
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/version"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
 	hcache "github.com/ava-labs/hypersdk/cache"
 	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
 	"github.com/ava-labs/hypersdk/config"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/crypto/bls"
 	"github.com/ava-labs/hypersdk/emap"
 	"github.com/ava-labs/hypersdk/mempool"
 	"github.com/ava-labs/hypersdk/trace"
@@ -81,3 +86,173 @@ func TestBlockCache(t *testing.T) {
 	require.NoError(err)
 	require.Equal(blk, blk2)
 }
+
+func TestHealth(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tracer, _ := trace.New(&trace.Config{Enabled: false})
+	vm := &VM{
+		snowCtx: &snow.Context{Log: logging.NoLog{}},
+		config:  &config.Config{},
+
+		mempool:       mempool.New[*chain.Transaction](tracer, 100, 32, nil),
+		acceptedQueue: make(chan *chain.StatelessBlock, 10),
+		ready:         make(chan struct{}),
+		lastAccepted: &chain.StatelessBlock{
+			StatefulBlock: &chain.StatefulBlock{
+				Hght:   10,
+				Tmstmp: time.Now().UnixMilli(),
+			},
+		},
+	}
+	vm.stateSyncClient = &stateSyncerClient{vm: vm, done: make(chan struct{})}
+	vm.warpManager = NewWarpManager(vm)
+
+	// Not ready until synced and bootstrapped
+	ctx := context.TODO()
+	report := vm.Health(ctx)
+	require.False(report.Ready)
+	require.True(report.Live)
+	_, err := vm.HealthCheck(ctx)
+	require.ErrorIs(err, ErrNotReady)
+
+	close(vm.stateSyncClient.done)
+	close(vm.ready)
+	vm.bootstrapped.Set(true)
+	report = vm.Health(ctx)
+	require.True(report.Ready)
+	require.True(report.Live)
+	require.True(report.Sync.Healthy)
+	require.False(report.Sync.StateSynced)
+	_, err = vm.HealthCheck(ctx)
+	require.NoError(err)
+
+	// Not ready when falling behind on accepted blocks
+	for i := 0; i < 9; i++ {
+		vm.acceptedQueue <- nil
+	}
+	report = vm.Health(ctx)
+	require.False(report.Ready)
+	require.False(report.AcceptedQueue.Healthy)
+	require.Equal(9, report.AcceptedQueue.Depth)
+	require.Equal(10, report.AcceptedQueue.Capacity)
+	for i := 0; i < 9; i++ {
+		<-vm.acceptedQueue
+	}
+
+	// Not live when pending txs are not included
+	vm.lastAccepted.Tmstmp = time.Now().Add(-2 * time.Minute).UnixMilli()
+	report = vm.Health(ctx)
+	require.True(report.Live)
+	auth := chain.NewMockAuth(ctrl)
+	auth.EXPECT().Payer().Return([]byte("payer")).AnyTimes()
+	vm.mempool.Add(ctx, []*chain.Transaction{{
+		Base: &chain.Base{Timestamp: time.Now().UnixMilli()},
+		Auth: auth,
+	}})
+	report = vm.Health(ctx)
+	require.True(report.Ready)
+	require.False(report.Live)
+	require.False(report.LastAccepted.Healthy)
+	_, err = vm.HealthCheck(ctx)
+	require.ErrorIs(err, ErrNotLive)
+}
+
+// aggregateAuth is a [chain.AggregateAuth] with signature [sig].
+type aggregateAuth struct {
+	*chain.MockAuth
+
+	sig *bls.Signature
+}
+
+func (*aggregateAuth) AggregateKey() bls.PublicKey {
+	return bls.EmptyPublicKey
+}
+
+func (a *aggregateAuth) AggregateSignature() *bls.Signature {
+	return a.sig
+}
+
+func (a *aggregateAuth) Aggregated() chain.AggregateAuth {
+	return &aggregateAuth{a.MockAuth, nil}
+}
+
+// newTestTx returns a parsed [chain.Transaction] authorized by [auth] (with a
+// unique ID for each [maxFee]).
+func newTestTx(t *testing.T, ctrl *gomock.Controller, maxFee uint64, auth chain.Auth) *chain.Transaction {
+	require := require.New(t)
+
+	action := chain.NewMockAction(ctrl)
+	action.EXPECT().GetTypeID().Return(uint8(0)).AnyTimes()
+	action.EXPECT().Marshal(gomock.Any()).AnyTimes()
+	actionRegistry := codec.NewTypeParser[chain.Action, *warp.Message]()
+	require.NoError(actionRegistry.Register(0, func(*codec.Packer, *warp.Message) (chain.Action, error) {
+		return action, nil
+	}, false))
+	authRegistry := codec.NewTypeParser[chain.Auth, *warp.Message]()
+	require.NoError(authRegistry.Register(0, func(*codec.Packer, *warp.Message) (chain.Auth, error) {
+		return auth, nil
+	}, false))
+
+	timestamp := time.Now().Unix() * consts.MillisecondsPerSecond
+	base := &chain.Base{Timestamp: timestamp, ChainID: ids.GenerateTestID(), MaxFee: maxFee}
+	tx := chain.NewTx(base, nil, action)
+	tx.Auth = auth
+	p := codec.NewWriter(0, consts.NetworkSizeLimit)
+	require.NoError(tx.Marshal(p))
+	tx, err := chain.UnmarshalTx(codec.NewReader(p.Bytes(), consts.NetworkSizeLimit), actionRegistry, authRegistry)
+	require.NoError(err)
+	return tx
+}
+
+func TestRejectedRestoresTxs(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	auth := chain.NewMockAuth(ctrl)
+	auth.EXPECT().GetTypeID().Return(uint8(0)).AnyTimes()
+	auth.EXPECT().Marshal(gomock.Any()).AnyTimes()
+	auth.EXPECT().Size().Return(0).AnyTimes()
+	auth.EXPECT().Payer().Return([]byte("payer")).AnyTimes()
+	priv, err := bls.GeneratePrivateKey()
+	require.NoError(err)
+	sig, err := bls.Sign([]byte("digest"), priv)
+	require.NoError(err)
+
+	tx := newTestTx(t, ctrl, 1, auth)
+	signed := newTestTx(t, ctrl, 2, &aggregateAuth{auth, &sig})
+	aggregated := newTestTx(t, ctrl, 3, &aggregateAuth{auth, nil})
+	blk := &chain.StatelessBlock{
+		StatefulBlock: &chain.StatefulBlock{
+			Prnt: ids.GenerateTestID(),
+			Hght: 10000,
+			Txs:  []*chain.Transaction{tx, signed, aggregated},
+		},
+	}
+
+	tracer, _ := trace.New(&trace.Config{Enabled: false})
+	controller := NewMockController(ctrl)
+	controller.EXPECT().Rejected(gomock.Any(), blk).Return(nil)
+	vm := VM{
+		snowCtx: &snow.Context{Log: logging.NoLog{}},
+		config:  &config.Config{},
+
+		tracer:         tracer,
+		verifiedBlocks: map[ids.ID]*chain.StatelessBlock{blk.ID(): blk},
+		mempool:        mempool.New[*chain.Transaction](tracer, 100, 32, nil),
+		c:              controller,
+	}
+
+	// Txs whose [chain.AggregateAuth] signature was removed when the block was
+	// built can't be included in another block, so they are dropped
+	ctx := context.TODO()
+	vm.Rejected(ctx, blk)
+	require.Empty(vm.verifiedBlocks)
+	require.Equal(2, vm.mempool.Len(ctx))
+	require.True(vm.mempool.Has(ctx, tx.ID()))
+	require.True(vm.mempool.Has(ctx, signed.ID()))
+	require.False(vm.mempool.Has(ctx, aggregated.ID()))
+}
@@ -0,0 +1,109 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+)
+
+type warpSigner struct {
+	*warp.Validator
+
+	signature []byte // nil if we don't have a signature
+}
+
+// warpSigners returns the current validator set (in canonical order) with the
+// signatures we have stored for the warp message produced by [txID]. It also
+// returns the total weight of the subnet and the weight that has signed.
+//
+// Validators without a registered BLS public key can never sign, but their
+// weight is still counted in the total.
+func (vm *VM) warpSigners(ctx context.Context, txID ids.ID) ([]*warpSigner, uint64, uint64, error) {
+	height, err := vm.snowCtx.ValidatorState.GetCurrentHeight(ctx)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	vdrs, totalWeight, err := warp.GetCanonicalValidatorSet(ctx, vm.snowCtx.ValidatorState, height, vm.snowCtx.SubnetID)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	var (
+		signers      = make([]*warpSigner, 0, len(vdrs))
+		signedWeight uint64
+	)
+	for _, vdr := range vdrs {
+		signer := &warpSigner{Validator: vdr}
+		sig, err := vm.GetWarpSignature(txID, vdr.PublicKey)
+		if err != nil {
+			return nil, 0, 0, err
+		}
+		if sig != nil {
+			signer.signature = sig.Signature
+			signedWeight += vdr.Weight // can't overflow because total did not
+		}
+		signers = append(signers, signer)
+	}
+	return signers, totalWeight, signedWeight, nil
+}
+
+// AggregateWarpMessage returns the warp message produced by [txID] signed by
+// all current validators that we have collected signatures from, the total
+// weight of the subnet, and the weight that signed the message.
+//
+// If the signed weight is below [GetWarpSignatureThreshold], we also start
+// gathering the missing signatures. If [txID] did not produce a warp message,
+// a nil message is returned.
+func (vm *VM) AggregateWarpMessage(ctx context.Context, txID ids.ID) (*warp.Message, uint64, uint64, error) {
+	unsignedMessage, err := vm.GetOutgoingWarpMessage(txID)
+	if err != nil || unsignedMessage == nil {
+		return nil, 0, 0, err
+	}
+	signers, totalWeight, signedWeight, err := vm.warpSigners(ctx, txID)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	if warp.VerifyWeight(signedWeight, totalWeight, vm.config.GetWarpSignatureThreshold(), quorumDen) != nil {
+		vm.warpManager.GatherSignatures(ctx, txID, unsignedMessage.Bytes())
+	}
+	message, err := aggregateWarpSignatures(unsignedMessage, signers)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	return message, totalWeight, signedWeight, nil
+}
+
+// aggregateWarpSignatures signs [unsignedMessage] with the aggregate of all
+// signatures held by [signers]. [signers] must be in canonical order.
+func aggregateWarpSignatures(unsignedMessage *warp.UnsignedMessage, signers []*warpSigner) (*warp.Message, error) {
+	var (
+		bits       = set.NewBits()
+		signatures = []*bls.Signature{}
+	)
+	for i, signer := range signers {
+		if signer.signature == nil {
+			continue
+		}
+		sig, err := bls.SignatureFromBytes(signer.signature)
+		if err != nil {
+			return nil, err
+		}
+		bits.Add(i)
+		signatures = append(signatures, sig)
+	}
+	if len(signatures) == 0 {
+		return nil, ErrNoWarpSignatures
+	}
+	aggregate, err := bls.AggregateSignatures(signatures)
+	if err != nil {
+		return nil, err
+	}
+	signature := &warp.BitSetSignature{Signers: bits.Bytes()}
+	copy(signature.Signature[:], bls.SignatureToBytes(aggregate))
+	return warp.NewMessage(unsignedMessage, signature)
+}
@@ -14,52 +14,72 @@ import (
 	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/utils/crypto/bls"
 	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
 	"github.com/ava-labs/hypersdk/chain"
 	"github.com/ava-labs/hypersdk/codec"
 	"github.com/ava-labs/hypersdk/consts"
 	"github.com/ava-labs/hypersdk/heap"
+	"github.com/ava-labs/hypersdk/network"
 	"github.com/ava-labs/hypersdk/utils"
 	"go.uber.org/zap"
 )
 
 const (
 	maxWarpResponse   = bls.PublicKeyLen + bls.SignatureLen
-	minGatherInterval = 30 * 60 // 30 minutes
-	initialBackoff    = 2       // give time for others to sign
-	backoffIncrease   = 5
+	minGatherInterval = 30 * 60 * 1000 // 30 minutes (ms)
+	initialBackoff    = 2              // give time for others to sign
+	maxBackoff        = 5 * 60
 	maxRetries        = 10
-	maxOutstanding    = 8 // TODO: make a config
+	quorumDen         = 100
 )
 
-// WarpManager takes requests to get signatures from other nodes and then
-// stores the result in our DB for future usage.
+// WarpManager aggregates signatures for the warp messages produced by
+// accepted transactions.
+//
+// Each message is tracked by a job that is persisted to disk, so aggregation
+// resumes after a restart. On each attempt, we request signatures from all
+// validators we don't have a signature from and then back off exponentially
+// until the stake that has signed reaches [GetWarpSignatureThreshold] (or we
+// give up after [maxRetries] attempts).
 type WarpManager struct {
 	vm        *VM
 	appSender common.AppSender
+	clock     mockable.Clock
 
 	l         sync.Mutex
 	requestID uint32
 
-	pendingJobs *heap.Heap[*signatureJob, int64]
-	jobs        map[uint32]*signatureJob
+	pendingJobs *heap.Heap[*warpJob, int64]
+	inflight    set.Set[ids.ID] // jobs removed from [pendingJobs] for an attempt
+	requests    map[uint32]*signatureRequest
+	requested   set.Set[ids.ID] // txID+nodeID of outstanding requests
 
 	done chan struct{}
 }
 
-type signatureJob struct {
+type warpJob struct {
+	txID     ids.ID
+	msg      []byte
+	attempts int
+	next     int64 // unix seconds
+}
+
+type signatureRequest struct {
 	id        ids.ID
 	nodeID    ids.NodeID
 	publicKey []byte
 	txID      ids.ID
-	retry     int
 	msg       []byte
 }
 
 func NewWarpManager(vm *VM) *WarpManager {
 	return &WarpManager{
 		vm:          vm,
-		pendingJobs: heap.New[*signatureJob, int64](64, true),
-		jobs:        map[uint32]*signatureJob{},
+		pendingJobs: heap.New[*warpJob, int64](64, true),
+		requests:    map[uint32]*signatureRequest{},
+		inflight:    set.NewSet[ids.ID](64),
+		requested:   set.NewSet[ids.ID](64),
 		done:        make(chan struct{}),
 	}
 }
@@ -70,33 +90,36 @@ func (w *WarpManager) Run(appSender common.AppSender) {
 	w.vm.Logger().Info("starting warp manager")
 	defer close(w.done)
 
+	// Resume jobs that were pending when we last shutdown
+	jobs, err := w.vm.GetWarpJobs()
+	if err != nil {
+		w.vm.snowCtx.Log.Error("unable to load warp jobs", zap.Error(err))
+	}
+	w.l.Lock()
+	for _, job := range jobs {
+		w.push(job)
+	}
+	w.l.Unlock()
+	if len(jobs) > 0 {
+		w.vm.snowCtx.Log.Info("resumed warp jobs", zap.Int("jobs", len(jobs)))
+	}
+
 	t := time.NewTicker(1 * time.Second)
 	defer t.Stop()
 	for {
 		select {
 		case <-t.C:
-			w.l.Lock()
-			now := time.Now().Unix()
-			for w.pendingJobs.Len() > 0 && len(w.jobs) < maxOutstanding {
-				first := w.pendingJobs.First()
-				if first.Val > now {
-					break
-				}
-				w.pendingJobs.Pop()
-
-				// Send request
-				job := first.Item
-				if err := w.request(context.Background(), job); err != nil {
-					w.vm.snowCtx.Log.Error(
-						"unable to request signature",
-						zap.Stringer("nodeID", job.nodeID),
-						zap.Error(err),
-					)
-				}
+			ready := w.readyJobs()
+			for _, job := range ready {
+				w.attempt(context.Background(), job)
 			}
-			l := w.pendingJobs.Len()
-			w.l.Unlock()
-			w.vm.snowCtx.Log.Debug("checked for ready jobs", zap.Int("pending", l))
+			pending, outstanding := w.Backlog()
+			w.vm.snowCtx.Log.Debug(
+				"checked for ready jobs",
+				zap.Int("ready", len(ready)),
+				zap.Int("pending", pending),
+				zap.Int("outstanding", outstanding),
+			)
 		case <-w.vm.stop:
 			w.vm.Logger().Info("stopping warp manager")
 			return
@@ -104,6 +127,40 @@ func (w *WarpManager) Run(appSender common.AppSender) {
 	}
 }
 
+// you must hold [w.l] when calling this function
+func (w *WarpManager) push(job *warpJob) {
+	w.inflight.Remove(job.txID)
+	w.pendingJobs.Push(&heap.Entry[*warpJob, int64]{
+		ID:    job.txID,
+		Item:  job,
+		Val:   job.next,
+		Index: w.pendingJobs.Len(),
+	})
+}
+
+// readyJobs removes and returns all jobs that should be attempted now.
+//
+// Returned jobs are considered inflight until they are either pushed back onto
+// [pendingJobs] or finished, so that [GatherSignatures] does not enqueue a
+// duplicate job while an attempt is in progress.
+func (w *WarpManager) readyJobs() []*warpJob {
+	w.l.Lock()
+	defer w.l.Unlock()
+
+	now := w.clock.Time().Unix()
+	ready := []*warpJob{}
+	for w.pendingJobs.Len() > 0 {
+		first := w.pendingJobs.First()
+		if first.Val > now {
+			break
+		}
+		w.pendingJobs.Pop()
+		w.inflight.Add(first.Item.txID)
+		ready = append(ready, first.Item)
+	}
+	return ready
+}
+
 // GatherSignatures makes a best effort to acquire signatures from other
 // validators and store them inside the vmDB.
 //
@@ -111,103 +168,180 @@ func (w *WarpManager) Run(appSender common.AppSender) {
 // may be triggered by RPC (if missing signatures are detected). To prevent RPC
 // abuse, we limit how frequently we attempt to gather signatures for a given
 // TxID.
-func (w *WarpManager) GatherSignatures(ctx context.Context, txID ids.ID, msg []byte) {
+func (w *WarpManager) GatherSignatures(_ context.Context, txID ids.ID, msg []byte) {
 	lastFetch, err := w.vm.GetWarpFetch(txID)
 	if err != nil {
 		w.vm.snowCtx.Log.Error("unable to get last fetch", zap.Error(err))
 		return
 	}
-	if time.Now().Unix()-lastFetch < minGatherInterval {
+	now := w.clock.Time()
+	if now.UnixMilli()-lastFetch < minGatherInterval {
 		w.vm.snowCtx.Log.Error("skipping fetch too recent", zap.Stringer("txID", txID))
 		return
 	}
-	if err := w.vm.StoreWarpFetch(txID); err != nil {
+	if err := w.vm.StoreWarpFetch(txID, now.UnixMilli()); err != nil {
 		w.vm.snowCtx.Log.Error("unable to get last fetch", zap.Error(err))
 		return
 	}
-	height, err := w.vm.snowCtx.ValidatorState.GetCurrentHeight(ctx)
-	if err != nil {
-		w.vm.snowCtx.Log.Error("unable to get current p-chain height", zap.Error(err))
+
+	w.l.Lock()
+	defer w.l.Unlock()
+	if w.pendingJobs.Has(txID) || w.inflight.Contains(txID) {
+		// We may already have enqueued a job when the block was accepted.
 		return
 	}
-	validators, err := w.vm.snowCtx.ValidatorState.GetValidatorSet(
-		ctx,
-		height,
-		w.vm.snowCtx.SubnetID,
-	)
+	job := &warpJob{
+		txID: txID,
+		msg:  msg,
+		next: now.Unix() + initialBackoff,
+	}
+	if err := w.vm.StoreWarpJob(job); err != nil {
+		w.vm.snowCtx.Log.Error("unable to store warp job", zap.Error(err))
+		return
+	}
+	w.push(job)
+	w.vm.snowCtx.Log.Debug("enqueued warp job", zap.Stringer("txID", txID))
+}
+
+// attempt completes [job] if enough stake has signed its message or requests
+// the missing signatures and schedules the next attempt.
+func (w *WarpManager) attempt(ctx context.Context, job *warpJob) {
+	signers, totalWeight, signedWeight, err := w.vm.warpSigners(ctx, job.txID)
 	if err != nil {
-		w.vm.snowCtx.Log.Error("unable to get validator set", zap.Error(err))
+		w.vm.snowCtx.Log.Warn(
+			"unable to determine signature weight",
+			zap.Stringer("txID", job.txID),
+			zap.Error(err),
+		)
+	}
+	if err == nil && warp.VerifyWeight(signedWeight, totalWeight, w.vm.config.GetWarpSignatureThreshold(), quorumDen) == nil {
+		w.vm.snowCtx.Log.Info(
+			"aggregated warp signatures",
+			zap.Stringer("txID", job.txID),
+			zap.Uint64("signed weight", signedWeight),
+			zap.Uint64("total weight", totalWeight),
+			zap.Int("attempts", job.attempts),
+		)
+		w.finish(job)
 		return
 	}
-	for nodeID, validator := range validators {
-		// Only request from validators that have registered BLS public keys and
-		// that we have not already gotten a signature from.
-		if validator.PublicKey == nil {
-			w.vm.snowCtx.Log.Info(
-				"skipping fetch for validator with no registered public key",
-				zap.Stringer("nodeID", nodeID),
-				zap.Uint64("pchain height", height),
-			)
+
+	// Request missing signatures
+	var (
+		now      = w.clock.Time().Unix()
+		complete = true
+	)
+	w.l.Lock()
+	for _, vdr := range signers {
+		if vdr.signature != nil {
 			continue
 		}
-		previousSignature, err := w.vm.GetWarpSignature(txID, validator.PublicKey)
-		if err != nil {
-			w.vm.snowCtx.Log.Error("unable to fetch previous signature", zap.Error(err))
-			return
-		}
-		if previousSignature != nil {
-			continue
+		if len(w.requests) >= w.vm.config.GetWarpMaxOutstanding() {
+			complete = false
+			break
 		}
-
-		idb := make([]byte, consts.IDLen+consts.NodeIDLen)
-		copy(idb, txID[:])
-		copy(idb[consts.IDLen:], nodeID.Bytes())
-		id := utils.ToID(idb)
-		w.l.Lock()
-		if w.pendingJobs.Has(id) {
-			// We may already have enqueued a job when the block was accepted.
-			w.l.Unlock()
-			continue
+		// Validators that share a public key only need to sign once
+		//
+		// [vdr.PublicKeyBytes] is the uncompressed key, whereas responses
+		// contain the compressed key.
+		if err := w.request(ctx, job, vdr.NodeIDs[0], bls.PublicKeyToBytes(vdr.PublicKey)); err != nil {
+			w.vm.snowCtx.Log.Error(
+				"unable to request signature",
+				zap.Stringer("nodeID", vdr.NodeIDs[0]),
+				zap.Error(err),
+			)
 		}
-		w.pendingJobs.Push(&heap.Entry[*signatureJob, int64]{
-			ID: id,
-			Item: &signatureJob{
-				id,
-				nodeID,
-				bls.PublicKeyToBytes(validator.PublicKey),
-				txID,
-				0,
-				msg,
-			},
-			Val:   time.Now().Unix() + initialBackoff,
-			Index: w.pendingJobs.Len(),
-		})
+	}
+	if !complete {
+		// Try again once some requests have finished (this does not count as
+		// an attempt)
+		job.next = now + 1
+		w.push(job)
 		w.l.Unlock()
-		w.vm.snowCtx.Log.Debug(
-			"enqueued fetch job",
-			zap.Stringer("nodeID", nodeID),
-			zap.Stringer("txID", txID),
+		return
+	}
+	job.attempts++
+	if job.attempts > maxRetries {
+		w.l.Unlock()
+		w.vm.snowCtx.Log.Info(
+			"warp job failed",
+			zap.Stringer("txID", job.txID),
+			zap.Uint64("signed weight", signedWeight),
+			zap.Uint64("total weight", totalWeight),
 		)
+		w.finish(job)
+		return
 	}
+	backoff := int64(initialBackoff << job.attempts)
+	if backoff > maxBackoff {
+		backoff = maxBackoff
+	}
+	job.next = now + backoff
+	w.push(job)
+	w.l.Unlock()
+
+	if err := w.vm.StoreWarpJob(job); err != nil {
+		w.vm.snowCtx.Log.Error("unable to store warp job", zap.Error(err))
+	}
+}
+
+// finish removes [job] from disk. The job is only released once it has been
+// deleted, so a concurrent [GatherSignatures] can't have its new job removed.
+func (w *WarpManager) finish(job *warpJob) {
+	w.l.Lock()
+	defer w.l.Unlock()
+
+	if err := w.vm.DeleteWarpJob(job.txID); err != nil {
+		w.vm.snowCtx.Log.Error("unable to delete warp job", zap.Error(err))
+	}
+	w.inflight.Remove(job.txID)
 }
 
 // you must hold [w.l] when calling this function
 func (w *WarpManager) request(
 	ctx context.Context,
-	j *signatureJob,
+	job *warpJob,
+	nodeID ids.NodeID,
+	publicKey []byte,
 ) error {
+	idb := make([]byte, consts.IDLen+consts.NodeIDLen)
+	copy(idb, job.txID[:])
+	copy(idb[consts.IDLen:], nodeID.Bytes())
+	id := utils.ToID(idb)
+	if w.requested.Contains(id) {
+		return nil
+	}
+
 	requestID := w.requestID
 	w.requestID++
-	w.jobs[requestID] = j
+	w.requests[requestID] = &signatureRequest{
+		id:        id,
+		nodeID:    nodeID,
+		publicKey: publicKey,
+		txID:      job.txID,
+		msg:       job.msg,
+	}
+	w.requested.Add(id)
 
 	return w.appSender.SendAppRequest(
 		ctx,
-		set.Of(j.nodeID),
+		set.Of(nodeID),
 		requestID,
-		j.txID[:],
+		job.txID[:],
 	)
 }
 
+// you must hold [w.l] when calling this function
+func (w *WarpManager) complete(requestID uint32) (*signatureRequest, bool) {
+	req, ok := w.requests[requestID]
+	if !ok {
+		return nil, false
+	}
+	delete(w.requests, requestID)
+	w.requested.Remove(req.id)
+	return req, true
+}
+
 func (w *WarpManager) AppRequest(
 	ctx context.Context,
 	nodeID ids.NodeID,
@@ -261,8 +395,7 @@ func (w *WarpManager) AppRequest(
 
 func (w *WarpManager) HandleResponse(requestID uint32, msg []byte) error {
 	w.l.Lock()
-	job, ok := w.jobs[requestID]
-	delete(w.jobs, requestID)
+	job, ok := w.complete(requestID)
 	w.l.Unlock()
 	if !ok {
 		return nil
@@ -276,6 +409,7 @@ func (w *WarpManager) HandleResponse(requestID uint32, msg []byte) error {
 	r.UnpackFixedBytes(bls.SignatureLen, &signature)
 	if err := r.Err(); err != nil {
 		w.vm.snowCtx.Log.Warn("could not decode warp signature", zap.Error(err))
+		w.vm.ReportPeer(job.nodeID, network.OffenseWarpResponseFailure)
 		return nil
 	}
 
@@ -286,6 +420,7 @@ func (w *WarpManager) HandleResponse(requestID uint32, msg []byte) error {
 			zap.String("found", hex.EncodeToString(publicKey)),
 			zap.String("expected", hex.EncodeToString(job.publicKey)),
 		)
+		w.vm.ReportPeer(job.nodeID, network.OffenseWarpResponseFailure)
 		return nil
 	}
 
@@ -293,15 +428,18 @@ func (w *WarpManager) HandleResponse(requestID uint32, msg []byte) error {
 	pk, err := bls.PublicKeyFromBytes(publicKey)
 	if err != nil {
 		w.vm.snowCtx.Log.Warn("could not decode public key", zap.Error(err))
+		w.vm.ReportPeer(job.nodeID, network.OffenseWarpResponseFailure)
 		return nil
 	}
 	sig, err := bls.SignatureFromBytes(signature)
 	if err != nil {
 		w.vm.snowCtx.Log.Warn("could not decode signature", zap.Error(err))
+		w.vm.ReportPeer(job.nodeID, network.OffenseWarpResponseFailure)
 		return nil
 	}
 	if !bls.Verify(pk, sig, job.msg) {
 		w.vm.snowCtx.Log.Warn("could not verify signature")
+		w.vm.ReportPeer(job.nodeID, network.OffenseWarpResponseFailure)
 		return nil
 	}
 
@@ -324,36 +462,29 @@ func (w *WarpManager) HandleResponse(requestID uint32, msg []byte) error {
 }
 
 func (w *WarpManager) HandleRequestFailed(requestID uint32) error {
+	// The signature will be requested again on the next attempt
 	w.l.Lock()
-	job, ok := w.jobs[requestID]
-	delete(w.jobs, requestID)
+	job, ok := w.complete(requestID)
 	w.l.Unlock()
-	if !ok {
-		return nil
-	}
-
-	// Drop if we've already retried too many times
-	if job.retry >= maxRetries {
-		w.vm.snowCtx.Log.Info(
-			"fetch job failed",
+	if ok {
+		w.vm.snowCtx.Log.Debug(
+			"signature request failed",
 			zap.Stringer("nodeID", job.nodeID),
 			zap.Stringer("txID", job.txID),
 		)
-		return nil
 	}
-	job.retry++
-
-	w.l.Lock()
-	w.pendingJobs.Push(&heap.Entry[*signatureJob, int64]{
-		ID:    job.id,
-		Item:  job,
-		Val:   time.Now().Unix() + int64(backoffIncrease*job.retry),
-		Index: w.pendingJobs.Len(),
-	})
-	w.l.Unlock()
 	return nil
 }
 
 func (w *WarpManager) Done() {
 	<-w.done
 }
+
+// Backlog returns the number of messages waiting for signatures and the
+// number of requests awaiting a response.
+func (w *WarpManager) Backlog() (int, int) {
+	w.l.Lock()
+	defer w.l.Unlock()
+
+	return w.pendingJobs.Len(), len(w.requests)
+}
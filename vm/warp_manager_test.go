@@ -0,0 +1,378 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/database/manager"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/version"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/config"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/network"
+)
+
+const (
+	testWarpNetworkID = 1
+	testWarpHeight    = 10
+)
+
+type testWarpValidator struct {
+	nodeID ids.NodeID
+	sk     *bls.SecretKey
+	weight uint64
+}
+
+func (v *testWarpValidator) sign(msg *warp.UnsignedMessage) []byte {
+	return bls.SignatureToBytes(bls.Sign(v.sk, msg.Bytes()))
+}
+
+type testWarpRequest struct {
+	nodeID    ids.NodeID
+	requestID uint32
+}
+
+// newTestWarpVM returns a VM that is the first of [weights] validators. A
+// validator with zero weight is added that has no BLS key (and can never
+// sign).
+func newTestWarpVM(t *testing.T, weights ...uint64) (*VM, []*testWarpValidator, *[]*testWarpRequest) {
+	require := require.New(t)
+
+	vdrs := make([]*testWarpValidator, len(weights))
+	vdrSet := map[ids.NodeID]*validators.GetValidatorOutput{}
+	for i, weight := range weights {
+		sk, err := bls.NewSecretKey()
+		require.NoError(err)
+		vdrs[i] = &testWarpValidator{
+			nodeID: ids.GenerateTestNodeID(),
+			sk:     sk,
+			weight: weight,
+		}
+		vdrSet[vdrs[i].nodeID] = &validators.GetValidatorOutput{
+			NodeID:    vdrs[i].nodeID,
+			PublicKey: bls.PublicFromSecretKey(sk),
+			Weight:    weight,
+		}
+	}
+	noKey := ids.GenerateTestNodeID()
+	vdrSet[noKey] = &validators.GetValidatorOutput{NodeID: noKey, Weight: 10}
+
+	chainID, subnetID := ids.GenerateTestID(), ids.GenerateTestID()
+	snowCtx := &snow.Context{
+		NetworkID: testWarpNetworkID,
+		SubnetID:  subnetID,
+		ChainID:   chainID,
+		NodeID:    vdrs[0].nodeID,
+		PublicKey: bls.PublicFromSecretKey(vdrs[0].sk),
+		Log:       logging.NoLog{},
+		ValidatorState: &validators.TestState{
+			GetCurrentHeightF: func(context.Context) (uint64, error) {
+				return testWarpHeight, nil
+			},
+			GetSubnetIDF: func(context.Context, ids.ID) (ids.ID, error) {
+				return subnetID, nil
+			},
+			GetValidatorSetF: func(context.Context, uint64, ids.ID) (map[ids.NodeID]*validators.GetValidatorOutput, error) {
+				return vdrSet, nil
+			},
+		},
+		WarpSigner: warp.NewSigner(vdrs[0].sk, testWarpNetworkID, chainID),
+	}
+	requests := []*testWarpRequest{}
+	sender := &common.SenderTest{
+		T: t,
+		SendAppRequestF: func(_ context.Context, nodeIDs set.Set[ids.NodeID], requestID uint32, _ []byte) error {
+			for nodeID := range nodeIDs {
+				requests = append(requests, &testWarpRequest{nodeID, requestID})
+			}
+			return nil
+		},
+	}
+	vm := &VM{
+		snowCtx:        snowCtx,
+		config:         &config.Config{},
+		pkBytes:        bls.PublicKeyToBytes(snowCtx.PublicKey),
+		vmDB:           manager.NewMemDB(version.Semantic1_0_0).Current().Database,
+		networkManager: network.NewManager(logging.NoLog{}, snowCtx.NodeID, sender, nil),
+		stop:           make(chan struct{}),
+	}
+	vm.networkManager.SetReputation(network.NewReputation(network.DefaultReputationConfig(), nil))
+	vm.warpManager = NewWarpManager(vm)
+	vm.warpManager.appSender = sender
+	return vm, vdrs, &requests
+}
+
+func newTestWarpMessage(t *testing.T, vm *VM) *warp.UnsignedMessage {
+	msg, err := warp.NewUnsignedMessage(testWarpNetworkID, vm.snowCtx.ChainID, []byte("payload"))
+	require.NoError(t, err)
+	return msg
+}
+
+func warpResponse(pk *bls.PublicKey, signature []byte) []byte {
+	p := codec.NewWriter(maxWarpResponse, maxWarpResponse)
+	p.PackFixedBytes(bls.PublicKeyToBytes(pk))
+	p.PackFixedBytes(signature)
+	return p.Bytes()
+}
+
+func TestWarpJobStorage(t *testing.T) {
+	require := require.New(t)
+
+	vm, _, _ := newTestWarpVM(t, 10)
+	jobs, err := vm.GetWarpJobs()
+	require.NoError(err)
+	require.Empty(jobs)
+
+	job1 := &warpJob{txID: ids.GenerateTestID(), msg: []byte{1, 2, 3}, attempts: 2, next: 100}
+	job2 := &warpJob{txID: ids.GenerateTestID(), msg: []byte{4}, next: 50}
+	require.NoError(vm.StoreWarpJob(job1))
+	require.NoError(vm.StoreWarpJob(job2))
+
+	// Jobs are stored under their own prefix
+	k := PrefixWarpJobKey(job1.txID)
+	require.Equal(byte(warpJobPrefix), k[0])
+	require.Equal(job1.txID[:], k[1:])
+	require.NoError(vm.StoreWarpFetch(job1.txID, 1))
+	require.NoError(vm.StoreWarpSignature(job1.txID, vm.snowCtx.PublicKey, []byte{1}))
+
+	jobs, err = vm.GetWarpJobs()
+	require.NoError(err)
+	require.ElementsMatch([]*warpJob{job1, job2}, jobs)
+
+	// Updates overwrite the existing job
+	job1.attempts++
+	job1.next = 200
+	require.NoError(vm.StoreWarpJob(job1))
+	require.NoError(vm.DeleteWarpJob(job2.txID))
+	jobs, err = vm.GetWarpJobs()
+	require.NoError(err)
+	require.Equal([]*warpJob{job1}, jobs)
+
+	// Corrupt jobs are reported
+	require.NoError(vm.vmDB.Put(PrefixWarpJobKey(ids.GenerateTestID()), []byte{1}))
+	_, err = vm.GetWarpJobs()
+	require.Error(err)
+}
+
+func TestWarpSignersAndAggregation(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	vm, vdrs, _ := newTestWarpVM(t, 40, 30, 20)
+	msg := newTestWarpMessage(t, vm)
+	txID := ids.GenerateTestID()
+
+	// Nothing to aggregate without signatures
+	signers, totalWeight, signedWeight, err := vm.warpSigners(ctx, txID)
+	require.NoError(err)
+	require.Len(signers, len(vdrs)) // the validator without a key is omitted
+	require.Equal(uint64(100), totalWeight)
+	require.Zero(signedWeight)
+	_, err = aggregateWarpSignatures(msg, signers)
+	require.ErrorIs(err, ErrNoWarpSignatures)
+
+	// Only stored signatures are counted
+	for _, vdr := range vdrs[:2] {
+		require.NoError(vm.StoreWarpSignature(txID, bls.PublicFromSecretKey(vdr.sk), vdr.sign(msg)))
+	}
+	signers, totalWeight, signedWeight, err = vm.warpSigners(ctx, txID)
+	require.NoError(err)
+	require.Equal(uint64(100), totalWeight)
+	require.Equal(uint64(70), signedWeight)
+	var signed int
+	for _, signer := range signers {
+		if signer.signature != nil {
+			signed++
+		}
+	}
+	require.Equal(2, signed)
+
+	// The aggregate signature is valid for the signed weight
+	message, err := aggregateWarpSignatures(msg, signers)
+	require.NoError(err)
+	require.Equal(msg.Bytes(), message.UnsignedMessage.Bytes())
+	require.NoError(message.Signature.Verify(ctx, &message.UnsignedMessage, testWarpNetworkID, vm.snowCtx.ValidatorState, testWarpHeight, 70, 100))
+	require.ErrorIs(
+		message.Signature.Verify(ctx, &message.UnsignedMessage, testWarpNetworkID, vm.snowCtx.ValidatorState, testWarpHeight, 71, 100),
+		warp.ErrInsufficientWeight,
+	)
+}
+
+func TestWarpManagerGatherSignatures(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	vm, vdrs, requests := newTestWarpVM(t, 40, 40, 10)
+	w := vm.warpManager
+	start := time.Unix(1_700_000_000, 0)
+	w.clock.Set(start)
+	msg := newTestWarpMessage(t, vm)
+	txID := ids.GenerateTestID()
+	require.NoError(vm.StoreWarpSignature(txID, vm.snowCtx.PublicKey, vdrs[0].sign(msg)))
+
+	// Jobs are persisted and only attempted after the initial backoff
+	w.GatherSignatures(ctx, txID, msg.Bytes())
+	jobs, err := vm.GetWarpJobs()
+	require.NoError(err)
+	require.Len(jobs, 1)
+	pending, outstanding := w.Backlog()
+	require.Equal(1, pending)
+	require.Zero(outstanding)
+	require.Empty(w.readyJobs())
+
+	// A job can't be enqueued twice while it is being attempted
+	w.clock.Set(start.Add(minGatherInterval * time.Millisecond))
+	ready := w.readyJobs()
+	require.Len(ready, 1)
+	w.GatherSignatures(ctx, txID, msg.Bytes())
+	pending, _ = w.Backlog()
+	require.Zero(pending)
+
+	// We request signatures from validators we don't have a signature from
+	w.attempt(ctx, ready[0])
+	require.Len(*requests, 2)
+	pending, outstanding = w.Backlog()
+	require.Equal(1, pending)
+	require.Equal(2, outstanding)
+	require.Empty(w.readyJobs())
+	jobs, err = vm.GetWarpJobs()
+	require.NoError(err)
+	require.Equal(1, jobs[0].attempts)
+
+	// Invalid responses are penalized and valid responses are stored
+	for _, req := range *requests {
+		switch req.nodeID {
+		case vdrs[1].nodeID:
+			require.NoError(w.HandleResponse(req.requestID, warpResponse(bls.PublicFromSecretKey(vdrs[1].sk), vdrs[1].sign(msg))))
+		case vdrs[2].nodeID:
+			require.NoError(w.HandleResponse(req.requestID, warpResponse(bls.PublicFromSecretKey(vdrs[2].sk), vdrs[1].sign(msg))))
+		}
+	}
+	_, outstanding = w.Backlog()
+	require.Zero(outstanding)
+	scores := vm.PeerScores()
+	require.Len(scores, 1)
+	require.Equal(vdrs[2].nodeID, scores[0].NodeID)
+	sig, err := vm.GetWarpSignature(txID, bls.PublicFromSecretKey(vdrs[1].sk))
+	require.NoError(err)
+	require.NotNil(sig)
+	sig, err = vm.GetWarpSignature(txID, bls.PublicFromSecretKey(vdrs[2].sk))
+	require.NoError(err)
+	require.Nil(sig)
+
+	// Once enough stake has signed, the job is finished
+	w.clock.Set(w.clock.Time().Add(maxBackoff * time.Second))
+	ready = w.readyJobs()
+	require.Len(ready, 1)
+	w.attempt(ctx, ready[0])
+	require.Len(*requests, 2)
+	pending, outstanding = w.Backlog()
+	require.Zero(pending)
+	require.Zero(outstanding)
+	jobs, err = vm.GetWarpJobs()
+	require.NoError(err)
+	require.Empty(jobs)
+	require.Zero(w.inflight.Len())
+}
+
+func TestWarpManagerGatherSignaturesThrottled(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	vm, _, _ := newTestWarpVM(t, 10, 10)
+	w := vm.warpManager
+	start := time.Unix(1_700_000_000, 0)
+	w.clock.Set(start)
+	msg := newTestWarpMessage(t, vm)
+	txID := ids.GenerateTestID()
+
+	w.GatherSignatures(ctx, txID, msg.Bytes())
+	require.NoError(vm.DeleteWarpJob(txID))
+	w.l.Lock()
+	w.pendingJobs.Pop()
+	w.l.Unlock()
+
+	// Fetches for the same message are limited to once per
+	// [minGatherInterval]
+	w.clock.Set(start.Add(minGatherInterval*time.Millisecond - time.Millisecond))
+	w.GatherSignatures(ctx, txID, msg.Bytes())
+	pending, _ := w.Backlog()
+	require.Zero(pending)
+
+	w.clock.Set(start.Add(minGatherInterval * time.Millisecond))
+	w.GatherSignatures(ctx, txID, msg.Bytes())
+	pending, _ = w.Backlog()
+	require.Equal(1, pending)
+}
+
+func TestWarpManagerRetries(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	vm, _, requests := newTestWarpVM(t, 10, 10)
+	w := vm.warpManager
+	w.clock.Set(time.Unix(1_700_000_000, 0))
+	msg := newTestWarpMessage(t, vm)
+	txID := ids.GenerateTestID()
+	w.GatherSignatures(ctx, txID, msg.Bytes())
+
+	// Failed requests are retried on the next attempt until we give up
+	for i := 0; i <= maxRetries; i++ {
+		w.clock.Set(w.clock.Time().Add(maxBackoff * time.Second))
+		ready := w.readyJobs()
+		require.Len(ready, 1)
+		w.attempt(ctx, ready[0])
+		for _, req := range *requests {
+			require.NoError(w.HandleRequestFailed(req.requestID))
+		}
+		*requests = nil
+	}
+	pending, outstanding := w.Backlog()
+	require.Zero(pending)
+	require.Zero(outstanding)
+	jobs, err := vm.GetWarpJobs()
+	require.NoError(err)
+	require.Empty(jobs)
+}
+
+func TestWarpManagerAppRequest(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	vm, vdrs, _ := newTestWarpVM(t, 10)
+	msg := newTestWarpMessage(t, vm)
+	txID := ids.GenerateTestID()
+	signature := vdrs[0].sign(msg)
+	require.NoError(vm.StoreWarpSignature(txID, vm.snowCtx.PublicKey, signature))
+
+	var response []byte
+	vm.warpManager.appSender = &common.SenderTest{
+		T: t,
+		SendAppResponseF: func(_ context.Context, _ ids.NodeID, requestID uint32, msg []byte) error {
+			require.Equal(uint32(7), requestID)
+			response = msg
+			return nil
+		},
+	}
+	require.NoError(vm.warpManager.AppRequest(ctx, ids.GenerateTestNodeID(), 7, txID[:]))
+	require.Equal(warpResponse(vm.snowCtx.PublicKey, signature), response)
+
+	// Malformed requests are dropped
+	response = nil
+	require.NoError(vm.warpManager.AppRequest(ctx, ids.GenerateTestNodeID(), 7, txID[:consts.IDLen-1]))
+	require.Nil(response)
+}